@@ -17,8 +17,11 @@ limitations under the License.
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type DSPASpec struct {
@@ -37,6 +40,12 @@ type DSPASpec struct {
 	// Deploy the KFP UI with DS Pipelines UI. This feature is unsupported, and primarily used for exploration, testing, and development purposes.
 	// +kubebuilder:validation:Optional
 	*MlPipelineUI `json:"mlpipelineUI"`
+	// Deploy the KFP Visualization Server, used by the API Server to render visualizations of pipeline run artifacts. This feature is unsupported, and primarily used for exploration, testing, and development purposes.
+	// +kubebuilder:validation:Optional
+	*VisualizationServer `json:"visualizationServer,omitempty"`
+	// Deploy the KFP Viewer CRD controller, which serves the TensorBoard viewers opened from the KFP UI. This feature is unsupported, and primarily used for exploration, testing, and development purposes.
+	// +kubebuilder:validation:Optional
+	*CRDViewer `json:"crdViewer,omitempty"`
 	// ObjectStorage specifies Object Store configurations, used for DS Pipelines artifact passing and storage. Specify either the your own External Storage (e.g. AWS S3), or use the default Minio deployment (unsupported, primarily for development, and testing) .
 	// +kubebuilder:validation:Required
 	*ObjectStorage `json:"objectStorage"`
@@ -53,6 +62,280 @@ type DSPASpec struct {
 	// WorkflowController is an argo-specific component that manages a DSPA's Workflow objects and handles the orchestration of them with the central Argo server
 	// +kubebuilder:validation:Optional
 	*WorkflowController `json:"workflowController,omitempty"`
+
+	// ImagePullSecrets are added to all DSPA component pods, and to the pipeline runner ServiceAccount used by pipeline run pods.
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// ImageRegistryOverride replaces the registry of all default (operator configured) images, e.g. "mirror.example.com" or "mirror.example.com/quay". Images explicitly set in the DSPA are not modified.
+	// +kubebuilder:validation:Optional
+	ImageRegistryOverride string `json:"imageRegistryOverride,omitempty"`
+	// FIPSMode set to "true" deploys DSPA components in a FIPS 140-2 compliant configuration: FIPS image variants
+	// configured in the operator, TLS restricted to FIPS approved protocols and ciphers. Defaults to the FIPS mode
+	// of the cluster the operator runs on.
+	// +kubebuilder:validation:Optional
+	FIPSMode *bool `json:"fipsMode,omitempty"`
+	// HA set to "true" runs the API Server, UI and MLMD with multiple replicas, spread across zones and nodes
+	// unless topologySpreadConstraints are set on the component.
+	// +kubebuilder:validation:Optional
+	HA *bool `json:"ha,omitempty"`
+	// TLS configures how certificates for in-namespace TLS connections between DSPA components are issued.
+	// +kubebuilder:validation:Optional
+	TLS *TLS `json:"tls,omitempty"`
+	// ServiceMesh runs the API Server and UI in an Istio / OpenShift Service Mesh.
+	// +kubebuilder:validation:Optional
+	ServiceMesh *ServiceMesh `json:"serviceMesh,omitempty"`
+	// Limits caps the pipeline runs of this DSPA, so that runaway recurring runs cannot starve the cluster.
+	// Requires the DSPA workflowController to be deployed with its default configuration.
+	// +kubebuilder:validation:Optional
+	Limits *RunLimits `json:"limits,omitempty"`
+	// PodDefaults are applied to the pods of all pipeline steps, e.g. the tolerations and runtimeClassName
+	// of GPU nodes, through the workflow defaults of the DSPA workflow controller. Requires the DSPA
+	// workflowController to be deployed with its default configuration.
+	// +kubebuilder:validation:Optional
+	PodDefaults *PodDefaults `json:"podDefaults,omitempty"`
+	// ManagementState of the DSPA resources. Set to one of the following values:
+	//
+	// - "Managed" : The operator reconciles the DSPA resources.
+	// - "Unmanaged" : The operator reports the health of the DSPA without creating, updating or deleting any of its
+	//   resources, e.g. while they are migrated.
+	// - "Removed" : The operator deletes the DSPA components. The DSPA, its PersistentVolumeClaims and Secrets are
+	//   kept, so that setting it back to Managed redeploys the components with their data.
+	//
+	// Defaults to the DSPO.ManagementState operator config, Managed when unset.
+	// +kubebuilder:validation:Enum=Managed;Unmanaged;Removed
+	// +kubebuilder:validation:Optional
+	ManagementState ManagementState `json:"managementState,omitempty"`
+	// Platform the DSPA is deployed on. Set to one of the following values:
+	//
+	// - "openshift" : Components are exposed by Routes, and authenticated by the OpenShift OAuth proxy.
+	// - "kubernetes" : Components are exposed by Ingresses, and authenticated by oauth2-proxy against the OIDC
+	//   provider of the DSPO.Kubernetes operator config. Pod to pod TLS requires spec.tls.issuerRef, the OpenShift
+	//   service CA being unavailable.
+	//
+	// Defaults to the platform detected by the operator, openshift when Routes are served.
+	// +kubebuilder:validation:Enum=openshift;kubernetes
+	// +kubebuilder:validation:Optional
+	Platform Platform `json:"platform,omitempty"`
+	// Monitoring exports the saturation metrics of the MariaDB and Minio deployed for the DSPA to Prometheus.
+	// +kubebuilder:validation:Optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+	// MultiTenancy serves the pipelines of other namespaces from the API Server of the DSPA, the pipeline runs
+	// execute in the namespace they are submitted to.
+	// +kubebuilder:validation:Optional
+	MultiTenancy *MultiTenancy `json:"multiTenancy,omitempty"`
+}
+
+type ManagementState string
+
+const (
+	ManagementStateManaged   ManagementState = "Managed"
+	ManagementStateUnmanaged ManagementState = "Unmanaged"
+	ManagementStateRemoved   ManagementState = "Removed"
+)
+
+type Platform string
+
+const (
+	PlatformOpenShift  Platform = "openshift"
+	PlatformKubernetes Platform = "kubernetes"
+)
+
+type ServiceMesh struct {
+	// Enable to inject mesh sidecars in the API Server and UI pods, expose them with VirtualServices bound to the
+	// Gateway rather than Routes, and authorize requests with AuthorizationPolicies rather than the OAuth proxy.
+	// Pod to pod TLS is disabled in favor of the mesh mTLS. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Gateway the VirtualServices are bound to, as <namespace>/<name>. Required when enabled.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9-]+/[a-z0-9.-]+$`
+	// +kubebuilder:validation:Optional
+	Gateway string `json:"gateway,omitempty"`
+	// Domain served by the Gateway, the API Server and UI are exposed on <resource name>-<namespace>.<domain>.
+	// Required when enabled.
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+	// AllowedNamespaces are authorized to reach the API Server and UI from within the mesh, in addition to the
+	// DSPA and Gateway namespaces.
+	// +kubebuilder:validation:Optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// MTLSMode of the mesh mTLS on the MariaDB, Minio and MLMD gRPC connections, their pods are
+	// injected with sidecars and identified by their mesh certificates, which the mesh rotates.
+	// Set to one of the following values:
+	//
+	// - "PERMISSIVE" : Clients within the mesh use mTLS, clients outside of it are still accepted.
+	// - "STRICT" : Only mTLS connections are accepted, pipeline step pods must be part of the mesh.
+	//
+	// +kubebuilder:validation:Enum=PERMISSIVE;STRICT
+	// +kubebuilder:default:=PERMISSIVE
+	// +kubebuilder:validation:Optional
+	MTLSMode string `json:"mtlsMode,omitempty"`
+}
+
+type Monitoring struct {
+	// Scrape sets how Prometheus discovers the metrics of the exporters. Set to one of the following values:
+	//
+	// - "ServiceMonitor" : A ServiceMonitor is created for each exporter, for the Prometheus Operator, e.g. the
+	//   OpenShift user workload monitoring.
+	// - "Annotations" : The exporter pods are annotated with the prometheus.io/scrape, port and path annotations.
+	//
+	// +kubebuilder:validation:Enum=ServiceMonitor;Annotations
+	// +kubebuilder:default:=ServiceMonitor
+	// +kubebuilder:validation:Optional
+	Scrape string `json:"scrape,omitempty"`
+	// Exporters of the metrics of the MariaDB and Minio deployed by the operator, an external database or
+	// object storage is not monitored.
+	// +kubebuilder:validation:Optional
+	Exporters *MonitoringExporters `json:"exporters,omitempty"`
+	// Dashboards set to "true" creates a GrafanaDashboard of the DSPA health, API server latency and run counts,
+	// when the Grafana operator is installed. It is imported by the Grafana instances selected by the
+	// DSPO.Monitoring.Grafana.InstanceSelector operator config, labelled dashboards=grafana when unset. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Dashboards bool `json:"dashboards,omitempty"`
+	// Alerts configures a PrometheusRule alerting on the degradation of the DSPA components, for Alertmanager.
+	// +kubebuilder:validation:Optional
+	Alerts *MonitoringAlerts `json:"alerts,omitempty"`
+}
+
+type MonitoringAlerts struct {
+	// Enable to create the PrometheusRule of the DSPA alerts. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Severities override the severity label of the alerts, by alert name, e.g. DSPAPersistenceAgentDown: critical.
+	// The alerts are DSPAAPIServerDown, DSPAPersistenceAgentDown, DSPAScheduledWorkflowDown, DSPADatabaseUnreachable
+	// and DSPAArtifactStoreErrors.
+	// +kubebuilder:validation:Optional
+	Severities map[string]string `json:"severities,omitempty"`
+	// Labels are added to every alert, along with the dspa and dspa_namespace labels, e.g. to route them in
+	// Alertmanager.
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type MultiTenancy struct {
+	// Enable to run the API Server in multi-user mode, each request targets a namespace, and is authorized against
+	// the RBAC of the user in it. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Namespaces the pipelines run in, along with the DSPA namespace. The operator deploys the persistence agent,
+	// the scheduled workflow controller, the pipeline runner ServiceAccount and RBAC, the kfp-launcher ConfigMap
+	// and a copy of the object storage credentials in each of them. They must exist, be reconciled by the operator
+	// and not have a DSPA of their own.
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// WorkflowControllerScope of the workflow controller the DSPA deploys. Set to one of the following values:
+	//
+	// - "Namespace" : A workflow controller is deployed in each of the namespaces, only watching the workflows of
+	//   its namespace.
+	// - "Cluster" : The workflow controller of the DSPA namespace watches the workflows of all namespaces, bound to
+	//   a ClusterRole. It must be the only workflow controller of the cluster.
+	//
+	// +kubebuilder:validation:Enum=Namespace;Cluster
+	// +kubebuilder:default:=Namespace
+	// +kubebuilder:validation:Optional
+	WorkflowControllerScope string `json:"workflowControllerScope,omitempty"`
+}
+
+type MonitoringExporters struct {
+	// MariaDB metrics are exported by a mysqld-exporter sidecar of the MariaDB pods.
+	// +kubebuilder:validation:Optional
+	MariaDB *MariaDBExporter `json:"mariaDB,omitempty"`
+	// Minio metrics are served by Minio itself.
+	// +kubebuilder:validation:Optional
+	Minio *MinioExporter `json:"minio,omitempty"`
+}
+
+type MariaDBExporter struct {
+	// Enable to run the mysqld-exporter sidecar, connecting to MariaDB with the DSPA database credentials.
+	// Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Image of the mysqld-exporter sidecar. Defaults to the Images.MysqldExporter operator config, the
+	// upstream mysqld-exporter image when unset.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+	// Resources of the mysqld-exporter sidecar.
+	// +kubebuilder:validation:Optional
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+type MinioExporter struct {
+	// Enable to serve the Minio metrics to Prometheus without authentication. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// MetricsPath of the Minio metrics, as served by the Minio image. Default: /minio/v2/metrics/cluster
+	// +kubebuilder:validation:Pattern=`^/`
+	// +kubebuilder:validation:Optional
+	MetricsPath string `json:"metricsPath,omitempty"`
+}
+
+type RunLimits struct {
+	// Maximum number of runs executed concurrently, further runs are queued as pending by the workflow controller.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxConcurrentRuns int32 `json:"maxConcurrentRuns,omitempty"`
+	// Maximum number of pending runs. Runs are not rejected once it is reached, the DSPA is
+	// reported with a RunLimitExceeded Warning event until the pending runs are started.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxPendingRuns int32 `json:"maxPendingRuns,omitempty"`
+}
+
+type PodDefaults struct {
+	// Tolerations added to the pods of all pipeline steps.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector of the pods of all pipeline steps.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// RuntimeClassName of the pods of all pipeline steps, e.g. "nvidia".
+	// +kubebuilder:validation:Optional
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+	// Env added to the main container of all pipeline steps.
+	// +kubebuilder:validation:Optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnvFrom injects the keys of Secrets and ConfigMaps, e.g. shared credentials, as env of the main
+	// container of all pipeline steps.
+	// +kubebuilder:validation:Optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+	// Volumes added to the pods of all pipeline steps, e.g. Secret and ConfigMap volumes.
+	// The "kfp-workspace" name is reserved for the default workspace.
+	// +kubebuilder:validation:Optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// VolumeMounts of the volumes above in the main container of all pipeline steps.
+	// +kubebuilder:validation:Optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type TLS struct {
+	// IssuerRef references a cert-manager Issuer or ClusterIssuer. When set, the operator requests Certificates
+	// from cert-manager for MariaDB, Minio, MLMD gRPC and the API Server instead of relying on OpenShift service-ca,
+	// and enables TLS on all in-namespace connections regardless of podToPodTLS.
+	// +kubebuilder:validation:Optional
+	IssuerRef *CertManagerIssuerRef `json:"issuerRef,omitempty"`
+}
+
+type CertManagerIssuerRef struct {
+	// Name of the Issuer or ClusterIssuer.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Kind of the issuer, either Issuer or ClusterIssuer. Default: Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default:=Issuer
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+	// API group of the issuer. Default: cert-manager.io
+	// +kubebuilder:default:="cert-manager.io"
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
 }
 
 // +kubebuilder:validation:Pattern=`^(Managed|Removed)$`
@@ -77,6 +360,26 @@ type ManagedPipelinesSpec struct {
 	InstructLab *ManagedPipelineOptions `json:"instructLab,omitempty"`
 }
 
+type SamplePipeline struct {
+	// Name of the sample pipeline in the ManagedPipelinesMetadata of the operator configuration, case insensitive.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Set to one of the following values:
+	//
+	// - "Managed" : This pipeline is automatically imported.
+	// - "Removed" : This pipeline is not automatically imported, existing versions of the pipeline are not removed.
+	//
+	// +kubebuilder:validation:Enum=Managed;Removed
+	// +kubebuilder:default=Managed
+	// +kubebuilder:validation:Optional
+	State ManagedPipelineState `json:"state,omitempty"`
+	// Import the sample bundled with each release of the operator as a new version of the pipeline, which becomes its
+	// default version. When false, the pipeline keeps the version it was first imported with. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	AutoUpdateDefaultVersion *bool `json:"autoUpdateDefaultVersion,omitempty"`
+}
+
 type APIServer struct {
 	// Enable DS Pipelines Operator management of DSP API Server. Setting Deploy to false disables operator reconciliation. Default: true
 	// +kubebuilder:default:=true
@@ -106,11 +409,62 @@ type APIServer struct {
 	RHELAIImage string `json:"rhelAIImage,omitempty"`
 	// Enable various managed pipelines on this DSP API server.
 	ManagedPipelines *ManagedPipelinesSpec `json:"managedPipelines,omitempty"`
+	// Bundled sample pipelines loaded by this DSP API Server, named after their entry in the ManagedPipelinesMetadata of
+	// the operator configuration, e.g. iris or instructlab. An entry takes precedence over enableSamplePipeline for the
+	// iris sample, and over managedPipelines for the instructlab pipeline.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:Optional
+	SamplePipelines []SamplePipeline `json:"samplePipelines,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Ports the API server listens on, and is reached at through its Services, Route and oauth proxy. Default: 8888 for
+	// HTTP and 8887 for gRPC.
+	// +kubebuilder:validation:Optional
+	Ports *APIServerPorts `json:"ports,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Constrain how this component's pods are spread across the cluster. Defaults to spreading across zones and nodes when spec.ha is set.
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// Specify init container resource requirements. The init container
 	// is used to build managed-pipelines and store them in a shared volume.
 	InitResources *ResourceRequirements `json:"initResources,omitempty"`
+	// ArtifactStepResources are the resource requirements of the init and wait containers the workflow controller
+	// injects in the pods of all pipeline steps, which load and save their artifacts, e.g. so that they are not
+	// OOM-killed on big artifacts. They replace the artifact and move-results steps of DSP v1. Requires the DSPA
+	// workflowController to be deployed with its default configuration.
+	// +kubebuilder:validation:Optional
+	ArtifactStepResources *ResourceRequirements `json:"artifactStepResources,omitempty"`
+	// Specify the log level for DSP API Server. Defaults to the component's built-in level when omitted.
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel,omitempty"`
+	// Specify the log output format for DSP API Server, either "text" or "json". Defaults to the component's built-in format when omitted.
+	// +kubebuilder:validation:Enum=text;json
+	// +kubebuilder:validation:Optional
+	LogFormat string `json:"logFormat,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Additional flags appended to the DSP API Server command, e.g. "--maxConcurrentRuns=10". Flags managed by the
+	// operator (config, sample config, TLS and log level) cannot be set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=`^--?[A-Za-z][A-Za-z0-9_.-]*(=.*)?$`
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+	// KFP feature flags to enable or disable on the DSP API Server, passed as --<flag>=<true|false>.
+	// +kubebuilder:validation:Optional
+	FeatureFlags map[string]bool `json:"featureFlags,omitempty"`
 
 	// If the Object store/DB is behind a TLS secured connection that is
 	// unrecognized by the host OpenShift/K8s cluster, then you can
@@ -136,6 +490,14 @@ type APIServer struct {
 	// +kubebuilder:validation:Optional
 	CustomKfpLauncherConfigMap string `json:"customKfpLauncherConfigMap,omitempty"`
 
+	// TerminationStrategy is the Argo shutdown strategy applied to the Workflows of the runs terminated through
+	// the API server, the DSP v2 equivalent of the DSP v1 terminateStatus. "Stop" runs the exit handlers of the
+	// pipeline, as StoppedRunFinally and CancelledRunFinally ran the finally tasks, "Terminate" skips them, as
+	// Cancelled did. When unset the Workflows are only failed on the deadline the API server sets.
+	// +kubebuilder:validation:Enum=Stop;Terminate
+	// +kubebuilder:validation:Optional
+	TerminationStrategy string `json:"terminationStrategy,omitempty"`
+
 	// This is the path where the ca bundle will be mounted in the
 	// pipeline server and user executor pods
 	// +kubebuilder:validation:Optional
@@ -151,6 +513,187 @@ type APIServer struct {
 	// +kubebuilder:default:=60
 	// +kubebuilder:validation:Optional
 	ArtifactSignedURLExpirySeconds *int `json:"artifactSignedURLExpirySeconds"`
+
+	// Enable pipeline step caching. When set to false, steps are always executed and the
+	// cache cleanup CronJob is not deployed. Default: true
+	// +kubebuilder:validation:Optional
+	CacheEnabled *bool `json:"cacheEnabled,omitempty"`
+	// Default maximum age of cached step results that can be reused, for pipelines that don't
+	// set their own cache staleness, e.g. "24h". Default: no limit
+	// +kubebuilder:validation:Optional
+	DefaultCacheTTL *metav1.Duration `json:"defaultCacheTTL,omitempty"`
+
+	// CacheCleanup configures a CronJob that periodically removes expired
+	// pipeline cache entries from the DSP database.
+	// +kubebuilder:validation:Optional
+	CacheCleanup *CacheCleanup `json:"cacheCleanup,omitempty"`
+
+	// RunRetention configures a CronJob that periodically archives or deletes
+	// completed runs through the DSP API Server.
+	// +kubebuilder:validation:Optional
+	RunRetention *RunRetention `json:"runRetention,omitempty"`
+
+	// AuditLog records the API Server requests that create, change or delete pipelines, runs
+	// and experiments, with the user that made them, in a sidecar of the API Server.
+	// +kubebuilder:validation:Optional
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+
+	// DefaultWorkspace provisions a PVC that is mounted in the steps of all pipeline runs, as a workspace
+	// shared between steps. Requires the DSPA workflowController to be deployed.
+	// +kubebuilder:validation:Optional
+	DefaultWorkspace *DefaultWorkspace `json:"defaultWorkspace,omitempty"`
+
+	// Security configures who is authorized to use this DSP API Server through its OAuth proxy.
+	// +kubebuilder:validation:Optional
+	Security *APIServerSecurity `json:"security,omitempty"`
+
+	// DBConfig configures the pool of connections of the DSP API Server to its database, e.g. to keep
+	// the replicas of the API Server of large installs from exhausting the connections of the database.
+	// +kubebuilder:validation:Optional
+	DBConfig *APIServerDBConfig `json:"dbConfig,omitempty"`
+}
+
+type APIServerDBConfig struct {
+	// Maximum number of open connections to the database per API Server replica. Default: no limit
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxOpenConnections *int32 `json:"maxOpenConnections,omitempty"`
+	// Maximum number of idle connections kept open per API Server replica, at most maxOpenConnections. Default: 2
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxIdleConnections *int32 `json:"maxIdleConnections,omitempty"`
+	// Maximum time a connection is reused before it is closed, e.g. "5m". Default: 120s
+	// +kubebuilder:validation:Optional
+	ConnectionMaxLifetime *metav1.Duration `json:"connectionMaxLifetime,omitempty"`
+	// How long the API Server retries connecting to the database on startup before exiting, e.g. "10m". Default: 6m
+	// +kubebuilder:validation:Optional
+	ConnectionTimeout *metav1.Duration `json:"connectionTimeout,omitempty"`
+}
+
+type APIServerSecurity struct {
+	// RBAC configures the SubjectAccessReview the OAuth proxy of the DSP API Server runs for each request.
+	// +kubebuilder:validation:Optional
+	RBAC *APIServerRBAC `json:"rbac,omitempty"`
+}
+
+type APIServerRBAC struct {
+	// Set to one of the following values:
+	//
+	// - "Namespace" : Users that can get the DSP API Server Route, e.g. any editor or viewer of the namespace, are authorized.
+	// - "Groups" : Only members of the OpenShift groups below are authorized. The OAuth proxy checks that users can get
+	//              the "datasciencepipelinesapplications/api" subresource of this DSPA, and the operator creates a Role
+	//              granting it, bound to each group. Access can be granted to other users and groups by binding them to
+	//              the Role "ds-pipeline-group-access-<dspa name>".
+	//
+	// +kubebuilder:validation:Enum=Namespace;Groups
+	// +kubebuilder:default:=Namespace
+	// +kubebuilder:validation:Optional
+	Mode string `json:"mode,omitempty"`
+	// OpenShift groups whose members are authorized to use the DSP API Server, required in the "Groups" mode.
+	// +kubebuilder:validation:Optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+type DefaultWorkspace struct {
+	// Size of the workspace PVC. Default: 10Gi
+	// +kubebuilder:default:="10Gi"
+	// +kubebuilder:validation:Optional
+	Size resource.Quantity `json:"size,omitempty"`
+	// Volume Mode Filesystem storageClass to use for PVC creation
+	// +kubebuilder:validation:Optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Access mode of the workspace PVC, steps of a run may be scheduled on different nodes
+	// so ReadWriteOnce only suits single node clusters. Default: ReadWriteMany
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany;ReadWriteOncePod
+	// +kubebuilder:default:=ReadWriteMany
+	// +kubebuilder:validation:Optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+	// Path the workspace is mounted at in pipeline step containers. Default: /workspace
+	// +kubebuilder:default:="/workspace"
+	// +kubebuilder:validation:Optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+type CacheCleanup struct {
+	// Enable DS Pipelines Operator management of the cache cleanup CronJob. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Cron schedule on which cache cleanup is run. Default: "0 0 * * *"
+	// +kubebuilder:default:="0 0 * * *"
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+	// Cache entries older than this many hours are removed. Default: 168
+	// +kubebuilder:default:=168
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxAgeHours int `json:"maxAgeHours,omitempty"`
+	// Specify a custom image for the cache cleanup job. The image must
+	// provide a mysql client. Defaults to the MariaDB image.
+	Image string `json:"image,omitempty"`
+	// Specify custom Pod resource requirements for the cache cleanup job.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+type AuditLog struct {
+	// Enable the API Server audit log. Requires enableOauth, users are identified by the OAuth proxy. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Ship the audit log entries as JSON lines to the pipeline bucket of the object storage,
+	// under the audit-log/ prefix. Entries are always written to the audit log volume. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	ExportToObjectStorage bool `json:"exportToObjectStorage,omitempty"`
+	// Interval on which the audit log entries are shipped to the object storage. Default: "5m"
+	// +kubebuilder:validation:Optional
+	ExportInterval *metav1.Duration `json:"exportInterval,omitempty"`
+	// Specify a custom image for the audit log sidecar. The image must
+	// provide python3. Defaults to the toolbox image.
+	Image string `json:"image,omitempty"`
+	// Specify custom container resource requirements for the audit log sidecar.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+type RunRetention struct {
+	// Enable DS Pipelines Operator management of the run retention CronJob. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Cron schedule on which the run retention policy is applied. Default: "0 1 * * *"
+	// +kubebuilder:default:="0 1 * * *"
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+	// Completed runs that finished longer ago than this are removed, e.g. "720h".
+	// +kubebuilder:validation:Optional
+	CompletedRunTTL *metav1.Duration `json:"completedRunTTL,omitempty"`
+	// Only the most recent completed runs of each experiment, up to this number, are kept.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxRunsPerExperiment int `json:"maxRunsPerExperiment,omitempty"`
+	// Archived runs that finished longer ago than this are deleted, e.g. "2160h". The API server does not record when
+	// runs are archived, with the Archive action it should be longer than completedRunTTL.
+	// +kubebuilder:validation:Optional
+	ArchivedRunTTL *metav1.Duration `json:"archivedRunTTL,omitempty"`
+	// Apply the action to the experiments, other than the default one, created longer ago than completedRunTTL and
+	// left without active runs, and delete the archived experiments left without runs once archivedRunTTL passed.
+	// Requires completedRunTTL. Default: false
+	// +kubebuilder:validation:Optional
+	PruneExperiments bool `json:"pruneExperiments,omitempty"`
+	// Set to one of the following values:
+	//
+	// - "Archive" : Runs past the policy are archived, and remain available from the archive.
+	// - "Delete" : Runs past the policy are deleted.
+	//
+	// +kubebuilder:validation:Enum=Archive;Delete
+	// +kubebuilder:default:=Archive
+	// +kubebuilder:validation:Optional
+	Action string `json:"action,omitempty"`
+	// Specify a custom image for the run retention job. The image must
+	// provide python3. Defaults to the toolbox image.
+	Image string `json:"image,omitempty"`
+	// Specify custom Pod resource requirements for the run retention job.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
 }
 
 type CABundle struct {
@@ -177,8 +720,42 @@ type PersistenceAgent struct {
 	// Number of worker for Persistence Agent sync job. Default: 2
 	// +kubebuilder:default:=2
 	NumWorkers int `json:"numWorkers,omitempty"`
+	// Maximum queries per second the Persistence Agent sends to the Kubernetes API server. Defaults to the component's built-in value when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	ClientQPS int `json:"clientQPS,omitempty"`
+	// Maximum burst of queries the Persistence Agent sends to the Kubernetes API server. Defaults to the component's built-in value when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	ClientBurst int `json:"clientBurst,omitempty"`
+	// Interval at which the Persistence Agent resyncs every Workflow with the API server, e.g. "30s". Defaults to the component's built-in interval when omitted.
+	// +kubebuilder:validation:Optional
+	ResyncInterval *metav1.Duration `json:"resyncInterval,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Specify the log level for DSP PersistenceAgent. Defaults to the component's built-in level when omitted.
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel,omitempty"`
+	// Specify the log output format for DSP PersistenceAgent, either "text" or "json". Defaults to the component's built-in format when omitted.
+	// +kubebuilder:validation:Enum=text;json
+	// +kubebuilder:validation:Optional
+	LogFormat string `json:"logFormat,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 type ScheduledWorkflow struct {
@@ -191,8 +768,39 @@ type ScheduledWorkflow struct {
 	// Specify the Cron timezone used for ScheduledWorkflow PipelineRuns. Default: UTC
 	// +kubebuilder:default:=UTC
 	CronScheduleTimezone string `json:"cronScheduleTimezone,omitempty"`
+	// Maximum number of runs a recurring run may have running at once, for recurring runs that do not set their own. Defaults to the component's built-in value when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:validation:Optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// Whether recurring runs start the runs they missed while the controller was down, for recurring runs that do not set their own. Set to false to only resume from the next scheduled run. Defaults to the component's built-in behavior when omitted.
+	// +kubebuilder:validation:Optional
+	CatchUp *bool `json:"catchUp,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Specify the log level for DSP ScheduledWorkflow controller. Defaults to the component's built-in level when omitted.
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel,omitempty"`
+	// Specify the log output format for DSP ScheduledWorkflow controller, either "text" or "json". Defaults to the component's built-in format when omitted.
+	// +kubebuilder:validation:Enum=text;json
+	// +kubebuilder:validation:Optional
+	LogFormat string `json:"logFormat,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 type MlPipelineUI struct {
@@ -203,9 +811,127 @@ type MlPipelineUI struct {
 	ConfigMapName string `json:"configMap,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Constrain how this component's pods are spread across the cluster. Defaults to spreading across zones and nodes when spec.ha is set.
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// Specify a custom image for KFP UI pod.
 	// +kubebuilder:validation:Required
 	Image string `json:"image"`
+	// Brand the KFP UI with a title, logo and documentation links. The customization is merged into the UI ConfigMap managed by the operator, it is not applied when configMap is set.
+	// +kubebuilder:validation:Optional
+	Customization *UICustomization `json:"customization,omitempty"`
+	// Configure how the KFP UI fetches artifacts for previews, e.g. from a private S3 endpoint. Defaults to the object storage of this DSPA.
+	// +kubebuilder:validation:Optional
+	ArtifactProxy *UIArtifactProxy `json:"artifactProxy,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+type UICustomization struct {
+	// Title shown in the header and browser tab of the KFP UI.
+	// +kubebuilder:validation:Optional
+	Title string `json:"title,omitempty"`
+	// Logo shown in the header of the KFP UI, read from a ConfigMap in the DSPA namespace.
+	// +kubebuilder:validation:Optional
+	Logo *UILogo `json:"logo,omitempty"`
+	// Links to documentation added to the navigation of the KFP UI.
+	// +kubebuilder:validation:Optional
+	DocumentationLinks []UILink `json:"documentationLinks,omitempty"`
+}
+
+type UILogo struct {
+	// +kubebuilder:validation:Required
+	ConfigMapName string `json:"configMapName"`
+	// Key should map to an image. The key is also used to name
+	// the logo file (e.g. logo.svg)
+	// +kubebuilder:validation:Required
+	ConfigMapKey string `json:"configMapKey"`
+}
+
+type UILink struct {
+	// Text of the link.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// URL the link opens, must be an http or https URL.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+type UIArtifactProxy struct {
+	// Endpoint the UI fetches artifacts from, e.g. https://s3.example.com:9000. Defaults to the endpoint of the object storage of this DSPA.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint,omitempty"`
+	// Fetch artifacts with the credentials of the object storage of this DSPA. Set to false for endpoints serving artifacts anonymously. Default: true
+	// +kubebuilder:validation:Optional
+	ReuseCredentials *bool `json:"reuseCredentials,omitempty"`
+	// Address buckets in the URL path (https://host/bucket) rather than in the host name (https://bucket.host), as required by most S3 compatible stores. Default: false
+	// +kubebuilder:validation:Optional
+	PathStyle bool `json:"pathStyle,omitempty"`
+}
+
+type VisualizationServer struct {
+	// Enable DS Pipelines Operator management of the Visualization Server. Setting Deploy to false disables operator reconciliation. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Deploy bool `json:"deploy"`
+	// Specify a custom image for the Visualization Server pod.
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+	// Specify custom Pod resource requirements for this component.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Create an OpenShift Route, behind an OAuth proxy, for this Visualization Server. Default: false
+	// +kubebuilder:validation:Optional
+	EnableRoute bool `json:"enableRoute,omitempty"`
+	// Maximum time in seconds the kernel may run a visualization before it is interrupted. Defaults to the component's built-in value when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	KernelTimeoutSeconds int `json:"kernelTimeoutSeconds,omitempty"`
+	// Allow the KFP UI to request custom visualizations, which run arbitrary Python code in the kernel of the Visualization Server. Default: true
+	// +kubebuilder:validation:Optional
+	AllowCustomVisualizations *bool `json:"allowCustomVisualizations,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+}
+
+type CRDViewer struct {
+	// Enable DS Pipelines Operator management of the Viewer CRD controller. Setting Deploy to false disables operator reconciliation. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Deploy bool `json:"deploy"`
+	// Specify a custom image for the Viewer CRD controller pod.
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+	// Specify custom Pod resource requirements for this component.
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Maximum number of viewers kept running in the namespace, the oldest viewers are removed beyond it. Defaults to the component's built-in value when omitted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxNumViewers int `json:"maxNumViewers,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
 }
 
 type Database struct {
@@ -228,6 +954,43 @@ type Database struct {
 	// +kubebuilder:default:=false
 	// +kubebuilder:validation:Optional
 	DisableHealthCheck bool `json:"disableHealthCheck"`
+
+	// Backup configures a CronJob that periodically dumps the database to a PersistentVolumeClaim.
+	// +kubebuilder:validation:Optional
+	Backup *DatabaseBackup `json:"backup,omitempty"`
+}
+
+type DatabaseBackup struct {
+	// Enable DS Pipelines Operator management of the database backup CronJob. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Cron schedule on which the database is dumped, the latest dump replacing the previous one. Default: "0 2 * * *"
+	// +kubebuilder:default:="0 2 * * *"
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+	// Size of the PersistentVolumeClaim holding the dump. Default: 10Gi
+	// +kubebuilder:validation:Optional
+	PVCSize resource.Quantity `json:"pvcSize,omitempty"`
+	// Specify a custom image for the backup and verification jobs. The image must
+	// provide the mysqldump, mysql and mysqld binaries. Defaults to the MariaDB image.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+	// Periodically restore the dump into a throwaway MariaDB server, reporting the
+	// outcome in the BackupVerified condition.
+	// +kubebuilder:validation:Optional
+	Verification *BackupVerification `json:"verification,omitempty"`
+}
+
+type BackupVerification struct {
+	// Enable DS Pipelines Operator management of the backup verification CronJob. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Cron schedule on which the dump is verified. Default: "0 4 * * *"
+	// +kubebuilder:default:="0 4 * * *"
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 type MariaDB struct {
@@ -235,6 +998,13 @@ type MariaDB struct {
 	// +kubebuilder:default:=true
 	// +kubebuilder:validation:Optional
 	Deploy bool `json:"deploy"`
+	// Namespace to deploy MariaDB in, rather than the namespace of the DSPA, e.g. a locked-down namespace separate from
+	// the API server. The operator copies the database credentials Secret into it, and deletes its resources there along with the DSPA.
+	// Must be in the reconciliation scope of the operator, and cannot be set along with spec.tls.issuerRef or spec.serviceMesh.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
 	// Specify a custom image for DSP MariaDB pod.
 	Image string `json:"image,omitempty"`
 	// The MariadB username that will be created. Should match `^[a-zA-Z0-9_]+`. Default: mlpipeline
@@ -242,6 +1012,9 @@ type MariaDB struct {
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_]+$`
 	Username       string          `json:"username,omitempty"`
 	PasswordSecret *SecretKeyValue `json:"passwordSecret,omitempty"`
+	// Overrides the name and labels of the Secret holding the generated password, when no passwordSecret is set. An existing Secret of the default name is migrated to the new name.
+	// +kubebuilder:validation:Optional
+	GeneratedSecret *GeneratedSecret `json:"generatedSecret,omitempty"`
 	// +kubebuilder:default:=mlpipeline
 	// The database name that will be created. Should match `^[a-zA-Z0-9_]+`. // Default: mlpipeline
 	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_]+$`
@@ -254,15 +1027,111 @@ type MariaDB struct {
 	StorageClassName string `json:"storageClassName,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Deployment strategy of this component. Recreate avoids two pods contending for a ReadWriteOnce PVC during a rollout.
+	// +kubebuilder:default:=Recreate
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +kubebuilder:validation:Optional
+	DeploymentStrategy appsv1.DeploymentStrategyType `json:"deploymentStrategy,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// MariaDB server settings rendered into the [mariadb] section of a my.cnf mounted into the pod, e.g. max_connections: "300" or character_set_server: utf8mb4. These are set over the operator defaults of max_connections and innodb_buffer_pool_size, an empty value sets an option without a value. The pod is restarted when they change.
+	// +kubebuilder:validation:Optional
+	Config map[string]string `json:"config,omitempty"`
+	// Deploy MariaDB as a multi-primary Galera cluster StatefulSet instead of a single pod Deployment, so that the database survives the loss of a node. The image must ship the Galera provider and rsync. Enabling it on an existing DSPA starts an empty cluster, the data of the single pod is kept in its PVC but is not migrated.
+	// +kubebuilder:validation:Optional
+	HighAvailability *MariaDBHighAvailability `json:"highAvailability,omitempty"`
+}
+
+type MariaDBHighAvailability struct {
+	// Deploy MariaDB as a Galera cluster. Default: false
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+	// Number of Galera nodes, must be odd so that a majority of the nodes keeps a quorum when a node is lost. Default: 3
+	// +kubebuilder:default:=3
+	// +kubebuilder:validation:Minimum=3
+	// +kubebuilder:validation:Optional
+	Replicas int32 `json:"replicas,omitempty"`
 }
 
 type ExternalDB struct {
 	// +kubebuilder:validation:Required
-	Host           string          `json:"host"`
-	Port           string          `json:"port"`
-	Username       string          `json:"username"`
-	DBName         string          `json:"pipelineDBName"`
-	PasswordSecret *SecretKeyValue `json:"passwordSecret"`
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	Username string `json:"username"`
+	DBName   string `json:"pipelineDBName"`
+	// Secret containing the database password. Either passwordSecret or passwordSecretRef must be specified.
+	// +kubebuilder:validation:Optional
+	PasswordSecret *SecretKeyValue `json:"passwordSecret,omitempty"`
+	// Source the database password from an external secret manager through the External Secrets Operator.
+	// The operator creates an ExternalSecret and waits for the Secret to be materialized before deploying DSP components.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *ExternalSecretRef `json:"passwordSecretRef,omitempty"`
+	// TLS settings of the connections of the DSP API Server, MLMD and the database health check to the database. Default: the server certificate is verified
+	// +kubebuilder:validation:Optional
+	TLS *ExternalDBTLS `json:"tls,omitempty"`
+	// Major version of the MySQL server. MySQL 8 authenticates users with caching_sha2_password by default, which MLMD only supports over TLS, so connections to it cannot disable TLS. Default: "5.7"
+	// +kubebuilder:validation:Enum="5.7";"8"
+	// +kubebuilder:validation:Optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+}
+
+type ExternalDBTLS struct {
+	// Set to one of the following values:
+	//
+	// - "disable" : Connections are not encrypted.
+	// - "preferred" : Connections are encrypted when the server supports TLS, the server certificate is not verified.
+	// - "require" : Connections are encrypted, the server certificate is not verified.
+	// - "verify-full" : Connections are encrypted, the server certificate and hostname are verified against the CA
+	//                   bundles of the DSPA and the caSecret.
+	//
+	// +kubebuilder:validation:Enum=disable;preferred;require;verify-full
+	// +kubebuilder:default:=verify-full
+	// +kubebuilder:validation:Optional
+	Mode string `json:"mode,omitempty"`
+	// Secret key holding the PEM encoded CA certificate that issued the server certificate, trusted along with the CA bundles of the DSPA.
+	// +kubebuilder:validation:Optional
+	CASecret *SecretKeyValue `json:"caSecret,omitempty"`
+	// Name of a kubernetes.io/tls Secret holding the client certificate and key that MLMD and the database health check present to the database.
+	// +kubebuilder:validation:Optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+}
+
+// ExternalSecretRef references a credential stored in an external secret
+// manager, made available through the External Secrets Operator.
+type ExternalSecretRef struct {
+	// The secret manager the credential is stored in. Default: vault
+	// +kubebuilder:validation:Enum=vault
+	// +kubebuilder:default:=vault
+	// +kubebuilder:validation:Optional
+	Provider string `json:"provider,omitempty"`
+	// Name of the External Secrets Operator SecretStore configured for the provider.
+	// +kubebuilder:validation:Required
+	SecretStoreName string `json:"secretStoreName"`
+	// Kind of the SecretStore, either SecretStore or ClusterSecretStore. Default: SecretStore
+	// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+	// +kubebuilder:default:=SecretStore
+	// +kubebuilder:validation:Optional
+	SecretStoreKind string `json:"secretStoreKind,omitempty"`
+	// Path of the secret in the provider, e.g. "dspa/database".
+	// +kubebuilder:validation:Required
+	RemoteKey string `json:"remoteKey"`
+	// Property of the remote secret holding the credential. Defaults to the whole remote secret value when omitted.
+	// +kubebuilder:validation:Optional
+	Property string `json:"property,omitempty"`
+	// How often the Secret is refreshed from the provider. Default: 1h
+	// +kubebuilder:default:="1h"
+	// +kubebuilder:validation:Optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
 }
 
 type ObjectStorage struct {
@@ -277,18 +1146,95 @@ type ObjectStorage struct {
 	// +kubebuilder:default:=false
 	// +kubebuilder:validation:Optional
 	EnableExternalRoute bool `json:"enableExternalRoute"`
+	// Set to one of the following values:
+	//
+	// - "Create" : The operator creates the bucket, in the configured region and with BucketPolicy, if it does not exist.
+	// - "Verify" : The operator only verifies that the bucket exists, reporting it in the ObjectStoreAvailable condition otherwise.
+	// - "Skip" : The operator does not check the bucket, the DSP API Server attempts to create it when missing.
+	//
+	// Bucket checks run as part of the object storage health check. Default: Skip
+	// +kubebuilder:validation:Enum=Create;Verify;Skip
+	// +kubebuilder:default:=Skip
+	// +kubebuilder:validation:Optional
+	EnsureBucket EnsureBucketMode `json:"ensureBucket,omitempty"`
+	// S3 bucket policy document (JSON) applied to the bucket when it is created with ensureBucket Create.
+	// +kubebuilder:validation:Optional
+	BucketPolicy string `json:"bucketPolicy,omitempty"`
+	// Use separate buckets per purpose, e.g. to apply different retention policies to pipeline definitions and run
+	// artifacts. Buckets that are not set default to the minio or externalStorage bucket.
+	// +kubebuilder:validation:Optional
+	Buckets *ObjectStorageBuckets `json:"buckets,omitempty"`
+	// Copy the object storage credentials Secret into other namespaces, e.g. for pipeline steps running in them, and keep the copies in sync.
+	// +kubebuilder:validation:Optional
+	SecretPropagation *SecretPropagation `json:"secretPropagation,omitempty"`
+	// Store the run artifacts as OCI artifacts in a container registry, used as the default pipeline root. The pipeline
+	// definitions and the Argo artifact repository keep using the minio or externalStorage bucket.
+	// +kubebuilder:validation:Optional
+	OCIRegistry *OCIRegistry `json:"ociRegistry,omitempty"`
 }
 
+type SecretPropagation struct {
+	// Namespaces the credentials Secret is copied into, under the same name. Namespaces outside of the reconciliation scope of the operator are
+	// skipped, as are Secrets of the same name not copied by the operator.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+type ObjectStorageBuckets struct {
+	// Bucket where run artifacts are stored, used as the default pipeline root and the Argo artifact repository.
+	// +kubebuilder:validation:Optional
+	Artifacts string `json:"artifacts,omitempty"`
+	// Bucket where the DSP API Server stores uploaded pipeline definitions.
+	// +kubebuilder:validation:Optional
+	PipelineDefinitions string `json:"pipelineDefinitions,omitempty"`
+}
+
+type OCIRegistry struct {
+	// Host of the registry, with its port when not the default one, e.g. quay.io or registry.example.com:5000.
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+	// Repository the run artifacts are pushed to, e.g. my-org/pipeline-artifacts.
+	// +kubebuilder:validation:Required
+	Repository string `json:"repository"`
+	// Name of a kubernetes.io/dockerconfigjson Secret in the DSPA namespace with the credentials to push the artifacts
+	// to the repository and pull them from it. The registry is accessed anonymously when not set.
+	// +kubebuilder:validation:Optional
+	PushPullSecret string `json:"pushPullSecret,omitempty"`
+	// Access the registry over plain HTTP. Default: false
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+type EnsureBucketMode string
+
+const (
+	EnsureBucketCreate EnsureBucketMode = "Create"
+	EnsureBucketVerify EnsureBucketMode = "Verify"
+	EnsureBucketSkip   EnsureBucketMode = "Skip"
+)
+
 type Minio struct {
 	// Enable DS Pipelines Operator management of Minio. Setting Deploy to false disables operator reconciliation. Default: true
 	// +kubebuilder:default:=true
 	// +kubebuilder:validation:Optional
 	Deploy bool `json:"deploy"`
+	// Namespace to deploy Minio in, rather than the namespace of the DSPA, e.g. a locked-down namespace separate from
+	// the API server. The operator copies the object storage credentials Secret into it, and deletes its resources there along with the DSPA.
+	// Must be in the reconciliation scope of the operator, and cannot be set along with spec.tls.issuerRef or spec.serviceMesh.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
 	// Provide the Bucket name that will be used to store artifacts in S3. If provided bucket does not exist, DSP Apiserver will attempt to create it. As such the credentials provided should have sufficient permissions to do create buckets. Default: mlpipeline
 	// +kubebuilder:default:=mlpipeline
 	Bucket string `json:"bucket,omitempty"`
 	// Credentials for the S3 user (e.g. IAM user cred stored in a k8s secret.). Note that the S3 user should have the permissions to create a bucket if the provided bucket does not exist.
 	*S3CredentialSecret `json:"s3CredentialsSecret,omitempty"`
+	// Overrides the name and labels of the Secret holding the generated credentials, when no s3CredentialsSecret is set. An existing Secret of the default name is migrated to the new name.
+	// +kubebuilder:validation:Optional
+	GeneratedSecret *GeneratedSecret `json:"generatedSecret,omitempty"`
 	// Customize the size of the PVC created for the Minio instance. Default: 10Gi
 	// +kubebuilder:default:="10Gi"
 	PVCSize resource.Quantity `json:"pvcSize,omitempty"`
@@ -297,13 +1243,36 @@ type Minio struct {
 	StorageClassName string `json:"storageClassName,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Deployment strategy of this component. Recreate avoids two pods contending for a ReadWriteOnce PVC during a rollout.
+	// +kubebuilder:default:=Recreate
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	// +kubebuilder:validation:Optional
+	DeploymentStrategy appsv1.DeploymentStrategyType `json:"deploymentStrategy,omitempty"`
 	// Specify a custom image for Minio pod.
 	// +kubebuilder:validation:Required
 	Image string `json:"image"`
+	// Number of Minio nodes. A single node is deployed as a Deployment, 4 nodes or more are deployed as a distributed Minio StatefulSet, each node with its own PVC, with objects erasure coded across the nodes so that they survive the loss of up to half of them. The number of nodes of a distributed deployment cannot be changed once it stored data. Default: 1
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 type MLMD struct {
 	// Enable DS Pipelines Operator management of MLMD. Setting Deploy to false disables operator reconciliation. Default: true
+	// Its Envoy proxy and gRPC server are managed individually through spec.mlmd.envoy.deploy and spec.mlmd.grpc.deploy.
 	// +kubebuilder:default:=false
 	// +kubebuilder:validation:Optional
 	Deploy bool `json:"deploy"`
@@ -312,18 +1281,105 @@ type MLMD struct {
 }
 
 type Envoy struct {
+	// Deploy the MLMD Envoy proxy. The UI reads the pipeline run metadata through it, it cannot be disabled while
+	// spec.mlpipelineUI is deployed. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Deploy    *bool                 `json:"deploy,omitempty"`
 	Resources *ResourceRequirements `json:"resources,omitempty"`
 	Image     string                `json:"image,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Constrain how this component's pods are spread across the cluster. Defaults to spreading across zones and nodes when spec.ha is set.
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// +kubebuilder:default:=true
 	// +kubebuilder:validation:Optional
 	DeployRoute bool `json:"deployRoute"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Port the Envoy listener binds to and the metadata Service exposes. Default: 9090
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Optional
+	Port int32 `json:"port,omitempty"`
+	// Timeout for connecting to the MLMD gRPC upstream. Default: 30s
+	// +kubebuilder:validation:Optional
+	UpstreamTimeout *metav1.Duration `json:"upstreamTimeout,omitempty"`
+	// Terminate TLS on the Envoy listener. Clients connecting to the listener directly, including the UI, must then use https.
+	// +kubebuilder:validation:Optional
+	TLS *EnvoyTLS `json:"tls,omitempty"`
+	// Additional HTTP filters, each a single Envoy filter definition in YAML, inserted ahead of the router filter.
+	// +kubebuilder:validation:Optional
+	ExtraHTTPFilters []string `json:"extraHttpFilters,omitempty"`
+}
+
+type EnvoyTLS struct {
+	// Name of a kubernetes.io/tls Secret, in the DSPA namespace, holding the listener certificate and key.
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
 }
 
 type GRPC struct {
+	// Deploy the MLMD gRPC server. The API server and the pipeline runs record their metadata in it, it cannot be
+	// disabled while spec.apiServer is deployed, and the Envoy proxy requires it. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Deploy    *bool                 `json:"deploy,omitempty"`
 	Resources *ResourceRequirements `json:"resources,omitempty"`
 	Image     string                `json:"image,omitempty"`
+	// Override the liveness and readiness probe timings of this component's main container.
+	// +kubebuilder:validation:Optional
+	Probes *Probes `json:"probes,omitempty"`
+	// Tune the rolling update of this component's Deployment. Defaults to the Kubernetes RollingUpdate defaults.
+	// +kubebuilder:validation:Optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Constrain how this component's pods are spread across the cluster. Defaults to spreading across zones and nodes when spec.ha is set.
+	// +kubebuilder:validation:Optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 	// +kubebuilder:validation:Optional
 	Port string `json:"port"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Create an additional Service for the MLMD gRPC server, e.g. to give clients in other namespaces a stable DNS name.
+	// +kubebuilder:validation:Optional
+	Service *GRPCService `json:"service,omitempty"`
+	// Namespaces whose pods are allowed to connect to the MLMD gRPC server, in addition to the pods of the DSPA.
+	// +kubebuilder:validation:Optional
+	ClientNamespaces []string `json:"clientNamespaces,omitempty"`
+	// Expose the MLMD gRPC server outside the cluster for remote metadata clients, through a Route re-encrypting to the
+	// server, or an Ingress on the kubernetes platform. Requires pod to pod TLS. Default: false
+	// +kubebuilder:validation:Optional
+	EnableRoute bool `json:"enableRoute,omitempty"`
+}
+
+// GRPCService configures an additional Service of the MLMD gRPC server.
+type GRPCService struct {
+	// Name of the Service, required unless the Service is headless. Default: ds-pipeline-metadata-grpc-headless-<DSPA name>
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// Create a headless Service, resolving to the IPs of the MLMD gRPC pods. Their DNS subdomain is then set to the
+	// Service, so that each pod is also resolved at <pod name>.<Service name>.<namespace>.svc. Default: false
+	// +kubebuilder:validation:Optional
+	Headless bool `json:"headless,omitempty"`
 }
 
 type Writer struct {
@@ -341,6 +1397,15 @@ type WorkflowController struct {
 	CustomConfig  string `json:"customConfig,omitempty"`
 	// Specify custom Pod resource requirements for this component.
 	Resources *ResourceRequirements `json:"resources,omitempty"`
+	// Specify a custom container SecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Specify a custom PodSecurityContext for this component. Defaults to settings compliant with the restricted Pod Security Standard.
+	// +kubebuilder:validation:Optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Run this component as an existing ServiceAccount instead of one created by the operator. The operator binds the Roles required by this component to the given ServiceAccount.
+	// +kubebuilder:validation:Optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
 }
 
 // ResourceRequirements structures compute resource requirements.
@@ -356,8 +1421,57 @@ type Resources struct {
 	Memory resource.Quantity `json:"memory,omitempty"`
 }
 
+// Rollout tunes the RollingUpdate strategy of a stateless component's Deployment.
+type Rollout struct {
+	// Maximum number of pods, or percentage of desired pods, created above the desired count during an update.
+	// +kubebuilder:validation:Optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// Maximum number of pods, or percentage of desired pods, that can be unavailable during an update.
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// APIServerPorts sets the ports of the API server, unset ports keep their defaults.
+type APIServerPorts struct {
+	// Port of the REST API. Default: 8888
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Optional
+	HTTP int32 `json:"http,omitempty"`
+	// Port of the gRPC API. Default: 8887
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Optional
+	GRPC int32 `json:"grpc,omitempty"`
+}
+
+// Probes overrides the timings of a container's liveness and readiness probes,
+// unset fields keep the component's defaults.
+type Probes struct {
+	// +kubebuilder:validation:Optional
+	Liveness *ProbeTiming `json:"liveness,omitempty"`
+	// +kubebuilder:validation:Optional
+	Readiness *ProbeTiming `json:"readiness,omitempty"`
+}
+
+type ProbeTiming struct {
+	// Seconds after the container has started before the probe is initiated.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// How often, in seconds, to perform the probe.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// Consecutive failures after which the probe is considered failed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
 type ExternalStorage struct {
-	// +kubebuilder:validation:Required
+	// Required unless composed from the dataConnectionRef.
+	// +kubebuilder:validation:Optional
 	Host   string `json:"host"`
 	Bucket string `json:"bucket"`
 	Scheme string `json:"scheme"`
@@ -371,6 +1485,46 @@ type ExternalStorage struct {
 	Secure *bool `json:"secure"`
 	// +kubebuilder:validation:Optional
 	Port string `json:"port"`
+	// Name of an ODH Dashboard data connection Secret in the DSPA namespace. The host, scheme, port,
+	// bucket, region and credentials are composed from its AWS_* keys, fields set here take precedence.
+	// +kubebuilder:validation:Optional
+	DataConnectionRef string `json:"dataConnectionRef,omitempty"`
+	// Transfer the objects through the S3 Transfer Acceleration endpoint of the bucket, which must have Transfer
+	// Acceleration enabled. Only supported for AWS S3 hosts. Default: false
+	// +kubebuilder:validation:Optional
+	TransferAcceleration bool `json:"transferAcceleration,omitempty"`
+	// Endpoints the DSPA fails over to when the host fails the object storage health check, e.g. the replica of the
+	// bucket in another region, tried by ascending priority. The components use the first endpoint passing the health
+	// check, and fail back to the host once it passes it again. The credentials are shared with the host.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	FailoverEndpoints []ObjectStorageEndpoint `json:"failoverEndpoints,omitempty"`
+	// Attempts of the DSP API Server artifact client on retryable object storage errors before failing a request.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+}
+
+// ObjectStorageEndpoint is an endpoint the object storage fails over to.
+type ObjectStorageEndpoint struct {
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+	// Defaults to the scheme of the externalStorage.
+	// +kubebuilder:validation:Optional
+	Scheme string `json:"scheme,omitempty"`
+	// +kubebuilder:validation:Optional
+	Port string `json:"port,omitempty"`
+	// Defaults to the region of the externalStorage.
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+	// Bucket replicating the externalStorage bucket at this endpoint. Defaults to the externalStorage bucket.
+	// +kubebuilder:validation:Optional
+	Bucket string `json:"bucket,omitempty"`
+	// Endpoints with a lower priority are tried first, endpoints of the same priority in the order they are listed.
+	// Default: 0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 type S3CredentialSecret struct {
@@ -382,6 +1536,18 @@ type S3CredentialSecret struct {
 	SecretKey string `json:"secretKey"`
 }
 
+// GeneratedSecret overrides the name and labels of a Secret holding credentials generated by the operator
+type GeneratedSecret struct {
+	// Name of the Secret. Default: ds-pipeline-db-<dspa name> for the database, ds-pipeline-s3-<dspa name> for the object storage
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// Labels added to the Secret, alongside the labels set by the operator
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
 type SecretKeyValue struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
@@ -392,18 +1558,125 @@ type DSPAStatus struct {
 	// +kubebuilder:validation:Optional
 	Components ComponentStatus    `json:"components,omitempty"`
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ResolvedImageDigests maps default images to the digest they were pinned to, when image digest resolution is enabled in the operator config.
+	// +kubebuilder:validation:Optional
+	ResolvedImageDigests map[string]string `json:"resolvedImageDigests,omitempty"`
+	// FIPSEnabled reports whether DSPA components are deployed in FIPS mode.
+	// +kubebuilder:validation:Optional
+	FIPSEnabled *bool `json:"fipsEnabled,omitempty"`
+	// FeatureGates lists the operator feature gates enabled for the DSPA, from the --feature-gates flag of the operator
+	// and the datasciencepipelinesapplications.opendatahub.io/feature-gates annotation of the DSPA.
+	// +kubebuilder:validation:Optional
+	FeatureGates []string `json:"featureGates,omitempty"`
+	// Runs reports the current usage of the run limits, when spec.limits is set.
+	// +kubebuilder:validation:Optional
+	Runs *RunUsage `json:"runs,omitempty"`
+	// ManagementState the DSPA was last reconciled in, from spec.managementState or the operator config.
+	// +kubebuilder:validation:Optional
+	ManagementState ManagementState `json:"managementState,omitempty"`
+	// Platform the DSPA was last reconciled for, from spec.platform or the detected platform.
+	// +kubebuilder:validation:Optional
+	Platform Platform `json:"platform,omitempty"`
+	// PendingChanges counts the resources the operator would create or update, while the DSPA is annotated with
+	// datasciencepipelinesapplications.opendatahub.io/diff.
+	// +kubebuilder:validation:Optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
+	// DSPVersionDetail rolls up the DSP version and the component images the DSPA runs, e.g. for support cases.
+	// +kubebuilder:validation:Optional
+	DSPVersionDetail *DSPVersionDetail `json:"dspVersionDetail,omitempty"`
+	// ComponentNamespaces lists the namespaces other than its own that the components of the DSPA are deployed in,
+	// from spec.database.mariaDB.namespace, spec.objectStorage.minio.namespace and spec.multiTenancy.namespaces, so that their resources are
+	// deleted from them once they are no longer used.
+	// +kubebuilder:validation:Optional
+	ComponentNamespaces []string `json:"componentNamespaces,omitempty"`
+	// Buckets and database the runs of the DSPA are stored in, recorded once it is deployed. Changing them in the spec
+	// breaks the artifacts and lineage of the existing runs, the change is not applied and the SpecChangeUnsupported
+	// condition is reported instead.
+	// +kubebuilder:validation:Optional
+	Storage *StorageBinding `json:"storage,omitempty"`
+}
+
+type StorageBinding struct {
+	// Bucket the run artifacts are stored in.
+	// +kubebuilder:validation:Optional
+	ArtifactBucket string `json:"artifactBucket,omitempty"`
+	// Bucket the pipeline definitions are stored in.
+	// +kubebuilder:validation:Optional
+	PipelineBucket string `json:"pipelineBucket,omitempty"`
+	// Name of the database of the API server and ML Metadata.
+	// +kubebuilder:validation:Optional
+	DatabaseName string `json:"databaseName,omitempty"`
+}
+
+type PendingChanges struct {
+	// ConfigMap detailing the resources to create or update, and their changed fields.
+	ConfigMap string `json:"configMap"`
+	// Create is the number of resources that do not exist yet.
+	Create int32 `json:"create"`
+	// Update is the number of live resources that differ from their rendered manifests.
+	Update int32 `json:"update"`
+	// Unchanged is the number of live resources that match their rendered manifests.
+	Unchanged int32 `json:"unchanged"`
+}
+
+type RunUsage struct {
+	// Running is the number of runs being executed.
+	Running int32 `json:"running"`
+	// Pending is the number of runs queued by the workflow controller.
+	Pending int32 `json:"pending"`
 }
 
 type ComponentStatus struct {
 	// +kubebuilder:validation:Optional
 	MLMDProxy ComponentDetailStatus `json:"mlmdProxy,omitempty"`
 	APIServer ComponentDetailStatus `json:"apiServer,omitempty"`
+	// +kubebuilder:validation:Optional
+	PersistenceAgent ComponentDetailStatus `json:"persistenceAgent,omitempty"`
+	// +kubebuilder:validation:Optional
+	ScheduledWorkflow ComponentDetailStatus `json:"scheduledWorkflow,omitempty"`
+	// +kubebuilder:validation:Optional
+	MlPipelineUI ComponentDetailStatus `json:"mlPipelineUI,omitempty"`
+	// +kubebuilder:validation:Optional
+	VisualizationServer ComponentDetailStatus `json:"visualizationServer,omitempty"`
+	// +kubebuilder:validation:Optional
+	CRDViewer ComponentDetailStatus `json:"crdViewer,omitempty"`
+	// +kubebuilder:validation:Optional
+	MLMDGRPC ComponentDetailStatus `json:"mlmdGRPC,omitempty"`
+	// +kubebuilder:validation:Optional
+	MariaDB ComponentDetailStatus `json:"mariaDB,omitempty"`
+	// +kubebuilder:validation:Optional
+	Minio ComponentDetailStatus `json:"minio,omitempty"`
+	// +kubebuilder:validation:Optional
+	WorkflowController ComponentDetailStatus `json:"workflowController,omitempty"`
 }
 
 type ComponentDetailStatus struct {
 	Url string `json:"url,omitempty"`
 	// +kubebuilder:validation:Optional
 	ExternalUrl string `json:"externalUrl,omitempty"`
+	// Image the component is deployed with.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+	// Where the image was resolved from: "DSPA" when set in the DSPA spec, or "OperatorConfig" when defaulted from the DSPO config.
+	// +kubebuilder:validation:Optional
+	ImageSource string `json:"imageSource,omitempty"`
+	// Node architecture the image was selected for, when the DSPO config sets the image per architecture. The pods of the
+	// component are only scheduled on nodes of that architecture.
+	// +kubebuilder:validation:Optional
+	Architecture string `json:"architecture,omitempty"`
+	// Digest of the image the running pods of the component were pulled at, as reported by the kubelet. Unset while the pods
+	// report different digests, e.g. during a rollout.
+	// +kubebuilder:validation:Optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+}
+
+type DSPVersionDetail struct {
+	// DSPVersion the DSPA is deployed at.
+	// +kubebuilder:validation:Optional
+	DSPVersion string `json:"dspVersion,omitempty"`
+	// Images maps each deployed component to the image it runs, pinned by digest once its pods report one.
+	// +kubebuilder:validation:Optional
+	Images map[string]string `json:"images,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,174 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PipelineSpec struct {
+	// Name of the DataSciencePipelinesApplication, in the namespace of this resource, whose API server the pipeline is
+	// uploaded to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dspaName is immutable"
+	DSPAName string `json:"dspaName"`
+	// Name of the pipeline in the API server. Defaults to the name of this resource.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="displayName is immutable"
+	DisplayName string `json:"displayName,omitempty"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// The pipeline definition compiled by the KFP SDK, in YAML. Each change is uploaded as a new version of the
+	// pipeline, previous versions are kept.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	PipelineSpec string `json:"pipelineSpec"`
+	// Name of the pipeline version uploaded from pipelineSpec. Defaults to a digest of pipelineSpec.
+	// +kubebuilder:validation:Optional
+	VersionName string `json:"versionName,omitempty"`
+}
+
+type PipelineStatus struct {
+	// ID of the pipeline in the API server.
+	// +kubebuilder:validation:Optional
+	PipelineID string `json:"pipelineID,omitempty"`
+	// ID of the pipeline version uploaded from the current pipelineSpec.
+	// +kubebuilder:validation:Optional
+	PipelineVersionID string `json:"pipelineVersionID,omitempty"`
+	// Name of the pipeline version uploaded from the current pipelineSpec.
+	// +kubebuilder:validation:Optional
+	VersionName string `json:"versionName,omitempty"`
+	// Generation of the spec last synced to the API server.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DSPA",type=string,JSONPath=`.spec.dspaName`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.versionName`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+
+// Pipeline is a pipeline, and its versions, uploaded by the operator to the API server of a
+// DataSciencePipelinesApplication. Requires the DeclarativePipelines feature gate.
+type Pipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PipelineSpec   `json:"spec,omitempty"`
+	Status            PipelineStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type PipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Pipeline `json:"items"`
+}
+
+type RecurringRunSpec struct {
+	// Name of the DataSciencePipelinesApplication, in the namespace of this resource, whose API server schedules the
+	// runs.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dspaName is immutable"
+	DSPAName string `json:"dspaName"`
+	// Name of the recurring run in the API server. Defaults to the name of this resource.
+	// +kubebuilder:validation:Optional
+	DisplayName string `json:"displayName,omitempty"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Name of the Pipeline resource, in the namespace of this resource, whose current version is run.
+	// +kubebuilder:validation:Required
+	PipelineRef string `json:"pipelineRef"`
+	// Cron schedule of the runs, with a leading seconds field, e.g. "0 0 * * * *" runs every hour.
+	// +kubebuilder:validation:Required
+	Cron string `json:"cron"`
+	// Maximum number of runs of the recurring run executing at once. Default: 1
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:validation:Optional
+	MaxConcurrency int64 `json:"maxConcurrency,omitempty"`
+	// Skip the runs missed while the recurring run was disabled, or its schedule could not be met. Default: false
+	// +kubebuilder:validation:Optional
+	NoCatchup bool `json:"noCatchup,omitempty"`
+	// Schedule the runs, disabling keeps the recurring run in the API server without starting runs. Default: true
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Values of the pipeline parameters, by name.
+	// +kubebuilder:validation:Optional
+	Parameters map[string]apiextensionsv1.JSON `json:"parameters,omitempty"`
+}
+
+type RecurringRunStatus struct {
+	// ID of the recurring run in the API server.
+	// +kubebuilder:validation:Optional
+	RecurringRunID string `json:"recurringRunID,omitempty"`
+	// ID of the pipeline version the recurring run runs.
+	// +kubebuilder:validation:Optional
+	PipelineVersionID string `json:"pipelineVersionID,omitempty"`
+	// Digest of the settings the recurring run was created with, the API server does not update recurring runs, they
+	// are recreated once their settings change.
+	// +kubebuilder:validation:Optional
+	SpecDigest string `json:"specDigest,omitempty"`
+	// Whether the API server schedules the runs.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+	// When the latest run was started.
+	// +kubebuilder:validation:Optional
+	LastTriggeredTime *metav1.Time `json:"lastTriggeredTime,omitempty"`
+	// When the next run is scheduled.
+	// +kubebuilder:validation:Optional
+	NextTriggeredTime *metav1.Time `json:"nextTriggeredTime,omitempty"`
+	// Generation of the spec last synced to the API server.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="DSPA",type=string,JSONPath=`.spec.dspaName`
+//+kubebuilder:printcolumn:name="Pipeline",type=string,JSONPath=`.spec.pipelineRef`
+//+kubebuilder:printcolumn:name="Cron",type=string,JSONPath=`.spec.cron`
+//+kubebuilder:printcolumn:name="Last Run",type=date,JSONPath=`.status.lastTriggeredTime`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+
+// RecurringRun is a schedule of runs of a Pipeline, created by the operator in the API server of a
+// DataSciencePipelinesApplication. Requires the DeclarativePipelines feature gate.
+type RecurringRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              RecurringRunSpec   `json:"spec,omitempty"`
+	Status            RecurringRunStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+type RecurringRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecurringRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Pipeline{}, &PipelineList{}, &RecurringRun{}, &RecurringRunList{})
+}
@@ -22,8 +22,11 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -34,16 +37,72 @@ func (in *APIServer) DeepCopyInto(out *APIServer) {
 		*out = new(ManagedPipelinesSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SamplePipelines != nil {
+		in, out := &in.SamplePipelines, &out.SamplePipelines
+		*out = make([]SamplePipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = new(APIServerPorts)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.InitResources != nil {
 		in, out := &in.InitResources, &out.InitResources
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArtifactStepResources != nil {
+		in, out := &in.ArtifactStepResources, &out.ArtifactStepResources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureFlags != nil {
+		in, out := &in.FeatureFlags, &out.FeatureFlags
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CABundle != nil {
 		in, out := &in.CABundle, &out.CABundle
 		*out = new(CABundle)
@@ -59,6 +118,46 @@ func (in *APIServer) DeepCopyInto(out *APIServer) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.CacheEnabled != nil {
+		in, out := &in.CacheEnabled, &out.CacheEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultCacheTTL != nil {
+		in, out := &in.DefaultCacheTTL, &out.DefaultCacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CacheCleanup != nil {
+		in, out := &in.CacheCleanup, &out.CacheCleanup
+		*out = new(CacheCleanup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunRetention != nil {
+		in, out := &in.RunRetention, &out.RunRetention
+		*out = new(RunRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultWorkspace != nil {
+		in, out := &in.DefaultWorkspace, &out.DefaultWorkspace
+		*out = new(DefaultWorkspace)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(APIServerSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DBConfig != nil {
+		in, out := &in.DBConfig, &out.DBConfig
+		*out = new(APIServerDBConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServer.
@@ -71,6 +170,136 @@ func (in *APIServer) DeepCopy() *APIServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerDBConfig) DeepCopyInto(out *APIServerDBConfig) {
+	*out = *in
+	if in.MaxOpenConnections != nil {
+		in, out := &in.MaxOpenConnections, &out.MaxOpenConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxIdleConnections != nil {
+		in, out := &in.MaxIdleConnections, &out.MaxIdleConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConnectionMaxLifetime != nil {
+		in, out := &in.ConnectionMaxLifetime, &out.ConnectionMaxLifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ConnectionTimeout != nil {
+		in, out := &in.ConnectionTimeout, &out.ConnectionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerDBConfig.
+func (in *APIServerDBConfig) DeepCopy() *APIServerDBConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerDBConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerPorts) DeepCopyInto(out *APIServerPorts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerPorts.
+func (in *APIServerPorts) DeepCopy() *APIServerPorts {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerPorts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerRBAC) DeepCopyInto(out *APIServerRBAC) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerRBAC.
+func (in *APIServerRBAC) DeepCopy() *APIServerRBAC {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerRBAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerSecurity) DeepCopyInto(out *APIServerSecurity) {
+	*out = *in
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(APIServerRBAC)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSecurity.
+func (in *APIServerSecurity) DeepCopy() *APIServerSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+	if in.ExportInterval != nil {
+		in, out := &in.ExportInterval, &out.ExportInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerification) DeepCopyInto(out *BackupVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerification.
+func (in *BackupVerification) DeepCopy() *BackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CABundle) DeepCopyInto(out *CABundle) {
 	*out = *in
@@ -86,6 +315,71 @@ func (in *CABundle) DeepCopy() *CABundle {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDViewer) DeepCopyInto(out *CRDViewer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDViewer.
+func (in *CRDViewer) DeepCopy() *CRDViewer {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDViewer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheCleanup) DeepCopyInto(out *CacheCleanup) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheCleanup.
+func (in *CacheCleanup) DeepCopy() *CacheCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentDetailStatus) DeepCopyInto(out *ComponentDetailStatus) {
 	*out = *in
@@ -106,6 +400,15 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
 	out.MLMDProxy = in.MLMDProxy
 	out.APIServer = in.APIServer
+	out.PersistenceAgent = in.PersistenceAgent
+	out.ScheduledWorkflow = in.ScheduledWorkflow
+	out.MlPipelineUI = in.MlPipelineUI
+	out.VisualizationServer = in.VisualizationServer
+	out.CRDViewer = in.CRDViewer
+	out.MLMDGRPC = in.MLMDGRPC
+	out.MariaDB = in.MariaDB
+	out.Minio = in.Minio
+	out.WorkflowController = in.WorkflowController
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -146,6 +449,16 @@ func (in *DSPASpec) DeepCopyInto(out *DSPASpec) {
 		*out = new(MlPipelineUI)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VisualizationServer != nil {
+		in, out := &in.VisualizationServer, &out.VisualizationServer
+		*out = new(VisualizationServer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRDViewer != nil {
+		in, out := &in.CRDViewer, &out.CRDViewer
+		*out = new(CRDViewer)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ObjectStorage != nil {
 		in, out := &in.ObjectStorage, &out.ObjectStorage
 		*out = new(ObjectStorage)
@@ -166,6 +479,51 @@ func (in *DSPASpec) DeepCopyInto(out *DSPASpec) {
 		*out = new(WorkflowController)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FIPSMode != nil {
+		in, out := &in.FIPSMode, &out.FIPSMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HA != nil {
+		in, out := &in.HA, &out.HA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMesh)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RunLimits)
+		**out = **in
+	}
+	if in.PodDefaults != nil {
+		in, out := &in.PodDefaults, &out.PodDefaults
+		*out = new(PodDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MultiTenancy != nil {
+		in, out := &in.MultiTenancy, &out.MultiTenancy
+		*out = new(MultiTenancy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSPASpec.
@@ -189,6 +547,48 @@ func (in *DSPAStatus) DeepCopyInto(out *DSPAStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResolvedImageDigests != nil {
+		in, out := &in.ResolvedImageDigests, &out.ResolvedImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FIPSEnabled != nil {
+		in, out := &in.FIPSEnabled, &out.FIPSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = new(RunUsage)
+		**out = **in
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		**out = **in
+	}
+	if in.DSPVersionDetail != nil {
+		in, out := &in.DSPVersionDetail, &out.DSPVersionDetail
+		*out = new(DSPVersionDetail)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ComponentNamespaces != nil {
+		in, out := &in.ComponentNamespaces, &out.ComponentNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageBinding)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSPAStatus.
@@ -201,6 +601,28 @@ func (in *DSPAStatus) DeepCopy() *DSPAStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DSPVersionDetail) DeepCopyInto(out *DSPVersionDetail) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DSPVersionDetail.
+func (in *DSPVersionDetail) DeepCopy() *DSPVersionDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(DSPVersionDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSciencePipelinesApplication) DeepCopyInto(out *DataSciencePipelinesApplication) {
 	*out = *in
@@ -278,6 +700,11 @@ func (in *Database) DeepCopyInto(out *Database) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(DatabaseBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Database.
@@ -291,59 +718,213 @@ func (in *Database) DeepCopy() *Database {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Envoy) DeepCopyInto(out *Envoy) {
+func (in *DatabaseBackup) DeepCopyInto(out *DatabaseBackup) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	out.PVCSize = in.PVCSize.DeepCopy()
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(BackupVerification)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Envoy.
-func (in *Envoy) DeepCopy() *Envoy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackup.
+func (in *DatabaseBackup) DeepCopy() *DatabaseBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(Envoy)
+	out := new(DatabaseBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalDB) DeepCopyInto(out *ExternalDB) {
+func (in *DefaultWorkspace) DeepCopyInto(out *DefaultWorkspace) {
 	*out = *in
-	if in.PasswordSecret != nil {
-		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(SecretKeyValue)
-		**out = **in
-	}
+	out.Size = in.Size.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDB.
-func (in *ExternalDB) DeepCopy() *ExternalDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultWorkspace.
+func (in *DefaultWorkspace) DeepCopy() *DefaultWorkspace {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalDB)
+	out := new(DefaultWorkspace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalStorage) DeepCopyInto(out *ExternalStorage) {
+func (in *Envoy) DeepCopyInto(out *Envoy) {
 	*out = *in
-	if in.S3CredentialSecret != nil {
-		in, out := &in.S3CredentialSecret, &out.S3CredentialSecret
-		*out = new(S3CredentialSecret)
-		**out = **in
-	}
-	if in.Secure != nil {
-		in, out := &in.Secure, &out.Secure
+	if in.Deploy != nil {
+		in, out := &in.Deploy, &out.Deploy
 		*out = new(bool)
 		**out = **in
 	}
-}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpstreamTimeout != nil {
+		in, out := &in.UpstreamTimeout, &out.UpstreamTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EnvoyTLS)
+		**out = **in
+	}
+	if in.ExtraHTTPFilters != nil {
+		in, out := &in.ExtraHTTPFilters, &out.ExtraHTTPFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Envoy.
+func (in *Envoy) DeepCopy() *Envoy {
+	if in == nil {
+		return nil
+	}
+	out := new(Envoy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyTLS) DeepCopyInto(out *EnvoyTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyTLS.
+func (in *EnvoyTLS) DeepCopy() *EnvoyTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDB) DeepCopyInto(out *ExternalDB) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretKeyValue)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(ExternalSecretRef)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalDBTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDB.
+func (in *ExternalDB) DeepCopy() *ExternalDB {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDBTLS) DeepCopyInto(out *ExternalDBTLS) {
+	*out = *in
+	if in.CASecret != nil {
+		in, out := &in.CASecret, &out.CASecret
+		*out = new(SecretKeyValue)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDBTLS.
+func (in *ExternalDBTLS) DeepCopy() *ExternalDBTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDBTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRef) DeepCopyInto(out *ExternalSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRef.
+func (in *ExternalSecretRef) DeepCopy() *ExternalSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalStorage) DeepCopyInto(out *ExternalStorage) {
+	*out = *in
+	if in.S3CredentialSecret != nil {
+		in, out := &in.S3CredentialSecret, &out.S3CredentialSecret
+		*out = new(S3CredentialSecret)
+		**out = **in
+	}
+	if in.Secure != nil {
+		in, out := &in.Secure, &out.Secure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailoverEndpoints != nil {
+		in, out := &in.FailoverEndpoints, &out.FailoverEndpoints
+		*out = make([]ObjectStorageEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalStorage.
 func (in *ExternalStorage) DeepCopy() *ExternalStorage {
@@ -356,94 +937,1038 @@ func (in *ExternalStorage) DeepCopy() *ExternalStorage {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GRPC) DeepCopyInto(out *GRPC) {
+func (in *GRPC) DeepCopyInto(out *GRPC) {
+	*out = *in
+	if in.Deploy != nil {
+		in, out := &in.Deploy, &out.Deploy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(GRPCService)
+		**out = **in
+	}
+	if in.ClientNamespaces != nil {
+		in, out := &in.ClientNamespaces, &out.ClientNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPC.
+func (in *GRPC) DeepCopy() *GRPC {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCService) DeepCopyInto(out *GRPCService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCService.
+func (in *GRPCService) DeepCopy() *GRPCService {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedSecret) DeepCopyInto(out *GeneratedSecret) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedSecret.
+func (in *GeneratedSecret) DeepCopy() *GeneratedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MLMD) DeepCopyInto(out *MLMD) {
+	*out = *in
+	if in.Envoy != nil {
+		in, out := &in.Envoy, &out.Envoy
+		*out = new(Envoy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPC)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLMD.
+func (in *MLMD) DeepCopy() *MLMD {
+	if in == nil {
+		return nil
+	}
+	out := new(MLMD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedPipelineOptions) DeepCopyInto(out *ManagedPipelineOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPipelineOptions.
+func (in *ManagedPipelineOptions) DeepCopy() *ManagedPipelineOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedPipelineOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedPipelinesSpec) DeepCopyInto(out *ManagedPipelinesSpec) {
+	*out = *in
+	if in.InstructLab != nil {
+		in, out := &in.InstructLab, &out.InstructLab
+		*out = new(ManagedPipelineOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPipelinesSpec.
+func (in *ManagedPipelinesSpec) DeepCopy() *ManagedPipelinesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedPipelinesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDB) DeepCopyInto(out *MariaDB) {
+	*out = *in
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretKeyValue)
+		**out = **in
+	}
+	if in.GeneratedSecret != nil {
+		in, out := &in.GeneratedSecret, &out.GeneratedSecret
+		*out = new(GeneratedSecret)
+		(*in).DeepCopyInto(*out)
+	}
+	out.PVCSize = in.PVCSize.DeepCopy()
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(MariaDBHighAvailability)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDB.
+func (in *MariaDB) DeepCopy() *MariaDB {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBExporter) DeepCopyInto(out *MariaDBExporter) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBExporter.
+func (in *MariaDBExporter) DeepCopy() *MariaDBExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MariaDBHighAvailability) DeepCopyInto(out *MariaDBHighAvailability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDBHighAvailability.
+func (in *MariaDBHighAvailability) DeepCopy() *MariaDBHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(MariaDBHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Minio) DeepCopyInto(out *Minio) {
+	*out = *in
+	if in.S3CredentialSecret != nil {
+		in, out := &in.S3CredentialSecret, &out.S3CredentialSecret
+		*out = new(S3CredentialSecret)
+		**out = **in
+	}
+	if in.GeneratedSecret != nil {
+		in, out := &in.GeneratedSecret, &out.GeneratedSecret
+		*out = new(GeneratedSecret)
+		(*in).DeepCopyInto(*out)
+	}
+	out.PVCSize = in.PVCSize.DeepCopy()
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Minio.
+func (in *Minio) DeepCopy() *Minio {
+	if in == nil {
+		return nil
+	}
+	out := new(Minio)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinioExporter) DeepCopyInto(out *MinioExporter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinioExporter.
+func (in *MinioExporter) DeepCopy() *MinioExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(MinioExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MlPipelineUI) DeepCopyInto(out *MlPipelineUI) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(UICustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArtifactProxy != nil {
+		in, out := &in.ArtifactProxy, &out.ArtifactProxy
+		*out = new(UIArtifactProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MlPipelineUI.
+func (in *MlPipelineUI) DeepCopy() *MlPipelineUI {
+	if in == nil {
+		return nil
+	}
+	out := new(MlPipelineUI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Exporters != nil {
+		in, out := &in.Exporters, &out.Exporters
+		*out = new(MonitoringExporters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = new(MonitoringAlerts)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAlerts) DeepCopyInto(out *MonitoringAlerts) {
+	*out = *in
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAlerts.
+func (in *MonitoringAlerts) DeepCopy() *MonitoringAlerts {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAlerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringExporters) DeepCopyInto(out *MonitoringExporters) {
+	*out = *in
+	if in.MariaDB != nil {
+		in, out := &in.MariaDB, &out.MariaDB
+		*out = new(MariaDBExporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Minio != nil {
+		in, out := &in.Minio, &out.Minio
+		*out = new(MinioExporter)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringExporters.
+func (in *MonitoringExporters) DeepCopy() *MonitoringExporters {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringExporters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiTenancy) DeepCopyInto(out *MultiTenancy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiTenancy.
+func (in *MultiTenancy) DeepCopy() *MultiTenancy {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiTenancy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIRegistry) DeepCopyInto(out *OCIRegistry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIRegistry.
+func (in *OCIRegistry) DeepCopy() *OCIRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorage) DeepCopyInto(out *ObjectStorage) {
+	*out = *in
+	if in.Minio != nil {
+		in, out := &in.Minio, &out.Minio
+		*out = new(Minio)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalStorage != nil {
+		in, out := &in.ExternalStorage, &out.ExternalStorage
+		*out = new(ExternalStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = new(ObjectStorageBuckets)
+		**out = **in
+	}
+	if in.SecretPropagation != nil {
+		in, out := &in.SecretPropagation, &out.SecretPropagation
+		*out = new(SecretPropagation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCIRegistry != nil {
+		in, out := &in.OCIRegistry, &out.OCIRegistry
+		*out = new(OCIRegistry)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorage.
+func (in *ObjectStorage) DeepCopy() *ObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageBuckets) DeepCopyInto(out *ObjectStorageBuckets) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageBuckets.
+func (in *ObjectStorageBuckets) DeepCopy() *ObjectStorageBuckets {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageBuckets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageEndpoint) DeepCopyInto(out *ObjectStorageEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageEndpoint.
+func (in *ObjectStorageEndpoint) DeepCopy() *ObjectStorageEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChanges) DeepCopyInto(out *PendingChanges) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChanges.
+func (in *PendingChanges) DeepCopy() *PendingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceAgent) DeepCopyInto(out *PersistenceAgent) {
+	*out = *in
+	if in.ResyncInterval != nil {
+		in, out := &in.ResyncInterval, &out.ResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceAgent.
+func (in *PersistenceAgent) DeepCopy() *PersistenceAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pipeline) DeepCopyInto(out *Pipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pipeline.
+func (in *Pipeline) DeepCopy() *Pipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(Pipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Pipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineList) DeepCopyInto(out *PipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Pipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineList.
+func (in *PipelineList) DeepCopy() *PipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
+func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStatus.
+func (in *PipelineStatus) DeepCopy() *PipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDefaults) DeepCopyInto(out *PodDefaults) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDefaults.
+func (in *PodDefaults) DeepCopy() *PodDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTiming) DeepCopyInto(out *ProbeTiming) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTiming.
+func (in *ProbeTiming) DeepCopy() *ProbeTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probes) DeepCopyInto(out *Probes) {
+	*out = *in
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeTiming)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probes.
+func (in *Probes) DeepCopy() *Probes {
+	if in == nil {
+		return nil
+	}
+	out := new(Probes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringRun) DeepCopyInto(out *RecurringRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringRun.
+func (in *RecurringRun) DeepCopy() *RecurringRun {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecurringRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringRunList) DeepCopyInto(out *RecurringRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RecurringRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringRunList.
+func (in *RecurringRunList) DeepCopy() *RecurringRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecurringRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringRunSpec) DeepCopyInto(out *RecurringRunSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringRunSpec.
+func (in *RecurringRunSpec) DeepCopy() *RecurringRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringRunStatus) DeepCopyInto(out *RecurringRunStatus) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.LastTriggeredTime != nil {
+		in, out := &in.LastTriggeredTime, &out.LastTriggeredTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTriggeredTime != nil {
+		in, out := &in.NextTriggeredTime, &out.NextTriggeredTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPC.
-func (in *GRPC) DeepCopy() *GRPC {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringRunStatus.
+func (in *RecurringRunStatus) DeepCopy() *RecurringRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GRPC)
+	out := new(RecurringRunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MLMD) DeepCopyInto(out *MLMD) {
+func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
 	*out = *in
-	if in.Envoy != nil {
-		in, out := &in.Envoy, &out.Envoy
-		*out = new(Envoy)
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.GRPC != nil {
-		in, out := &in.GRPC, &out.GRPC
-		*out = new(GRPC)
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MLMD.
-func (in *MLMD) DeepCopy() *MLMD {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
+func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	if in == nil {
 		return nil
 	}
-	out := new(MLMD)
+	out := new(ResourceRequirements)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedPipelineOptions) DeepCopyInto(out *ManagedPipelineOptions) {
+func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPipelineOptions.
-func (in *ManagedPipelineOptions) DeepCopy() *ManagedPipelineOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedPipelineOptions)
+	out := new(Resources)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedPipelinesSpec) DeepCopyInto(out *ManagedPipelinesSpec) {
+func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
-	if in.InstructLab != nil {
-		in, out := &in.InstructLab, &out.InstructLab
-		*out = new(ManagedPipelineOptions)
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPipelinesSpec.
-func (in *ManagedPipelinesSpec) DeepCopy() *ManagedPipelinesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedPipelinesSpec)
+	out := new(Rollout)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MariaDB) DeepCopyInto(out *MariaDB) {
+func (in *RunLimits) DeepCopyInto(out *RunLimits) {
 	*out = *in
-	if in.PasswordSecret != nil {
-		in, out := &in.PasswordSecret, &out.PasswordSecret
-		*out = new(SecretKeyValue)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunLimits.
+func (in *RunLimits) DeepCopy() *RunLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RunLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunRetention) DeepCopyInto(out *RunRetention) {
+	*out = *in
+	if in.CompletedRunTTL != nil {
+		in, out := &in.CompletedRunTTL, &out.CompletedRunTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ArchivedRunTTL != nil {
+		in, out := &in.ArchivedRunTTL, &out.ArchivedRunTTL
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	out.PVCSize = in.PVCSize.DeepCopy()
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(ResourceRequirements)
@@ -451,210 +1976,322 @@ func (in *MariaDB) DeepCopyInto(out *MariaDB) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MariaDB.
-func (in *MariaDB) DeepCopy() *MariaDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunRetention.
+func (in *RunRetention) DeepCopy() *RunRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(MariaDB)
+	out := new(RunRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Minio) DeepCopyInto(out *Minio) {
+func (in *RunUsage) DeepCopyInto(out *RunUsage) {
 	*out = *in
-	if in.S3CredentialSecret != nil {
-		in, out := &in.S3CredentialSecret, &out.S3CredentialSecret
-		*out = new(S3CredentialSecret)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunUsage.
+func (in *RunUsage) DeepCopy() *RunUsage {
+	if in == nil {
+		return nil
 	}
-	out.PVCSize = in.PVCSize.DeepCopy()
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	out := new(RunUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3CredentialSecret) DeepCopyInto(out *S3CredentialSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CredentialSecret.
+func (in *S3CredentialSecret) DeepCopy() *S3CredentialSecret {
+	if in == nil {
+		return nil
 	}
+	out := new(S3CredentialSecret)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Minio.
-func (in *Minio) DeepCopy() *Minio {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SamplePipeline) DeepCopyInto(out *SamplePipeline) {
+	*out = *in
+	if in.AutoUpdateDefaultVersion != nil {
+		in, out := &in.AutoUpdateDefaultVersion, &out.AutoUpdateDefaultVersion
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamplePipeline.
+func (in *SamplePipeline) DeepCopy() *SamplePipeline {
 	if in == nil {
 		return nil
 	}
-	out := new(Minio)
+	out := new(SamplePipeline)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MlPipelineUI) DeepCopyInto(out *MlPipelineUI) {
+func (in *ScheduledWorkflow) DeepCopyInto(out *ScheduledWorkflow) {
 	*out = *in
+	if in.CatchUp != nil {
+		in, out := &in.CatchUp, &out.CatchUp
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(Probes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MlPipelineUI.
-func (in *MlPipelineUI) DeepCopy() *MlPipelineUI {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledWorkflow.
+func (in *ScheduledWorkflow) DeepCopy() *ScheduledWorkflow {
 	if in == nil {
 		return nil
 	}
-	out := new(MlPipelineUI)
+	out := new(ScheduledWorkflow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStorage) DeepCopyInto(out *ObjectStorage) {
+func (in *ScriptConfigMap) DeepCopyInto(out *ScriptConfigMap) {
 	*out = *in
-	if in.Minio != nil {
-		in, out := &in.Minio, &out.Minio
-		*out = new(Minio)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExternalStorage != nil {
-		in, out := &in.ExternalStorage, &out.ExternalStorage
-		*out = new(ExternalStorage)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptConfigMap.
+func (in *ScriptConfigMap) DeepCopy() *ScriptConfigMap {
+	if in == nil {
+		return nil
 	}
+	out := new(ScriptConfigMap)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorage.
-func (in *ObjectStorage) DeepCopy() *ObjectStorage {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyValue) DeepCopyInto(out *SecretKeyValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyValue.
+func (in *SecretKeyValue) DeepCopy() *SecretKeyValue {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStorage)
+	out := new(SecretKeyValue)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistenceAgent) DeepCopyInto(out *PersistenceAgent) {
+func (in *SecretPropagation) DeepCopyInto(out *SecretPropagation) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceAgent.
-func (in *PersistenceAgent) DeepCopy() *PersistenceAgent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagation.
+func (in *SecretPropagation) DeepCopy() *SecretPropagation {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistenceAgent)
+	out := new(SecretPropagation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRequirements) DeepCopyInto(out *ResourceRequirements) {
+func (in *ServiceMesh) DeepCopyInto(out *ServiceMesh) {
 	*out = *in
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = new(Resources)
-		(*in).DeepCopyInto(*out)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Requests != nil {
-		in, out := &in.Requests, &out.Requests
-		*out = new(Resources)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMesh.
+func (in *ServiceMesh) DeepCopy() *ServiceMesh {
+	if in == nil {
+		return nil
 	}
+	out := new(ServiceMesh)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRequirements.
-func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageBinding) DeepCopyInto(out *StorageBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageBinding.
+func (in *StorageBinding) DeepCopy() *StorageBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRequirements)
+	out := new(StorageBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resources) DeepCopyInto(out *Resources) {
+func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
-	out.CPU = in.CPU.DeepCopy()
-	out.Memory = in.Memory.DeepCopy()
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerRef)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
-func (in *Resources) DeepCopy() *Resources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
 	if in == nil {
 		return nil
 	}
-	out := new(Resources)
+	out := new(TLS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3CredentialSecret) DeepCopyInto(out *S3CredentialSecret) {
+func (in *UIArtifactProxy) DeepCopyInto(out *UIArtifactProxy) {
 	*out = *in
+	if in.ReuseCredentials != nil {
+		in, out := &in.ReuseCredentials, &out.ReuseCredentials
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3CredentialSecret.
-func (in *S3CredentialSecret) DeepCopy() *S3CredentialSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UIArtifactProxy.
+func (in *UIArtifactProxy) DeepCopy() *UIArtifactProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(S3CredentialSecret)
+	out := new(UIArtifactProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScheduledWorkflow) DeepCopyInto(out *ScheduledWorkflow) {
+func (in *UICustomization) DeepCopyInto(out *UICustomization) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Logo != nil {
+		in, out := &in.Logo, &out.Logo
+		*out = new(UILogo)
+		**out = **in
+	}
+	if in.DocumentationLinks != nil {
+		in, out := &in.DocumentationLinks, &out.DocumentationLinks
+		*out = make([]UILink, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledWorkflow.
-func (in *ScheduledWorkflow) DeepCopy() *ScheduledWorkflow {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UICustomization.
+func (in *UICustomization) DeepCopy() *UICustomization {
 	if in == nil {
 		return nil
 	}
-	out := new(ScheduledWorkflow)
+	out := new(UICustomization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScriptConfigMap) DeepCopyInto(out *ScriptConfigMap) {
+func (in *UILink) DeepCopyInto(out *UILink) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptConfigMap.
-func (in *ScriptConfigMap) DeepCopy() *ScriptConfigMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UILink.
+func (in *UILink) DeepCopy() *UILink {
 	if in == nil {
 		return nil
 	}
-	out := new(ScriptConfigMap)
+	out := new(UILink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyValue) DeepCopyInto(out *SecretKeyValue) {
+func (in *UILogo) DeepCopyInto(out *UILogo) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyValue.
-func (in *SecretKeyValue) DeepCopy() *SecretKeyValue {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UILogo.
+func (in *UILogo) DeepCopy() *UILogo {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyValue)
+	out := new(UILogo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VisualizationServer) DeepCopyInto(out *VisualizationServer) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowCustomVisualizations != nil {
+		in, out := &in.AllowCustomVisualizations, &out.AllowCustomVisualizations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisualizationServer.
+func (in *VisualizationServer) DeepCopy() *VisualizationServer {
+	if in == nil {
+		return nil
+	}
+	out := new(VisualizationServer)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -667,6 +2304,16 @@ func (in *WorkflowController) DeepCopyInto(out *WorkflowController) {
 		*out = new(ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowController.
@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// +kubebuilder:validation:XValidation:rule="(has(self.dspVersion) ? self.dspVersion : 'v1') == 'v1' || !has(self.apiServer) || !has(self.apiServer.terminateStatus) || self.apiServer.terminateStatus == 'Cancelled'",message="spec.apiServer.terminateStatus StoppedRunFinally and CancelledRunFinally are Tekton statuses only supported in DSP v1, set spec.apiServer.terminationStrategy of the v1 API instead"
 type DSPASpec struct {
 	// DS Pipelines API Server configuration.
 	// +kubebuilder:default:={deploy: true}
@@ -165,6 +166,8 @@ type APIServer struct {
 	// +kubebuilder:validation:Optional
 	StripEOF bool `json:"stripEOF"`
 	// Default: "Cancelled" - Allowed Values: "Cancelled", "StoppedRunFinally", "CancelledRunFinally"
+	// StoppedRunFinally and CancelledRunFinally are rejected unless dspVersion is v1, DSP v2 runs are
+	// terminated according to the terminationStrategy of the v1 API.
 	// Deprecated: DSP V1 only, will be removed in the future.
 	// +kubebuilder:validation:Enum=Cancelled;StoppedRunFinally;CancelledRunFinally
 	// +kubebuilder:default:=Cancelled
@@ -0,0 +1,36 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config embeds the manifest templates of config/internal, so that
+// the operator does not depend on them being shipped next to its binary.
+package config
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed internal
+var internal embed.FS
+
+// Templates returns the embedded manifest templates, rooted at config/internal.
+func Templates() fs.FS {
+	templates, err := fs.Sub(internal, "internal")
+	if err != nil {
+		// Only returned for an invalid directory name
+		panic(err)
+	}
+	return templates
+}
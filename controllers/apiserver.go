@@ -20,19 +20,53 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	dspa "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
-	v1 "github.com/openshift/api/route/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var apiServerTemplatesDir = "apiserver/default"
 
 const apiServerDefaultResourceNamePrefix = "ds-pipeline-"
 
+// Cache cleanup CronJob is a resource deployed conditionally
+// as such it is handled separately
+var apiServerCacheCleanupTemplatesDir = "apiserver/cache-cleanup"
+
+const (
+	defaultWorkspacePVCNamePrefix = "ds-pipeline-workspace-"
+	defaultWorkspacePVCTemplate   = "apiserver/workspace/pvc.yaml.tmpl"
+	defaultWorkspaceVolumeName    = "kfp-workspace"
+)
+
+const cacheCleanupDefaultResourceNamePrefix = "ds-pipeline-cache-cleanup-"
+
+// Run retention CronJob is a resource deployed conditionally
+// as such it is handled separately
+var apiServerRunRetentionTemplatesDir = "apiserver/run-retention"
+
+const runRetentionDefaultResourceNamePrefix = "ds-pipeline-run-retention-"
+
+// Audit log script ConfigMap, mounted in the API server sidecar, is a resource
+// deployed conditionally as such it is handled separately
+var apiServerAuditLogTemplatesDir = "apiserver/audit-log"
+
+const auditLogDefaultResourceNamePrefix = "ds-pipeline-audit-log-"
+
+// Group access Role and RoleBinding are resources deployed conditionally
+// as such they are handled separately
+var apiServerGroupAccessTemplatesDir = "apiserver/group-access"
+
+const apiServerGroupAccessResourceNamePrefix = "ds-pipeline-group-access-"
+
 // serverRoute is a resource deployed conditionally
 // as such it is handled separately
 const serverRoute = "apiserver/route/route.yaml.tmpl"
@@ -44,7 +78,12 @@ var samplePipelineTemplates = map[string]string{
 	"sample-config":   "apiserver/sample-pipeline/sample-config.yaml.tmpl",
 }
 
-func (r *DSPAReconciler) GenerateSamplePipelineMetadataBlock(pipeline string) (map[string]string, error) {
+// GenerateSamplePipelineMetadataBlock returns the sample config entry of the
+// sample pipeline. Its version is named after the platform version when
+// autoUpdateDefaultVersion is set, so that each release of the operator imports
+// its sample as a new default version of the pipeline, rather than keeping the
+// version first imported.
+func (r *DSPAReconciler) GenerateSamplePipelineMetadataBlock(pipeline string, autoUpdateDefaultVersion bool) (map[string]string, error) {
 
 	item := make(map[string]string)
 
@@ -68,39 +107,61 @@ func (r *DSPAReconciler) GenerateSamplePipelineMetadataBlock(pipeline string) (m
 	item["name"] = pName
 	item["file"] = pFile
 	item["description"] = pDesc
-	item["versionName"] = fmt.Sprintf("%s - %s", pVerName, strings.Trim(platformVersion, "\""))
+	item["versionName"] = pVerName
+	if autoUpdateDefaultVersion {
+		item["versionName"] = fmt.Sprintf("%s - %s", pVerName, strings.Trim(platformVersion, "\""))
+	}
 	item["versionDescription"] = pVerDesc
 
 	return item, nil
 
 }
 
+// samplePipelineOptions are the bundled sample pipelines the API server of a
+// DSPA imports, by name, and whether they auto update their default version.
+type samplePipelineOptions map[string]bool
+
 func (r *DSPAReconciler) GetSampleConfig(dsp *dspa.DataSciencePipelinesApplication) (string, error) {
+	samples := samplePipelineOptions{}
 	// Check if InstructLab Pipeline enabled in this DSPA
-	enableInstructLabPipeline := false
 	if dsp.Spec.APIServer.ManagedPipelines != nil && dsp.Spec.APIServer.ManagedPipelines.InstructLab != nil {
 		settingInDSPA := dsp.Spec.APIServer.ManagedPipelines.InstructLab.State
-		if settingInDSPA != "" {
-			enableInstructLabPipeline = strings.EqualFold(string(settingInDSPA), "Managed")
+		if strings.EqualFold(string(settingInDSPA), "Managed") {
+			samples["instructlab"] = true
+		}
+	}
+	if dsp.Spec.APIServer.EnableSamplePipeline {
+		samples["iris"] = true
+	}
+
+	// The entries of samplePipelines take precedence over the toggles above
+	available := config.GetSamplePipelineNames()
+	for _, sample := range dsp.Spec.APIServer.SamplePipelines {
+		name := strings.ToLower(sample.Name)
+		if !slices.Contains(available, name) {
+			return "", fmt.Errorf("[spec.apiServer.samplePipelines] unknown sample pipeline %q, must be one of [%s]",
+				sample.Name, strings.Join(available, ", "))
+		}
+		delete(samples, name)
+		if sample.State == "" || strings.EqualFold(string(sample.State), "Managed") {
+			samples[name] = sample.AutoUpdateDefaultVersion == nil || *sample.AutoUpdateDefaultVersion
 		}
 	}
 
-	return r.generateSampleConfigJSON(enableInstructLabPipeline, dsp.Spec.APIServer.EnableSamplePipeline)
+	return r.generateSampleConfigJSON(samples)
 }
 
-func (r *DSPAReconciler) generateSampleConfigJSON(enableInstructLabPipeline, enableIrisPipeline bool) (string, error) {
+func (r *DSPAReconciler) generateSampleConfigJSON(samples samplePipelineOptions) (string, error) {
 
-	// Now generate a sample config
-	var pipelineConfig = make([]map[string]string, 0)
-	if enableInstructLabPipeline {
-		item, err := r.GenerateSamplePipelineMetadataBlock("instructlab")
-		if err != nil {
-			return "", err
-		}
-		pipelineConfig = append(pipelineConfig, item)
+	// Now generate a sample config, in a stable order not to restart the API server
+	var names []string
+	for name := range samples {
+		names = append(names, name)
 	}
-	if enableIrisPipeline {
-		item, err := r.GenerateSamplePipelineMetadataBlock("iris")
+	slices.Sort(names)
+	var pipelineConfig = make([]map[string]string, 0)
+	for _, name := range names {
+		item, err := r.GenerateSamplePipelineMetadataBlock(name, samples[name])
 		if err != nil {
 			return "", err
 		}
@@ -121,7 +182,7 @@ func (r *DSPAReconciler) generateSampleConfigJSON(enableInstructLabPipeline, ena
 }
 
 func (r *DSPAReconciler) ReconcileAPIServer(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	if !dsp.Spec.APIServer.Deploy {
 		r.Log.Info("Skipping Application of APIServer Resources")
@@ -144,15 +205,115 @@ func (r *DSPAReconciler) ReconcileAPIServer(ctx context.Context, dsp *dspav1.Dat
 		return err
 	}
 
-	if dsp.Spec.APIServer.EnableRoute {
+	// In the service mesh the API Server is exposed by a VirtualService instead
+	if dsp.Spec.APIServer.EnableRoute && params.ServiceMesh == nil {
 		err := r.Apply(dsp, params, serverRoute)
 		if err != nil {
 			return err
 		}
 	} else {
-		route := &v1.Route{}
 		namespacedNamed := types.NamespacedName{Name: "ds-pipeline-" + dsp.Name, Namespace: dsp.Namespace}
-		err := r.DeleteResourceIfItExists(ctx, route, namespacedNamed)
+		err := r.deleteExternalRoute(ctx, params, namespacedNamed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if params.ServiceMesh != nil {
+		log.Info("Applying APIServer Service Mesh Resources")
+		err := r.ApplyDir(dsp, params, apiServerServiceMeshTemplatesDir)
+		if err != nil {
+			return err
+		}
+		if dsp.Spec.APIServer.EnableRoute {
+			err = r.Apply(dsp, params, apiServerVirtualService)
+		} else {
+			err = r.deleteServiceMeshResources(ctx, params.APIServerDefaultResourceName, dsp.Namespace, virtualServiceGVK)
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		err := r.deleteServiceMeshResources(ctx, params.APIServerDefaultResourceName, dsp.Namespace,
+			virtualServiceGVK, destinationRuleGVK, authorizationPolicyGVK)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(params.APIServerAccessGroups) > 0 {
+		log.Info("Applying APIServer Group Access Resources")
+		err := r.ApplyDir(dsp, params, apiServerGroupAccessTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: apiServerGroupAccessResourceNamePrefix + dsp.Name, Namespace: dsp.Namespace}
+		for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+			err := r.DeleteResourceIfItExists(ctx, obj, namespacedNamed)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Nothing is written to the cache when caching is disabled
+	if params.CacheEnabled && params.APIServer.CacheCleanup != nil && params.APIServer.CacheCleanup.Enabled {
+		log.Info("Applying Cache Cleanup Resources")
+		err := r.ApplyDir(dsp, params, apiServerCacheCleanupTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: params.CacheCleanupDefaultResourceName, Namespace: dsp.Namespace}
+		err := r.DeleteResourceIfItExists(ctx, &batchv1.CronJob{}, namespacedNamed)
+		if err != nil {
+			return err
+		}
+		err = r.DeleteResourceIfItExists(ctx, &corev1.ServiceAccount{}, namespacedNamed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if params.APIServer.RunRetention != nil && params.APIServer.RunRetention.Enabled {
+		log.Info("Applying Run Retention Resources")
+		err := r.ApplyDir(dsp, params, apiServerRunRetentionTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: params.RunRetentionDefaultResourceName, Namespace: dsp.Namespace}
+		for _, obj := range []client.Object{&batchv1.CronJob{}, &corev1.ConfigMap{}, &corev1.ServiceAccount{}} {
+			err := r.DeleteResourceIfItExists(ctx, obj, namespacedNamed)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := r.recordRunRetentionMetrics(ctx, dsp, params); err != nil {
+		return err
+	}
+
+	if params.APIServer.AuditLog != nil && params.APIServer.AuditLog.Enabled {
+		log.Info("Applying Audit Log Resources")
+		err := r.ApplyDir(dsp, params, apiServerAuditLogTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: params.AuditLogDefaultResourceName, Namespace: dsp.Namespace}
+		err := r.DeleteResourceIfItExists(ctx, &corev1.ConfigMap{}, namespacedNamed)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The workspace PVC is left in place when defaultWorkspace is unset, so
+	// that its data is not lost, and removed with the DSPA.
+	if params.DefaultWorkspace != nil {
+		log.Info("Applying Default Workspace PVC")
+		err := r.Apply(dsp, params, defaultWorkspacePVCTemplate)
 		if err != nil {
 			return err
 		}
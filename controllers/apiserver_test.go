@@ -18,13 +18,25 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestDeployAPIServer(t *testing.T) {
@@ -180,3 +192,1206 @@ func TestApiServerEndpoints(t *testing.T) {
 	assert.NotNil(t, dspa_created.Status.Components.APIServer.Url)
 	assert.NotNil(t, dspa_created.Status.Components.APIServer.ExternalUrl)
 }
+
+func TestDeployAPIServerCacheCleanup(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedCacheCleanupName := cacheCleanupDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer and cache cleanup enabled
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+				CacheCleanup: &dspav1.CacheCleanup{
+					Enabled:     true,
+					MaxAgeHours: 24,
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultCacheCleanupSchedule, params.APIServer.CacheCleanup.Schedule)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert Cache Cleanup CronJob now exists
+	cronJob := &batchv1.CronJob{}
+	created, err := reconciler.IsResourceCreated(ctx, cronJob, expectedCacheCleanupName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultCacheCleanupSchedule, cronJob.Spec.Schedule)
+	assert.Equal(t, expectedCacheCleanupName, cronJob.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName)
+
+	// Disable cache cleanup and reconcile again
+	dspa.Spec.APIServer.CacheCleanup.Enabled = false
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert Cache Cleanup CronJob has been removed
+	cronJob = &batchv1.CronJob{}
+	created, err = reconciler.IsResourceCreated(ctx, cronJob, expectedCacheCleanupName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+}
+
+func TestDeployAPIServerRunRetention(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedRunRetentionName := runRetentionDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer and run retention enabled
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+				RunRetention: &dspav1.RunRetention{
+					Enabled:              true,
+					CompletedRunTTL:      &metav1.Duration{Duration: 720 * time.Hour},
+					MaxRunsPerExperiment: 50,
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultRunRetentionSchedule, params.APIServer.RunRetention.Schedule)
+	assert.Equal(t, config.DefaultRunRetentionAction, params.APIServer.RunRetention.Action)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert Run Retention CronJob now exists with the policy in its env
+	cronJob := &batchv1.CronJob{}
+	created, err := reconciler.IsResourceCreated(ctx, cronJob, expectedRunRetentionName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, config.DefaultRunRetentionSchedule, cronJob.Spec.Schedule)
+	env := map[string]string{}
+	for _, envVar := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "http://ds-pipeline-testdspa.testnamespace.svc.cluster.local:8888", env["API_SERVER_URL"])
+	assert.Equal(t, "2592000", env["COMPLETED_RUN_TTL_SECONDS"])
+	assert.Equal(t, "50", env["MAX_RUNS_PER_EXPERIMENT"])
+	assert.Equal(t, "Archive", env["RETENTION_ACTION"])
+
+	// Disable run retention and reconcile again
+	dspa.Spec.APIServer.RunRetention.Enabled = false
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert Run Retention CronJob and script have been removed
+	cronJob = &batchv1.CronJob{}
+	created, err = reconciler.IsResourceCreated(ctx, cronJob, expectedRunRetentionName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, expectedRunRetentionName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+
+	// Assert a policy without limits is rejected
+	dspa.Spec.APIServer.RunRetention = &dspav1.RunRetention{Enabled: true}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "requires completedRunTTL, maxRunsPerExperiment or archivedRunTTL")
+}
+
+func TestDeployAPIServerAuditLog(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+	expectedAuditLogName := auditLogDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer and the audit log shipped to the object storage
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy:      true,
+				EnableRoute: true,
+				AuditLog: &dspav1.AuditLog{
+					Enabled:               true,
+					ExportToObjectStorage: true,
+					ExportInterval:        &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	assert.Equal(t, int64(60), params.AuditLogExportIntervalSeconds)
+	assert.Equal(t, params.APIServer.ToolboxImage, params.APIServer.AuditLog.Image)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Assert the OAuth proxy is routed through the audit log sidecar
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, expectedAuditLogName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	deployment := &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	require.True(t, created)
+	require.Nil(t, err)
+	containers := map[string]corev1.Container{}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		containers[container.Name] = container
+	}
+	assert.Contains(t, containers["oauth-proxy"].Args, "--upstream=http://localhost:8889")
+	require.Contains(t, containers, "audit-log")
+	env := map[string]string{}
+	for _, envVar := range containers["audit-log"].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "http://localhost:8888", env["API_SERVER_URL"])
+	assert.Equal(t, "true", env["AUDIT_LOG_EXPORT"])
+	assert.Equal(t, "60", env["AUDIT_LOG_EXPORT_INTERVAL_SECONDS"])
+	assert.Equal(t, params.ObjectStorageConnection.PipelineBucket, env["OBJECT_STORE_BUCKET"])
+
+	// Disable the audit log and reconcile again
+	dspa.Spec.APIServer.AuditLog.Enabled = false
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Assert the audit log script has been removed
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, expectedAuditLogName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+
+	// Assert the audit log requires the OAuth proxy to identify users
+	dspa.Spec.APIServer.AuditLog.Enabled = true
+	dspa.Spec.APIServer.EnableRoute = false
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "requires [spec.apiServer.enableOauth]")
+}
+
+func TestDeployAPIServerCacheConfig(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+	expectedCacheCleanupName := cacheCleanupDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer, caching disabled and a default cache TTL
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy:          true,
+				CacheEnabled:    boolPtr(false),
+				DefaultCacheTTL: &metav1.Duration{Duration: 24 * time.Hour},
+				CacheCleanup: &dspav1.CacheCleanup{
+					Enabled: true,
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.False(t, params.CacheEnabled)
+	assert.Equal(t, "PT86400S", params.CacheStaleness)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert cache settings are passed to the API Server
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{Name: "CACHEENABLED", Value: "false"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "DEFAULT_CACHE_STALENESS", Value: "PT86400S"})
+
+	// Assert Cache Cleanup CronJob is not deployed while caching is disabled
+	cronJob := &batchv1.CronJob{}
+	created, err = reconciler.IsResourceCreated(ctx, cronJob, expectedCacheCleanupName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+
+	// Assert non-positive TTLs are rejected
+	dspa.Spec.APIServer.DefaultCacheTTL = &metav1.Duration{}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.NotNil(t, err)
+}
+
+func TestDeployAPIServerDBConfig(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	maxOpen, maxIdle := int32(20), int32(5)
+	dspa.Spec.APIServer.DBConfig = &dspav1.APIServerDBConfig{
+		MaxOpenConnections:    &maxOpen,
+		MaxIdleConnections:    &maxIdle,
+		ConnectionMaxLifetime: &metav1.Duration{Duration: 5 * time.Minute},
+		ConnectionTimeout:     &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	serverConfig := func(ctx context.Context, reconciler *DSPAReconciler) map[string]interface{} {
+		configMap := &corev1.ConfigMap{}
+		created, err := reconciler.IsResourceCreated(ctx, configMap, "ds-pipeline-server-config-"+dspa.Name, dspa.Namespace)
+		require.Nil(t, err)
+		require.True(t, created)
+		parsed := map[string]interface{}{}
+		require.Nil(t, json.Unmarshal([]byte(configMap.Data["config.json"]), &parsed))
+		return parsed
+	}
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+
+	// Assert the pool settings are rendered into the API Server config
+	parsed := serverConfig(ctx, reconciler)
+	dbConfig := parsed["DBConfig"].(map[string]interface{})
+	assert.Equal(t, float64(20), dbConfig["MaxOpenConns"])
+	assert.Equal(t, float64(5), dbConfig["MaxIdleConns"])
+	assert.Equal(t, "5m0s", dbConfig["ConMaxLifeTime"])
+	assert.Equal(t, "10m0s", parsed["InitConnectionTimeout"])
+
+	// Assert the connection limits are left to the API Server defaults when unset
+	dspa.Spec.APIServer.DBConfig = nil
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	parsed = serverConfig(ctx, reconciler)
+	dbConfig = parsed["DBConfig"].(map[string]interface{})
+	assert.NotContains(t, dbConfig, "MaxOpenConns")
+	assert.NotContains(t, dbConfig, "MaxIdleConns")
+	assert.Equal(t, "120s", dbConfig["ConMaxLifeTime"])
+	assert.Equal(t, "6m", parsed["InitConnectionTimeout"])
+
+	// Assert more idle than open connections are rejected
+	maxIdle = 30
+	dspa.Spec.APIServer.DBConfig = &dspav1.APIServerDBConfig{MaxOpenConnections: &maxOpen, MaxIdleConnections: &maxIdle}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "spec.apiServer.dbConfig.maxIdleConnections")
+}
+
+func TestDeployAPIServerDefaultWorkspace(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedWorkspaceName := defaultWorkspacePVCNamePrefix + testDSPAName
+	expectedWorkflowControllerConfigMapName := "ds-pipeline-workflow-controller-testdspa"
+
+	// Construct DSPASpec with deployed APIServer and a default workspace
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+				DefaultWorkspace: &dspav1.DefaultWorkspace{
+					StorageClassName: "nfs",
+				},
+			},
+			WorkflowController: &dspav1.WorkflowController{
+				Deploy: true,
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileWorkflowController(dspa, params)
+	assert.Nil(t, err)
+
+	// Assert workspace PVC is created with defaults
+	pvc := &corev1.PersistentVolumeClaim{}
+	created, err := reconciler.IsResourceCreated(ctx, pvc, expectedWorkspaceName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, "nfs", *pvc.Spec.StorageClassName)
+	assert.Equal(t, "10Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	// Assert workflows default to mounting the workspace
+	configMap := &corev1.ConfigMap{}
+	created, err = reconciler.IsResourceCreated(ctx, configMap, expectedWorkflowControllerConfigMapName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Contains(t, configMap.Data["workflowDefaults"], "claimName: "+expectedWorkspaceName)
+	assert.Contains(t, configMap.Data["workflowDefaults"], `"mountPath":"/workspace"`)
+}
+
+func TestDeployAPIServerLogConfig(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer and custom logging
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy:    true,
+				LogLevel:  "debug",
+				LogFormat: "json",
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert APIServer container has the log configuration applied
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Args, "--logLevel=debug")
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "LOG_FORMAT", Value: "json"})
+}
+
+func TestDeployAPIServerSecurityContext(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+	runAsUser := int64(1001)
+
+	// Construct DSPASpec with deployed APIServer and a custom pod security context
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+				PodSecurityContext: &corev1.PodSecurityContext{
+					RunAsUser: &runAsUser,
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert the custom pod security context is used as is
+	assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &runAsUser}, deployment.Spec.Template.Spec.SecurityContext)
+
+	// Assert containers default to restricted settings
+	for _, container := range append(deployment.Spec.Template.Spec.InitContainers, deployment.Spec.Template.Spec.Containers...) {
+		assert.Equal(t, &config.DefaultContainerSecurityContext, container.SecurityContext)
+	}
+}
+
+func TestDeployAPIServerRollout(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+	maxSurge := intstr.FromInt(0)
+	maxUnavailable := intstr.FromString("50%")
+
+	// Construct DSPASpec with deployed APIServer and a tuned rolling update
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+				Rollout: &dspav1.Rollout{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert the rolling update parameters are rendered into the Deployment strategy
+	assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, deployment.Spec.Strategy.Type)
+	require.NotNil(t, deployment.Spec.Strategy.RollingUpdate)
+	assert.Equal(t, &maxSurge, deployment.Spec.Strategy.RollingUpdate.MaxSurge)
+	assert.Equal(t, &maxUnavailable, deployment.Spec.Strategy.RollingUpdate.MaxUnavailable)
+}
+
+func TestDeployAPIServerBuckets(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with separate buckets for artifacts and pipeline definitions
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+				Buckets: &dspav1.ObjectStorageBuckets{
+					Artifacts:           "run-artifacts",
+					PipelineDefinitions: "pipeline-definitions",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pipeline-definitions", "run-artifacts"}, params.ObjectStorageConnection.DistinctBuckets())
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert each bucket is rendered into the API Server env
+	env := map[string]string{}
+	for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "pipeline-definitions", env["OBJECTSTORECONFIG_BUCKETNAME"])
+	assert.Equal(t, "run-artifacts", env["OBJECTSTORECONFIG_ARTIFACTBUCKETNAME"])
+
+	// Assert run artifacts default to the artifacts bucket
+	launcherConfig := &corev1.ConfigMap{}
+	created, err = reconciler.IsResourceCreated(ctx, launcherConfig, "kfp-launcher", testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, "s3://run-artifacts", launcherConfig.Data["defaultPipelineRoot"])
+}
+
+func TestDeployAPIServerOCIRegistry(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.OCIRegistry = &dspav1.OCIRegistry{
+		Host:           "registry.example.com:5000",
+		Repository:     "my-org/pipeline-artifacts/",
+		PushPullSecret: "registry-credentials",
+	}
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+
+	// Assert the push/pull Secret must hold registry credentials
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.objectStorage.ociRegistry.pushPullSecret] unable to retrieve the Secret registry-credentials")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: dspa.Namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+	require.Nil(t, reconciler.Create(ctx, secret))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "my-org/pipeline-artifacts", params.OCIRegistry.Repository)
+	assert.Equal(t, []string{"mlpipeline"}, params.ObjectStorageConnection.DistinctBuckets())
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+
+	// Assert the API Server stores the run artifacts in the registry
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	env := map[string]string{}
+	for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "oci", env["OBJECTSTORECONFIG_ARTIFACTSTORE"])
+	assert.Equal(t, "registry.example.com:5000", env["OCIREGISTRY_HOST"])
+	assert.Equal(t, "my-org/pipeline-artifacts", env["OCIREGISTRY_REPOSITORY"])
+	assert.Equal(t, "registry-credentials", env["OCIREGISTRY_CREDENTIALSSECRET"])
+	assert.Equal(t, "mlpipeline", env["OBJECTSTORECONFIG_BUCKETNAME"])
+
+	// Assert the launcher pushes the run artifacts to the registry
+	launcherConfig := &corev1.ConfigMap{}
+	created, err = reconciler.IsResourceCreated(ctx, launcherConfig, "kfp-launcher", dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "oci://registry.example.com:5000/my-org/pipeline-artifacts", launcherConfig.Data["defaultPipelineRoot"])
+	assert.Contains(t, launcherConfig.Data["providers"], "registry: registry.example.com:5000")
+	assert.Contains(t, launcherConfig.Data["providers"], "secretName: registry-credentials")
+
+	// Assert the pipeline steps pull the run artifacts with the push/pull Secret
+	runnerSA := &corev1.ServiceAccount{}
+	created, err = reconciler.IsResourceCreated(ctx, runnerSA, "pipeline-runner-"+dspa.Name, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, runnerSA.ImagePullSecrets, corev1.LocalObjectReference{Name: "registry-credentials"})
+
+	// Assert a separate artifacts bucket is rejected along with the registry
+	dspa.Spec.ObjectStorage.Buckets = &dspav1.ObjectStorageBuckets{Artifacts: "run-artifacts"}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.objectStorage.buckets.artifacts] cannot be set along with [spec.objectStorage.ociRegistry]")
+
+	// Assert the host must not include a scheme
+	dspa.Spec.ObjectStorage.Buckets = nil
+	dspa.Spec.ObjectStorage.OCIRegistry.Host = "https://registry.example.com"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.objectStorage.ociRegistry.host]")
+}
+
+func TestDeployAPIServerHA(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer in HA mode
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			HA:          boolPtr(true),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert the API Server is scaled out and spread across zones and nodes
+	require.NotNil(t, deployment.Spec.Replicas)
+	assert.Equal(t, int32(config.DefaultHAReplicas), *deployment.Spec.Replicas)
+	constraints := deployment.Spec.Template.Spec.TopologySpreadConstraints
+	require.Len(t, constraints, 2)
+	assert.Equal(t, corev1.LabelTopologyZone, constraints[0].TopologyKey)
+	assert.Equal(t, corev1.LabelHostname, constraints[1].TopologyKey)
+	assert.Equal(t, deployment.Spec.Selector, constraints[0].LabelSelector)
+
+	// Assert constraints set on the component replace the defaults
+	custom := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           2,
+		TopologyKey:       corev1.LabelTopologyRegion,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}}
+	dspa.Spec.APIServer.TopologySpreadConstraints = custom
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, custom, params.APIServer.TopologySpreadConstraints)
+}
+
+func TestDeployAPIServerExtraArgs(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer with extra flags
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy:       true,
+				ExtraArgs:    []string{"--maxConcurrentRuns=10"},
+				FeatureFlags: map[string]bool{"cacheEnabled": false, "enableV2Features": true},
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert feature flags, then extra args, are appended to the operator managed flags
+	args := deployment.Spec.Template.Spec.Containers[0].Args
+	require.GreaterOrEqual(t, len(args), 3)
+	assert.Equal(t, []string{"--cacheEnabled=false", "--enableV2Features=true", "--maxConcurrentRuns=10"}, args[len(args)-3:])
+
+	// Assert operator managed, malformed and duplicate flags are rejected
+	for _, extraArgs := range [][]string{{"--config=/tmp"}, {"maxConcurrentRuns"}, {"--cacheEnabled=true"}} {
+		dspa.Spec.APIServer.ExtraArgs = extraArgs
+		_, params, _ = CreateNewTestObjects()
+		err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+		assert.ErrorIs(t, err, ErrInvalidAPIServerArgs)
+	}
+}
+
+func TestDeployAPIServerCustomServiceAccount(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	testServiceAccountName := "custom-sa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer running as an existing ServiceAccount
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy:             true,
+				ServiceAccountName: testServiceAccountName,
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert the APIServer runs as the existing ServiceAccount
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, testServiceAccountName, deployment.Spec.Template.Spec.ServiceAccountName)
+
+	// Assert the APIServer Role is bound to the existing ServiceAccount
+	roleBinding := &rbacv1.RoleBinding{}
+	created, err = reconciler.IsResourceCreated(ctx, roleBinding, expectedAPIServerName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, testServiceAccountName, roleBinding.Subjects[0].Name)
+
+	// Assert the default ServiceAccount is not created
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ServiceAccount{}, expectedAPIServerName, testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+}
+
+func TestDeployAPIServerSharedResourceConflict(t *testing.T) {
+	testNamespace := "testnamespace"
+
+	newDSPA := func(name, uid string) *dspav1.DataSciencePipelinesApplication {
+		dspa := &dspav1.DataSciencePipelinesApplication{
+			Spec: dspav1.DSPASpec{
+				PodToPodTLS: boolPtr(false),
+				APIServer: &dspav1.APIServer{
+					Deploy: true,
+				},
+				MLMD: &dspav1.MLMD{
+					Deploy: true,
+				},
+				Database: &dspav1.Database{
+					DisableHealthCheck: false,
+					MariaDB: &dspav1.MariaDB{
+						Deploy: true,
+					},
+				},
+				ObjectStorage: &dspav1.ObjectStorage{
+					DisableHealthCheck: false,
+					Minio: &dspav1.Minio{
+						Deploy: false,
+						Image:  "someimage",
+					},
+				},
+			},
+		}
+		dspa.Name = name
+		dspa.Namespace = testNamespace
+		dspa.UID = types.UID(uid)
+		return dspa
+	}
+
+	ctx, params, reconciler := CreateNewTestObjects()
+
+	// Deploy the first DSPA, it takes ownership of the shared resources
+	first := newDSPA("first", "first-uid")
+	err := params.ExtractParams(ctx, first, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, first, params)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileMLMD(ctx, first, params)
+	assert.Nil(t, err)
+	assert.Empty(t, params.ResourceConflicts)
+
+	// Deploy a second DSPA in the same namespace
+	_, secondParams, _ := CreateNewTestObjects()
+	second := newDSPA("second", "second-uid")
+	err = secondParams.ExtractParams(ctx, second, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, second, secondParams)
+	assert.Nil(t, err)
+	err = reconciler.ReconcileMLMD(ctx, second, secondParams)
+	assert.Nil(t, err)
+
+	// Assert conflicts on shared resources are recorded
+	assert.Contains(t, secondParams.ResourceConflicts, "ConfigMap/kfp-launcher (owned by DSPA first)")
+	assert.Contains(t, secondParams.ResourceConflicts, "Service/ml-pipeline (owned by DSPA first)")
+	assert.Contains(t, secondParams.ResourceConflicts, "ConfigMap/metadata-grpc-configmap (owned by DSPA first)")
+
+	// Assert the shared resource is still owned by the first DSPA only
+	launcherConfigMap := &corev1.ConfigMap{}
+	err = reconciler.Get(ctx, types.NamespacedName{Name: "kfp-launcher", Namespace: testNamespace}, launcherConfigMap)
+	assert.Nil(t, err)
+	assert.Len(t, launcherConfigMap.OwnerReferences, 1)
+	assert.Equal(t, types.UID("first-uid"), launcherConfigMap.OwnerReferences[0].UID)
+
+	// Assert instance-suffixed resources of the second DSPA are deployed
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, apiServerDefaultResourceNamePrefix+"second", testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+}
+
+func TestDeployAPIServerGroupAccess(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + testDSPAName
+	expectedAccessName := apiServerGroupAccessResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with deployed APIServer restricted to OpenShift groups
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.APIServer.EnableRoute = true
+	dspa.Spec.APIServer.Security = &dspav1.APIServerSecurity{
+		RBAC: &dspav1.APIServerRBAC{
+			Mode:   config.APIServerRBACModeGroups,
+			Groups: []string{"data-scientists"},
+		},
+	}
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Assert the OAuth proxy authorizes users granted the api subresource of the DSPA
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	var proxyArgs []string
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "oauth-proxy" {
+			proxyArgs = container.Args
+		}
+	}
+	assert.Contains(t, proxyArgs, `--openshift-sar={"namespace":"testnamespace","resource":"datasciencepipelinesapplications","resourceName":"testdspa","subresource":"api","verb":"get","resourceAPIGroup":"datasciencepipelinesapplications.opendatahub.io"}`)
+
+	// Assert the groups are bound to a Role granting the api subresource
+	role := &rbacv1.Role{}
+	created, err = reconciler.IsResourceCreated(ctx, role, expectedAccessName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, []string{"datasciencepipelinesapplications/api"}, role.Rules[0].Resources)
+	assert.Equal(t, []string{testDSPAName}, role.Rules[0].ResourceNames)
+	roleBinding := &rbacv1.RoleBinding{}
+	created, err = reconciler.IsResourceCreated(ctx, roleBinding, expectedAccessName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "data-scientists"}}, roleBinding.Subjects)
+
+	// Assert the group access is removed in the Namespace mode
+	dspa.Spec.APIServer.Security.RBAC.Mode = "Namespace"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	err = reconciler.ReconcileAPIServer(ctx, dspa, params)
+	require.Nil(t, err)
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.RoleBinding{}, expectedAccessName, testNamespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the Groups mode requires at least one group
+	dspa.Spec.APIServer.Security.RBAC = &dspav1.APIServerRBAC{Mode: config.APIServerRBACModeGroups}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.apiServer.security.rbac.groups")
+}
+
+func TestDeployAPIServerPorts(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.APIServer.EnableRoute = true
+	dspa.Spec.APIServer.Ports = &dspav1.APIServerPorts{HTTP: 9090, GRPC: 9091}
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+
+	// Assert the API server listens on the ports, and is reached at them
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedAPIServerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	apiServer := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, apiServer.Args, "--httpPortFlag=:9090")
+	assert.Contains(t, apiServer.Args, "--rpcPortFlag=:9091")
+	assert.Equal(t, []corev1.ContainerPort{{Name: "http", ContainerPort: 9090}, {Name: "grpc", ContainerPort: 9091}}, apiServer.Ports)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[1].Args, "--upstream=http://localhost:9090")
+
+	service := &corev1.Service{}
+	created, err = reconciler.IsResourceCreated(ctx, service, params.APIServerServiceName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	ports := map[string]int32{}
+	for _, port := range service.Spec.Ports {
+		ports[port.Name] = port.Port
+	}
+	assert.Equal(t, map[string]int32{"oauth": 8443, "http": 9090, "grpc": 9091}, ports)
+
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	grpcEndpoint := ""
+	for _, resource := range resources {
+		if resource.GetKind() == "ConfigMap" && resource.GetName() == "ds-pipeline-connection-testdspa" {
+			grpcEndpoint = resource.Object["data"].(map[string]interface{})["DSP_API_GRPC_ENDPOINT"].(string)
+		}
+	}
+	assert.Equal(t, "ds-pipeline-testdspa.testnamespace.svc.cluster.local:9091", grpcEndpoint)
+
+	// Assert conflicting ports are rejected
+	for _, ports := range []dspav1.APIServerPorts{{HTTP: 9090, GRPC: 9090}, {GRPC: 8888}, {HTTP: config.OAuthProxyPort}} {
+		dspa.Spec.APIServer.Ports = &ports
+		_, params, _ = CreateNewTestObjects()
+		assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.apiServer.ports]")
+	}
+}
+
+func TestGetSampleConfig(t *testing.T) {
+	viper.Set("ManagedPipelinesMetadata", map[string]interface{}{
+		"Instructlab": map[string]interface{}{"Name": "Instructlab", "Filepath": "/config/managed-pipelines/instructlab.yaml"},
+		"Iris":        map[string]interface{}{"Name": "[Demo] iris-training", "Filepath": "/samples/iris-pipeline-compiled.yaml"},
+	})
+	viper.Set("DSPO.PlatformVersion", "v1.2.3")
+	defer viper.Set("ManagedPipelinesMetadata", nil)
+	defer viper.Set("DSPO.PlatformVersion", nil)
+
+	_, _, reconciler := CreateNewTestObjects()
+	dspa := testutil.CreateDefaultDSPA()
+	sampleVersions := func() map[string]string {
+		sampleConfigJSON, err := reconciler.GetSampleConfig(dspa)
+		require.Nil(t, err)
+		var sampleConfig struct {
+			Pipelines []map[string]string `json:"pipelines"`
+		}
+		require.Nil(t, json.Unmarshal([]byte(sampleConfigJSON), &sampleConfig))
+		versions := map[string]string{}
+		for _, pipeline := range sampleConfig.Pipelines {
+			versions[pipeline["name"]] = pipeline["versionName"]
+		}
+		return versions
+	}
+
+	// Assert the iris sample follows enableSamplePipeline without samplePipelines
+	assert.Empty(t, sampleVersions())
+	dspa.Spec.APIServer.EnableSamplePipeline = true
+	assert.Equal(t, map[string]string{"[Demo] iris-training": "[Demo] iris-training - v1.2.3"}, sampleVersions())
+
+	// Assert the samplePipelines entries take precedence, and may keep their first version
+	dspa.Spec.APIServer.SamplePipelines = []dspav1.SamplePipeline{
+		{Name: "Iris", State: "Removed"},
+		{Name: "instructlab", AutoUpdateDefaultVersion: boolPtr(false)},
+	}
+	assert.Equal(t, map[string]string{"Instructlab": "Instructlab"}, sampleVersions())
+
+	// Assert unknown samples are reported
+	dspa.Spec.APIServer.SamplePipelines = []dspav1.SamplePipeline{{Name: "mnist"}}
+	_, err := reconciler.GetSampleConfig(dspa)
+	assert.ErrorContains(t, err, "[spec.apiServer.samplePipelines] unknown sample pipeline \"mnist\", must be one of [instructlab, iris]")
+}
@@ -0,0 +1,144 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// appliedManifests records a hash of the manifests last applied from each
+// template of every DSPA, so that a template is only applied again once the
+// params feeding it change, rather than on every reconcile. The hash covers
+// the rendered and transformed manifests, so that any input of a template,
+// whichever params fields it reads, is accounted for.
+//
+// Applied templates are applied again once their resync period elapsed, after
+// any change to the resources owned by their DSPA (see forgetAppliedQueue), and
+// when they were not applied in the previous reconcile of their DSPA (e.g. as
+// their component was disabled and its resources deleted).
+type appliedManifests struct {
+	mu    sync.Mutex
+	dspas map[types.NamespacedName]*dspaAppliedManifests
+}
+
+type dspaAppliedManifests struct {
+	uid       types.UID
+	templates map[string]appliedManifest
+}
+
+type appliedManifest struct {
+	hash        string
+	appliedAt   time.Time
+	reconcileID string
+}
+
+// manifestHash hashes the resources of manifest, JSON encoding sorts map keys
+// so equal resources have equal hashes.
+func manifestHash(manifest mf.Manifest) (string, error) {
+	b, err := json.Marshal(manifest.Resources())
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// upToDate reports whether template was applied for owner with the same hash
+// within the resync period, and marks it as seen by the reconcile reconcileID.
+func (a *appliedManifests) upToDate(owner mf.Owner, template, hash, reconcileID string, resyncPeriod time.Duration) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	dspa, ok := a.dspas[types.NamespacedName{Namespace: owner.GetNamespace(), Name: owner.GetName()}]
+	if !ok || dspa.uid != owner.GetUID() {
+		return false
+	}
+	applied, ok := dspa.templates[template]
+	if !ok || applied.hash != hash || time.Since(applied.appliedAt) > resyncPeriod {
+		return false
+	}
+	applied.reconcileID = reconcileID
+	dspa.templates[template] = applied
+	return true
+}
+
+// record records that template was applied for owner with hash
+func (a *appliedManifests) record(owner mf.Owner, template, hash, reconcileID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.dspas == nil {
+		a.dspas = map[types.NamespacedName]*dspaAppliedManifests{}
+	}
+	nn := types.NamespacedName{Namespace: owner.GetNamespace(), Name: owner.GetName()}
+	dspa, ok := a.dspas[nn]
+	if !ok || dspa.uid != owner.GetUID() {
+		dspa = &dspaAppliedManifests{uid: owner.GetUID(), templates: map[string]appliedManifest{}}
+		a.dspas[nn] = dspa
+	}
+	dspa.templates[template] = appliedManifest{hash: hash, appliedAt: time.Now(), reconcileID: reconcileID}
+}
+
+// forgetTemplate forgets that template was applied for the DSPA nn
+func (a *appliedManifests) forgetTemplate(nn types.NamespacedName, template string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if dspa, ok := a.dspas[nn]; ok {
+		delete(dspa.templates, template)
+	}
+}
+
+// forget forgets the templates applied for the DSPA nn
+func (a *appliedManifests) forget(nn types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.dspas, nn)
+}
+
+// sweep forgets the templates of the DSPA nn not applied by the reconcile
+// reconcileID.
+func (a *appliedManifests) sweep(nn types.NamespacedName, reconcileID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if dspa, ok := a.dspas[nn]; ok {
+		for template, applied := range dspa.templates {
+			if applied.reconcileID != reconcileID {
+				delete(dspa.templates, template)
+			}
+		}
+	}
+}
+
+// forgetAppliedQueue forgets the templates applied for the DSPAs it enqueues,
+// so that resources changed or deleted outside the operator are restored.
+type forgetAppliedQueue struct {
+	workqueue.RateLimitingInterface
+	applied *appliedManifests
+}
+
+func (q *forgetAppliedQueue) Add(item interface{}) {
+	if req, ok := item.(reconcile.Request); ok {
+		q.applied.forget(req.NamespacedName)
+	}
+	q.RateLimitingInterface.Add(item)
+}
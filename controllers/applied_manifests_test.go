@@ -0,0 +1,81 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSelectiveApply(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	nn := types.NamespacedName{Namespace: dspa.Namespace, Name: dspa.Name}
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + dspa.Name
+	defer viper.Set(config.SelectiveApplyResyncPeriodConfigName, nil)
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	apiServerDeployed := func() bool {
+		created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedAPIServerName, dspa.Namespace)
+		require.Nil(t, err)
+		return created
+	}
+	deleteAPIServer := func() {
+		deployment := &appsv1.Deployment{}
+		require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: expectedAPIServerName, Namespace: dspa.Namespace}, deployment))
+		require.Nil(t, reconciler.Delete(ctx, deployment))
+	}
+	require.True(t, apiServerDeployed())
+
+	// Assert unchanged templates are not applied again
+	deleteAPIServer()
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.False(t, apiServerDeployed())
+
+	// Assert templates are applied again once their params change
+	dspa.Spec.APIServer.Image = "quay.io/opendatahub/ds-pipelines-api-server:changed"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.True(t, apiServerDeployed())
+
+	// Assert templates are applied again once the DSPA resources changed
+	deleteAPIServer()
+	reconciler.appliedManifests.forget(nn)
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.True(t, apiServerDeployed())
+
+	// Assert templates not applied by the previous reconcile are applied again
+	deleteAPIServer()
+	reconciler.appliedManifests.sweep(nn, "another-reconcile")
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.True(t, apiServerDeployed())
+
+	// Assert a resync period of 0 applies every template
+	viper.Set(config.SelectiveApplyResyncPeriodConfigName, "0s")
+	deleteAPIServer()
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.True(t, apiServerDeployed())
+}
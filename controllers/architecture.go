@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podTemplatePaths are the paths of the pod templates of the workload kinds
+// rendered by the templates.
+var podTemplatePaths = map[string][]string{
+	"Deployment":  {"spec", "template"},
+	"StatefulSet": {"spec", "template"},
+	"Job":         {"spec", "template"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template"},
+}
+
+// retrieveNodeArchitectures counts the schedulable nodes of each architecture.
+func retrieveNodeArchitectures(ctx context.Context, cl client.Client) (map[string]int, error) {
+	nodes := &v1.NodeList{}
+	if err := cl.List(ctx, nodes); err != nil {
+		return nil, err
+	}
+	architectures := map[string]int{}
+	for _, node := range nodes.Items {
+		if arch := node.Labels[config.NodeArchitectureLabel]; arch != "" && !node.Spec.Unschedulable {
+			architectures[arch]++
+		}
+	}
+	return architectures, nil
+}
+
+// architectureImage returns the image set at configPath, and the architecture
+// it was selected for when set per node architecture: the architecture with
+// the most schedulable nodes among those with an image, the first in
+// alphabetical order on ties or when no node runs any of them. The default
+// image and architecture are returned when configPath is not set.
+func (p *DSPAParams) architectureImage(configPath, defaultImage, defaultArch string) (image, arch string) {
+	image, err := config.GetStringConfig(configPath)
+	if err != nil {
+		return defaultImage, defaultArch
+	}
+	images := config.GetArchitectureImagesConfig(configPath)
+	if len(images) == 0 {
+		return image, ""
+	}
+	architectures := make([]string, 0, len(images))
+	for architecture := range images {
+		architectures = append(architectures, architecture)
+	}
+	sort.Strings(architectures)
+	arch = architectures[0]
+	for _, architecture := range architectures {
+		if p.nodeArchitectures[architecture] > p.nodeArchitectures[arch] {
+			arch = architecture
+		}
+	}
+	return images[arch], arch
+}
+
+// nodeArchitectureTransformer requires the pods of workloads running images
+// selected for a node architecture to be scheduled on nodes of that
+// architecture, as the images would not run on the others.
+func nodeArchitectureTransformer(imageArchitectures map[string]string) mf.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		path, ok := podTemplatePaths[u.GetKind()]
+		if !ok || len(imageArchitectures) == 0 {
+			return nil
+		}
+		podSpec := &v1.PodSpec{}
+		podSpecPath := append(append([]string{}, path...), "spec")
+		podSpecObj, found, err := unstructured.NestedMap(u.Object, podSpecPath...)
+		if err != nil || !found {
+			return err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(podSpecObj, podSpec); err != nil {
+			return err
+		}
+
+		seen := map[string]bool{}
+		var architectures []string
+		for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+			if arch, ok := imageArchitectures[container.Image]; ok && !seen[arch] {
+				seen[arch] = true
+				architectures = append(architectures, arch)
+			}
+		}
+		if len(architectures) == 0 {
+			return nil
+		}
+		sort.Strings(architectures)
+
+		requirement := v1.NodeSelectorRequirement{Key: config.NodeArchitectureLabel, Operator: v1.NodeSelectorOpIn, Values: architectures}
+		affinity := podSpec.Affinity
+		if affinity == nil {
+			affinity = &v1.Affinity{}
+		}
+		if affinity.NodeAffinity == nil {
+			affinity.NodeAffinity = &v1.NodeAffinity{}
+		}
+		required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if required == nil || len(required.NodeSelectorTerms) == 0 {
+			required = &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{}}}
+		}
+		// The terms are ORed, the requirement must hold for each of them
+		for i := range required.NodeSelectorTerms {
+			required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+		}
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+
+		affinityObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(affinity)
+		if err != nil {
+			return err
+		}
+		return unstructured.SetNestedMap(u.Object, affinityObj, append(podSpecPath, "affinity")...)
+	}
+}
@@ -0,0 +1,132 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestExtractParams_ArchitectureImages(t *testing.T) {
+	viper.Set(config.APIServerImagePath, map[string]interface{}{
+		"amd64": "quay.io/opendatahub/ds-pipelines-api-server:amd64",
+		"arm64": "quay.io/opendatahub/ds-pipelines-api-server:arm64",
+	})
+	viper.Set(config.PersistenceAgentImagePath, map[string]interface{}{
+		"amd64": "quay.io/opendatahub/ds-pipelines-persistenceagent:amd64",
+	})
+	defer viper.Reset()
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.PersistenceAgent = &dspav1.PersistenceAgent{Deploy: true}
+
+	// Assert the image of the first architecture is selected without nodes
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:amd64", params.APIServer.Image)
+
+	// Assert the image of the architecture most schedulable nodes run is selected
+	for name, arch := range map[string]string{"amd64-1": "amd64", "arm64-1": "arm64", "arm64-2": "arm64"} {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{config.NodeArchitectureLabel: arch}}}
+		require.Nil(t, reconciler.Create(ctx, node))
+	}
+	cordoned := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "amd64-2", Labels: map[string]string{config.NodeArchitectureLabel: "amd64"}}}
+	cordoned.Spec.Unschedulable = true
+	require.Nil(t, reconciler.Create(ctx, cordoned))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:arm64", params.APIServer.Image)
+	assert.Equal(t, "arm64", params.ComponentImages["apiServer"].Architecture)
+	// Assert components with images for a single architecture run on it
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-persistenceagent:amd64", params.PersistenceAgent.Image)
+	assert.Equal(t, "amd64", params.ComponentImages["persistenceAgent"].Architecture)
+	assert.Empty(t, params.ComponentImages["mariaDB"].Architecture)
+
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	affinities := map[string]*v1.Affinity{}
+	for _, resource := range resources {
+		if resource.GetKind() != "Deployment" {
+			continue
+		}
+		deployment := &appsv1.Deployment{}
+		require.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, deployment))
+		affinities[deployment.Name] = deployment.Spec.Template.Spec.Affinity
+	}
+	require.NotNil(t, affinities["ds-pipeline-testdspa"])
+	assert.Equal(t, []string{"arm64"}, affinities["ds-pipeline-testdspa"].NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	require.NotNil(t, affinities["ds-pipeline-persistenceagent-testdspa"])
+	assert.Equal(t, []string{"amd64"}, affinities["ds-pipeline-persistenceagent-testdspa"].NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	// Assert multi-arch images are scheduled on any node
+	assert.Nil(t, affinities["mariadb-testdspa"])
+}
+
+func TestNodeArchitectureTransformer(t *testing.T) {
+	transform := nodeArchitectureTransformer(map[string]string{"image:amd64": "amd64"})
+	statefulSet := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "StatefulSet",
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "main", "image": "image:amd64"}},
+			"affinity": map[string]interface{}{
+				"podAntiAffinity": map[string]interface{}{},
+				"nodeAffinity": map[string]interface{}{"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+					"nodeSelectorTerms": []interface{}{
+						map[string]interface{}{"matchExpressions": []interface{}{map[string]interface{}{"key": "zone", "operator": "In", "values": []interface{}{"a"}}}},
+						map[string]interface{}{"matchExpressions": []interface{}{map[string]interface{}{"key": "zone", "operator": "In", "values": []interface{}{"b"}}}},
+					},
+				}},
+			},
+		}}},
+	}}
+	require.Nil(t, transform(statefulSet))
+	affinity := &v1.Affinity{}
+	affinityObj, _, _ := unstructured.NestedMap(statefulSet.Object, "spec", "template", "spec", "affinity")
+	require.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(affinityObj, affinity))
+
+	// Assert the architecture is required by every term, and the other affinities are kept
+	assert.NotNil(t, affinity.PodAntiAffinity)
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	for _, term := range terms {
+		require.Len(t, term.MatchExpressions, 2)
+		assert.Equal(t, config.NodeArchitectureLabel, term.MatchExpressions[1].Key)
+		assert.Equal(t, []string{"amd64"}, term.MatchExpressions[1].Values)
+	}
+
+	// Assert the pods of CronJobs are scheduled on the architecture too
+	cronJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"spec": map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "main", "image": "image:amd64"}},
+		}}}}},
+	}}
+	require.Nil(t, transform(cronJob))
+	_, found, _ := unstructured.NestedMap(cronJob.Object, "spec", "jobTemplate", "spec", "template", "spec", "affinity", "nodeAffinity")
+	assert.True(t, found)
+}
@@ -0,0 +1,69 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const certificatesTemplate = "cert-manager/certificates.yaml.tmpl"
+
+// ReconcileCertificates requests the component Certificates from cert-manager
+// when spec.tls.issuerRef is set, and reports whether all of them have been
+// issued to their secrets.
+func (r *DSPAReconciler) ReconcileCertificates(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) (bool, error) {
+
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	if params.CertManagerIssuer == nil {
+		return true, nil
+	}
+
+	log.Info("Applying cert-manager Certificates")
+	err := r.Apply(dsp, params, certificatesTemplate)
+	if err != nil {
+		return false, err
+	}
+
+	for _, certificate := range params.TLSCertificates {
+		secret, err := util.GetSecret(ctx, certificate.SecretName, dsp.Namespace, r.Client)
+		if apierrs.IsNotFound(err) {
+			log.Info("Waiting for cert-manager to issue Certificate", "secret", certificate.SecretName)
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if len(secret.Data["tls.crt"]) == 0 || len(secret.Data["tls.key"]) == 0 {
+			log.Info("Waiting for cert-manager to issue Certificate", "secret", certificate.SecretName)
+			return false, nil
+		}
+		// The issuing CA is read while extracting params, requeue so that
+		// components are deployed trusting it.
+		if len(secret.Data[config.CertManagerCASecretKey]) != 0 && len(params.CertManagerCABundle) == 0 {
+			log.Info("Certificate issued, CA bundle will be loaded on the next reconcile", "secret", certificate.SecretName)
+			return false, nil
+		}
+	}
+
+	log.Info("Finished applying cert-manager Certificates")
+	return true, nil
+}
@@ -0,0 +1,135 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDeployCertificates(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedSecretNames := []string{
+		"ds-pipeline-tls-testdspa",
+		"ds-pipelines-mariadb-tls-testdspa",
+		"ds-pipelines-minio-tls-testdspa",
+		"ds-pipeline-metadata-grpc-tls-certs-testdspa",
+	}
+
+	// Construct DSPA Spec with certificates issued by cert-manager
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			DSPVersion:  "v2",
+			PodToPodTLS: boolPtr(false),
+			TLS: &dspav1.TLS{
+				IssuerRef: &dspav1.CertManagerIssuerRef{Name: "dspa-ca"},
+			},
+			APIServer: &dspav1.APIServer{Deploy: true},
+			MLMD:      &dspav1.MLMD{Deploy: true},
+			Database: &dspav1.Database{
+				MariaDB: &dspav1.MariaDB{Deploy: true},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				Minio: &dspav1.Minio{
+					Deploy: true,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Assert TLS is enabled on all connections, including Minio
+	assert.True(t, params.PodToPodTLS)
+	assert.Equal(t, "Issuer", params.CertManagerIssuer.Kind)
+	assert.Equal(t, "https", params.ObjectStorageConnection.Scheme)
+	require.Len(t, params.TLSCertificates, len(expectedSecretNames))
+	assert.Contains(t, params.TLSCertificates[0].DNSNames, "ds-pipeline-testdspa.testnamespace.svc.cluster.local")
+
+	// Run test reconciliation, certificates are not issued yet
+	ready, err := reconciler.ReconcileCertificates(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.False(t, ready)
+
+	// Assert Certificates now exist and simulate cert-manager issuing them
+	for _, secretName := range expectedSecretNames {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"})
+		created, err := reconciler.IsResourceCreated(ctx, certificate, secretName, testNamespace)
+		assert.True(t, created)
+		assert.Nil(t, err)
+
+		issuer, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+		assert.Equal(t, "dspa-ca", issuer)
+
+		err = reconciler.Client.Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: testNamespace},
+			Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		})
+		require.Nil(t, err)
+	}
+
+	ready, err = reconciler.ReconcileCertificates(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.True(t, ready)
+}
+
+func TestDontDeployCertificates(t *testing.T) {
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			Database: &dspav1.Database{
+				MariaDB: &dspav1.MariaDB{Deploy: true},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				Minio: &dspav1.Minio{
+					Deploy: true,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+	dspa.Name = "testdspa"
+	dspa.Namespace = "testnamespace"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	assert.Empty(t, params.TLSCertificates)
+	assert.Equal(t, "http", params.ObjectStorageConnection.Scheme)
+
+	ready, err := reconciler.ReconcileCertificates(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.True(t, ready)
+}
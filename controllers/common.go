@@ -16,6 +16,8 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 )
 
@@ -23,8 +25,8 @@ var commonTemplatesDir = "common/default"
 
 const commonCusterRolebindingTemplate = "common/no-owner/clusterrolebinding.yaml.tmpl"
 
-func (r *DSPAReconciler) ReconcileCommon(dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+func (r *DSPAReconciler) ReconcileCommon(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	log.Info("Applying Common Resources")
 	err := r.ApplyDir(dsp, params, commonTemplatesDir)
@@ -36,6 +38,23 @@ func (r *DSPAReconciler) ReconcileCommon(dsp *dspav1.DataSciencePipelinesApplica
 		return err
 	}
 
+	// In the service mesh the database, object storage and MLMD gRPC
+	// connections are secured by the mesh mTLS
+	if params.ServiceMesh != nil {
+		log.Info("Applying Common Service Mesh Resources")
+		err = r.ApplyDir(dsp, params, commonServiceMeshTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, name := range []string{"mariadb-" + dsp.Name, "minio-" + dsp.Name, "ds-pipeline-metadata-grpc-" + dsp.Name} {
+			err = r.deleteServiceMeshResources(ctx, name, dsp.Namespace, peerAuthenticationGVK)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	log.Info("Finished applying Common Resources")
 	return nil
 }
@@ -0,0 +1,96 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// dspaV1Alpha1GVK is the older served version of the DSPA, its schema still
+// carries the DSP v1 (Tekton) fields that the v1 API prunes.
+var dspaV1Alpha1GVK = schema.GroupVersionKind{
+	Group:   dspav1.GroupVersion.Group,
+	Version: "v1alpha1",
+	Kind:    "DataSciencePipelinesApplication",
+}
+
+// compatibilityRule is a DSPA field that is only supported by some DSP versions.
+type compatibilityRule struct {
+	// Path of the field in the v1alpha1 DSPA
+	path []string
+	// Default value of the field, defaulted fields are only reported when
+	// set to another value, nil for fields without a default
+	defaultValue interface{}
+	// DSP versions that support the field
+	versions []string
+}
+
+// compatibilityMatrix lists the fields that are not supported by every DSP version.
+var compatibilityMatrix = []compatibilityRule{
+	{path: []string{"spec", "apiServer", "applyTektonCustomResource"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "archiveLogs"}, defaultValue: false, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "artifactImage"}, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "cacheImage"}, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "moveResultsImage"}, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "artifactScriptConfigMap"}, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "injectDefaultScript"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "stripEOF"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "terminateStatus"}, defaultValue: "Cancelled", versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "trackArtifacts"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "dbConfigConMaxLifetimeSec"}, defaultValue: int64(120), versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "collectMetrics"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "apiServer", "autoUpdatePipelineDefaultVersion"}, defaultValue: true, versions: []string{"v1"}},
+	{path: []string{"spec", "mlmd", "writer"}, versions: []string{"v1"}},
+}
+
+// CheckVersionCompatibility returns the fields of dspa that are set but not
+// supported by its DSP version. Fields only known to older API versions are
+// read through the v1alpha1 API, as they are pruned from the v1 DSPA.
+func (r *DSPAReconciler) CheckVersionCompatibility(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication) ([]string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(dspaV1Alpha1GVK)
+	err := r.Get(ctx, types.NamespacedName{Name: dspa.Name, Namespace: dspa.Namespace}, obj)
+	if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, rule := range compatibilityMatrix {
+		if slices.Contains(rule.versions, dspa.Spec.DSPVersion) {
+			continue
+		}
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, rule.path...)
+		if err != nil || !found || value == nil || reflect.DeepEqual(value, rule.defaultValue) {
+			continue
+		}
+		violations = append(violations, fmt.Sprintf("%s is only supported in DSP %s",
+			strings.Join(rule.path, "."), strings.Join(rule.versions, ", ")))
+	}
+	return violations, nil
+}
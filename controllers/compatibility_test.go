@@ -0,0 +1,76 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCheckVersionCompatibility(t *testing.T) {
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			DSPVersion: "v2",
+		},
+	}
+	dspa.Name = "testdspa"
+	dspa.Namespace = "testnamespace"
+
+	ctx, _, reconciler := CreateNewTestObjects()
+
+	// Assert a DSPA not served through v1alpha1 has no violations
+	violations, err := reconciler.CheckVersionCompatibility(ctx, dspa)
+	assert.Nil(t, err)
+	assert.Empty(t, violations)
+
+	// Create the v1alpha1 view of the DSPA with Tekton specific fields
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"dspVersion": "v2",
+			"apiServer": map[string]interface{}{
+				"deploy": true,
+				// Defaulted values are not reported
+				"applyTektonCustomResource": true,
+				"terminateStatus":           "Cancelled",
+				"stripEOF":                  false,
+				"artifactImage":             "quay.io/example/artifact:latest",
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(dspaV1Alpha1GVK)
+	obj.SetName(dspa.Name)
+	obj.SetNamespace(dspa.Namespace)
+	require.Nil(t, reconciler.Create(ctx, obj))
+
+	violations, err = reconciler.CheckVersionCompatibility(ctx, dspa)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"spec.apiServer.artifactImage is only supported in DSP v1",
+		"spec.apiServer.stripEOF is only supported in DSP v1",
+	}, violations)
+
+	// Assert the same fields are compatible with DSP v1
+	dspa.Spec.DSPVersion = "v1"
+	violations, err = reconciler.CheckVersionCompatibility(ctx, dspa)
+	assert.Nil(t, err)
+	assert.Empty(t, violations)
+}
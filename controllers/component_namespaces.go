@@ -0,0 +1,254 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// componentNamespaceKinds are the kinds of the resources the templates of
+// MariaDB, Minio and the tenants of spec.multiTenancy deploy, along with the
+// Secrets copied for them, deleted from the namespaces of
+// spec.database.mariaDB.namespace, spec.objectStorage.minio.namespace and
+// spec.multiTenancy.namespaces once they are no longer used.
+var componentNamespaceKinds = []schema.GroupVersionKind{
+	appsv1.SchemeGroupVersion.WithKind("Deployment"),
+	appsv1.SchemeGroupVersion.WithKind("StatefulSet"),
+	corev1.SchemeGroupVersion.WithKind("Service"),
+	corev1.SchemeGroupVersion.WithKind("ServiceAccount"),
+	corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+	corev1.SchemeGroupVersion.WithKind("Secret"),
+	corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"),
+	networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"),
+	networkingv1.SchemeGroupVersion.WithKind("Ingress"),
+	policyv1.SchemeGroupVersion.WithKind("PodDisruptionBudget"),
+	rbacv1.SchemeGroupVersion.WithKind("Role"),
+	rbacv1.SchemeGroupVersion.WithKind("RoleBinding"),
+	routev1.GroupVersion.WithKind("Route"),
+	serviceMonitorGVK,
+}
+
+// injectOwner sets owner as the controller of the resources in its namespace.
+// Owner references cannot cross namespaces, the resources of the components
+// deployed in another namespace are labelled with the UID of owner instead,
+// and deleted along with it, see CleanUpComponentNamespaces.
+func injectOwner(owner mf.Owner) mf.Transformer {
+	inject := mf.InjectOwner(owner)
+	return func(u *unstructured.Unstructured) error {
+		if u.GetNamespace() == "" || u.GetNamespace() == owner.GetNamespace() {
+			return inject(u)
+		}
+		labels := u.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[config.OwnerUIDLabel] = string(owner.GetUID())
+		u.SetLabels(labels)
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[config.OwnerAnnotation] = owner.GetNamespace() + "/" + owner.GetName()
+		u.SetAnnotations(annotations)
+		return nil
+	}
+}
+
+// ownedBy returns true when obj is controlled by dsp, or is labelled with its
+// UID in the namespace of a component.
+func ownedBy(obj metav1.Object, dsp *dspav1.DataSciencePipelinesApplication) bool {
+	return metav1.IsControlledBy(obj, dsp) || (obj.GetLabels()[config.OwnerUIDLabel] == string(dsp.UID) && dsp.UID != "")
+}
+
+// componentNamespaces returns the namespaces other than the DSPA namespace
+// that its managed MariaDB and Minio, and its tenants, are deployed in.
+func (p *DSPAParams) componentNamespaces() []string {
+	var namespaces []string
+	candidates := []string{p.MariaDBNamespace, p.MinioNamespace}
+	if p.MultiTenancy != nil {
+		candidates = append(candidates, p.MultiTenancy.Namespaces...)
+	}
+	for _, namespace := range candidates {
+		if namespace != "" && namespace != p.Namespace && !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// ReconcileComponentNamespaces records the namespaces the components of dsp
+// are deployed in, other than its own, in its status, and deletes the
+// resources of dsp from the namespaces previously recorded that are no longer
+// used, e.g. once a component moved back to the DSPA namespace. It runs before
+// the components are deployed, so that their namespaces are recorded even if
+// deploying them fails.
+func (r *DSPAReconciler) ReconcileComponentNamespaces(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	namespaces := params.componentNamespaces()
+	var stale []string
+	for _, namespace := range dsp.Status.ComponentNamespaces {
+		if !slices.Contains(namespaces, namespace) {
+			stale = append(stale, namespace)
+		}
+	}
+	// Namespaces failing to be cleaned up stay recorded, to be retried
+	defer func() {
+		recorded := append(slices.Clone(namespaces), stale...)
+		slices.Sort(recorded)
+		dspaStatus.SetComponentNamespaces(recorded)
+	}()
+
+	for len(stale) > 0 {
+		log.Info(fmt.Sprintf("Deleting the DSPA components no longer deployed in namespace %s.", stale[0]))
+		if err := r.deleteComponentNamespaceResources(ctx, dsp, stale[0]); err != nil {
+			return err
+		}
+		stale = stale[1:]
+	}
+	return nil
+}
+
+// CleanUpComponentNamespaces deletes the resources of dsp from the namespaces
+// recorded in its status, and from those set in its spec in case they were not
+// recorded yet.
+func (r *DSPAReconciler) CleanUpComponentNamespaces(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication) error {
+	namespaces := slices.Clone(dsp.Status.ComponentNamespaces)
+	if dsp.Spec.Database != nil && dsp.Spec.Database.MariaDB != nil {
+		namespaces = append(namespaces, dsp.Spec.Database.MariaDB.Namespace)
+	}
+	if dsp.Spec.ObjectStorage != nil && dsp.Spec.ObjectStorage.Minio != nil {
+		namespaces = append(namespaces, dsp.Spec.ObjectStorage.Minio.Namespace)
+	}
+	if dsp.Spec.MultiTenancy != nil {
+		namespaces = append(namespaces, dsp.Spec.MultiTenancy.Namespaces...)
+	}
+	slices.Sort(namespaces)
+	for _, namespace := range slices.Compact(namespaces) {
+		if namespace == "" || namespace == dsp.Namespace {
+			continue
+		}
+		if err := r.deleteComponentNamespaceResources(ctx, dsp, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteComponentNamespaceResources deletes the resources labelled with the
+// UID of dsp from namespace. Kinds not served by the cluster, e.g. Routes on
+// Kubernetes, are skipped.
+func (r *DSPAReconciler) deleteComponentNamespaceResources(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	namespace string) error {
+	if dsp.UID == "" {
+		return nil
+	}
+	for _, gvk := range componentNamespaceKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := r.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{config.OwnerUIDLabel: string(dsp.UID)})
+		if meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			if err := client.IgnoreNotFound(r.Delete(ctx, &list.Items[i])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copySecretToComponentNamespace creates or updates the copy of the Secret
+// name of dsp in namespace, the namespace a component reading it is deployed
+// in. Secrets of the same name that are not copies of the Secret of dsp are
+// not overwritten.
+func (r *DSPAReconciler) copySecretToComponentNamespace(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	name, namespace string) error {
+	source := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: dsp.Namespace}, source); err != nil {
+		return fmt.Errorf("unable to retrieve the Secret %s to copy to namespace %s: %w", name, namespace, err)
+	}
+
+	secret := &corev1.Secret{}
+	secret.Name, secret.Namespace = name, namespace
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if err == nil && !ownedBy(secret, dsp) {
+		return fmt.Errorf("namespace %s already has a Secret %s not copied from DSPA %s/%s", namespace, name, dsp.Namespace, dsp.Name)
+	} else if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Labels["component"] = "data-science-pipelines"
+		secret.Labels[config.OwnerUIDLabel] = string(dsp.UID)
+		secret.Annotations[config.OwnerAnnotation] = dsp.Namespace + "/" + dsp.Name
+		secret.Type = source.Type
+		secret.Data = source.Data
+		return nil
+	})
+	return err
+}
+
+// enqueueLabelledOwner enqueues the DSPA owning a resource deployed in the
+// namespace of one of its components, from its OwnerAnnotation.
+func enqueueLabelledOwner() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		owner, ok := o.GetAnnotations()[config.OwnerAnnotation]
+		if !ok || o.GetLabels()[config.OwnerUIDLabel] == "" {
+			return nil
+		}
+		namespace, name, ok := strings.Cut(owner, "/")
+		if !ok {
+			return nil
+		}
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+	})
+}
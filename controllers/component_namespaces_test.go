@@ -0,0 +1,162 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestDeployComponentNamespaces(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.UID = types.UID("testdspa-uid")
+	dspa.Spec.Database.MariaDB.Namespace = "locked-down"
+	expectedDatabaseName := "mariadb-" + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	// Assert a namespace that does not exist is rejected
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "namespace locked-down does not exist")
+
+	// Assert a namespace that did not opt in to the components of the DSPA namespace is rejected
+	lockedDown := &corev1.Namespace{}
+	lockedDown.Name = "locked-down"
+	lockedDown.Annotations = map[string]string{config.ComponentSourceNamespacesAnnotation: "othernamespace"}
+	require.Nil(t, reconciler.Create(ctx, lockedDown))
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log),
+		"[spec.database.mariaDB.namespace] namespace locked-down does not allow the components of DSPAs in namespace testnamespace")
+
+	lockedDown.Annotations[config.ComponentSourceNamespacesAnnotation] = "othernamespace, testnamespace"
+	require.Nil(t, reconciler.Update(ctx, lockedDown))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "locked-down", params.MariaDBNamespace)
+	assert.Equal(t, dspa.Namespace, params.MinioNamespace)
+	assert.Equal(t, "mariadb-testdspa.locked-down.svc.cluster.local", params.DBConnection.Host)
+
+	// Assert the namespace is recorded before MariaDB is deployed in it
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	require.Nil(t, reconciler.ReconcileComponentNamespaces(ctx, dspa, params, dspaStatus))
+	assert.Equal(t, []string{"locked-down"}, dspaStatus.GetComponentNamespaces())
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	// Assert MariaDB is labelled with the DSPA rather than owned by it
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, "locked-down")
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Empty(t, deployment.OwnerReferences)
+	assert.Equal(t, "testdspa-uid", deployment.Labels[config.OwnerUIDLabel])
+	assert.Equal(t, "testnamespace/testdspa", deployment.Annotations[config.OwnerAnnotation])
+	assert.True(t, ownedBy(deployment, dspa))
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the credentials are copied, and the API server is allowed in from the DSPA namespace
+	secret := &corev1.Secret{}
+	created, err = reconciler.IsResourceCreated(ctx, secret, params.DBConnection.CredentialsSecret.Name, "locked-down")
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.NotEmpty(t, secret.Data[params.DBConnection.CredentialsSecret.Key])
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	created, err = reconciler.IsResourceCreated(ctx, networkPolicy, expectedDatabaseName, "locked-down")
+	require.Nil(t, err)
+	require.True(t, created)
+	require.NotNil(t, networkPolicy.Spec.Ingress[0].From[1].NamespaceSelector)
+	assert.Equal(t, map[string]string{"kubernetes.io/metadata.name": dspa.Namespace},
+		networkPolicy.Spec.Ingress[0].From[1].NamespaceSelector.MatchLabels)
+
+	// Assert the resources are deleted from the namespace once MariaDB moves back
+	dspa.Status.ComponentNamespaces = dspaStatus.GetComponentNamespaces()
+	dspa.Spec.Database.MariaDB.Namespace = ""
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	dspaStatus = dspastatus.NewDSPAStatus(dspa)
+	require.Nil(t, reconciler.ReconcileComponentNamespaces(ctx, dspa, params, dspaStatus))
+	assert.Empty(t, dspaStatus.GetComponentNamespaces())
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedDatabaseName, "locked-down")
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Secret{}, params.DBConnection.CredentialsSecret.Name, "locked-down")
+	require.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestComponentNamespaceValidation(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, componentNamespace("locked-down")))
+
+	// Assert the namespace is not combined with certificates issued in the DSPA namespace
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	dspa.Spec.ObjectStorage.Minio.Namespace = "locked-down"
+	dspa.Spec.TLS = &dspav1.TLS{IssuerRef: &dspav1.CertManagerIssuerRef{Name: "testissuer"}}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log),
+		"[spec.objectStorage.minio.namespace] and [spec.tls.issuerRef] must not be set together")
+
+	// Assert the namespace set to the DSPA namespace is the default
+	dspa = testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	dspa.Spec.ObjectStorage.Minio.Namespace = dspa.Namespace
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, dspa.Namespace, params.MinioNamespace)
+	assert.Empty(t, params.componentNamespaces())
+}
+
+func TestCleanUpComponentNamespaces(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.UID = types.UID("testdspa-uid")
+	dspa.Spec.Database.MariaDB.Namespace = "locked-down"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, componentNamespace("locked-down")))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	// Assert the resources of other DSPAs are kept
+	other := &corev1.ConfigMap{}
+	other.Name, other.Namespace = "other", "locked-down"
+	other.Labels = map[string]string{config.OwnerUIDLabel: "other-uid"}
+	require.Nil(t, reconciler.Create(ctx, other))
+
+	// Assert the resources are deleted from the namespaces set in the spec, even if not recorded yet
+	require.Nil(t, reconciler.CleanUpComponentNamespaces(ctx, dspa))
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, "mariadb-"+dspa.Name, "locked-down")
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, "other", "locked-down")
+	require.Nil(t, err)
+	assert.True(t, created)
+}
+
+// componentNamespace returns a namespace that allows the components of the
+// DSPAs in testnamespace.
+func componentNamespace(name string) *corev1.Namespace {
+	namespace := &corev1.Namespace{}
+	namespace.Name = name
+	namespace.Annotations = map[string]string{config.ComponentSourceNamespacesAnnotation: "testnamespace"}
+	return namespace
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const DSPV2VersionString = "v2"
@@ -35,8 +41,79 @@ var SupportedDSPVersions = []string{DSPV2VersionString}
 const (
 	DefaultImageValue = "MustSetInConfig"
 
+	// Sources of the images reported in the DSPA component status
+	ImageSourceDSPA           = "DSPA"
+	ImageSourceOperatorConfig = "OperatorConfig"
+
 	CustomCABundleRootMountPath = "/dsp-custom-certs"
 
+	// MlPipelineUILogoMountPath is where the logo of a customized UI is mounted
+	MlPipelineUILogoMountPath = "/etc/ui-customization"
+
+	// APIServerRBACModeGroups restricts the API Server to members of OpenShift groups
+	APIServerRBACModeGroups = "Groups"
+
+	// ExternalDBTLSModeVerifyFull verifies the certificate of external
+	// databases, ExternalDBTLSModeDisable connects to them in plain text
+	ExternalDBTLSModeVerifyFull = "verify-full"
+	ExternalDBTLSModeDisable    = "disable"
+	// ExternalDBClientCertMountPath is where MLMD reads the client certificate
+	// it presents to external databases
+	ExternalDBClientCertMountPath = "/dbclient-tls"
+	// ExternalDBMySQL8 is the serverVersion of MySQL 8 external databases
+	ExternalDBMySQL8 = "8"
+
+	// DefaultServiceMeshMTLSMode accepts connections from clients outside of the mesh
+	DefaultServiceMeshMTLSMode = "PERMISSIVE"
+
+	// Defaults of the DSPAs provisioned in project namespaces, labelled with
+	// ProjectIntegrationLabel so that DSPAs created by users are never deleted
+	DefaultProjectIntegrationSelector = "opendatahub.io/dashboard=true"
+	DefaultProjectIntegrationDSPAName = "dspa"
+	ProjectIntegrationLabel           = "datasciencepipelinesapplications.opendatahub.io/provisioned-by-project"
+	// ProjectIntegrationOptOutAnnotation set to true on a project namespace
+	// opts it out of the provisioned DSPA, deleting it if it was provisioned
+	ProjectIntegrationOptOutAnnotation = "datasciencepipelinesapplications.opendatahub.io/skip-project-integration"
+
+	// PropagatedFromLabel holds the UID of the DSPA whose object storage
+	// credentials a Secret is a copy of, PropagatedFromAnnotation its namespace
+	// and name
+	PropagatedFromLabel      = "datasciencepipelinesapplications.opendatahub.io/propagated-from"
+	PropagatedFromAnnotation = "datasciencepipelinesapplications.opendatahub.io/propagated-from"
+
+	// OwnerUIDLabel holds the UID of the DSPA owning a resource deployed in the
+	// namespace of a component, as owner references cannot cross namespaces,
+	// OwnerAnnotation its namespace and name
+	OwnerUIDLabel   = "datasciencepipelinesapplications.opendatahub.io/owner-uid"
+	OwnerAnnotation = "datasciencepipelinesapplications.opendatahub.io/owner"
+
+	// ComponentSourceNamespacesAnnotation on a namespace lists, comma
+	// separated, the namespaces whose DSPAs may deploy components in it
+	ComponentSourceNamespacesAnnotation = "datasciencepipelinesapplications.opendatahub.io/component-source-namespaces"
+
+	// StorageChangeAcknowledgedAnnotation applies a change of the buckets or
+	// database name of a DSPA, once its data was migrated, see
+	// status.storage. The operator removes it once the change is recorded.
+	StorageChangeAcknowledgedAnnotation = "datasciencepipelinesapplications.opendatahub.io/storage-change-acknowledged"
+
+	// Scopes of the workflow controller of a DSPA with spec.multiTenancy, a
+	// controller per namespace by default
+	WorkflowControllerScopeNamespace = "Namespace"
+	WorkflowControllerScopeCluster   = "Cluster"
+
+	// DiffAnnotation on a DSPA publishes the changes the operator would apply
+	// to its resources, see ReconcileDiff
+	DiffAnnotation = "datasciencepipelinesapplications.opendatahub.io/diff"
+
+	// Standard labels set on every resource owned by a DSPA, see
+	// ResourceLabelsConfigName
+	AppNameLabel      = "app.kubernetes.io/name"
+	AppInstanceLabel  = "app.kubernetes.io/instance"
+	AppPartOfLabel    = "app.kubernetes.io/part-of"
+	AppManagedByLabel = "app.kubernetes.io/managed-by"
+	DefaultAppPartOf  = "data-science-pipelines"
+	AppManagedBy      = "data-science-pipelines-operator"
+
 	// GlobalODHCaBundleConfigMapName key and label values  are a contract with
 	// ODH Platform https://github.com/opendatahub-io/architecture-decision-records/pull/28
 	GlobalODHCaBundleConfigMapName = "odh-trusted-ca-bundle"
@@ -50,6 +127,38 @@ const (
 	OpenshiftServiceCAConfigMapName = "openshift-service-ca.crt"
 	OpenshiftServiceCAConfigMapKey  = "service-ca.crt"
 
+	// Certificates issued by cert-manager are written to these secrets, the
+	// MariaDB and MLMD gRPC names match the ones used with OpenShift service-ca
+	APIServerTLSSecretNamePrefix  = "ds-pipeline-tls-"
+	MariaDBTLSSecretNamePrefix    = "ds-pipelines-mariadb-tls-"
+	MinioTLSSecretNamePrefix      = "ds-pipelines-minio-tls-"
+	MlmdGRPCTLSSecretNamePrefix   = "ds-pipeline-metadata-grpc-tls-certs-"
+	CertManagerCASecretKey        = "ca.crt"
+	DefaultCertManagerIssuerKind  = "Issuer"
+	DefaultCertManagerIssuerGroup = "cert-manager.io"
+
+	// Services of the MLMD gRPC server, the shared Service is named as in KFP
+	MlmdGRPCServiceNamePrefix         = "ds-pipeline-metadata-grpc-"
+	MlmdGRPCSharedServiceName         = "metadata-grpc-service"
+	MlmdGRPCHeadlessServiceNamePrefix = "ds-pipeline-metadata-grpc-headless-"
+
+	// On the kubernetes platform components are authenticated by oauth2-proxy,
+	// with the client credentials and cookie secret of the
+	// DefaultOAuth2ProxySecretName Secret of the DSPA namespace
+	DefaultOAuth2ProxyImage          = "quay.io/oauth2-proxy/oauth2-proxy:v7.6.0"
+	DefaultOAuth2ProxyProvider       = "oidc"
+	DefaultOAuth2ProxySecretName     = "ds-pipelines-oauth2-proxy"
+	OAuth2ProxyClientIDSecretKey     = "client-id"
+	OAuth2ProxyClientSecretSecretKey = "client-secret"
+	OAuth2ProxyCookieSecretSecretKey = "cookie-secret"
+
+	// Metrics exporters of spec.monitoring, Minio serves its own metrics
+	DefaultMysqldExporterImage = "quay.io/prometheus/mysqld-exporter:v0.15.1"
+	MysqldExporterPort         = 9104
+	DefaultMinioMetricsPath    = "/minio/v2/metrics/cluster"
+	MonitoringScrapeMonitor    = "ServiceMonitor"
+	MonitoringScrapeAnnotation = "Annotations"
+
 	DefaultSystemSSLCertFile     = "SSL_CERT_FILE"
 	DefaultSystemSSLCertFilePath = "/etc/pki/tls/certs/ca-bundle.crt" // Fedora/RHEL 6
 
@@ -59,23 +168,42 @@ const (
 	CustomServerConfigMapNameKey    = "config.json"
 	DSPServicePrefix                = "ds-pipeline"
 
-	DefaultDBSecretNamePrefix = "ds-pipeline-db-"
-	DefaultDBSecretKey        = "password"
-	GeneratedDBPasswordLength = 12
+	DefaultDBSecretNamePrefix  = "ds-pipeline-db-"
+	ExternalDBSecretNamePrefix = "ds-pipeline-db-external-"
+	DefaultDBSecretKey         = "password"
+	GeneratedDBPasswordLength  = 12
 
 	DefaultSignedUrlExpiryTimeSeconds = 60
 
+	DefaultAPIServerHTTPPort int32 = 8888
+	DefaultAPIServerGRPCPort int32 = 8887
+	// OAuthProxyPort is the port of the oauth proxy sidecars
+	OAuthProxyPort int32 = 8443
+
 	MariaDBName        = "mlpipeline"
 	MariaDBHostPrefix  = "mariadb"
 	MariaDBHostPort    = "3306"
 	MariaDBUser        = "mlpipeline"
 	MariaDBNamePVCSize = "10Gi"
+	// MariaDBGaleraReplicas is the default number of nodes of a Galera cluster
+	MariaDBGaleraReplicas = 3
 
 	MinioHostPrefix    = "minio"
 	MinioPort          = "9000"
 	MinioScheme        = "http"
 	MinioDefaultBucket = "mlpipeline"
 	MinioPVCSize       = "10Gi"
+	// MinioDistributedMinReplicas is the minimum number of nodes of a
+	// distributed Minio, below which its objects cannot be erasure coded
+	MinioDistributedMinReplicas = 4
+
+	// S3TransferAccelerationHost is the host of the AWS S3 Transfer
+	// Acceleration endpoint, addressing the buckets by virtual host
+	S3TransferAccelerationHost = "s3-accelerate.amazonaws.com"
+
+	DefaultWorkspacePVCSize    = "10Gi"
+	DefaultWorkspaceAccessMode = "ReadWriteMany"
+	DefaultWorkspaceMountPath  = "/workspace"
 
 	DefaultObjectStorageSecretNamePrefix  = "ds-pipeline-s3-"
 	DefaultObjectStorageAccessKey         = "accesskey"
@@ -83,7 +211,38 @@ const (
 	GeneratedObjectStorageAccessKeyLength = 16
 	GeneratedObjectStorageSecretKeyLength = 24
 
+	// Keys of the ODH Dashboard data connection Secrets
+	DataConnectionAccessKey = "AWS_ACCESS_KEY_ID"
+	DataConnectionSecretKey = "AWS_SECRET_ACCESS_KEY"
+	DataConnectionEndpoint  = "AWS_S3_ENDPOINT"
+	DataConnectionBucket    = "AWS_S3_BUCKET"
+	DataConnectionRegion    = "AWS_DEFAULT_REGION"
+
 	MlmdGrpcPort = "8080"
+
+	DefaultHAReplicas = 2
+
+	MlmdEnvoyPort            = 9090
+	MlmdEnvoyUpstreamTimeout = 30 * time.Second
+
+	DefaultExternalSecretProvider        = "vault"
+	DefaultExternalSecretStoreKind       = "SecretStore"
+	DefaultExternalSecretRefreshInterval = "1h"
+
+	DefaultCacheCleanupSchedule    = "0 0 * * *"
+	DefaultCacheCleanupMaxAgeHours = 168
+
+	DefaultDatabaseBackupSchedule             = "0 2 * * *"
+	DefaultDatabaseBackupVerificationSchedule = "0 4 * * *"
+	DefaultDatabaseBackupPVCSize              = "10Gi"
+
+	DefaultRunRetentionSchedule = "0 1 * * *"
+	DefaultRunRetentionAction   = "Archive"
+
+	DefaultDBConnectionMaxLifetime = "120s"
+	DefaultDBInitConnectionTimeout = "6m"
+
+	DefaultAuditLogExportInterval = 5 * time.Minute
 )
 
 // DSPO Config File Paths
@@ -100,15 +259,71 @@ const (
 	ArgoWorkflowControllerImagePath = "Images.ArgoWorkflowController"
 	MariaDBImagePath                = "Images.MariaDB"
 	OAuthProxyImagePath             = "Images.OAuthProxy"
+	OAuth2ProxyImagePath            = "Images.OAuth2Proxy"
 	RuntimeGenericPath              = "Images.RuntimeGeneric"
 	ToolboxImagePath                = "Images.Toolbox"
 	RHELAIImagePath                 = "Images.RHELAI"
+	MysqldExporterImagePath         = "Images.MysqldExporter"
+
+	// FIPS variants of the images above can be configured under the same
+	// key in ImagesFIPS, e.g. ImagesFIPS.ApiServer
+	ImagesPathPrefix     = "Images."
+	FIPSImagesPathPrefix = "ImagesFIPS."
+
+	// The images above may also be set per node architecture, e.g.
+	// Images.ApiServer.arm64, rather than as a single multi-arch image.
+	// NodeArchitectureLabel is the well-known label of the node architectures.
+	NodeArchitectureLabel = "kubernetes.io/arch"
 
 	// Other configs
 	ObjStoreConnectionTimeoutConfigName      = "DSPO.HealthCheck.ObjectStore.ConnectionTimeout"
 	DBConnectionTimeoutConfigName            = "DSPO.HealthCheck.Database.ConnectionTimeout"
 	RequeueTimeConfigName                    = "DSPO.RequeueTime"
+	ReconcileParallelismConfigName           = "DSPO.ReconcileParallelism"
+	RateLimitNamespaceQPSConfigName          = "DSPO.RateLimit.NamespaceQPS"
+	RateLimitNamespaceBurstConfigName        = "DSPO.RateLimit.NamespaceBurst"
+	RateLimitApplyQPSConfigName              = "DSPO.RateLimit.ApplyQPS"
+	RateLimitApplyBurstConfigName            = "DSPO.RateLimit.ApplyBurst"
+	SelectiveApplyResyncPeriodConfigName     = "DSPO.SelectiveApply.ResyncPeriod"
 	ApiServerIncludeOwnerReferenceConfigName = "DSPO.ApiServer.IncludeOwnerReference"
+	NamespaceSelectorConfigName              = "DSPO.NamespaceSelector"
+	AllowedNamespacesConfigName              = "DSPO.AllowedNamespaces"
+	DeniedNamespacesConfigName               = "DSPO.DeniedNamespaces"
+	SingleInstancePerNamespaceConfigName     = "DSPO.SingleInstancePerNamespace"
+	MultiTenancyAllowClusterScopeConfigName  = "DSPO.MultiTenancy.AllowClusterScope"
+	ResolveImageDigestsConfigName            = "DSPO.ImageDigests.Resolve"
+	RequireImageDigestsConfigName            = "DSPO.ImageDigests.Required"
+	CosignPublicKeyConfigName                = "DSPO.ImageDigests.CosignPublicKey"
+	UpgradeTimeoutConfigName                 = "DSPO.Upgrade.Timeout"
+	APIServerRolloutEnabledConfigName        = "DSPO.ApiServer.ImageRollout.Enabled"
+	APIServerRolloutCanarySelectorConfigName = "DSPO.ApiServer.ImageRollout.CanarySelector"
+	APIServerRolloutCanaryPercentConfigName  = "DSPO.ApiServer.ImageRollout.CanaryPercentage"
+	APIServerRolloutPausedConfigName         = "DSPO.ApiServer.ImageRollout.Paused"
+	ProjectIntegrationEnabledConfigName      = "DSPO.ProjectIntegration.Enabled"
+	ProjectIntegrationSelectorConfigName     = "DSPO.ProjectIntegration.NamespaceSelector"
+	ProjectIntegrationDSPANameConfigName     = "DSPO.ProjectIntegration.DSPAName"
+	ProjectIntegrationDefaultSpecConfigName  = "DSPO.ProjectIntegration.DefaultSpec"
+	APIServerRolloutAbortedConfigName        = "DSPO.ApiServer.ImageRollout.Aborted"
+	NotificationsWebhookURLConfigName        = "DSPO.Notifications.Webhook.URL"
+	NotificationsSlackWebhookURLConfigName   = "DSPO.Notifications.Slack.WebhookURL"
+	NotificationsSMTPAddressConfigName       = "DSPO.Notifications.SMTP.Address"
+	NotificationsSMTPFromConfigName          = "DSPO.Notifications.SMTP.From"
+	NotificationsSMTPToConfigName            = "DSPO.Notifications.SMTP.To"
+	NotificationsSMTPUsernameConfigName      = "DSPO.Notifications.SMTP.Username"
+	NotificationsSMTPPasswordConfigName      = "DSPO.Notifications.SMTP.Password"
+	NotificationsTimeoutConfigName           = "DSPO.Notifications.Timeout"
+	ManagementStateConfigName                = "DSPO.ManagementState"
+	ResourceLabelsConfigName                 = "DSPO.ResourceLabels"
+	DeclarativePipelinesSyncPeriodConfigName = "DSPO.DeclarativePipelines.SyncPeriod"
+	KubernetesIngressDomainConfigName        = "DSPO.Kubernetes.Ingress.Domain"
+	KubernetesIngressClassNameConfigName     = "DSPO.Kubernetes.Ingress.ClassName"
+	KubernetesIngressAnnotationsConfigName   = "DSPO.Kubernetes.Ingress.Annotations"
+	KubernetesIngressTLSConfigName           = "DSPO.Kubernetes.Ingress.TLS"
+	KubernetesOAuth2ProxyProviderConfigName  = "DSPO.Kubernetes.OAuth2Proxy.Provider"
+	KubernetesOAuth2ProxyIssuerURLConfigName = "DSPO.Kubernetes.OAuth2Proxy.IssuerURL"
+	KubernetesOAuth2ProxySecretConfigName    = "DSPO.Kubernetes.OAuth2Proxy.SecretName"
+	KubernetesOAuth2ProxyGroupsConfigName    = "DSPO.Kubernetes.OAuth2Proxy.AllowedGroups"
+	GrafanaInstanceSelectorConfigName        = "DSPO.Monitoring.Grafana.InstanceSelector"
 )
 
 // DSPA Status Condition Types
@@ -120,6 +335,11 @@ const (
 	ScheduledWorkflowReady = "ScheduledWorkflowReady"
 	MLMDProxyReady         = "MLMDProxyReady"
 	CrReady                = "Ready"
+	Degraded               = "Degraded"
+	UpgradeProgressing     = "UpgradeProgressing"
+	CRDViewerReady         = "CRDViewerReady"
+	BackupVerified         = "BackupVerified"
+	SpecChangeUnsupported  = "SpecChangeUnsupported"
 )
 
 // DSPA Ready Status Condition Reasons
@@ -128,11 +348,66 @@ const (
 // kubectl get output, and in summarizing
 // occurrences of causes
 const (
+	UnknownReason               = "Unknown"
 	MinimumReplicasAvailable    = "MinimumReplicasAvailable"
 	FailingToDeploy             = "FailingToDeploy"
 	Deploying                   = "Deploying"
 	ComponentDeploymentNotFound = "ComponentDeploymentNotFound"
 	UnsupportedVersion          = "UnsupportedVersion"
+	NamespaceNotAllowed         = "NamespaceNotAllowed"
+	ResourceConflict            = "ResourceConflict"
+	MultipleInstancesNotAllowed = "MultipleInstancesNotAllowed"
+	ImageNotPinned              = "ImageNotPinned"
+	ImageSignatureNotVerified   = "ImageSignatureNotVerified"
+	ExternalSecretNotReady      = "ExternalSecretNotReady"
+	CertificatesNotReady        = "CertificatesNotReady"
+	InvalidAPIServerArgs        = "InvalidAPIServerArgs"
+	InvalidTimezone             = "InvalidTimezone"
+	InvalidFeatureGates         = "InvalidFeatureGates"
+	QuotaInsufficient           = "QuotaInsufficient"
+	ExternalDBAuthFailed        = "ExternalDBAuthFailed"
+	DatabaseAuthFailed          = "DatabaseAuthFailed"
+	DatabaseTLSFailed           = "DatabaseTLSFailed"
+	DatabaseHostNotFound        = "DatabaseHostNotFound"
+	DatabaseUnreachable         = "DatabaseUnreachable"
+	AccessDenied                = "AccessDenied"
+	BucketNotFound              = "BucketNotFound"
+	BucketCreationFailed        = "BucketCreationFailed"
+	ObjectStorageFailedOver     = "ObjectStorageFailedOver"
+	IncompatibleFields          = "IncompatibleFields"
+	RunLimitExceeded            = "RunLimitExceeded"
+	SecretPropagationConflict   = "SecretPropagationConflict"
+	ComponentsRemoved           = "ComponentsRemoved"
+	VersionCompatible           = "VersionCompatible"
+	// StorageChanged is the SpecChangeUnsupported reason of the DSPAs whose
+	// buckets or database name changed after they were deployed
+	StorageChanged = "StorageChanged"
+)
+
+// DSP v1 to v2 Upgrade Phases, reported as the UpgradeProgressing condition reason
+const (
+	UpgradeSnapshottingDatabase = "SnapshottingDatabase"
+	UpgradeMigratingToV2        = "MigratingToV2"
+	UpgradeCompleted            = "UpgradeCompleted"
+	UpgradeRollingBack          = "RollingBack"
+	UpgradeRolledBack           = "RolledBack"
+	UpgradeFailed               = "UpgradeFailed"
+)
+
+// Database backup verification outcomes, reported as the BackupVerified condition reason
+const (
+	BackupVerificationPending   = "VerificationPending"
+	BackupVerificationSucceeded = "VerificationSucceeded"
+	BackupVerificationFailed    = "VerificationFailed"
+)
+
+// Synced condition of the Pipelines and RecurringRuns, and its reasons
+const (
+	Synced              = "Synced"
+	SyncFailed          = "SyncFailed"
+	FeatureGateDisabled = "FeatureGateDisabled"
+	DSPANotReady        = "DSPANotReady"
+	PipelineNotSynced   = "PipelineNotSynced"
 )
 
 // Any required Configmap paths can be added here,
@@ -152,12 +427,63 @@ const DefaultDBConnectionTimeout = time.Second * 15
 // DefaultObjStoreConnectionTimeout is the default Object storage healthcheck timeout
 const DefaultObjStoreConnectionTimeout = time.Second * 15
 
+// DefaultNotificationsTimeout is how long a DSPA state change notification
+// may take to be delivered
+const DefaultNotificationsTimeout = time.Second * 10
+
 const DefaultMaxConcurrentReconciles = 10
 
 const DefaultRequeueTime = time.Second * 20
 
+// DefaultDeclarativePipelinesSyncPeriod is how often the Pipelines and
+// RecurringRuns are synced into the API server, restoring those deleted from
+// it and refreshing the state of the recurring runs
+const DefaultDeclarativePipelinesSyncPeriod = time.Minute * 5
+
+// DefaultReconcileParallelism is how many independent components of a DSPA
+// are reconciled concurrently
+const DefaultReconcileParallelism = 4
+
+// DefaultRateLimitNamespaceQPS is how many reconciles of the DSPAs of a
+// namespace are queued per second, after a burst of DefaultRateLimitNamespaceBurst
+const DefaultRateLimitNamespaceQPS = 5.0
+
+const DefaultRateLimitNamespaceBurst = 20
+
+// DefaultRateLimitApplyQPS does not limit the resource writes of the reconcilers
+const DefaultRateLimitApplyQPS = 0.0
+
+const DefaultRateLimitApplyBurst = 20
+
+// DefaultSelectiveApplyResyncPeriod is how long a template is not applied
+// again while the manifests rendered from it do not change
+const DefaultSelectiveApplyResyncPeriod = time.Minute * 10
+
+// DefaultUpgradeTimeout is how long the v2 components have to become ready
+// before a DSP v1 to v2 upgrade is rolled back
+const DefaultUpgradeTimeout = time.Minute * 15
+
 const DefaultApiServerIncludeOwnerReferenceConfigName = true
 
+const DefaultSingleInstancePerNamespace = false
+
+// DefaultMultiTenancyAllowClusterScope refuses workflow controllers of DSPAs
+// watching the workflows of all namespaces
+const DefaultMultiTenancyAllowClusterScope = false
+
+// DefaultManagementState of the DSPAs that do not set spec.managementState
+const DefaultManagementState = "Managed"
+
+const DefaultResolveImageDigests = false
+
+const DefaultAPIServerRolloutEnabled = false
+
+// DefaultAPIServerRolloutCanaryPercentage is the share of DSPAs updated first
+// when no canary selector is set
+const DefaultAPIServerRolloutCanaryPercentage = 10
+
+const DefaultRequireImageDigests = false
+
 const DefaultManagedPipelines = "{}"
 
 const DefaultPlatformVersion = "v0.0.0"
@@ -166,6 +492,20 @@ func GetConfigRequiredFields() []string {
 	return requiredFields
 }
 
+// DefaultGrafanaInstanceSelector selects the Grafana instances importing the
+// DSPA dashboards, as labelled in the Grafana operator examples
+var DefaultGrafanaInstanceSelector = map[string]string{"dashboards": "grafana"}
+
+// DefaultAlertSeverities are the severities of the alerts of
+// spec.monitoring.alerts, by alert name
+var DefaultAlertSeverities = map[string]string{
+	"DSPAAPIServerDown":         "critical",
+	"DSPAPersistenceAgentDown":  "warning",
+	"DSPAScheduledWorkflowDown": "warning",
+	"DSPADatabaseUnreachable":   "critical",
+	"DSPAArtifactStoreErrors":   "warning",
+}
+
 // Default ResourceRequirements
 var (
 	APIServerResourceRequirements          = createResourceRequirement(resource.MustParse("250m"), resource.MustParse("500Mi"), resource.MustParse("500m"), resource.MustParse("1Gi"))
@@ -176,10 +516,71 @@ var (
 	MariaDBResourceRequirements            = createResourceRequirement(resource.MustParse("300m"), resource.MustParse("800Mi"), resource.MustParse("1"), resource.MustParse("1Gi"))
 	MinioResourceRequirements              = createResourceRequirement(resource.MustParse("200m"), resource.MustParse("100Mi"), resource.MustParse("250m"), resource.MustParse("1Gi"))
 	MlPipelineUIResourceRequirements       = createResourceRequirement(resource.MustParse("100m"), resource.MustParse("256Mi"), resource.MustParse("100m"), resource.MustParse("256Mi"))
+	CRDViewerResourceRequirements          = createResourceRequirement(resource.MustParse("100m"), resource.MustParse("128Mi"), resource.MustParse("250m"), resource.MustParse("256Mi"))
+	VisualizationResourceRequirements      = createResourceRequirement(resource.MustParse("30m"), resource.MustParse("500Mi"), resource.MustParse("250m"), resource.MustParse("1Gi"))
 	MlmdEnvoyResourceRequirements          = createResourceRequirement(resource.MustParse("100m"), resource.MustParse("256Mi"), resource.MustParse("100m"), resource.MustParse("256Mi"))
 	MlmdGRPCResourceRequirements           = createResourceRequirement(resource.MustParse("100m"), resource.MustParse("256Mi"), resource.MustParse("100m"), resource.MustParse("256Mi"))
+	CacheCleanupResourceRequirements       = createResourceRequirement(resource.MustParse("50m"), resource.MustParse("64Mi"), resource.MustParse("100m"), resource.MustParse("128Mi"))
+	RunRetentionResourceRequirements       = createResourceRequirement(resource.MustParse("50m"), resource.MustParse("64Mi"), resource.MustParse("100m"), resource.MustParse("128Mi"))
+	AuditLogResourceRequirements           = createResourceRequirement(resource.MustParse("50m"), resource.MustParse("64Mi"), resource.MustParse("100m"), resource.MustParse("128Mi"))
+	MysqldExporterResourceRequirements     = createResourceRequirement(resource.MustParse("50m"), resource.MustParse("64Mi"), resource.MustParse("100m"), resource.MustParse("128Mi"))
+)
+
+// Default probe timings of each component's main container
+var (
+	APIServerProbes         = createProbes(createProbeTiming(3, 5, 3), createProbeTiming(3, 5, 3))
+	PersistenceAgentProbes  = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(3, 5, 3))
+	ScheduledWorkflowProbes = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(3, 5, 3))
+	MariaDBProbes           = createProbes(createProbeTiming(30, 10, 3), createProbeTiming(5, 10, 3))
+	MinioProbes             = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(5, 5, 3))
+	MlPipelineUIProbes      = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(30, 5, 3))
+	MlmdEnvoyProbes         = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(3, 5, 3))
+	MlmdGRPCProbes          = createProbes(createProbeTiming(30, 5, 3), createProbeTiming(3, 5, 3))
 )
 
+// MariaDBDefaultConfig are the server settings of the managed MariaDB, sized
+// for its default 1Gi memory limit rather than the stock settings
+var MariaDBDefaultConfig = map[string]string{
+	"max_connections":         "300",
+	"innodb_buffer_pool_size": "256M",
+}
+
+// MariaDBGaleraDBExtraParams are added to the DSN of a Galera cluster. The
+// timeouts drop the connections to a lost node so that they are reopened
+// through the Service to a node still in the cluster, and wsrep_sync_wait
+// lets a node read the writes committed through the other nodes.
+var MariaDBGaleraDBExtraParams = DBExtraParams{
+	"timeout":         "10s",
+	"readTimeout":     "60s",
+	"writeTimeout":    "60s",
+	"wsrep_sync_wait": "1",
+}
+
+// Default SecurityContexts, compliant with the restricted Pod Security Standard
+var (
+	DefaultPodSecurityContext = corev1.PodSecurityContext{
+		RunAsNonRoot:   boolPtr(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	DefaultContainerSecurityContext = corev1.SecurityContext{
+		AllowPrivilegeEscalation: boolPtr(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		RunAsNonRoot:             boolPtr(true),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	WorkflowControllerSecurityContext = corev1.SecurityContext{
+		AllowPrivilegeEscalation: boolPtr(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		ReadOnlyRootFilesystem:   boolPtr(true),
+		RunAsNonRoot:             boolPtr(true),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 type DBExtraParams map[string]string
 
 func createResourceRequirement(RequestsCPU resource.Quantity, RequestsMemory resource.Quantity, LimitsCPU resource.Quantity, LimitsMemory resource.Quantity) dspav1.ResourceRequirements {
@@ -195,7 +596,59 @@ func createResourceRequirement(RequestsCPU resource.Quantity, RequestsMemory res
 	}
 }
 
+// DefaultTopologySpreadConstraints spreads the pods matching selector across
+// zones and nodes, scheduling anyway when the cluster can't satisfy it.
+func DefaultTopologySpreadConstraints(selector map[string]string) []corev1.TopologySpreadConstraint {
+	var constraints []corev1.TopologySpreadConstraint
+	for _, topologyKey := range []string{corev1.LabelTopologyZone, corev1.LabelHostname} {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: selector},
+		})
+	}
+	return constraints
+}
+
+func createProbes(liveness, readiness dspav1.ProbeTiming) dspav1.Probes {
+	return dspav1.Probes{
+		Liveness:  &liveness,
+		Readiness: &readiness,
+	}
+}
+
+func createProbeTiming(initialDelaySeconds, periodSeconds, failureThreshold int32) dspav1.ProbeTiming {
+	return dspav1.ProbeTiming{
+		InitialDelaySeconds: &initialDelaySeconds,
+		PeriodSeconds:       &periodSeconds,
+		FailureThreshold:    &failureThreshold,
+	}
+}
+
+// configMu guards the config against a reload while it is read, viper is not
+// safe for concurrent reads and writes. Writes other than LoadConfig, e.g. the
+// initial read of the config file, are made before the reconcilers start.
+var configMu sync.RWMutex
+
+// LoadConfig replaces the config with the content of the config file read by
+// viper, data, once validated by the caller.
+func LoadConfig(data []byte) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+	return viper.ReadConfig(bytes.NewReader(data))
+}
+
+// AllSettings returns the settings of the config, merged with the environment.
+func AllSettings() map[string]interface{} {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.AllSettings()
+}
+
 func GetStringConfig(configName string) (string, error) {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	if !viper.IsSet(configName) {
 		return "", fmt.Errorf("value not set in config for configname %s", configName)
 	}
@@ -204,6 +657,8 @@ func GetStringConfig(configName string) (string, error) {
 }
 
 func GetStringConfigWithDefault(configName, value string) string {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	if !viper.IsSet(configName) {
 		return value
 	}
@@ -211,19 +666,117 @@ func GetStringConfigWithDefault(configName, value string) string {
 }
 
 func GetDurationConfigWithDefault(configName string, value time.Duration) time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	if !viper.IsSet(configName) {
 		return value
 	}
 	return viper.GetDuration(configName)
 }
 
+func GetIntConfigWithDefault(configName string, value int) int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if !viper.IsSet(configName) {
+		return value
+	}
+	return viper.GetInt(configName)
+}
+
+func GetFloatConfigWithDefault(configName string, value float64) float64 {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if !viper.IsSet(configName) {
+		return value
+	}
+	return viper.GetFloat64(configName)
+}
+
 func GetBoolConfigWithDefault(configName string, value bool) bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
 	if !viper.IsSet(configName) {
 		return value
 	}
 	return viper.GetBool(configName)
 }
 
+// GetStringSliceConfigWithDefault accepts either a list or a comma
+// separated string (e.g. when set through an environment variable).
+func GetStringSliceConfigWithDefault(configName string, value []string) []string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if !viper.IsSet(configName) {
+		return value
+	}
+	var values []string
+	for _, v := range viper.GetStringSlice(configName) {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+func GetStringMapConfigWithDefault(configName string, value map[string]string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if !viper.IsSet(configName) {
+		return value
+	}
+	return viper.GetStringMapString(configName)
+}
+
+// GetArchitectureImagesConfig returns the images set per node architecture at
+// configName, e.g. Images.ApiServer.arm64, or nil when a single image is set.
+func GetArchitectureImagesConfig(configName string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if !isMap(viper.Get(configName)) {
+		return nil
+	}
+	return viper.GetStringMapString(configName)
+}
+
+// HasArchitectureImages reports whether any image of the Images or ImagesFIPS
+// configs is set per node architecture.
+func HasArchitectureImages() bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	for _, prefix := range []string{ImagesPathPrefix, FIPSImagesPathPrefix} {
+		for _, image := range viper.GetStringMap(strings.TrimSuffix(prefix, ".")) {
+			if isMap(image) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetSamplePipelineNames returns the names of the sample pipelines bundled
+// with the API server, the entries of the ManagedPipelinesMetadata config,
+// sorted and lowercased as viper keys are case insensitive.
+func GetSamplePipelineNames() []string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	var names []string
+	for name := range viper.GetStringMap("ManagedPipelinesMetadata") {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isMap(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, map[string]string:
+		return true
+	}
+	return false
+}
+
 // GetCABundleFileMountPath provides the location in pipeline step-copy-artifact step where the
 // ca bundle is mounted for aws cli to connect to s3 store.
 // Since pipeline step-copy-artifact step uses aws cli, and there are issues surrounding
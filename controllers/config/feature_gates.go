@@ -0,0 +1,140 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FeatureGatesAnnotation enables or disables feature gates for a DSPA, as a
+// comma separated list of Name=true|false pairs, over the operator gates.
+const FeatureGatesAnnotation = "datasciencepipelinesapplications.opendatahub.io/feature-gates"
+
+// Pre-release stages of the feature gates
+const (
+	// FeatureGateAlpha behaviors are experimental and disabled by default
+	FeatureGateAlpha = "Alpha"
+	// FeatureGateBeta behaviors are enabled by default, and can be disabled
+	// while they settle
+	FeatureGateBeta = "Beta"
+)
+
+// Feature gates
+const (
+	// SelectiveApplyFeatureGate skips applying the templates whose manifests
+	// did not change since they were last applied, see DSPO.SelectiveApply.ResyncPeriod
+	SelectiveApplyFeatureGate = "SelectiveApply"
+	// DeclarativePipelinesFeatureGate syncs the Pipeline and RecurringRun
+	// resources of a DSPA into its API server
+	DeclarativePipelinesFeatureGate = "DeclarativePipelines"
+)
+
+// FeatureGate is an opt-in or opt-out operator behavior.
+type FeatureGate struct {
+	// Default enablement of the gate
+	Default bool
+	// PreRelease stage of the behavior, FeatureGateAlpha or FeatureGateBeta
+	PreRelease string
+}
+
+// featureGates is the registry of the known feature gates, by name.
+var featureGates = map[string]FeatureGate{
+	SelectiveApplyFeatureGate:       {Default: true, PreRelease: FeatureGateBeta},
+	DeclarativePipelinesFeatureGate: {Default: false, PreRelease: FeatureGateAlpha},
+}
+
+var (
+	operatorFeatureGatesMu sync.RWMutex
+	// operatorFeatureGates are the gates set by the --feature-gates flag
+	operatorFeatureGates = map[string]bool{}
+)
+
+// FeatureGateNames returns the names of the known feature gates, sorted.
+func FeatureGateNames() []string {
+	names := make([]string, 0, len(featureGates))
+	for name := range featureGates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFeatureGates parses a comma separated list of Name=true|false pairs,
+// rejecting unknown gates.
+func ParseFeatureGates(value string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, enabled, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found {
+			return nil, fmt.Errorf("feature gate %s must be set as %s=true or %s=false", name, name, name)
+		}
+		if _, ok := featureGates[name]; !ok {
+			return nil, fmt.Errorf("unknown feature gate %s, the known gates are %s", name, strings.Join(FeatureGateNames(), ", "))
+		}
+		parsed, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return nil, fmt.Errorf("feature gate %s must be set to true or false, got %s", name, enabled)
+		}
+		gates[name] = parsed
+	}
+	return gates, nil
+}
+
+// SetFeatureGates sets the operator feature gates from value, a comma
+// separated list of Name=true|false pairs. The gates not listed keep their
+// default.
+func SetFeatureGates(value string) error {
+	gates, err := ParseFeatureGates(value)
+	if err != nil {
+		return err
+	}
+	operatorFeatureGatesMu.Lock()
+	defer operatorFeatureGatesMu.Unlock()
+	operatorFeatureGates = gates
+	return nil
+}
+
+// ResolveFeatureGates returns the enablement of every known gate for a DSPA:
+// the gates set in overrides, e.g. the FeatureGatesAnnotation of the DSPA,
+// then the operator gates, then their default.
+func ResolveFeatureGates(overrides string) (map[string]bool, error) {
+	dspaGates, err := ParseFeatureGates(overrides)
+	if err != nil {
+		return nil, err
+	}
+	operatorFeatureGatesMu.RLock()
+	defer operatorFeatureGatesMu.RUnlock()
+	gates := map[string]bool{}
+	for name, gate := range featureGates {
+		gates[name] = gate.Default
+		if enabled, ok := operatorFeatureGates[name]; ok {
+			gates[name] = enabled
+		}
+		if enabled, ok := dspaGates[name]; ok {
+			gates[name] = enabled
+		}
+	}
+	return gates, nil
+}
@@ -17,18 +17,21 @@ limitations under the License.
 package config
 
 import (
+	"io/fs"
+
 	mfc "github.com/manifestival/controller-runtime-client"
 	mf "github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func Manifest(cl client.Client, templatePath string, context interface{}) (mf.Manifest, error) {
-	pathTmplSrc, err := PathTemplateSource(templatePath, context)
+func Manifest(cl client.Client, templates fs.FS, templatePath string, context interface{}) (mf.Manifest, error) {
+	resources, err := Render(templates, templatePath, context)
 	if err != nil {
 		return mf.Manifest{}, err
 	}
 
-	m, err := mf.ManifestFrom(pathTmplSrc)
+	m, err := mf.ManifestFrom(mf.Slice(resources))
 	if err != nil {
 		return mf.Manifest{}, err
 	}
@@ -36,3 +39,18 @@ func Manifest(cl client.Client, templatePath string, context interface{}) (mf.Ma
 
 	return m, err
 }
+
+// Render renders the template at templatePath in templates with context into
+// the resources it defines, without reaching the cluster.
+func Render(templates fs.FS, templatePath string, context interface{}) ([]unstructured.Unstructured, error) {
+	pathTmplSrc, err := PathTemplateSource(templates, templatePath, context)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := mf.ManifestFrom(pathTmplSrc)
+	if err != nil {
+		return nil, err
+	}
+	return m.Resources(), nil
+}
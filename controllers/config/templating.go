@@ -18,26 +18,42 @@ package config
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"os"
+	"reflect"
+	"sync"
 	"text/template"
+	"text/template/parse"
 
 	mf "github.com/manifestival/manifestival"
 )
 
-// PathPrefix is the file system path which template paths will be prefixed with.
-// Default is no prefix, which causes paths to be read relative to process working dir
-var PathPrefix string
+// CachedTemplates wraps templates so that each template is parsed once, on
+// its first render, rather than on every reconcile. Only templates that do not
+// change while the operator runs, such as the embedded ones, can be cached.
+func CachedTemplates(templates fs.FS) fs.FS {
+	return &cachedTemplates{FS: templates}
+}
+
+type cachedTemplates struct {
+	fs.FS
+	// parsed holds the *template.Template of each rendered path, parsed
+	// templates are safe to execute concurrently
+	parsed sync.Map
+}
 
-// PathTemplateSource A templating source read from a file
-func PathTemplateSource(path string, context interface{}) (mf.Source, error) {
-	f, err := os.Open(prefixedPath(path))
+// PathTemplateSource A templating source read from a file of templates
+func PathTemplateSource(templates fs.FS, path string, context interface{}) (mf.Source, error) {
+	t, err := parseTemplate(templates, path)
 	if err != nil {
 		return mf.Slice([]unstructured.Unstructured{}), err
 	}
 
-	tmplSrc, err := templateSource(f, context)
+	tmplSrc, err := templateSource(t, context)
 	if err != nil {
 		return mf.Slice([]unstructured.Unstructured{}), err
 	}
@@ -45,27 +61,203 @@ func PathTemplateSource(path string, context interface{}) (mf.Source, error) {
 	return tmplSrc, nil
 }
 
-func prefixedPath(p string) string {
-	if PathPrefix != "" {
-		return PathPrefix + "/" + p
+// templateFuncs are available to all manifest templates
+var templateFuncs = template.FuncMap{
+	// toJson renders a value inline, JSON being valid YAML flow syntax
+	"toJson": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// parseTemplate parses the template at path, from the cache of templates when
+// they are cached.
+func parseTemplate(templates fs.FS, path string) (*template.Template, error) {
+	cached, cacheable := templates.(*cachedTemplates)
+	if cacheable {
+		if t, ok := cached.parsed.Load(path); ok {
+			return t.(*template.Template), nil
+		}
 	}
-	return p
+
+	f, err := templates.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	// Fail on missing map keys rather than silently rendering "<no value>"
+	t, err := template.New("manifestTemplateDSP").Funcs(templateFuncs).Option("missingkey=error").Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		cached.parsed.Store(path, t)
+	}
+	return t, nil
 }
 
 // A templating manifest source
-func templateSource(r io.Reader, context interface{}) (mf.Source, error) {
-	b, err := io.ReadAll(r)
+func templateSource(t *template.Template, context interface{}) (mf.Source, error) {
+	var b bytes.Buffer
+	err := t.Execute(&b, context)
 	if err != nil {
 		return mf.Slice([]unstructured.Unstructured{}), err
 	}
-	t, err := template.New("manifestTemplateDSP").Parse(string(b))
+	return mf.Reader(&b), nil
+}
+
+// ValidateTemplateFields checks that every field referenced by the template at
+// path in templates resolves against contextType, including in the branches
+// not taken when rendering, which text/template only evaluates when taken.
+// Fields of maps and interfaces can only be resolved at render time and are
+// not checked.
+func ValidateTemplateFields(templates fs.FS, path string, contextType reflect.Type) error {
+	b, err := fs.ReadFile(templates, path)
 	if err != nil {
-		return mf.Slice([]unstructured.Unstructured{}), err
+		return err
 	}
-	var b2 bytes.Buffer
-	err = t.Execute(&b2, context)
+	t, err := template.New(path).Funcs(templateFuncs).Parse(string(b))
 	if err != nil {
-		return mf.Slice([]unstructured.Unstructured{}), err
+		return err
+	}
+	v := &fieldValidator{root: contextType}
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			v.walk(tmpl.Root, contextType)
+		}
+	}
+	if len(v.errs) > 0 {
+		return fmt.Errorf("%s: %w", path, errors.Join(v.errs...))
+	}
+	return nil
+}
+
+// fieldValidator walks a template parse tree, tracking the type of dot, and
+// collects the fields that do not resolve. A nil type is unknown.
+type fieldValidator struct {
+	root reflect.Type
+	errs []error
+}
+
+func (v *fieldValidator) walk(node parse.Node, dot reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			v.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		v.pipeType(n.Pipe, dot)
+	case *parse.IfNode:
+		v.pipeType(n.Pipe, dot)
+		v.walk(n.List, dot)
+		v.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		v.walk(n.List, v.pipeType(n.Pipe, dot))
+		v.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		v.walk(n.List, elemType(v.pipeType(n.Pipe, dot)))
+		v.walk(n.ElseList, dot)
+	case *parse.TemplateNode:
+		v.pipeType(n.Pipe, dot)
+	}
+}
+
+// pipeType checks the fields of pipe and returns the type it evaluates to.
+func (v *fieldValidator) pipeType(pipe *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var last reflect.Type
+	for _, cmd := range pipe.Cmds {
+		last = nil
+		for _, arg := range cmd.Args {
+			last = v.argType(arg, dot)
+		}
+		// The result of function calls is unknown
+		if len(cmd.Args) != 1 {
+			last = nil
+		}
+	}
+	if len(pipe.Decl) > 0 {
+		return nil
+	}
+	return last
+}
+
+func (v *fieldValidator) argType(arg parse.Node, dot reflect.Type) reflect.Type {
+	switch n := arg.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return v.resolve(dot, n.Ident)
+	case *parse.VariableNode:
+		// Only $ is known, it refers to the context of the template
+		if n.Ident[0] == "$" {
+			return v.resolve(v.root, n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		return v.resolve(v.argType(n.Node, dot), n.Field)
+	case *parse.PipeNode:
+		return v.pipeType(n, dot)
+	}
+	return nil
+}
+
+func (v *fieldValidator) resolve(t reflect.Type, fields []string) reflect.Type {
+	for _, field := range fields {
+		if t == nil {
+			return nil
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Interface {
+			return nil
+		}
+		if method, ok := reflect.PointerTo(t).MethodByName(field); ok {
+			if method.Type.NumOut() == 0 {
+				return nil
+			}
+			t = method.Type.Out(0)
+			continue
+		}
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			structField, ok := t.FieldByName(field)
+			if !ok || !structField.IsExported() {
+				v.errs = append(v.errs, fmt.Errorf("can't evaluate field %s in type %s", field, t))
+				return nil
+			}
+			t = structField.Type
+		default:
+			v.errs = append(v.errs, fmt.Errorf("can't evaluate field %s in type %s", field, t))
+			return nil
+		}
+	}
+	return t
+}
+
+// elemType returns the type of dot when ranging over values of type t.
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return t.Elem()
 	}
-	return mf.Reader(&b2), nil
+	return nil
 }
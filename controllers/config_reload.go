@@ -0,0 +1,187 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	configReloaded  = "reloaded"
+	configUnchanged = "unchanged"
+	configFailed    = "failed"
+)
+
+// ConfigReloader reloads the operator config file once it changes, e.g. once
+// the DSPO config ConfigMap is updated, so that changed image paths and
+// defaults are rolled out to the DSPAs without restarting the operator.
+// Settings read once at startup, e.g. the rate limits, still require one.
+type ConfigReloader struct {
+	// reloads holds a pending re-reconciliation of the DSPAs, reloads made
+	// while one is pending are coalesced into it
+	reloads chan event.GenericEvent
+
+	log        logr.Logger
+	configFile string
+
+	mu       sync.Mutex
+	settings map[string]interface{}
+}
+
+// NewConfigReloader returns a ConfigReloader of the config file loaded by
+// viper.
+func NewConfigReloader(log logr.Logger) *ConfigReloader {
+	return &ConfigReloader{
+		reloads:    make(chan event.GenericEvent, 1),
+		log:        log,
+		configFile: filepath.Clean(viper.ConfigFileUsed()),
+		settings:   config.AllSettings(),
+	}
+}
+
+// Start watches the directory of the config file until ctx is done, and
+// reloads the config file when it is written, or when the file it links to
+// changes, as when the ..data symlink of a mounted ConfigMap is swapped.
+func (c *ConfigReloader) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(c.configFile)); err != nil {
+		return fmt.Errorf("unable to watch the config file %s: %w", c.configFile, err)
+	}
+
+	linkedFile, _ := filepath.EvalSymlinks(c.configFile)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			currentLinkedFile, _ := filepath.EvalSymlinks(c.configFile)
+			written := filepath.Clean(e.Name) == c.configFile && e.Op&(fsnotify.Write|fsnotify.Create) != 0
+			if !written && (currentLinkedFile == "" || currentLinkedFile == linkedFile) {
+				continue
+			}
+			linkedFile = currentLinkedFile
+			if err := c.Reload(); err != nil {
+				c.log.Error(err, "unable to reload the config")
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			c.log.Error(err, "unable to watch the config file")
+		}
+	}
+}
+
+// NeedLeaderElection watches the config file on every replica, so that a
+// replica taking over the leadership reconciles with the current config.
+func (c *ConfigReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload reads the config file again, and re-reconciles the DSPAs when its
+// settings changed. The config file is validated before it is loaded, a
+// config file missing required fields is not loaded and the previous config
+// is kept.
+func (c *ConfigReloader) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.configFile)
+	if err != nil {
+		ConfigReloadsMetric.WithLabelValues(configFailed).Inc()
+		return fmt.Errorf("unable to read the changed config file %s: %w", c.configFile, err)
+	}
+	candidate := viper.New()
+	candidate.SetConfigType(strings.TrimPrefix(filepath.Ext(c.configFile), "."))
+	if err := candidate.ReadConfig(bytes.NewReader(data)); err != nil {
+		ConfigReloadsMetric.WithLabelValues(configFailed).Inc()
+		return fmt.Errorf("unable to read the changed config file %s: %w", c.configFile, err)
+	}
+	for _, field := range config.GetConfigRequiredFields() {
+		if !candidate.IsSet(field) {
+			ConfigReloadsMetric.WithLabelValues(configFailed).Inc()
+			return fmt.Errorf("missing required field in the changed config file %s: %s, keeping the previous config", c.configFile, field)
+		}
+	}
+	if err := config.LoadConfig(data); err != nil {
+		ConfigReloadsMetric.WithLabelValues(configFailed).Inc()
+		return fmt.Errorf("unable to load the changed config file %s: %w", c.configFile, err)
+	}
+
+	settings := config.AllSettings()
+	if reflect.DeepEqual(settings, c.settings) {
+		ConfigReloadsMetric.WithLabelValues(configUnchanged).Inc()
+		return nil
+	}
+	c.settings = settings
+	ConfigReloadsMetric.WithLabelValues(configReloaded).Inc()
+	select {
+	case c.reloads <- event.GenericEvent{Object: &dspav1.DataSciencePipelinesApplication{}}:
+	default:
+	}
+	return nil
+}
+
+// enqueueDSPAsOnConfigReload enqueues the DSPAs of supported DSP versions in
+// the reconciled namespaces, as defaults from the config apply to all of them.
+func (r *DSPAReconciler) enqueueDSPAsOnConfigReload() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var dspaList dspav1.DataSciencePipelinesApplicationList
+		if err := r.List(ctx, &dspaList); err != nil {
+			r.Log.Error(err, "unable to list DSPA's when attempting to handle the operator config reload.")
+			return nil
+		}
+		var reconcileRequests []reconcile.Request
+		for i := range dspaList.Items {
+			dspa := &dspaList.Items[i]
+			if !util.DSPAWithSupportedDSPVersion(dspa) {
+				continue
+			}
+			if inScope, err := util.NamespaceInScope(ctx, dspa.Namespace, r.Client); err == nil && !inScope {
+				continue
+			}
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: dspa.Name, Namespace: dspa.Namespace}})
+		}
+		r.Log.Info(fmt.Sprintf("Operator config reloaded, reconciling %d DSPAs", len(reconcileRequests)))
+		return reconcileRequests
+	})
+}
@@ -0,0 +1,140 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestConfigReloader(t *testing.T) {
+	defer viper.Reset()
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(apiServerImage string, extra string) {
+		require.Nil(t, os.WriteFile(configFile, []byte("Images:\n"+
+			"  ApiServer: "+apiServerImage+"\n"+
+			"  PersistenceAgent: quay.io/opendatahub/ds-pipelines-persistenceagent:latest\n"+
+			"  MariaDB: registry.redhat.io/rhel8/mariadb-103:1\n"+
+			"  OAuthProxy: registry.redhat.io/openshift4/ose-oauth-proxy:latest\n"+extra), 0644))
+	}
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v1", "  ScheduledWorkflow: quay.io/opendatahub/ds-pipelines-scheduledworkflow:latest\n")
+	viper.SetConfigFile(configFile)
+	require.Nil(t, viper.ReadInConfig())
+	reloader := NewConfigReloader(logr.Discard())
+	reloaded := func(result string) float64 {
+		return promtestutil.ToFloat64(ConfigReloadsMetric.WithLabelValues(result))
+	}
+
+	// Assert an unchanged config does not re-reconcile the DSPAs
+	unchanged := reloaded(configUnchanged)
+	require.Nil(t, reloader.Reload())
+	assert.Equal(t, unchanged+1, reloaded(configUnchanged))
+	assert.Len(t, reloader.reloads, 0)
+
+	// Assert a changed config is loaded, and re-reconciles the DSPAs once until handled
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v2", "  ScheduledWorkflow: quay.io/opendatahub/ds-pipelines-scheduledworkflow:latest\n")
+	require.Nil(t, reloader.Reload())
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:v2", viper.GetString(config.APIServerImagePath))
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v3", "  ScheduledWorkflow: quay.io/opendatahub/ds-pipelines-scheduledworkflow:latest\n")
+	require.Nil(t, reloader.Reload())
+	assert.Len(t, reloader.reloads, 1)
+
+	// Assert a config missing required fields is not loaded
+	failed := reloaded(configFailed)
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v4", "")
+	assert.ErrorContains(t, reloader.Reload(), config.ScheduledWorkflowImagePath)
+	assert.Equal(t, failed+1, reloaded(configFailed))
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:v3", viper.GetString(config.APIServerImagePath))
+}
+
+func TestConfigReloaderWatch(t *testing.T) {
+	defer viper.Reset()
+	configDir := t.TempDir()
+	configFile := filepath.Join(configDir, "config.yaml")
+	// Replace the config file, as the symlink swap of a mounted ConfigMap does, so that it is never read partially written
+	writeConfig := func(apiServerImage string, extra string) {
+		tmpFile := filepath.Join(configDir, ".config.yaml.tmp")
+		require.Nil(t, os.WriteFile(tmpFile, []byte("Images:\n"+
+			"  ApiServer: "+apiServerImage+"\n"+
+			"  PersistenceAgent: quay.io/opendatahub/ds-pipelines-persistenceagent:latest\n"+
+			"  MariaDB: registry.redhat.io/rhel8/mariadb-103:1\n"+
+			"  OAuthProxy: registry.redhat.io/openshift4/ose-oauth-proxy:latest\n"+extra), 0644))
+		require.Nil(t, os.Rename(tmpFile, configFile))
+	}
+	scheduledWorkflow := "  ScheduledWorkflow: quay.io/opendatahub/ds-pipelines-scheduledworkflow:latest\n"
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v1", scheduledWorkflow)
+	viper.SetConfigFile(configFile)
+	require.Nil(t, viper.ReadInConfig())
+	reloader := NewConfigReloader(logr.Discard())
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.Nil(t, reloader.Start(ctx))
+	}()
+
+	// Assert a changed config file is loaded once the watcher is running
+	assert.Eventually(t, func() bool {
+		writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v2", scheduledWorkflow)
+		return config.GetStringConfigWithDefault(config.APIServerImagePath, "") == "quay.io/opendatahub/ds-pipelines-api-server:v2"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// Assert a config file missing required fields is rejected, and the previous config is kept
+	failed := promtestutil.ToFloat64(ConfigReloadsMetric.WithLabelValues(configFailed))
+	writeConfig("quay.io/opendatahub/ds-pipelines-api-server:v3", "")
+	assert.Eventually(t, func() bool {
+		return promtestutil.ToFloat64(ConfigReloadsMetric.WithLabelValues(configFailed)) > failed
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:v2", config.GetStringConfigWithDefault(config.APIServerImagePath, ""))
+	assert.True(t, viper.IsSet(config.ScheduledWorkflowImagePath))
+}
+
+func TestEnqueueDSPAsOnConfigReload(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	for _, name := range []string{"first", "second"} {
+		dspa := &dspav1.DataSciencePipelinesApplication{Spec: dspav1.DSPASpec{DSPVersion: "v2"}}
+		dspa.Namespace, dspa.Name = "testnamespace", name
+		require.Nil(t, reconciler.Create(ctx, dspa))
+	}
+	unsupported := &dspav1.DataSciencePipelinesApplication{Spec: dspav1.DSPASpec{DSPVersion: "v1"}}
+	unsupported.Namespace, unsupported.Name = "testnamespace", "unsupported"
+	require.Nil(t, reconciler.Create(ctx, unsupported))
+
+	// Assert a reload enqueues every DSPA of a supported DSP version
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	reconciler.enqueueDSPAsOnConfigReload().Generic(ctx, event.GenericEvent{Object: &dspav1.DataSciencePipelinesApplication{}}, queue)
+	assert.Equal(t, 2, queue.Len())
+}
@@ -0,0 +1,73 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+)
+
+const connectionInfoTemplate = "connection-info/configmap.yaml.tmpl"
+
+// ConnectionInfo holds the endpoints of the connection info ConfigMap that are
+// resolved the same way as the component URLs of the DSPA status.
+type ConnectionInfo struct {
+	APIServerExternalURL string
+	MLMDProxyURL         string
+	MLMDProxyExternalURL string
+}
+
+// ReconcileConnectionInfo publishes the ds-pipeline-connection-<name>
+// ConfigMap, from which SDK clients read the DSPA endpoints and buckets.
+func (r *DSPAReconciler) ReconcileConnectionInfo(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+	info, err := r.resolveConnectionInfo(ctx, dsp, params)
+	if err != nil {
+		return err
+	}
+	params.ConnectionInfo = info
+
+	log.Info("Applying Connection Info ConfigMap")
+	return r.Apply(dsp, params, connectionInfoTemplate)
+}
+
+// resolveConnectionInfo resolves the endpoints of the connection info ConfigMap
+// of dsp from its Services and Routes.
+func (r *DSPAReconciler) resolveConnectionInfo(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) (*ConnectionInfo, error) {
+	// Named as in GetComponents, the MLMD proxy is served by the envoy Service and Route
+	mlmdProxyName := "ds-pipeline-md-" + dsp.Name
+
+	info := &ConnectionInfo{}
+	var err error
+	info.APIServerExternalURL, err = externalRouteURL(ctx, r.Client, params.Platform, params.APIServerDefaultResourceName, dsp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	info.MLMDProxyURL, err = util.GetServiceHostname(ctx, mlmdProxyName, dsp.Namespace, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	info.MLMDProxyExternalURL, err = externalRouteURL(ctx, r.Client, params.Platform, mlmdProxyName, dsp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
@@ -0,0 +1,57 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestReconcileConnectionInfo(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.MLMD = &dspav1.MLMD{Deploy: true}
+	expectedConnectionInfoName := "ds-pipeline-connection-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	require.Nil(t, reconciler.ReconcileConnectionInfo(ctx, dspa, params))
+
+	// Assert the endpoints and buckets are published, without the credentials
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedConnectionInfoName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "http://ds-pipeline-testdspa.testnamespace.svc.cluster.local:8888", configMap.Data["DSP_API_URL"])
+	assert.Equal(t, "ds-pipeline-testdspa.testnamespace.svc.cluster.local:8887", configMap.Data["DSP_API_GRPC_ENDPOINT"])
+	assert.Equal(t, "", configMap.Data["DSP_API_EXTERNAL_URL"])
+	assert.Equal(t, "ds-pipeline-metadata-grpc-testdspa.testnamespace.svc.cluster.local:8080", configMap.Data["DSP_MLMD_GRPC_ENDPOINT"])
+	assert.Equal(t, params.ObjectStorageConnection.PipelineBucket, configMap.Data["DSP_OBJECT_STORAGE_BUCKET"])
+	assert.Equal(t, params.ObjectStorageConnection.CredentialsSecret.SecretName, configMap.Data["DSP_OBJECT_STORAGE_CREDENTIALS_SECRET"])
+	for _, value := range configMap.Data {
+		assert.NotEqual(t, params.ObjectStorageConnection.SecretAccessKey.Value(), value)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+// validateDSPA defaults spec as the API server does before validating it
+// against the CEL rules of the DSPA CRD at version.
+func validateDSPA(t *testing.T, version string, spec map[string]interface{}) field.ErrorList {
+	manifest, err := os.ReadFile(filepath.Join("..", "config", "crd", "bases",
+		"datasciencepipelinesapplications.opendatahub.io_datasciencepipelinesapplications.yaml"))
+	require.Nil(t, err)
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.Nil(t, yaml.Unmarshal(manifest, crd))
+
+	for _, crdVersion := range crd.Spec.Versions {
+		if crdVersion.Name != version {
+			continue
+		}
+		props := &apiextensions.JSONSchemaProps{}
+		require.Nil(t, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(crdVersion.Schema.OpenAPIV3Schema, props, nil))
+		structural, err := structuralschema.NewStructural(props)
+		require.Nil(t, err)
+
+		obj := map[string]interface{}{
+			"apiVersion": "datasciencepipelinesapplications.opendatahub.io/" + version,
+			"kind":       "DataSciencePipelinesApplication",
+			"metadata":   map[string]interface{}{"name": "testdspa", "namespace": "testnamespace"},
+			"spec":       spec,
+		}
+		defaulting.Default(obj, structural)
+		errs, _ := cel.NewValidator(structural, true, celconfig.PerCallLimit).Validate(context.Background(),
+			field.NewPath("root"), structural, obj, nil, celconfig.RuntimeCELCostBudget)
+		return errs
+	}
+	require.Failf(t, "version not served", "the DSPA CRD does not serve %s", version)
+	return nil
+}
+
+func TestDSPATerminateStatusValidation(t *testing.T) {
+	spec := func(dspVersion, terminateStatus string) map[string]interface{} {
+		spec := map[string]interface{}{
+			"objectStorage": map[string]interface{}{"minio": map[string]interface{}{"image": "quay.io/minio/minio"}},
+			"apiServer":     map[string]interface{}{},
+		}
+		if dspVersion != "" {
+			spec["dspVersion"] = dspVersion
+		}
+		if terminateStatus != "" {
+			spec["apiServer"].(map[string]interface{})["terminateStatus"] = terminateStatus
+		}
+		return spec
+	}
+
+	// Assert the Tekton statuses are accepted for DSP v1, including when dspVersion is defaulted to v1
+	assert.Empty(t, validateDSPA(t, "v1alpha1", spec("v1", "StoppedRunFinally")))
+	assert.Empty(t, validateDSPA(t, "v1alpha1", spec("", "CancelledRunFinally")))
+
+	// Assert the Tekton statuses are rejected for DSP v2, where the defaulted Cancelled is still accepted
+	errs := validateDSPA(t, "v1alpha1", spec("v2", "StoppedRunFinally"))
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Detail, "spec.apiServer.terminationStrategy")
+	assert.Empty(t, validateDSPA(t, "v1alpha1", spec("v2", "")))
+}
@@ -0,0 +1,44 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+)
+
+var crdViewerTemplatesDir = "crd-viewer"
+
+const crdViewerDefaultResourceNamePrefix = "ds-pipeline-viewer-crd-"
+
+func (r *DSPAReconciler) ReconcileCRDViewer(dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	if dsp.Spec.CRDViewer == nil || !dsp.Spec.CRDViewer.Deploy {
+		log.Info("Skipping Application of CRDViewer Resources")
+		return nil
+	}
+
+	log.Info("Applying CRDViewer Resources")
+	err := r.ApplyDir(dsp, params, crdViewerTemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Finished applying CRDViewer Resources")
+	return nil
+}
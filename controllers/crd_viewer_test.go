@@ -0,0 +1,125 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeployCRDViewer(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedName := "ds-pipeline-viewer-crd-testdspa"
+
+	// Construct DSPASpec with deployed Viewer CRD controller
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.CRDViewer = &dspav1.CRDViewer{
+		Deploy:        true,
+		Image:         "viewer-crd-controller:test",
+		MaxNumViewers: 10,
+	}
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileCRDViewer(dspa, params)
+	require.Nil(t, err)
+
+	// Ensure the controller only watches the viewers of its namespace
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "NAMESPACE", Value: testNamespace})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "MAX_NUM_VIEWERS", Value: "10"})
+	assert.Equal(t, expectedName, deployment.Spec.Template.Spec.ServiceAccountName)
+
+	// Ensure Workflows can only be read
+	role := &rbacv1.Role{}
+	created, err = reconciler.IsResourceCreated(ctx, role, expectedName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	for _, rule := range role.Rules {
+		if rule.APIGroups[0] == "argoproj.io" {
+			assert.Equal(t, []string{"workflows"}, rule.Resources)
+			assert.Equal(t, []string{"get", "list", "watch"}, rule.Verbs)
+		}
+	}
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.RoleBinding{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.True(t, created)
+}
+
+func TestDontDeployCRDViewer(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedName := "ds-pipeline-viewer-crd-testdspa"
+
+	// Construct DSPASpec without a Viewer CRD controller
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileCRDViewer(dspa, params)
+	assert.Nil(t, err)
+
+	// Ensure the Deployment doesn't exist
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+
+	// Ensure no readiness condition is reported without a Viewer CRD controller
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	assert.Empty(t, util.GetConditionByType(config.CRDViewerReady, dspaStatus.GetConditions()).Type)
+}
+
+func TestCRDViewerReadyCondition(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	dspaStatus.SetCRDViewerStatus(dspastatus.BuildFalseCondition(config.CRDViewerReady, config.FailingToDeploy, "failing"))
+
+	// Ensure the DSPA is not ready while the Viewer CRD controller is not ready
+	conditions := dspaStatus.GetConditions()
+	assert.Equal(t, metav1.ConditionFalse, util.GetConditionByType(config.CRDViewerReady, conditions).Status)
+	assert.Equal(t, metav1.ConditionFalse, util.GetConditionByType(config.CrReady, conditions).Status)
+	assert.Contains(t, util.GetConditionByType(config.CrReady, conditions).Message, "failing")
+}
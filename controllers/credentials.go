@@ -0,0 +1,59 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/base64"
+)
+
+// Credential is a password or key of a DSPA connection. It holds the value as
+// read from the data of its Secret, which client-go already decodes, or as
+// generated. It is base64 encoded only at render time, by the templates of the
+// Secrets storing it, so the value is never encoded or decoded twice.
+type Credential struct {
+	value []byte
+}
+
+// NewCredential returns the Credential holding the decoded value
+func NewCredential(value []byte) Credential {
+	return Credential{value: value}
+}
+
+// Value returns the credential as is, e.g. for config files and health checks
+func (c Credential) Value() string {
+	return string(c.value)
+}
+
+// Bytes returns the credential as is
+func (c Credential) Bytes() []byte {
+	return c.value
+}
+
+// Base64 returns the credential encoded for the data of a Secret
+func (c Credential) Base64() string {
+	return base64.StdEncoding.EncodeToString(c.value)
+}
+
+// Empty reports whether the credential was not retrieved
+func (c Credential) Empty() bool {
+	return len(c.value) == 0
+}
+
+// String redacts the credential, so that it is not leaked by logs, nor
+// rendered by templates that do not pick Value or Base64.
+func (c Credential) String() string {
+	return "[redacted]"
+}
@@ -20,8 +20,9 @@ import (
 	cryptoTls "crypto/tls"
 	"crypto/x509"
 	"database/sql"
-	b64 "encoding/base64"
 	"fmt"
+	"net"
+	"sync/atomic"
 
 	"time"
 
@@ -32,19 +33,86 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"os"
 )
 
 const dbSecret = "mariadb/generated-secret/secret.yaml.tmpl"
 
+const dbExternalSecret = "external-secrets/database.externalsecret.yaml.tmpl"
+
+// ErrDatabaseAuthFailed is returned by the database health check when the
+// database rejects the configured credentials.
+var ErrDatabaseAuthFailed = errors.New("database rejected the configured credentials")
+
+// ErrDatabaseHostNotFound is returned by the database health check when the
+// database host cannot be resolved.
+var ErrDatabaseHostNotFound = errors.New("database host could not be resolved")
+
+// ErrDatabaseUnreachable is returned by the database health check when the
+// database host does not accept connections on the database port in time.
+var ErrDatabaseUnreachable = errors.New("database host is not reachable")
+
+// ErrDatabaseTLSFailed is returned by the database health check when the TLS
+// handshake with the database fails, e.g. as its certificate is not trusted.
+var ErrDatabaseTLSFailed = errors.New("TLS handshake with the database failed")
+
+// MySQL server errors ER_DBACCESS_DENIED_ERROR and ER_ACCESS_DENIED_ERROR
+var mysqlAuthErrorNumbers = map[uint16]bool{1044: true, 1045: true}
+
+// healthCheckTLSConfigs names the TLS configs registered with the MySQL
+// driver, so that concurrent health checks do not use each other's
+var healthCheckTLSConfigs atomic.Uint64
+
 var mariadbTemplates = []string{
-	"mariadb/default/deployment.yaml.tmpl",
-	"mariadb/default/pvc.yaml.tmpl",
+	mariadbDeploymentTemplate,
+	mariadbPVCTemplate,
 	"mariadb/default/service.yaml.tmpl",
 	"mariadb/default/mariadb-sa.yaml.tmpl",
 	"mariadb/default/networkpolicy.yaml.tmpl",
 	"mariadb/default/tls-config.yaml.tmpl",
+	"mariadb/default/server-config.yaml.tmpl",
+}
+
+const mariadbDeploymentTemplate = "mariadb/default/deployment.yaml.tmpl"
+
+const mariadbPVCTemplate = "mariadb/default/pvc.yaml.tmpl"
+
+// mariadbGaleraTemplates replace the Deployment and PVC of mariadbTemplates
+// when MariaDB is deployed as a Galera cluster.
+var mariadbGaleraTemplates = []string{
+	"mariadb/galera/statefulset.yaml.tmpl",
+	"mariadb/galera/service-headless.yaml.tmpl",
+	"mariadb/galera/galera-config.yaml.tmpl",
+	"mariadb/galera/networkpolicy.yaml.tmpl",
+	"mariadb/galera/poddisruptionbudget.yaml.tmpl",
+}
+
+// mariaDBTemplates returns the templates deploying the managed MariaDB, as a
+// single pod or as a Galera cluster, and its ServiceMonitor when its metrics
+// are exported.
+func mariaDBTemplates(params *DSPAParams) []string {
+	var templates []string
+	for _, template := range mariadbTemplates {
+		if !params.MariaDBHighAvailability() || (template != mariadbDeploymentTemplate && template != mariadbPVCTemplate) {
+			templates = append(templates, template)
+		}
+	}
+	if params.MariaDBHighAvailability() {
+		templates = append(templates, mariadbGaleraTemplates...)
+	}
+	if params.scrapedByServiceMonitor(params.MariaDBExporter != nil) {
+		templates = append(templates, mariadbMonitoringTemplates...)
+	}
+	return templates
 }
 
 // tLSClientConfig creates and returns a TLS client configuration that includes
@@ -56,6 +124,8 @@ var mariadbTemplates = []string{
 //
 //	pems [][]byte: PEM-encoded certificates to be appended to the
 //	root certificate pool.
+//	fipsMode bool: whether the DSPA is in FIPS mode, restricting the
+//	configuration to FIPS approved TLS versions and cipher suites.
 //
 // Returns:
 //
@@ -63,7 +133,7 @@ var mariadbTemplates = []string{
 //	certificate pool.
 //	error: An error if there is a failure in parsing any of the provided PEM
 //	certificates, or nil if successful.
-func tLSClientConfig(pems [][]byte) (*cryptoTls.Config, error) {
+func tLSClientConfig(pems [][]byte, fipsMode bool) (*cryptoTls.Config, error) {
 	rootCertPool := x509.NewCertPool()
 
 	if f := os.Getenv("SSL_CERT_FILE"); f != "" {
@@ -82,6 +152,7 @@ func tLSClientConfig(pems [][]byte) (*cryptoTls.Config, error) {
 	tlsConfig := &cryptoTls.Config{
 		RootCAs: rootCertPool,
 	}
+	util.RestrictTLSConfigToFIPS(tlsConfig, fipsMode)
 	return tlsConfig, nil
 }
 
@@ -115,6 +186,8 @@ var ConnectAndQueryDatabase = func(
 	port, username, password, dbname, tls string,
 	dbConnectionTimeout time.Duration,
 	pemCerts [][]byte,
+	fipsMode bool,
+	clientCerts []cryptoTls.Certificate,
 	extraParams map[string]string) (bool, error) {
 
 	mysqlConfig := createMySQLConfig(
@@ -136,7 +209,7 @@ var ConnectAndQueryDatabase = func(
 		// don't set anything
 	case "true":
 		var err error
-		tlsConfig, err = tLSClientConfig(pemCerts)
+		tlsConfig, err = tLSClientConfig(pemCerts, fipsMode)
 		if err != nil {
 			log.Info(fmt.Sprintf("Encountered error when processing custom ca bundle, Error: %v", err))
 			return false, err
@@ -149,17 +222,20 @@ var ConnectAndQueryDatabase = func(
 
 	// Only register tls config in the case of: "true", "skip-verify", "preferred"
 	if tlsConfig != nil {
-		err := mysql.RegisterTLSConfig("custom", tlsConfig)
+		tlsConfig.Certificates = clientCerts
+		tlsConfigName := fmt.Sprintf("health-check-%d", healthCheckTLSConfigs.Add(1))
+		err := mysql.RegisterTLSConfig(tlsConfigName, tlsConfig)
+		if err != nil {
+			return false, err
+		}
+		defer mysql.DeregisterTLSConfig(tlsConfigName)
 		// If ExtraParams{"tls": ".."} is set, that should take precedent over mysqlConfig.TLSConfig
 		// so we need to make sure we're setting our tls config to be used instead if it exists
 		if _, ok := mysqlConfig.Params["tls"]; ok {
-			mysqlConfig.Params["tls"] = "custom"
+			mysqlConfig.Params["tls"] = tlsConfigName
 		}
 		// Just to be safe, we also set it here, fallback from mysqlConfig.Params["tls"] not being set
-		mysqlConfig.TLSConfig = "custom"
-		if err != nil {
-			return false, err
-		}
+		mysqlConfig.TLSConfig = tlsConfigName
 	}
 
 	db, err := sql.Open("mysql", mysqlConfig.FormatDSN())
@@ -168,17 +244,63 @@ var ConnectAndQueryDatabase = func(
 	}
 	defer db.Close()
 
+	// The query authenticates against the database, so a proxy accepting the
+	// connection in front of an unavailable database does not pass the check
 	testStatement := "SELECT 1;"
-	_, err = db.QueryContext(ctx, testStatement)
+	rows, err := db.QueryContext(ctx, testStatement)
 	if err != nil {
-		return false, err
+		return false, databaseHealthCheckError(err)
 	}
+	defer rows.Close()
 	return true, nil
 }
 
+// databaseHealthCheckError wraps err, returned when connecting to or querying
+// the database, with the error of the health check stage that failed.
+func databaseHealthCheckError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	var dnsErr *net.DNSError
+	var netErr *net.OpError
+	var recordHeaderErr cryptoTls.RecordHeaderError
+	var certVerificationErr *cryptoTls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &mysqlErr) && mysqlAuthErrorNumbers[mysqlErr.Number]:
+		return fmt.Errorf("%w: %s", ErrDatabaseAuthFailed, err.Error())
+	case errors.Is(err, mysql.ErrNoTLS), errors.As(err, &recordHeaderErr), errors.As(err, &certVerificationErr),
+		errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr), errors.As(err, &certInvalidErr):
+		return fmt.Errorf("%w: %s", ErrDatabaseTLSFailed, err.Error())
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("%w: %s", ErrDatabaseHostNotFound, err.Error())
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", ErrDatabaseUnreachable, err.Error())
+	}
+	return err
+}
+
+// databaseNotReadyReason returns the DatabaseAvailable condition reason of
+// the database health check error err.
+func databaseNotReadyReason(err error, usingExternalDB bool) string {
+	switch {
+	case errors.Is(err, ErrDatabaseAuthFailed) && usingExternalDB:
+		return config.ExternalDBAuthFailed
+	case errors.Is(err, ErrDatabaseAuthFailed):
+		return config.DatabaseAuthFailed
+	case errors.Is(err, ErrDatabaseTLSFailed):
+		return config.DatabaseTLSFailed
+	case errors.Is(err, ErrDatabaseHostNotFound):
+		return config.DatabaseHostNotFound
+	case errors.Is(err, ErrDatabaseUnreachable):
+		return config.DatabaseUnreachable
+	}
+	return config.FailingToDeploy
+}
+
 func (r *DSPAReconciler) isDatabaseAccessible(dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) (bool, error) {
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	if params.DatabaseHealthCheckDisabled(dsp) {
 		infoMessage := "Database health check disabled, assuming database is available and ready."
@@ -196,7 +318,6 @@ func (r *DSPAReconciler) isDatabaseAccessible(dsp *dspav1.DataSciencePipelinesAp
 		return false, errors.New(errorMessage)
 	}
 
-	decodePass, _ := b64.StdEncoding.DecodeString(params.DBConnection.Password)
 	dbConnectionTimeout := config.GetDurationConfigWithDefault(config.DBConnectionTimeoutConfigName, config.DefaultDBConnectionTimeout)
 
 	var extraParamsJson map[string]string
@@ -210,8 +331,12 @@ func (r *DSPAReconciler) isDatabaseAccessible(dsp *dspav1.DataSciencePipelinesAp
 	// we default to true if it's an externalDB, false otherwise
 	// (if not specified via CustomExtraParams)
 	tls := "false"
+	var clientCerts []cryptoTls.Certificate
 	if usingExternalDB {
 		tls = "true"
+		if params.DBConnection.TLS != nil && params.DBConnection.TLS.ClientCertificate != nil {
+			clientCerts = []cryptoTls.Certificate{*params.DBConnection.TLS.ClientCertificate}
+		}
 	}
 
 	// Override tls with the value in ExtraParams, if specified
@@ -229,11 +354,13 @@ func (r *DSPAReconciler) isDatabaseAccessible(dsp *dspav1.DataSciencePipelinesAp
 		log,
 		params.DBConnection.Port,
 		params.DBConnection.Username,
-		string(decodePass),
+		params.DBConnection.Password.Value(),
 		params.DBConnection.DBName,
 		tls,
 		dbConnectionTimeout,
 		params.APICustomPemCerts,
+		params.FIPSEnabled,
+		clientCerts,
 		extraParamsJson)
 
 	if err != nil {
@@ -251,39 +378,65 @@ func (r *DSPAReconciler) isDatabaseAccessible(dsp *dspav1.DataSciencePipelinesAp
 func (r *DSPAReconciler) ReconcileDatabase(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) error {
 
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 	databaseSpecified := dsp.Spec.Database != nil
 	// DB field can be specified as an empty obj, confirm that subfields are also specified
 	// By default if Database is empty, we deploy mariadb
 	externalDBSpecified := params.UsingExternalDB(dsp)
-	mariaDBSpecified := dsp.Spec.Database.MariaDB != nil
+	mariaDBSpecified := databaseSpecified && dsp.Spec.Database.MariaDB != nil
 	defaultDBRequired := !databaseSpecified || (!externalDBSpecified && !mariaDBSpecified)
 
 	deployMariaDB := mariaDBSpecified && dsp.Spec.Database.MariaDB.Deploy
 	// Default DB is currently MariaDB as well, but storing these bools seperately in case that changes
 	deployDefaultDB := !databaseSpecified || defaultDBRequired
 
-	externalDBCredentialsProvided := externalDBSpecified && (dsp.Spec.Database.ExternalDB.PasswordSecret != nil)
+	externalDBCredentialsProvided := externalDBSpecified &&
+		(dsp.Spec.Database.ExternalDB.PasswordSecret != nil || dsp.Spec.Database.ExternalDB.PasswordSecretRef != nil)
 	mariaDBCredentialsProvided := mariaDBSpecified && (dsp.Spec.Database.MariaDB.PasswordSecret != nil)
 	databaseCredentialsProvided := externalDBCredentialsProvided || mariaDBCredentialsProvided
 
 	// If external db is specified, it takes precedence
 	if externalDBSpecified {
 		log.Info("Using externalDB, bypassing database deployment.")
+		if params.DBConnection.ExternalSecret != nil {
+			log.Info("Applying ExternalSecret for externalDB credentials.")
+			err := r.Apply(dsp, params, dbExternalSecret)
+			if err != nil {
+				return err
+			}
+		}
 	} else if deployMariaDB || deployDefaultDB {
 		if !databaseCredentialsProvided {
 			err := r.Apply(dsp, params, dbSecret)
 			if err != nil {
 				return err
 			}
+			err = r.deleteMigratedSecret(ctx, dsp, params.DBConnection.MigrateCredentialsSecretFrom)
+			if err != nil {
+				return err
+			}
+		}
+		if params.MariaDBNamespace != dsp.Namespace {
+			err := r.copySecretToComponentNamespace(ctx, dsp, params.DBConnection.CredentialsSecret.Name, params.MariaDBNamespace)
+			if err != nil {
+				return err
+			}
 		}
 		log.Info("Applying mariaDB resources.")
-		for _, template := range mariadbTemplates {
+		for _, template := range mariaDBTemplates(params) {
 			err := r.Apply(dsp, params, template)
 			if err != nil {
 				return err
 			}
 		}
+		if err := r.cleanUpMariaDBMode(ctx, dsp, params); err != nil {
+			return err
+		}
+		if !params.scrapedByServiceMonitor(params.MariaDBExporter != nil) {
+			if err := r.deleteExporterMonitoring(ctx, dsp, config.MariaDBHostPrefix, params.MariaDBNamespace, mariadbMonitoringTemplates); err != nil {
+				return err
+			}
+		}
 		// If no database was not specified, deploy mariaDB by default.
 		// Update the CR with the state of mariaDB to accurately portray
 		// desired state.
@@ -306,3 +459,34 @@ func (r *DSPAReconciler) ReconcileDatabase(ctx context.Context, dsp *dspav1.Data
 
 	return nil
 }
+
+// cleanUpMariaDBMode deletes the MariaDB Deployment once the Galera cluster is
+// deployed, and the Galera cluster once MariaDB is deployed as a single pod
+// again. The PVCs are kept, so that switching back restores their data.
+func (r *DSPAReconciler) cleanUpMariaDBMode(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	dspaNN := types.NamespacedName{Name: dsp.Name, Namespace: dsp.Namespace}
+	nn := types.NamespacedName{Name: config.MariaDBHostPrefix + "-" + dsp.Name, Namespace: params.MariaDBNamespace}
+	if params.MariaDBHighAvailability() {
+		r.appliedManifests.forgetTemplate(dspaNN, mariadbDeploymentTemplate)
+		return r.DeleteResourceIfItExists(ctx, &appsv1.Deployment{}, nn)
+	}
+
+	err := r.Get(ctx, nn, &appsv1.StatefulSet{})
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID).Info("Deleting the MariaDB Galera cluster.")
+	for _, template := range mariadbGaleraTemplates {
+		r.appliedManifests.forgetTemplate(dspaNN, template)
+	}
+	return r.deleteResourcesIfTheyExist(ctx, nn.Namespace,
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: nn.Name}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: nn.Name + "-galera"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ds-pipelines-mariadb-galera-config-" + dsp.Name}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: nn.Name + "-galera"}},
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: nn.Name}},
+	)
+}
@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Database backup and verification CronJobs are resources deployed
+// conditionally as such they are handled separately
+var (
+	databaseBackupTemplatesDir             = "database-backup/default"
+	databaseBackupVerificationTemplatesDir = "database-backup/verification"
+)
+
+const (
+	databaseBackupDefaultResourceNamePrefix      = "ds-pipeline-db-backup-"
+	databaseBackupVerificationResourceNamePrefix = "ds-pipeline-db-backup-verify-"
+)
+
+// ReconcileDatabaseBackup applies the CronJob dumping the database of dsp to a
+// PersistentVolumeClaim, and the CronJob restoring the dump to verify it, when
+// spec.database.backup enables them. The PersistentVolumeClaim, and the last
+// dump, are kept once the backups are disabled, and deleted along with dsp.
+func (r *DSPAReconciler) ReconcileDatabaseBackup(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	if params.DatabaseBackup != nil {
+		log.Info("Applying Database Backup Resources")
+		err := r.ApplyDir(dsp, params, databaseBackupTemplatesDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: params.DatabaseBackupDefaultResourceName, Namespace: dsp.Namespace}
+		err := r.DeleteResourceIfItExists(ctx, &batchv1.CronJob{}, namespacedNamed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if params.databaseBackupVerified() {
+		log.Info("Applying Database Backup Verification Resources")
+		return r.ApplyDir(dsp, params, databaseBackupVerificationTemplatesDir)
+	}
+	namespacedNamed := types.NamespacedName{Name: params.DatabaseBackupVerificationResourceName, Namespace: dsp.Namespace}
+	return r.DeleteResourceIfItExists(ctx, &batchv1.CronJob{}, namespacedNamed)
+}
+
+// databaseBackupVerified returns true when the database backups are restored
+// on a schedule to verify them.
+func (p *DSPAParams) databaseBackupVerified() bool {
+	return p.DatabaseBackup != nil && p.DatabaseBackup.Verification != nil && p.DatabaseBackup.Verification.Enabled
+}
+
+// setBackupVerifiedStatus reports the outcome of the latest finished Job of
+// the backup verification CronJob as the BackupVerified condition, pending
+// until a Job finishes. The condition is omitted when the backups are not
+// verified.
+func (r *DSPAReconciler) setBackupVerifiedStatus(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) error {
+	if !params.databaseBackupVerified() {
+		return nil
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(dsp.Namespace),
+		client.MatchingLabels{"app": params.DatabaseBackupVerificationResourceName, "dspa": dsp.Name})
+	if err != nil {
+		return err
+	}
+
+	var latest *batchv1.Job
+	var finishedAt time.Time
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		at, finished := jobFinishedAt(job)
+		if finished && (latest == nil || at.After(finishedAt)) {
+			latest, finishedAt = job, at
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:               config.BackupVerified,
+		Status:             metav1.ConditionUnknown,
+		Reason:             config.BackupVerificationPending,
+		Message:            "No database backup verification has finished yet.",
+		LastTransitionTime: metav1.Now(),
+	}
+	if latest != nil && isJobSucceeded(latest) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = config.BackupVerificationSucceeded
+		condition.Message = fmt.Sprintf("The database backup was restored by Job %s at %s.",
+			latest.Name, finishedAt.UTC().Format(time.RFC3339))
+	} else if latest != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = config.BackupVerificationFailed
+		condition.Message = fmt.Sprintf("The database backup failed to be restored by Job %s at %s, "+
+			"check the logs of its Pod.", latest.Name, finishedAt.UTC().Format(time.RFC3339))
+	}
+	dspaStatus.SetBackupVerifiedStatus(condition)
+	return nil
+}
+
+// jobFinishedAt returns when job succeeded or failed, and false while it runs.
+func jobFinishedAt(job *batchv1.Job) (time.Time, bool) {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
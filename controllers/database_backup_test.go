@@ -0,0 +1,148 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeployDatabaseBackup(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Database.Backup = &dspav1.DatabaseBackup{
+		Enabled:      true,
+		Verification: &dspav1.BackupVerification{Enabled: true},
+	}
+	expectedBackupName := databaseBackupDefaultResourceNamePrefix + dspa.Name
+	expectedVerificationName := databaseBackupVerificationResourceNamePrefix + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, config.DefaultDatabaseBackupSchedule, params.DatabaseBackup.Schedule)
+	assert.Equal(t, config.DefaultDatabaseBackupVerificationSchedule, params.DatabaseBackup.Verification.Schedule)
+	assert.Equal(t, config.DefaultDatabaseBackupPVCSize, params.DatabaseBackup.PVCSize.String())
+	assert.Equal(t, params.MariaDB.Image, params.DatabaseBackup.Image)
+	require.Nil(t, reconciler.ReconcileDatabaseBackup(ctx, dspa, params))
+
+	// Assert the dump is written to the PVC, and restored from it
+	cronJob := &batchv1.CronJob{}
+	created, err := reconciler.IsResourceCreated(ctx, cronJob, expectedBackupName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, config.DefaultDatabaseBackupSchedule, cronJob.Spec.Schedule)
+	assert.Equal(t, expectedBackupName, cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.PersistentVolumeClaim{}, expectedBackupName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	cronJob = &batchv1.CronJob{}
+	created, err = reconciler.IsResourceCreated(ctx, cronJob, expectedVerificationName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, config.DefaultDatabaseBackupVerificationSchedule, cronJob.Spec.Schedule)
+	assert.True(t, cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+
+	// Assert the verification is removed once disabled, and the backups along with it
+	dspa.Spec.Database.Backup.Verification.Enabled = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabaseBackup(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &batchv1.CronJob{}, expectedVerificationName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &batchv1.CronJob{}, expectedBackupName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+
+	dspa.Spec.Database.Backup.Enabled = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Nil(t, params.DatabaseBackup)
+	require.Nil(t, reconciler.ReconcileDatabaseBackup(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &batchv1.CronJob{}, expectedBackupName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the last dump is kept
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.PersistentVolumeClaim{}, expectedBackupName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+}
+
+func TestBackupVerifiedCondition(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Database.Backup = &dspav1.DatabaseBackup{
+		Enabled:      true,
+		Verification: &dspav1.BackupVerification{Enabled: true},
+	}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	backupVerified := func() metav1.Condition {
+		dspaStatus := dspastatus.NewDSPAStatus(dspa)
+		require.Nil(t, reconciler.setBackupVerifiedStatus(ctx, dspa, params, dspaStatus))
+		return util.GetConditionByType(config.BackupVerified, dspaStatus.GetConditions())
+	}
+	createJob := func(name string, conditionType batchv1.JobConditionType, finishedAt time.Time) {
+		job := &batchv1.Job{}
+		job.Name, job.Namespace = name, dspa.Namespace
+		job.Labels = map[string]string{"app": params.DatabaseBackupVerificationResourceName, "dspa": dspa.Name}
+		require.Nil(t, reconciler.Create(ctx, job))
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(finishedAt),
+			}}
+			if conditionType == batchv1.JobComplete {
+				job.Status.Succeeded = 1
+			}
+			require.Nil(t, reconciler.Status().Update(ctx, job))
+		}
+	}
+	now := time.Now().Truncate(time.Second)
+
+	// Assert the verification is pending until a Job finishes
+	createJob("running", "", now)
+	condition := backupVerified()
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, config.BackupVerificationPending, condition.Reason)
+
+	createJob("failed", batchv1.JobFailed, now.Add(-time.Hour))
+	condition = backupVerified()
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, config.BackupVerificationFailed, condition.Reason)
+
+	// Assert the latest finished Job is reported, along with when it finished
+	createJob("succeeded", batchv1.JobComplete, now)
+	condition = backupVerified()
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, config.BackupVerificationSucceeded, condition.Reason)
+	assert.Contains(t, condition.Message, "succeeded")
+	assert.Contains(t, condition.Message, now.UTC().Format(time.RFC3339))
+
+	// Assert the condition is omitted once the backups are not verified
+	dspa.Spec.Database.Backup.Verification.Enabled = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Empty(t, backupVerified().Type)
+}
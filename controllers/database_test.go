@@ -18,11 +18,30 @@ limitations under the License.
 package controllers
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/go-sql-driver/mysql"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestDeployDatabase(t *testing.T) {
@@ -75,6 +94,185 @@ func TestDeployDatabase(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDeployDatabaseProbeOverrides(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedDatabaseName := "mariadb-testdspa"
+	initialDelaySeconds := int32(120)
+	failureThreshold := int32(10)
+
+	// Construct DSPA Spec with deployed MariaDB Database and a slower liveness probe
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+					Probes: &dspav1.Probes{
+						Liveness: &dspav1.ProbeTiming{
+							InitialDelaySeconds: &initialDelaySeconds,
+							FailureThreshold:    &failureThreshold,
+						},
+					},
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileDatabase(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, testNamespace)
+	require.True(t, created)
+	assert.Nil(t, err)
+
+	// Assert overridden timings are applied and the rest keep their defaults
+	container := deployment.Spec.Template.Spec.Containers[0]
+	require.NotNil(t, container.LivenessProbe)
+	assert.Equal(t, int32(120), container.LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(10), container.LivenessProbe.PeriodSeconds)
+	assert.Equal(t, int32(10), container.LivenessProbe.FailureThreshold)
+	require.NotNil(t, container.ReadinessProbe)
+	assert.Equal(t, int32(5), container.ReadinessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(10), container.ReadinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(3), container.ReadinessProbe.FailureThreshold)
+}
+
+func TestDeployDatabaseServerConfig(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Database.MariaDB.Config = map[string]string{
+		"max_connections":      "1000",
+		"character_set_server": "utf8mb4",
+		"skip-name-resolve":    "",
+	}
+	expectedDatabaseName := "mariadb-" + dspa.Name
+	expectedConfigMapName := "ds-pipelines-mariadb-config-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	// Assert the settings are set over the defaults in the mounted my.cnf
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedConfigMapName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "[mariadb]\n"+
+		"character_set_server = utf8mb4\n"+
+		"innodb_buffer_pool_size = 256M\n"+
+		"max_connections = 1000\n"+
+		"skip-name-resolve\n", configMap.Data["mariadb-server-config.cnf"])
+
+	deployment := &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "mariadb-server-config",
+		MountPath: "/etc/my.cnf.d/z-mariadb-server-config.cnf",
+		SubPath:   "mariadb-server-config.cnf",
+	})
+	configHash := deployment.Spec.Template.Annotations["configHash"]
+	assert.NotEmpty(t, configHash)
+
+	// Assert changing the settings rolls out the pod
+	dspa.Spec.Database.MariaDB.Config["max_connections"] = "500"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	deployment = &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.NotEqual(t, configHash, deployment.Spec.Template.Annotations["configHash"])
+
+	// Assert malformed settings are rejected
+	dspa.Spec.Database.MariaDB.Config = map[string]string{"max_connections = 1\n[client]": "1"}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "spec.database.mariaDB.config")
+	dspa.Spec.Database.MariaDB.Config = map[string]string{"init_connect": "SET NAMES utf8mb4\n[client]"}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "spec.database.mariaDB.config")
+}
+
+func TestDeployDatabaseHighAvailability(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Database.MariaDB.HighAvailability = &dspav1.MariaDBHighAvailability{Enabled: true}
+	expectedDatabaseName := "mariadb-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	// Deploy MariaDB as a single pod first
+	single := testutil.CreateDefaultDSPA()
+	require.Nil(t, params.ExtractParams(ctx, single, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, single, params))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	// Assert a 3 node Galera cluster replaced the single pod Deployment
+	statefulSet := &appsv1.StatefulSet{}
+	created, err := reconciler.IsResourceCreated(ctx, statefulSet, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	assert.Equal(t, expectedDatabaseName+"-galera", statefulSet.Spec.ServiceName)
+	require.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	created, err = reconciler.IsResourceCreated(ctx, pdb, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, int32(2), pdb.Spec.MinAvailable.IntVal)
+
+	configMap := &corev1.ConfigMap{}
+	created, err = reconciler.IsResourceCreated(ctx, configMap, "ds-pipelines-mariadb-galera-config-"+dspa.Name, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, configMap.Data["mariadb-galera-config.cnf"], "wsrep_cluster_address = gcomm://"+
+		"mariadb-testdspa-0.mariadb-testdspa-galera.testnamespace.svc.cluster.local,"+
+		"mariadb-testdspa-1.mariadb-testdspa-galera.testnamespace.svc.cluster.local,"+
+		"mariadb-testdspa-2.mariadb-testdspa-galera.testnamespace.svc.cluster.local\n")
+
+	// Assert the clients connect through the Service to any synced node, and drop lost nodes
+	assert.Equal(t, "mariadb-testdspa.testnamespace.svc.cluster.local", params.DBConnection.Host)
+	assert.Contains(t, params.DBConnection.ExtraParams, `"wsrep_sync_wait":"1"`)
+	assert.Contains(t, params.DBConnection.ExtraParams, `"timeout":"10s"`)
+
+	// Assert disabling it deploys the single pod again, and deletes the cluster
+	dspa.Spec.Database.MariaDB.HighAvailability = nil
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.StatefulSet{}, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert clusters without a quorum are rejected
+	dspa.Spec.Database.MariaDB.HighAvailability = &dspav1.MariaDBHighAvailability{Enabled: true, Replicas: 4}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "spec.database.mariaDB.highAvailability.replicas")
+}
+
 func TestDontDeployDatabase(t *testing.T) {
 	testNamespace := "testnamespace"
 	testDSPAName := "testdspa"
@@ -124,3 +322,247 @@ func TestDontDeployDatabase(t *testing.T) {
 	assert.False(t, created)
 	assert.Nil(t, err)
 }
+
+func TestDeployDatabaseExternalSecret(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedExternalSecretName := "ds-pipeline-db-external-testdspa"
+
+	// Construct DSPA Spec with an externalDB whose password is sourced from Vault
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				ExternalDB: &dspav1.ExternalDB{
+					Host:     "mysql.example.com",
+					Port:     "3306",
+					Username: "mlpipeline",
+					DBName:   "mlpipeline",
+					PasswordSecretRef: &dspav1.ExternalSecretRef{
+						SecretStoreName: "vault-backend",
+						RemoteKey:       "dspa/database",
+						Property:        "password",
+					},
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedExternalSecretName, params.DBConnection.CredentialsSecret.Name)
+	assert.Equal(t, "SecretStore", params.DBConnection.ExternalSecret.SecretStoreKind)
+	assert.True(t, params.DBConnection.Password.Empty())
+
+	// Run test reconciliation
+	err = reconciler.ReconcileDatabase(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Assert ExternalSecret now exists and targets the credentials Secret
+	externalSecret := &unstructured.Unstructured{}
+	externalSecret.SetGroupVersionKind(schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"})
+	created, err := reconciler.IsResourceCreated(ctx, externalSecret, expectedExternalSecretName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+
+	storeName, _, _ := unstructured.NestedString(externalSecret.Object, "spec", "secretStoreRef", "name")
+	assert.Equal(t, "vault-backend", storeName)
+	targetName, _, _ := unstructured.NestedString(externalSecret.Object, "spec", "target", "name")
+	assert.Equal(t, expectedExternalSecretName, targetName)
+}
+
+func TestDeployDatabaseExternalTLS(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedMLMDGRPCName := "ds-pipeline-metadata-grpc-testdspa"
+
+	// Construct DSPA Spec with a MySQL 8 externalDB presenting a client certificate
+	newDSPA := func(tls *dspav1.ExternalDBTLS) *dspav1.DataSciencePipelinesApplication {
+		dspa := &dspav1.DataSciencePipelinesApplication{
+			Spec: dspav1.DSPASpec{
+				PodToPodTLS: boolPtr(false),
+				MLMD:        &dspav1.MLMD{Deploy: true},
+				Database: &dspav1.Database{
+					DisableHealthCheck: true,
+					ExternalDB: &dspav1.ExternalDB{
+						Host:           "mysql.example.com",
+						Port:           "3306",
+						Username:       "mlpipeline",
+						DBName:         "mlpipeline",
+						PasswordSecret: &dspav1.SecretKeyValue{Name: "db-password", Key: "password"},
+						ServerVersion:  "8",
+						TLS:            tls,
+					},
+				},
+				ObjectStorage: &dspav1.ObjectStorage{
+					DisableHealthCheck: true,
+					Minio: &dspav1.Minio{
+						Deploy: false,
+						Image:  "someimage",
+					},
+				},
+			},
+		}
+		dspa.Name = testDSPAName
+		dspa.Namespace = testNamespace
+		return dspa
+	}
+
+	// Create the password and client certificate secrets
+	ctx, params, reconciler := CreateNewTestObjects()
+	clientCert, clientKey := testClientCertificate(t)
+	newSecret := func(name string, data map[string][]byte) *corev1.Secret {
+		secret := &corev1.Secret{Data: data}
+		secret.Name, secret.Namespace = name, testNamespace
+		return secret
+	}
+	require.Nil(t, reconciler.Create(ctx, newSecret("db-password", map[string][]byte{"password": []byte("password")})))
+	require.Nil(t, reconciler.Create(ctx, newSecret("db-client-tls", map[string][]byte{corev1.TLSCertKey: clientCert, corev1.TLSPrivateKeyKey: clientKey})))
+	require.Nil(t, reconciler.Create(ctx, newSecret("db-invalid-tls", map[string][]byte{corev1.TLSCertKey: []byte("not a certificate")})))
+
+	// Assert the server certificate is verified by default, without changing the MLMD flags
+	dspa := newDSPA(nil)
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Contains(t, params.DBConnection.ExtraParams, `"tls":"true"`)
+	assert.False(t, params.DBConnection.TLS.VerifyServerCert)
+
+	// Assert the modes map to the tls param of the DSN
+	dspa = newDSPA(&dspav1.ExternalDBTLS{Mode: "require"})
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Contains(t, params.DBConnection.ExtraParams, `"tls":"skip-verify"`)
+
+	// Assert TLS cannot be disabled for MySQL 8
+	dspa = newDSPA(&dspav1.ExternalDBTLS{Mode: "disable"})
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.database.externalDB.tls.mode]")
+	dspa.Spec.Database.ExternalDB.ServerVersion = "5.7"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Contains(t, params.DBConnection.ExtraParams, `"tls":"false"`)
+
+	// Assert invalid client certificates are reported
+	dspa = newDSPA(&dspav1.ExternalDBTLS{ClientCertSecret: "db-invalid-tls"})
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.database.externalDB.tls.clientCertSecret]")
+
+	// Assert MLMD verifies the server certificate and presents the client certificate
+	dspa = newDSPA(&dspav1.ExternalDBTLS{Mode: "verify-full", ClientCertSecret: "db-client-tls"})
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.NotNil(t, params.DBConnection.TLS.ClientCertificate)
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedMLMDGRPCName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Args, "--mysql_config_verify_server_cert=true")
+	assert.Contains(t, container.Args, "--mysql_config_sslcert=/dbclient-tls/tls.crt")
+	assert.Contains(t, container.Args, "--mysql_config_sslkey=/dbclient-tls/tls.key")
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "database-client-tls", MountPath: "/dbclient-tls", ReadOnly: true})
+}
+
+// testClientCertificate returns a self-signed PEM encoded certificate and key
+func testClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mlpipeline"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestDatabaseHealthCheckError(t *testing.T) {
+	log := logr.Discard()
+	connect := func(host, port string) error {
+		_, err := ConnectAndQueryDatabase(host, log, port, "mlpipeline", "password", "mlpipeline", "false",
+			5*time.Second, nil, false, nil, map[string]string{})
+		return err
+	}
+
+	// Assert hosts that cannot be resolved are distinguished from those not accepting connections
+	err := connect("nonexistent.invalid", "3306")
+	assert.ErrorIs(t, err, ErrDatabaseHostNotFound)
+	assert.Equal(t, config.DatabaseHostNotFound, databaseNotReadyReason(err, true))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.Nil(t, err)
+	require.Nil(t, listener.Close())
+	err = connect("127.0.0.1", port)
+	assert.ErrorIs(t, err, ErrDatabaseUnreachable)
+	assert.Equal(t, config.DatabaseUnreachable, databaseNotReadyReason(err, true))
+
+	// Assert TLS and authentication failures are distinguished
+	err = databaseHealthCheckError(fmt.Errorf("handshake: %w", x509.UnknownAuthorityError{}))
+	assert.ErrorIs(t, err, ErrDatabaseTLSFailed)
+	assert.Equal(t, config.DatabaseTLSFailed, databaseNotReadyReason(err, true))
+	err = databaseHealthCheckError(&mysql.MySQLError{Number: 1045, Message: "Access denied"})
+	assert.ErrorIs(t, err, ErrDatabaseAuthFailed)
+	assert.Equal(t, config.ExternalDBAuthFailed, databaseNotReadyReason(err, true))
+	assert.Equal(t, config.DatabaseAuthFailed, databaseNotReadyReason(err, false))
+
+	// Assert other errors are reported as they are
+	err = databaseHealthCheckError(&mysql.MySQLError{Number: 1049, Message: "Unknown database"})
+	assert.Equal(t, config.FailingToDeploy, databaseNotReadyReason(err, true))
+}
+
+func TestDeployDatabaseGeneratedSecret(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	defaultSecretName := "ds-pipeline-db-testdspa"
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	generatedPassword := params.DBConnection.Password.Value()
+
+	// Assert renaming the generated Secret migrates its password, and deletes the Secret of the default name
+	dspa.Spec.Database.MariaDB.GeneratedSecret = &dspav1.GeneratedSecret{
+		Name:   "custom-db-credentials",
+		Labels: map[string]string{"example.com/team": "ml"},
+	}
+	_, params, _ = CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, generatedPassword, params.DBConnection.Password.Value())
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	secret := &corev1.Secret{}
+	created, err := reconciler.IsResourceCreated(ctx, secret, "custom-db-credentials", dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, generatedPassword, string(secret.Data["password"]))
+	assert.Equal(t, "ml", secret.Labels["example.com/team"])
+	assert.Equal(t, "data-science-pipelines", secret.Labels["component"])
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Secret{}, defaultSecretName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the password is kept once migrated
+	_, params, _ = CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, generatedPassword, params.DBConnection.Password.Value())
+
+	// Assert invalid labels, and the labels set by the operator, are rejected
+	dspa.Spec.Database.MariaDB.GeneratedSecret.Labels = map[string]string{"component": "other"}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.database.mariaDB.generatedSecret.labels]")
+	dspa.Spec.Database.MariaDB.GeneratedSecret.Labels = map[string]string{"team": "not a label value"}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.database.mariaDB.generatedSecret.labels]")
+}
@@ -0,0 +1,494 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+//+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=pipelines;recurringruns,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=pipelines/status;recurringruns/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=pipelines/finalizers;recurringruns/finalizers,verbs=update
+
+var scheduledWorkflowListGVK = schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1beta1", Kind: "ScheduledWorkflowList"}
+
+// errDSPANotReady is returned while the API server of the DSPA of a Pipeline
+// or RecurringRun cannot be reached, the resource is synced once it is ready.
+type errDSPANotReady struct {
+	reason, message string
+}
+
+func (e *errDSPANotReady) Error() string {
+	return e.message
+}
+
+// declarativePipelines holds what the Pipeline and RecurringRun reconcilers
+// share: resolving the DSPA whose API server they sync into.
+type declarativePipelines struct {
+	client.Client
+	Log logr.Logger
+	// newPipelineAPI returns the client of the API server of a DSPA,
+	// newPipelineAPIClient when nil
+	newPipelineAPI func(ctx context.Context, cl client.Client, dsp *dspav1.DataSciencePipelinesApplication) (*pipelineAPIClient, error)
+}
+
+// pipelineAPI returns the client of the API server of the DSPA name, once the
+// DSPA enables the DeclarativePipelines feature gate and its API server is
+// ready.
+func (d *declarativePipelines) pipelineAPI(ctx context.Context, namespace, name string) (*pipelineAPIClient, error) {
+	dsp := &dspav1.DataSciencePipelinesApplication{}
+	err := d.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, dsp)
+	if apierrs.IsNotFound(err) {
+		return nil, &errDSPANotReady{reason: config.DSPANotReady, message: fmt.Sprintf("DSPA %s not found", name)}
+	} else if err != nil {
+		return nil, err
+	}
+	gates, err := config.ResolveFeatureGates(dsp.Annotations[config.FeatureGatesAnnotation])
+	if err != nil {
+		return nil, &errDSPANotReady{reason: config.FeatureGateDisabled, message: err.Error()}
+	}
+	if !gates[config.DeclarativePipelinesFeatureGate] {
+		return nil, &errDSPANotReady{reason: config.FeatureGateDisabled,
+			message: fmt.Sprintf("DSPA %s does not enable the %s feature gate", name, config.DeclarativePipelinesFeatureGate)}
+	}
+	if util.GetConditionByType(config.APIServerReady, dsp.Status.Conditions).Status != metav1.ConditionTrue {
+		return nil, &errDSPANotReady{reason: config.DSPANotReady, message: fmt.Sprintf("API server of DSPA %s is not ready", name)}
+	}
+	newPipelineAPI := d.newPipelineAPI
+	if newPipelineAPI == nil {
+		newPipelineAPI = newPipelineAPIClient
+	}
+	return newPipelineAPI(ctx, d.Client, dsp)
+}
+
+// syncResult sets the Synced condition of obj from the outcome of its sync,
+// and returns when it is synced again: once the sync period elapses, or the
+// DSPA is ready while it is not.
+func (d *declarativePipelines) syncResult(ctx context.Context, obj client.Object, conditions *[]metav1.Condition,
+	message string, syncErr error) (ctrl.Result, error) {
+	condition := metav1.Condition{
+		Type:               config.Synced,
+		Status:             metav1.ConditionTrue,
+		Reason:             config.Synced,
+		Message:            message,
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	result := ctrl.Result{RequeueAfter: config.GetDurationConfigWithDefault(config.DeclarativePipelinesSyncPeriodConfigName,
+		config.DefaultDeclarativePipelinesSyncPeriod)}
+	var notReady *errDSPANotReady
+	switch {
+	case syncErr == nil:
+	case errors.As(syncErr, &notReady):
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, notReady.reason, notReady.message
+		result.RequeueAfter = config.GetDurationConfigWithDefault(config.RequeueTimeConfigName, config.DefaultRequeueTime)
+		// The DSPA is watched for its feature gates
+		if notReady.reason == config.FeatureGateDisabled {
+			result = ctrl.Result{}
+		}
+		syncErr = nil
+	default:
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, config.SyncFailed, syncErr.Error()
+	}
+	meta.SetStatusCondition(conditions, condition)
+	if err := d.Status().Update(ctx, obj); err != nil {
+		return ctrl.Result{}, err
+	}
+	if syncErr != nil {
+		return ctrl.Result{}, syncErr
+	}
+	return result, nil
+}
+
+// PipelineReconciler uploads the Pipelines to the API server of their DSPA,
+// each change of their pipelineSpec as a new pipeline version, and deletes
+// the pipeline and its versions from the API server along with them.
+type PipelineReconciler struct {
+	declarativePipelines
+}
+
+func NewPipelineReconciler(cl client.Client, log logr.Logger) *PipelineReconciler {
+	return &PipelineReconciler{declarativePipelines{Client: cl, Log: log}}
+}
+
+func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pipeline := &dspav1.Pipeline{}
+	if err := r.Get(ctx, req.NamespacedName, pipeline); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := dspaLogger(r.Log, pipeline.Namespace, pipeline.Spec.DSPAName, "").WithValues("pipeline", pipeline.Name)
+
+	if !pipeline.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(pipeline, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		if pipeline.Status.PipelineID != "" {
+			api, err := r.pipelineAPI(ctx, pipeline.Namespace, pipeline.Spec.DSPAName)
+			var notReady *errDSPANotReady
+			if err != nil && !errors.As(err, &notReady) {
+				return ctrl.Result{}, err
+			}
+			// Pipelines of DSPAs that are gone, or no longer sync them, are
+			// left in the API server
+			if err == nil {
+				log.Info(fmt.Sprintf("Deleting pipeline %s from the API server", pipeline.Status.PipelineID))
+				if err := api.deletePipeline(ctx, pipeline.Status.PipelineID); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+		controllerutil.RemoveFinalizer(pipeline, finalizerName)
+		return ctrl.Result{}, r.Update(ctx, pipeline)
+	}
+
+	message, err := r.sync(ctx, pipeline)
+	if err != nil {
+		log.Info(fmt.Sprintf("Pipeline not synced: %v", err))
+	}
+	return r.syncResult(ctx, pipeline, &pipeline.Status.Conditions, message, err)
+}
+
+// sync uploads the current pipelineSpec of pipeline, recreating the pipeline
+// when it was deleted from the API server.
+func (r *PipelineReconciler) sync(ctx context.Context, pipeline *dspav1.Pipeline) (string, error) {
+	api, err := r.pipelineAPI(ctx, pipeline.Namespace, pipeline.Spec.DSPAName)
+	if err != nil {
+		return "", err
+	}
+	if !controllerutil.ContainsFinalizer(pipeline, finalizerName) {
+		controllerutil.AddFinalizer(pipeline, finalizerName)
+		if err := r.Update(ctx, pipeline); err != nil {
+			return "", err
+		}
+	}
+
+	if pipeline.Status.PipelineID != "" {
+		_, err := api.getPipeline(ctx, pipeline.Status.PipelineID)
+		if isPipelineAPIStatus(err, http.StatusNotFound) {
+			pipeline.Status.PipelineID, pipeline.Status.PipelineVersionID = "", ""
+		} else if err != nil {
+			return "", err
+		}
+	}
+	if pipeline.Status.PipelineID == "" {
+		created, err := api.createPipeline(ctx, pipelineDisplayName(pipeline), pipeline.Spec.Description)
+		if err != nil {
+			return "", err
+		}
+		pipeline.Status.PipelineID = created.PipelineID
+	}
+
+	versionName := pipelineVersionName(pipeline)
+	if pipeline.Status.PipelineVersionID != "" && pipeline.Status.VersionName == versionName {
+		_, err := api.getPipelineVersion(ctx, pipeline.Status.PipelineID, pipeline.Status.PipelineVersionID)
+		if isPipelineAPIStatus(err, http.StatusNotFound) {
+			pipeline.Status.PipelineVersionID = ""
+		} else if err != nil {
+			return "", err
+		}
+	} else {
+		pipeline.Status.PipelineVersionID = ""
+	}
+	if pipeline.Status.PipelineVersionID == "" {
+		version, err := api.uploadPipelineVersion(ctx, pipeline.Status.PipelineID, versionName, pipeline.Spec.PipelineSpec)
+		if err != nil {
+			return "", err
+		}
+		pipeline.Status.PipelineVersionID = version.PipelineVersionID
+	}
+	pipeline.Status.VersionName = versionName
+	pipeline.Status.ObservedGeneration = pipeline.Generation
+	return fmt.Sprintf("Version %s of the pipeline is uploaded to the API server of DSPA %s", versionName, pipeline.Spec.DSPAName), nil
+}
+
+func pipelineDisplayName(pipeline *dspav1.Pipeline) string {
+	if pipeline.Spec.DisplayName != "" {
+		return pipeline.Spec.DisplayName
+	}
+	return pipeline.Name
+}
+
+// pipelineVersionName names the version uploaded from the pipelineSpec of
+// pipeline after its digest, unless spec.versionName is set.
+func pipelineVersionName(pipeline *dspav1.Pipeline) string {
+	if pipeline.Spec.VersionName != "" {
+		return pipeline.Spec.VersionName
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(pipeline.Spec.PipelineSpec)))[:12]
+}
+
+// pipelinesForDSPA enqueues the Pipelines uploaded to the DSPA o.
+func (r *PipelineReconciler) pipelinesForDSPA(ctx context.Context, o client.Object) []reconcile.Request {
+	pipelines := &dspav1.PipelineList{}
+	if err := r.List(ctx, pipelines, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range pipelines.Items {
+		if pipelines.Items[i].Spec.DSPAName == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pipelines.Items[i])})
+		}
+	}
+	return requests
+}
+
+func (r *PipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pipeline").
+		For(&dspav1.Pipeline{}).
+		// Synced once their DSPA is ready, or enables the feature gate
+		Watches(&dspav1.DataSciencePipelinesApplication{}, handler.EnqueueRequestsFromMapFunc(r.pipelinesForDSPA)).
+		Complete(r)
+}
+
+// RecurringRunReconciler creates the RecurringRuns in the API server of their
+// DSPA, running the current version of their Pipeline. The API server does
+// not update recurring runs, they are recreated once their settings, or the
+// version of their Pipeline, change.
+type RecurringRunReconciler struct {
+	declarativePipelines
+}
+
+func NewRecurringRunReconciler(cl client.Client, log logr.Logger) *RecurringRunReconciler {
+	return &RecurringRunReconciler{declarativePipelines{Client: cl, Log: log}}
+}
+
+func (r *RecurringRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	recurringRun := &dspav1.RecurringRun{}
+	if err := r.Get(ctx, req.NamespacedName, recurringRun); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := dspaLogger(r.Log, recurringRun.Namespace, recurringRun.Spec.DSPAName, "").WithValues("recurringrun", recurringRun.Name)
+
+	if !recurringRun.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(recurringRun, finalizerName) {
+			return ctrl.Result{}, nil
+		}
+		if recurringRun.Status.RecurringRunID != "" {
+			api, err := r.pipelineAPI(ctx, recurringRun.Namespace, recurringRun.Spec.DSPAName)
+			var notReady *errDSPANotReady
+			if err != nil && !errors.As(err, &notReady) {
+				return ctrl.Result{}, err
+			}
+			if err == nil {
+				log.Info(fmt.Sprintf("Deleting recurring run %s from the API server", recurringRun.Status.RecurringRunID))
+				if err := api.deleteRecurringRun(ctx, recurringRun.Status.RecurringRunID); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+		}
+		controllerutil.RemoveFinalizer(recurringRun, finalizerName)
+		return ctrl.Result{}, r.Update(ctx, recurringRun)
+	}
+
+	message, err := r.sync(ctx, recurringRun)
+	if err != nil {
+		log.Info(fmt.Sprintf("RecurringRun not synced: %v", err))
+	}
+	return r.syncResult(ctx, recurringRun, &recurringRun.Status.Conditions, message, err)
+}
+
+func (r *RecurringRunReconciler) sync(ctx context.Context, recurringRun *dspav1.RecurringRun) (string, error) {
+	api, err := r.pipelineAPI(ctx, recurringRun.Namespace, recurringRun.Spec.DSPAName)
+	if err != nil {
+		return "", err
+	}
+
+	pipeline := &dspav1.Pipeline{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: recurringRun.Namespace, Name: recurringRun.Spec.PipelineRef}, pipeline)
+	if apierrs.IsNotFound(err) {
+		return "", &errDSPANotReady{reason: config.PipelineNotSynced, message: fmt.Sprintf("Pipeline %s not found", recurringRun.Spec.PipelineRef)}
+	} else if err != nil {
+		return "", err
+	}
+	synced := meta.IsStatusConditionTrue(pipeline.Status.Conditions, config.Synced) && pipeline.Status.ObservedGeneration == pipeline.Generation
+	if pipeline.Spec.DSPAName != recurringRun.Spec.DSPAName {
+		return "", fmt.Errorf("Pipeline %s is uploaded to DSPA %s, not %s", pipeline.Name, pipeline.Spec.DSPAName, recurringRun.Spec.DSPAName)
+	} else if !synced || pipeline.Status.PipelineVersionID == "" {
+		return "", &errDSPANotReady{reason: config.PipelineNotSynced, message: fmt.Sprintf("Pipeline %s is not synced yet", pipeline.Name)}
+	}
+
+	if !controllerutil.ContainsFinalizer(recurringRun, finalizerName) {
+		controllerutil.AddFinalizer(recurringRun, finalizerName)
+		if err := r.Update(ctx, recurringRun); err != nil {
+			return "", err
+		}
+	}
+
+	desired := desiredRecurringRun(recurringRun, pipeline)
+	digest, err := recurringRunDigest(desired)
+	if err != nil {
+		return "", err
+	}
+	enabled := recurringRun.Spec.Enabled == nil || *recurringRun.Spec.Enabled
+
+	var current *apiRecurringRun
+	if recurringRun.Status.RecurringRunID != "" {
+		current, err = api.getRecurringRun(ctx, recurringRun.Status.RecurringRunID)
+		if isPipelineAPIStatus(err, http.StatusNotFound) {
+			current = nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+	if current != nil && recurringRun.Status.SpecDigest != digest {
+		if err := api.deleteRecurringRun(ctx, current.RecurringRunID); err != nil {
+			return "", err
+		}
+		current = nil
+	}
+	if current == nil {
+		recurringRun.Status.RecurringRunID = ""
+		desired.Mode = recurringRunModeDisable
+		if enabled {
+			desired.Mode = recurringRunModeEnable
+		}
+		current, err = api.createRecurringRun(ctx, desired)
+		if err != nil {
+			return "", err
+		}
+		recurringRun.Status.RecurringRunID = current.RecurringRunID
+		recurringRun.Status.SpecDigest = digest
+		recurringRun.Status.PipelineVersionID = pipeline.Status.PipelineVersionID
+	} else if (current.Status == recurringRunStatusEnabled) != enabled {
+		if err := api.setRecurringRunEnabled(ctx, current.RecurringRunID, enabled); err != nil {
+			return "", err
+		}
+	}
+	recurringRun.Status.Enabled = enabled
+	recurringRun.Status.ObservedGeneration = recurringRun.Generation
+
+	lastTriggered, nextTriggered, err := r.triggerTimes(ctx, recurringRun)
+	if err != nil {
+		return "", err
+	}
+	recurringRun.Status.LastTriggeredTime, recurringRun.Status.NextTriggeredTime = lastTriggered, nextTriggered
+	return fmt.Sprintf("Recurring run of version %s of Pipeline %s is created in the API server of DSPA %s",
+		pipeline.Status.VersionName, pipeline.Name, recurringRun.Spec.DSPAName), nil
+}
+
+func desiredRecurringRun(recurringRun *dspav1.RecurringRun, pipeline *dspav1.Pipeline) *apiRecurringRun {
+	desired := &apiRecurringRun{
+		DisplayName: recurringRun.Spec.DisplayName,
+		Description: recurringRun.Spec.Description,
+		PipelineVersionReference: &apiPipelineVersionReference{
+			PipelineID:        pipeline.Status.PipelineID,
+			PipelineVersionID: pipeline.Status.PipelineVersionID,
+		},
+		MaxConcurrency: recurringRun.Spec.MaxConcurrency,
+		NoCatchup:      recurringRun.Spec.NoCatchup,
+	}
+	if desired.DisplayName == "" {
+		desired.DisplayName = recurringRun.Name
+	}
+	if desired.MaxConcurrency == 0 {
+		desired.MaxConcurrency = 1
+	}
+	desired.RuntimeConfig.Parameters = recurringRun.Spec.Parameters
+	desired.Trigger.CronSchedule.Cron = recurringRun.Spec.Cron
+	return desired
+}
+
+// recurringRunDigest digests the settings of desired, its mode is updated in
+// place and not part of it.
+func recurringRunDigest(desired *apiRecurringRun) (string, error) {
+	b, err := json.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// triggerTimes returns when the ScheduledWorkflow of recurringRun, the
+// Kubernetes resource the API server schedules its runs with, last started a
+// run and starts the next one. The API server identifies recurring runs by the
+// UID of their ScheduledWorkflow.
+func (r *RecurringRunReconciler) triggerTimes(ctx context.Context, recurringRun *dspav1.RecurringRun) (*metav1.Time, *metav1.Time, error) {
+	scheduledWorkflows := &unstructured.UnstructuredList{}
+	scheduledWorkflows.SetGroupVersionKind(scheduledWorkflowListGVK)
+	if err := r.List(ctx, scheduledWorkflows, client.InNamespace(recurringRun.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	for _, scheduledWorkflow := range scheduledWorkflows.Items {
+		if string(scheduledWorkflow.GetUID()) != recurringRun.Status.RecurringRunID {
+			continue
+		}
+		parse := func(field string) *metav1.Time {
+			value, _, _ := unstructured.NestedString(scheduledWorkflow.Object, "status", "trigger", field)
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil
+			}
+			return &metav1.Time{Time: parsed}
+		}
+		return parse("lastTriggeredTime"), parse("nextTriggeredTime"), nil
+	}
+	return nil, nil, nil
+}
+
+// recurringRunsReferencing returns the map func enqueueing the RecurringRuns
+// whose field, their DSPA or Pipeline, names the object changed.
+func (r *RecurringRunReconciler) recurringRunsReferencing(field func(*dspav1.RecurringRun) string) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		recurringRuns := &dspav1.RecurringRunList{}
+		if err := r.List(ctx, recurringRuns, client.InNamespace(o.GetNamespace())); err != nil {
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range recurringRuns.Items {
+			if field(&recurringRuns.Items[i]) == o.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&recurringRuns.Items[i])})
+			}
+		}
+		return requests
+	}
+}
+
+func (r *RecurringRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("recurringrun").
+		For(&dspav1.RecurringRun{}).
+		Watches(&dspav1.DataSciencePipelinesApplication{}, handler.EnqueueRequestsFromMapFunc(
+			r.recurringRunsReferencing(func(rr *dspav1.RecurringRun) string { return rr.Spec.DSPAName }))).
+		// Recreated once their Pipeline is synced with a new version
+		Watches(&dspav1.Pipeline{}, handler.EnqueueRequestsFromMapFunc(
+			r.recurringRunsReferencing(func(rr *dspav1.RecurringRun) string { return rr.Spec.PipelineRef }))).
+		Complete(r)
+}
@@ -0,0 +1,359 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakePipelineAPI serves the v2beta1 endpoints of the API server the Pipeline
+// and RecurringRun reconcilers call.
+type fakePipelineAPI struct {
+	mu            sync.Mutex
+	nextID        int
+	pipelines     map[string]apiPipeline
+	versions      map[string]apiPipelineVersion
+	uploads       map[string]string
+	recurringRuns map[string]apiRecurringRun
+}
+
+func newFakePipelineAPI(t *testing.T) (*fakePipelineAPI, func(context.Context, client.Client, *dspav1.DataSciencePipelinesApplication) (*pipelineAPIClient, error)) {
+	api := &fakePipelineAPI{
+		pipelines:     map[string]apiPipeline{},
+		versions:      map[string]apiPipelineVersion{},
+		uploads:       map[string]string{},
+		recurringRuns: map[string]apiRecurringRun{},
+	}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, func(context.Context, client.Client, *dspav1.DataSciencePipelinesApplication) (*pipelineAPIClient, error) {
+		return &pipelineAPIClient{baseURL: server.URL, httpClient: server.Client()}, nil
+	}
+}
+
+func (f *fakePipelineAPI) id() string {
+	f.nextID++
+	return fmt.Sprintf("id-%d", f.nextID)
+}
+
+func (f *fakePipelineAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	respond := func(v any) {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/apis/v2beta1/"), "/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/apis/v2beta1/pipelines":
+		pipeline := apiPipeline{}
+		_ = json.NewDecoder(r.Body).Decode(&pipeline)
+		pipeline.PipelineID = f.id()
+		f.pipelines[pipeline.PipelineID] = pipeline
+		respond(pipeline)
+	case r.Method == http.MethodPost && r.URL.Path == "/apis/v2beta1/pipelines/upload_version":
+		file, _, err := r.FormFile("uploadfile")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		spec, _ := io.ReadAll(file)
+		version := apiPipelineVersion{PipelineID: r.URL.Query().Get("pipelineid"), PipelineVersionID: f.id(),
+			DisplayName: r.URL.Query().Get("name")}
+		f.versions[version.PipelineVersionID] = version
+		f.uploads[version.PipelineVersionID] = string(spec)
+		respond(version)
+	case path[0] == "pipelines" && len(path) == 2:
+		if _, ok := f.pipelines[path[1]]; !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.pipelines, path[1])
+		}
+		respond(f.pipelines[path[1]])
+	case path[0] == "pipelines" && len(path) == 3:
+		var versions []apiPipelineVersion
+		for _, version := range f.versions {
+			if version.PipelineID == path[1] {
+				versions = append(versions, version)
+			}
+		}
+		respond(map[string]any{"pipeline_versions": versions})
+	case path[0] == "pipelines" && len(path) == 4:
+		if _, ok := f.versions[path[3]]; !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.versions, path[3])
+		}
+		respond(f.versions[path[3]])
+	case r.Method == http.MethodPost && r.URL.Path == "/apis/v2beta1/recurringruns":
+		recurringRun := apiRecurringRun{}
+		_ = json.NewDecoder(r.Body).Decode(&recurringRun)
+		recurringRun.RecurringRunID = f.id()
+		recurringRun.Status = "DISABLED"
+		if recurringRun.Mode == recurringRunModeEnable {
+			recurringRun.Status = recurringRunStatusEnabled
+		}
+		f.recurringRuns[recurringRun.RecurringRunID] = recurringRun
+		respond(recurringRun)
+	case path[0] == "recurringruns" && len(path) == 2:
+		id, action, _ := strings.Cut(path[1], ":")
+		recurringRun, ok := f.recurringRuns[id]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(f.recurringRuns, id)
+		case action == "enable":
+			recurringRun.Status = recurringRunStatusEnabled
+			f.recurringRuns[id] = recurringRun
+		case action == "disable":
+			recurringRun.Status = "DISABLED"
+			f.recurringRuns[id] = recurringRun
+		}
+		respond(recurringRun)
+	default:
+		http.Error(w, `{"message":"unexpected request"}`, http.StatusBadRequest)
+	}
+}
+
+// declarativePipelinesTestObjects returns a client serving the status of the
+// Pipelines and RecurringRuns, and a DSPA whose API server is ready and that
+// enables the DeclarativePipelines feature gate.
+func declarativePipelinesTestObjects(t *testing.T) (context.Context, client.Client, *dspav1.DataSciencePipelinesApplication) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	cl := fake.NewClientBuilder().WithScheme(reconciler.Scheme).
+		WithStatusSubresource(&dspav1.Pipeline{}, &dspav1.RecurringRun{}).Build()
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Annotations = map[string]string{config.FeatureGatesAnnotation: config.DeclarativePipelinesFeatureGate + "=true"}
+	require.Nil(t, cl.Create(ctx, dspa))
+	dspa.Status.Conditions = []metav1.Condition{{Type: config.APIServerReady, Status: metav1.ConditionTrue, Reason: config.MinimumReplicasAvailable}}
+	require.Nil(t, cl.Update(ctx, dspa))
+	return ctx, cl, dspa
+}
+
+func TestPipelineReconciler(t *testing.T) {
+	ctx, cl, dspa := declarativePipelinesTestObjects(t)
+	api, newPipelineAPI := newFakePipelineAPI(t)
+	reconciler := NewPipelineReconciler(cl, ctrl.Log)
+	reconciler.newPipelineAPI = newPipelineAPI
+
+	pipeline := &dspav1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "iris", Namespace: dspa.Namespace},
+		Spec:       dspav1.PipelineSpec{DSPAName: dspa.Name, PipelineSpec: "pipelineInfo:\n  name: iris\n"},
+	}
+	require.Nil(t, reconciler.Create(ctx, pipeline))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pipeline)}
+
+	// Assert the pipeline and its first version are uploaded
+	result, err := reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Equal(t, config.DefaultDeclarativePipelinesSyncPeriod, result.RequeueAfter)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	assert.Contains(t, pipeline.Finalizers, finalizerName)
+	assert.True(t, meta.IsStatusConditionTrue(pipeline.Status.Conditions, config.Synced))
+	require.Contains(t, api.pipelines, pipeline.Status.PipelineID)
+	assert.Equal(t, "iris", api.pipelines[pipeline.Status.PipelineID].DisplayName)
+	assert.Equal(t, pipeline.Spec.PipelineSpec, api.uploads[pipeline.Status.PipelineVersionID])
+	assert.Equal(t, pipelineVersionName(pipeline), pipeline.Status.VersionName)
+	firstVersionID := pipeline.Status.PipelineVersionID
+
+	// Assert an unchanged spec is not uploaded again
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	assert.Equal(t, firstVersionID, pipeline.Status.PipelineVersionID)
+	assert.Len(t, api.versions, 1)
+
+	// Assert a changed spec is uploaded as a new version
+	pipeline.Spec.PipelineSpec = "pipelineInfo:\n  name: iris-v2\n"
+	pipeline.Spec.VersionName = "v2"
+	require.Nil(t, reconciler.Update(ctx, pipeline))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	assert.NotEqual(t, firstVersionID, pipeline.Status.PipelineVersionID)
+	assert.Equal(t, "v2", pipeline.Status.VersionName)
+	assert.Equal(t, "v2", api.versions[pipeline.Status.PipelineVersionID].DisplayName)
+	assert.Len(t, api.versions, 2)
+
+	// Assert the pipeline is uploaded again once deleted from the API server
+	delete(api.pipelines, pipeline.Status.PipelineID)
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	require.Contains(t, api.pipelines, pipeline.Status.PipelineID)
+
+	// Assert the pipeline and its versions are deleted along with the resource
+	require.Nil(t, reconciler.Delete(ctx, pipeline))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Empty(t, api.pipelines)
+	for _, version := range api.versions {
+		assert.NotEqual(t, pipeline.Status.PipelineID, version.PipelineID)
+	}
+	err = reconciler.Get(ctx, request.NamespacedName, pipeline)
+	assert.True(t, apierrs.IsNotFound(err))
+}
+
+func TestPipelineReconcilerFeatureGateDisabled(t *testing.T) {
+	ctx, cl, dspa := declarativePipelinesTestObjects(t)
+	api, newPipelineAPI := newFakePipelineAPI(t)
+	reconciler := NewPipelineReconciler(cl, ctrl.Log)
+	reconciler.newPipelineAPI = newPipelineAPI
+	dspa.Annotations = nil
+	require.Nil(t, reconciler.Update(ctx, dspa))
+
+	pipeline := &dspav1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "iris", Namespace: dspa.Namespace},
+		Spec:       dspav1.PipelineSpec{DSPAName: dspa.Name, PipelineSpec: "pipelineInfo: {}"},
+	}
+	require.Nil(t, reconciler.Create(ctx, pipeline))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pipeline)}
+
+	// Assert the pipeline is not uploaded, and waits for its DSPA to enable the gate
+	result, err := reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	condition := meta.FindStatusCondition(pipeline.Status.Conditions, config.Synced)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, config.FeatureGateDisabled, condition.Reason)
+	assert.Empty(t, api.pipelines)
+	assert.NotContains(t, pipeline.Finalizers, finalizerName)
+
+	// Assert the pipeline waits for the API server of its DSPA to be ready
+	dspa.Annotations = map[string]string{config.FeatureGatesAnnotation: config.DeclarativePipelinesFeatureGate + "=true"}
+	dspa.Status.Conditions[0].Status = metav1.ConditionFalse
+	require.Nil(t, reconciler.Update(ctx, dspa))
+	result, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Equal(t, config.DefaultRequeueTime, result.RequeueAfter)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, pipeline))
+	assert.Equal(t, config.DSPANotReady, meta.FindStatusCondition(pipeline.Status.Conditions, config.Synced).Reason)
+	assert.Empty(t, api.pipelines)
+}
+
+func TestRecurringRunReconciler(t *testing.T) {
+	ctx, cl, dspa := declarativePipelinesTestObjects(t)
+	api, newPipelineAPI := newFakePipelineAPI(t)
+	pipelineReconciler := NewPipelineReconciler(cl, ctrl.Log)
+	pipelineReconciler.newPipelineAPI = newPipelineAPI
+	reconciler := NewRecurringRunReconciler(cl, ctrl.Log)
+	reconciler.newPipelineAPI = newPipelineAPI
+
+	recurringRun := &dspav1.RecurringRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: dspa.Namespace},
+		Spec: dspav1.RecurringRunSpec{
+			DSPAName:       dspa.Name,
+			PipelineRef:    "iris",
+			Cron:           "0 0 0 * * *",
+			MaxConcurrency: 1,
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, recurringRun))
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(recurringRun)}
+
+	// Assert the recurring run waits for its pipeline
+	result, err := reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Equal(t, config.DefaultRequeueTime, result.RequeueAfter)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, recurringRun))
+	assert.Equal(t, config.PipelineNotSynced, meta.FindStatusCondition(recurringRun.Status.Conditions, config.Synced).Reason)
+	assert.Empty(t, api.recurringRuns)
+
+	pipeline := &dspav1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "iris", Namespace: dspa.Namespace},
+		Spec:       dspav1.PipelineSpec{DSPAName: dspa.Name, PipelineSpec: "pipelineInfo: {}"},
+	}
+	require.Nil(t, reconciler.Create(ctx, pipeline))
+	pipelineRequest := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pipeline)}
+	_, err = pipelineReconciler.Reconcile(ctx, pipelineRequest)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, pipelineRequest.NamespacedName, pipeline))
+
+	// Assert the recurring run is created, running the version of its pipeline
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, recurringRun))
+	assert.True(t, meta.IsStatusConditionTrue(recurringRun.Status.Conditions, config.Synced))
+	assert.True(t, recurringRun.Status.Enabled)
+	assert.Contains(t, recurringRun.Finalizers, finalizerName)
+	created, ok := api.recurringRuns[recurringRun.Status.RecurringRunID]
+	require.True(t, ok)
+	assert.Equal(t, "nightly", created.DisplayName)
+	assert.Equal(t, "0 0 0 * * *", created.Trigger.CronSchedule.Cron)
+	assert.Equal(t, pipeline.Status.PipelineVersionID, created.PipelineVersionReference.PipelineVersionID)
+	assert.Equal(t, recurringRunStatusEnabled, created.Status)
+	firstID := recurringRun.Status.RecurringRunID
+
+	// Assert disabling the recurring run keeps it in the API server
+	recurringRun.Spec.Enabled = boolPtr(false)
+	require.Nil(t, reconciler.Update(ctx, recurringRun))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, recurringRun))
+	assert.Equal(t, firstID, recurringRun.Status.RecurringRunID)
+	assert.False(t, recurringRun.Status.Enabled)
+	assert.Equal(t, "DISABLED", api.recurringRuns[firstID].Status)
+
+	// Assert the recurring run is recreated once its schedule changes
+	recurringRun.Spec.Cron = "0 0 12 * * *"
+	require.Nil(t, reconciler.Update(ctx, recurringRun))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	require.Nil(t, reconciler.Get(ctx, request.NamespacedName, recurringRun))
+	assert.NotEqual(t, firstID, recurringRun.Status.RecurringRunID)
+	assert.NotContains(t, api.recurringRuns, firstID)
+	assert.Equal(t, "0 0 12 * * *", api.recurringRuns[recurringRun.Status.RecurringRunID].Trigger.CronSchedule.Cron)
+	assert.Equal(t, "DISABLED", api.recurringRuns[recurringRun.Status.RecurringRunID].Status)
+
+	// Assert the recurring run is deleted along with the resource
+	require.Nil(t, reconciler.Delete(ctx, recurringRun))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	assert.Empty(t, api.recurringRuns)
+	err = reconciler.Get(ctx, types.NamespacedName{Name: recurringRun.Name, Namespace: recurringRun.Namespace}, recurringRun)
+	assert.True(t, apierrs.IsNotFound(err))
+}
@@ -0,0 +1,268 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	diffTemplate                  = "diff/configmap.yaml.tmpl"
+	diffDefaultResourceNamePrefix = "ds-pipeline-diff-"
+	// diffMaxValueLength truncates the values of the report, e.g. of config files
+	diffMaxValueLength = 120
+
+	diffActionCreate = "create"
+	diffActionUpdate = "update"
+)
+
+// ManifestDiff is the report of the diff ConfigMap
+type ManifestDiff struct {
+	Summary string
+	Diff    string
+}
+
+// resourceChange is a rendered resource that differs from its live resource,
+// with the paths of its changed fields.
+type resourceChange struct {
+	action string
+	id     string
+	fields []string
+}
+
+// ReconcileDiff publishes the changes the operator would apply to the live
+// resources of dsp in the ds-pipeline-diff-<name> ConfigMap and the status,
+// while dsp is annotated with config.DiffAnnotation, e.g. for GitOps reviewers
+// to see what a spec change does while the DSPA is Unmanaged. The ConfigMap is
+// deleted once the annotation is removed.
+func (r *DSPAReconciler) ReconcileDiff(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+	name := diffDefaultResourceNamePrefix + dsp.Name
+
+	if _, ok := dsp.Annotations[config.DiffAnnotation]; !ok {
+		return r.DeleteResourceIfItExists(ctx, &corev1.ConfigMap{}, types.NamespacedName{Name: name, Namespace: dsp.Namespace})
+	}
+
+	changes, unchanged, err := r.DiffManifests(ctx, dsp, params)
+	if err != nil {
+		return err
+	}
+	pending := &dspav1.PendingChanges{ConfigMap: name, Unchanged: int32(unchanged)}
+	var diff strings.Builder
+	for _, change := range changes {
+		if change.action == diffActionCreate {
+			pending.Create++
+		} else {
+			pending.Update++
+		}
+		fmt.Fprintf(&diff, "%s %s\n", change.action, change.id)
+		for _, field := range change.fields {
+			fmt.Fprintf(&diff, "  %s\n", field)
+		}
+	}
+
+	params.PendingChanges = &ManifestDiff{
+		Summary: fmt.Sprintf("%d to create, %d to update, %d unchanged", pending.Create, pending.Update, pending.Unchanged),
+		Diff:    diff.String(),
+	}
+	log.Info("Applying Diff ConfigMap: " + params.PendingChanges.Summary)
+	if err := r.Apply(dsp, params, diffTemplate); err != nil {
+		return err
+	}
+	dspaStatus.SetPendingChanges(pending)
+	return nil
+}
+
+// DiffManifests compares the manifests rendered for dsp with its live
+// resources. It returns the resources to create or update, sorted by kind and
+// name, and the number of unchanged resources. Only the fields set in the
+// manifests are compared, so that the fields defaulted by the API server or
+// set by other controllers are not reported.
+func (r *DSPAReconciler) DiffManifests(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) ([]resourceChange, int, error) {
+	// Resolve the endpoints as they would be applied, rather than left empty
+	if params.ConnectionInfo == nil {
+		info, err := r.resolveConnectionInfo(ctx, dsp, params)
+		if err != nil {
+			return nil, 0, err
+		}
+		withConnectionInfo := *params
+		withConnectionInfo.ConnectionInfo = info
+		params = &withConnectionInfo
+	}
+	resources, err := RenderAll(r.Templates, dsp, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var changes []resourceChange
+	unchanged := 0
+	for _, desired := range resources {
+		id := desired.GetKind() + "/" + desired.GetName()
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(desired.GroupVersionKind())
+		err := r.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, live)
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			changes = append(changes, resourceChange{action: diffActionCreate, id: id})
+			continue
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		// The metadata is owned by the API server, but for the labels and annotations
+		desiredFields := map[string]interface{}{}
+		for key, value := range desired.Object {
+			if key != "metadata" && key != "status" {
+				desiredFields[key] = value
+			}
+		}
+		desiredFields["metadata"] = map[string]interface{}{
+			"labels":      toInterfaceMap(desired.GetLabels()),
+			"annotations": toInterfaceMap(desired.GetAnnotations()),
+		}
+		// Credentials are not disclosed in the report
+		redact := desired.GetKind() == "Secret"
+		fields := diffFields("", desiredFields, live.Object, redact)
+		if len(fields) == 0 {
+			unchanged++
+			continue
+		}
+		changes = append(changes, resourceChange{action: diffActionUpdate, id: id, fields: fields})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].id < changes[j].id })
+	return changes, unchanged, nil
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
+
+// diffFields returns the paths of the fields of desired that differ in live,
+// as "<path>: <live> -> <desired>", without the values when redact is set.
+// Lists are compared element by element when their lengths match, and as a
+// whole otherwise. Zero values match unset fields, as the API server omits them.
+func diffFields(path string, desired, live interface{}, redact bool) []string {
+	if isZeroValue(desired) && isZeroValue(live) {
+		return nil
+	}
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if ok {
+			var fields []string
+			keys := make([]string, 0, len(desiredValue))
+			for key := range desiredValue {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fields = append(fields, diffFields(joinFieldPath(path, key), desiredValue[key], liveValue[key], redact)...)
+			}
+			return fields
+		}
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if ok && len(liveValue) == len(desiredValue) {
+			var fields []string
+			for i := range desiredValue {
+				fields = append(fields, diffFields(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], liveValue[i], redact)...)
+			}
+			return fields
+		}
+	default:
+		if equalJSON(desired, live) || equalQuantity(desired, live) {
+			return nil
+		}
+	}
+	if redact {
+		return []string{path + ": changed"}
+	}
+	return []string{fmt.Sprintf("%s: %s -> %s", path, diffValue(live), diffValue(desired))}
+}
+
+func joinFieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	return path + "." + key
+}
+
+// isZeroValue reports whether value is unset or the zero value of its type
+func isZeroValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// equalJSON compares scalars by their JSON encoding, so that numbers rendered
+// as floats match the integers of the live resources
+// equalQuantity reports whether a and b are the same quantity, as the API
+// server normalizes the quantities and int-or-strings it stores, e.g. a
+// rendered cpu limit of 1 is read back as "1".
+func equalQuantity(a, b interface{}) bool {
+	aQuantity, errA := resource.ParseQuantity(fmt.Sprint(a))
+	bQuantity, errB := resource.ParseQuantity(fmt.Sprint(b))
+	return errA == nil && errB == nil && aQuantity.Cmp(bQuantity) == 0
+}
+
+func equalJSON(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
+
+func diffValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if len(encoded) > diffMaxValueLength {
+		return string(encoded[:diffMaxValueLength]) + "..."
+	}
+	return string(encoded)
+}
@@ -0,0 +1,110 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileDiff(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Annotations = map[string]string{config.DiffAnnotation: ""}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	diffConfigMap := func() *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: "ds-pipeline-diff-testdspa", Namespace: dspa.Namespace}, cm))
+		return cm
+	}
+
+	// Assert resources that do not exist yet are reported as created
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	require.Nil(t, reconciler.ReconcileDiff(ctx, dspa, params, dspaStatus))
+	pending := dspaStatus.GetPendingChanges()
+	require.NotNil(t, pending)
+	assert.Equal(t, "ds-pipeline-diff-testdspa", pending.ConfigMap)
+	assert.Zero(t, pending.Update)
+	assert.Zero(t, pending.Unchanged)
+	assert.Contains(t, diffConfigMap().Data["diff"], "create Deployment/ds-pipeline-testdspa\n")
+
+	// Assert applied resources are unchanged
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	changes, _, err := reconciler.DiffManifests(ctx, dspa, params)
+	require.Nil(t, err)
+	for _, change := range changes {
+		assert.NotEqual(t, "Deployment/ds-pipeline-testdspa", change.id)
+		assert.NotEqual(t, "Deployment/mariadb-testdspa", change.id)
+	}
+
+	// Assert spec changes are reported with their fields, without disclosing credentials
+	dspa.Spec.APIServer.Image = "quay.io/opendatahub/ds-pipelines-api-server:changed"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	secret := &corev1.Secret{}
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: params.DBConnection.CredentialsSecret.Name, Namespace: dspa.Namespace}, secret))
+	secret.Data["password"] = []byte("changed-outside-the-operator")
+	require.Nil(t, reconciler.Update(ctx, secret))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	params.DBConnection.Password = NewCredential([]byte("rendered-password"))
+
+	dspaStatus = dspastatus.NewDSPAStatus(dspa)
+	require.Nil(t, reconciler.ReconcileDiff(ctx, dspa, params, dspaStatus))
+	diff := diffConfigMap().Data["diff"]
+	assert.Contains(t, diff, "update Deployment/ds-pipeline-testdspa\n")
+	assert.Contains(t, diff, `-> "quay.io/opendatahub/ds-pipelines-api-server:changed"`)
+	assert.Contains(t, diff, "update Secret/"+secret.Name+"\n  data.password: changed\n")
+	assert.NotContains(t, diff, "rendered-password")
+	assert.GreaterOrEqual(t, dspaStatus.GetPendingChanges().Update, int32(2))
+
+	// Assert the report is deleted along with the annotation
+	dspa.Annotations = nil
+	require.Nil(t, reconciler.ReconcileDiff(ctx, dspa, params, dspastatus.NewDSPAStatus(dspa)))
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, "ds-pipeline-diff-testdspa", dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestDiffFields(t *testing.T) {
+	desired := map[string]interface{}{
+		"replicas": int64(2),
+		"labels":   map[string]interface{}{"app": "x", "app.kubernetes.io/name": "x"},
+		"args":     []interface{}{"--a", "--b"},
+		"optional": "",
+	}
+	live := map[string]interface{}{
+		"replicas":  float64(1),
+		"labels":    map[string]interface{}{"app": "x", "app.kubernetes.io/name": "y"},
+		"args":      []interface{}{"--a"},
+		"defaulted": "by the API server",
+	}
+	assert.Equal(t, []string{
+		`args: ["--a"] -> ["--a","--b"]`,
+		`labels["app.kubernetes.io/name"]: "y" -> "x"`,
+		`replicas: 1 -> 2`,
+	}, diffFields("", desired, live, false))
+	assert.Equal(t, []string{"replicas: changed"}, diffFields("", map[string]interface{}{"replicas": int64(2)}, live, true))
+	assert.Empty(t, diffFields("", map[string]interface{}{"replicas": int64(1)}, live, false))
+}
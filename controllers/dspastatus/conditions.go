@@ -0,0 +1,105 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dspastatus
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// reasonPattern is the format the API server requires of condition reasons,
+// a status update with any other reason is rejected as a whole.
+var reasonPattern = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+// componentReasons are the reasons of the readiness conditions of the
+// deployed components.
+var componentReasons = []string{
+	config.MinimumReplicasAvailable, config.FailingToDeploy, config.Deploying,
+	config.ComponentDeploymentNotFound, config.UnsupportedVersion, config.ComponentsRemoved,
+}
+
+// conditionReasons are the reasons each condition type of the DSPA is
+// reported with, besides config.UnknownReason and the condition type itself,
+// the reason of True conditions built with BuildTrueCondition.
+var conditionReasons = map[string][]string{
+	config.DatabaseAvailable: {
+		config.FailingToDeploy, config.UnsupportedVersion, config.ComponentsRemoved, config.ExternalSecretNotReady,
+		config.ExternalDBAuthFailed, config.DatabaseAuthFailed, config.DatabaseTLSFailed,
+		config.DatabaseHostNotFound, config.DatabaseUnreachable,
+	},
+	config.ObjectStoreAvailable: {
+		config.FailingToDeploy, config.UnsupportedVersion, config.ComponentsRemoved,
+		config.AccessDenied, config.BucketNotFound, config.BucketCreationFailed,
+	},
+	config.APIServerReady:         componentReasons,
+	config.PersistenceAgentReady:  componentReasons,
+	config.ScheduledWorkflowReady: componentReasons,
+	config.MLMDProxyReady:         componentReasons,
+	config.CRDViewerReady:         componentReasons,
+	config.CrReady: {
+		config.MinimumReplicasAvailable, config.FailingToDeploy, config.ComponentsRemoved, config.UnsupportedVersion,
+		config.NamespaceNotAllowed, config.MultipleInstancesNotAllowed, config.ImageNotPinned,
+		config.ImageSignatureNotVerified, config.InvalidAPIServerArgs, config.InvalidTimezone,
+		config.InvalidFeatureGates, config.SpecChangeUnsupported, config.QuotaInsufficient,
+		config.CertificatesNotReady, config.ResourceConflict,
+	},
+	config.Degraded:              {config.VersionCompatible, config.IncompatibleFields},
+	config.SpecChangeUnsupported: {config.StorageChanged},
+	config.BackupVerified: {
+		config.BackupVerificationPending, config.BackupVerificationSucceeded, config.BackupVerificationFailed,
+	},
+	config.UpgradeProgressing: {
+		config.UpgradeSnapshottingDatabase, config.UpgradeMigratingToV2, config.UpgradeCompleted,
+		config.UpgradeRollingBack, config.UpgradeRolledBack, config.UpgradeFailed,
+	},
+}
+
+// ValidReason reports whether reason is one of the reasons of conditionType,
+// and is accepted by the API server. Condition types of the DSPA that are not
+// listed only need a reason the API server accepts.
+func ValidReason(conditionType, reason string) bool {
+	if len(reason) > 1024 || !reasonPattern.MatchString(reason) {
+		return false
+	}
+	reasons, ok := conditionReasons[conditionType]
+	return !ok || reason == conditionType || reason == config.UnknownReason || slices.Contains(reasons, reason)
+}
+
+// MergeConditions returns the desired conditions, in their order, set with
+// meta.SetStatusCondition onto the existing conditions of the same type. The
+// LastTransitionTime of a condition only moves when its status changes, so
+// that setting identical conditions leaves the conditions unchanged. Existing
+// conditions that are no longer desired are dropped, and conditions without a
+// valid reason for their type are reported with their type as reason, as
+// BuildTrueCondition does, rather than failing the status update.
+func MergeConditions(existing, desired []metav1.Condition, observedGeneration int64) []metav1.Condition {
+	merged := make([]metav1.Condition, 0, len(desired))
+	for _, condition := range desired {
+		if current := meta.FindStatusCondition(existing, condition.Type); current != nil {
+			merged = append(merged, *current)
+		}
+		if !ValidReason(condition.Type, condition.Reason) {
+			condition.Reason = condition.Type
+		}
+		condition.ObservedGeneration = observedGeneration
+		meta.SetStatusCondition(&merged, condition)
+	}
+	return merged
+}
@@ -0,0 +1,124 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dspastatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeConditions(t *testing.T) {
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	existing := []metav1.Condition{
+		{Type: config.DatabaseAvailable, Status: metav1.ConditionTrue, Reason: config.DatabaseAvailable, LastTransitionTime: earlier},
+		{Type: config.ObjectStoreAvailable, Status: metav1.ConditionTrue, Reason: config.ObjectStoreAvailable, LastTransitionTime: earlier},
+		{Type: "Removed", Status: metav1.ConditionTrue, Reason: "Removed", LastTransitionTime: earlier},
+	}
+	desired := []metav1.Condition{
+		BuildFalseCondition(config.ObjectStoreAvailable, config.BucketNotFound, "bucket not found"),
+		BuildTrueCondition(config.DatabaseAvailable, "Database connectivity successfully verified"),
+		{Type: config.APIServerReady, Status: metav1.ConditionUnknown},
+		BuildFalseCondition(config.PersistenceAgentReady, "Not Valid", "invalid reason"),
+	}
+
+	merged := MergeConditions(existing, desired, 3)
+	// Assert the desired conditions are reported in order, dropping the others
+	require.Len(t, merged, 4)
+	assert.Equal(t, config.ObjectStoreAvailable, merged[0].Type)
+	assert.Equal(t, config.DatabaseAvailable, merged[1].Type)
+	assert.Equal(t, config.APIServerReady, merged[2].Type)
+	assert.Nil(t, meta.FindStatusCondition(merged, "Removed"))
+
+	// Assert the transition time only moves when the status changes
+	assert.Equal(t, earlier, merged[1].LastTransitionTime)
+	assert.Equal(t, "Database connectivity successfully verified", merged[1].Message)
+	assert.True(t, merged[0].LastTransitionTime.After(earlier.Time))
+	assert.Equal(t, config.BucketNotFound, merged[0].Reason)
+	assert.False(t, merged[2].LastTransitionTime.IsZero())
+
+	// Assert missing and invalid reasons are replaced and the generation is observed
+	assert.Equal(t, config.APIServerReady, merged[2].Reason)
+	assert.Equal(t, config.PersistenceAgentReady, merged[3].Reason)
+	for _, condition := range merged {
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+	}
+
+	// Assert merging the same conditions again changes nothing
+	again := MergeConditions(merged, desired, 3)
+	assert.True(t, equality.Semantic.DeepEqual(merged, again))
+}
+
+func TestGetConditionsStable(t *testing.T) {
+	dspa := &dspav1.DataSciencePipelinesApplication{}
+	dspa.Generation = 2
+	newStatus := func() DSPAStatus {
+		status := NewDSPAStatus(dspa)
+		status.SetDatabaseReady()
+		status.SetObjStoreNotReady(errors.New("bucket not found"), config.BucketNotFound)
+		status.SetCRDViewerStatus(BuildTrueCondition(config.CRDViewerReady, "ready"))
+		return status
+	}
+
+	dspa.Status.Conditions = newStatus().GetConditions()
+	reported := dspa.Status.DeepCopy()
+	time.Sleep(10 * time.Millisecond)
+
+	// Assert an identical reconcile reports an identical status, so it is not written
+	dspa.Status.Conditions = newStatus().GetConditions()
+	assert.True(t, equality.Semantic.DeepEqual(reported, &dspa.Status))
+
+	// Assert a condition dropped between reconciles does not shift the others
+	status := newStatus()
+	status.SetUpgradeStatus(BuildTrueCondition(config.UpgradeProgressing, "upgrading"))
+	conditions := status.GetConditions()
+	for _, condition := range reported.Conditions {
+		current := meta.FindStatusCondition(conditions, condition.Type)
+		require.NotNil(t, current)
+		assert.Equal(t, condition.LastTransitionTime, current.LastTransitionTime, condition.Type)
+	}
+}
+
+func TestConditionReasons(t *testing.T) {
+	// Assert every reason is accepted by the API server
+	for conditionType, reasons := range conditionReasons {
+		for _, reason := range reasons {
+			assert.True(t, ValidReason(conditionType, reason), "%s: %s", conditionType, reason)
+		}
+	}
+	for _, condition := range NewDSPAStatus(&dspav1.DataSciencePipelinesApplication{}).GetConditions() {
+		assert.True(t, ValidReason(condition.Type, condition.Reason), condition.Type)
+	}
+	assert.True(t, ValidReason(config.DatabaseAvailable, config.DatabaseAvailable))
+
+	// Assert the reasons of other condition types are rejected
+	assert.False(t, ValidReason(config.DatabaseAvailable, config.BucketNotFound))
+	assert.False(t, ValidReason(config.CrReady, config.StorageChanged))
+	assert.False(t, ValidReason(config.APIServerReady, ""))
+	assert.False(t, ValidReason(config.APIServerReady, "Not Valid"))
+	assert.False(t, ValidReason("Other", "Not Valid"))
+	assert.True(t, ValidReason("Other", "Other"))
+}
@@ -25,7 +25,49 @@ type DSPAStatus interface {
 
 	SetDSPANotReady(err error, reason string)
 
+	SetResolvedImageDigests(digests map[string]string)
+
+	SetFIPSEnabled(enabled bool)
+
+	SetRunUsage(usage *dspav1.RunUsage)
+
+	SetPendingChanges(changes *dspav1.PendingChanges)
+
+	SetComponentImages(images map[string]dspav1.ComponentDetailStatus)
+
+	SetDegraded(err error, reason string)
+
+	SetUpgradeStatus(upgradeProgressing metav1.Condition)
+
+	SetCRDViewerStatus(crdViewerReady metav1.Condition)
+
+	SetBackupVerifiedStatus(backupVerified metav1.Condition)
+
+	SetSpecChangeUnsupported(message string)
+
+	SetStorageBinding(storage *dspav1.StorageBinding)
+
+	SetComponentNamespaces(namespaces []string)
+
+	SetFeatureGates(gates []string)
+
 	GetConditions() []metav1.Condition
+
+	GetResolvedImageDigests() map[string]string
+
+	GetFIPSEnabled() *bool
+
+	GetRunUsage() *dspav1.RunUsage
+
+	GetPendingChanges() *dspav1.PendingChanges
+
+	GetComponentImages() map[string]dspav1.ComponentDetailStatus
+
+	GetComponentNamespaces() []string
+
+	GetFeatureGates() []string
+
+	GetStorageBinding() *dspav1.StorageBinding
 }
 
 func NewDSPAStatus(dspa *dspav1.DataSciencePipelinesApplication) DSPAStatus {
@@ -44,6 +86,10 @@ func NewDSPAStatus(dspa *dspav1.DataSciencePipelinesApplication) DSPAStatus {
 		persistenceAgentReady:  &persistenceAgentCondition,
 		scheduledWorkflowReady: &scheduledWorkflowReadyCondition,
 		mlmdProxyReady:         &mlmdProxyReadyCondition,
+		// Kept when the reconcile stops before the components are deployed
+		componentNamespaces: dspa.Status.ComponentNamespaces,
+		featureGates:        dspa.Status.FeatureGates,
+		storageBinding:      dspa.Status.Storage,
 	}
 }
 
@@ -56,6 +102,19 @@ type dspaStatus struct {
 	scheduledWorkflowReady *metav1.Condition
 	mlmdProxyReady         *metav1.Condition
 	dspaReady              *metav1.Condition
+	resolvedImageDigests   map[string]string
+	fipsEnabled            *bool
+	runUsage               *dspav1.RunUsage
+	pendingChanges         *dspav1.PendingChanges
+	componentImages        map[string]dspav1.ComponentDetailStatus
+	degraded               *metav1.Condition
+	upgradeProgressing     *metav1.Condition
+	crdViewerReady         *metav1.Condition
+	backupVerified         *metav1.Condition
+	specChangeUnsupported  *metav1.Condition
+	componentNamespaces    []string
+	featureGates           []string
+	storageBinding         *dspav1.StorageBinding
 }
 
 func (s *dspaStatus) SetDatabaseNotReady(err error, reason string) {
@@ -117,6 +176,103 @@ func (s *dspaStatus) SetDSPANotReady(err error, reason string) {
 	s.dspaReady = &condition
 }
 
+func (s *dspaStatus) SetResolvedImageDigests(digests map[string]string) {
+	s.resolvedImageDigests = digests
+}
+
+func (s *dspaStatus) GetResolvedImageDigests() map[string]string {
+	return s.resolvedImageDigests
+}
+
+func (s *dspaStatus) SetFIPSEnabled(enabled bool) {
+	s.fipsEnabled = &enabled
+}
+
+func (s *dspaStatus) GetFIPSEnabled() *bool {
+	return s.fipsEnabled
+}
+
+func (s *dspaStatus) SetRunUsage(usage *dspav1.RunUsage) {
+	s.runUsage = usage
+}
+
+func (s *dspaStatus) GetRunUsage() *dspav1.RunUsage {
+	return s.runUsage
+}
+
+func (s *dspaStatus) SetPendingChanges(changes *dspav1.PendingChanges) {
+	s.pendingChanges = changes
+}
+
+func (s *dspaStatus) GetPendingChanges() *dspav1.PendingChanges {
+	return s.pendingChanges
+}
+
+func (s *dspaStatus) SetComponentImages(images map[string]dspav1.ComponentDetailStatus) {
+	s.componentImages = images
+}
+
+func (s *dspaStatus) SetDegraded(err error, reason string) {
+	condition := BuildTrueCondition(config.Degraded, err.Error())
+	condition.Reason = reason
+	s.degraded = &condition
+}
+
+// SetUpgradeStatus reports the progress of a DSP v1 to v2 upgrade, the
+// condition is omitted for DSPAs that were never upgraded.
+func (s *dspaStatus) SetUpgradeStatus(upgradeProgressing metav1.Condition) {
+	s.upgradeProgressing = &upgradeProgressing
+}
+
+// SetCRDViewerStatus reports the readiness of the Viewer CRD controller, the
+// condition is omitted for DSPAs that do not deploy it.
+func (s *dspaStatus) SetCRDViewerStatus(crdViewerReady metav1.Condition) {
+	s.crdViewerReady = &crdViewerReady
+}
+
+// SetBackupVerifiedStatus reports the outcome of the latest database backup
+// verification, the condition is omitted for DSPAs that do not verify their
+// backups.
+func (s *dspaStatus) SetBackupVerifiedStatus(backupVerified metav1.Condition) {
+	s.backupVerified = &backupVerified
+}
+
+// SetSpecChangeUnsupported reports the changes of the spec that are not
+// applied, the condition is omitted while there are none.
+func (s *dspaStatus) SetSpecChangeUnsupported(message string) {
+	condition := BuildTrueCondition(config.SpecChangeUnsupported, message)
+	condition.Reason = config.StorageChanged
+	s.specChangeUnsupported = &condition
+}
+
+func (s *dspaStatus) SetStorageBinding(storage *dspav1.StorageBinding) {
+	s.storageBinding = storage
+}
+
+func (s *dspaStatus) GetStorageBinding() *dspav1.StorageBinding {
+	return s.storageBinding
+}
+
+func (s *dspaStatus) SetComponentNamespaces(namespaces []string) {
+	s.componentNamespaces = namespaces
+}
+
+func (s *dspaStatus) GetComponentNamespaces() []string {
+	return s.componentNamespaces
+}
+
+func (s *dspaStatus) SetFeatureGates(gates []string) {
+	s.featureGates = gates
+}
+
+func (s *dspaStatus) GetFeatureGates() []string {
+	return s.featureGates
+}
+
+func (s *dspaStatus) GetComponentImages() map[string]dspav1.ComponentDetailStatus {
+	return s.componentImages
+}
+
 func (s *dspaStatus) GetConditions() []metav1.Condition {
 	componentConditions := []metav1.Condition{
 		*s.getDatabaseAvailableCondition(),
@@ -126,6 +282,9 @@ func (s *dspaStatus) GetConditions() []metav1.Condition {
 		*s.getScheduledWorkflowReadyCondition(),
 		*s.getMLMDProxyReadyCondition(),
 	}
+	if s.crdViewerReady != nil {
+		componentConditions = append(componentConditions, *s.crdViewerReady)
+	}
 
 	allReady := true
 	failureMessages := ""
@@ -169,16 +328,22 @@ func (s *dspaStatus) GetConditions() []metav1.Condition {
 		*s.scheduledWorkflowReady,
 		*s.mlmdProxyReady,
 		*crReady,
+		*s.getDegradedCondition(),
 	}
-
-	for i, condition := range s.dspa.Status.Conditions {
-		if condition.Status == conditions[i].Status {
-			conditions[i].LastTransitionTime = condition.LastTransitionTime
-		}
-		condition.ObservedGeneration = s.dspa.Generation
+	if s.crdViewerReady != nil {
+		conditions = append(conditions, *s.crdViewerReady)
+	}
+	if s.backupVerified != nil {
+		conditions = append(conditions, *s.backupVerified)
+	}
+	if s.specChangeUnsupported != nil {
+		conditions = append(conditions, *s.specChangeUnsupported)
+	}
+	if s.upgradeProgressing != nil {
+		conditions = append(conditions, *s.upgradeProgressing)
 	}
 
-	return conditions
+	return MergeConditions(s.dspa.Status.Conditions, conditions, s.dspa.Generation)
 }
 
 func (s *dspaStatus) getDatabaseAvailableCondition() *metav1.Condition {
@@ -205,6 +370,16 @@ func (s *dspaStatus) getMLMDProxyReadyCondition() *metav1.Condition {
 	return s.mlmdProxyReady
 }
 
+// getDegradedCondition returns the Degraded condition, False unless the DSPA
+// was found degraded.
+func (s *dspaStatus) getDegradedCondition() *metav1.Condition {
+	if s.degraded == nil {
+		condition := BuildFalseCondition(config.Degraded, config.VersionCompatible, "All fields are supported by the DSP version")
+		return &condition
+	}
+	return s.degraded
+}
+
 func BuildTrueCondition(conditionType string, message string) metav1.Condition {
 	condition := metav1.Condition{}
 	condition.Type = conditionType
@@ -231,7 +406,7 @@ func BuildUnknownCondition(conditionType string) metav1.Condition {
 	condition := metav1.Condition{}
 	condition.Type = conditionType
 	condition.Status = metav1.ConditionUnknown
-	condition.Reason = "Unknown"
+	condition.Reason = config.UnknownReason
 	condition.LastTransitionTime = metav1.Now()
 
 	return condition
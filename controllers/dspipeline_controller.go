@@ -18,7 +18,12 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,16 +36,29 @@ import (
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -50,17 +68,58 @@ const (
 	errorUpdatingDspaStatusMsg = "Encountered error when updating the DSPA status"
 )
 
+// dspaLogger returns log annotated with the fields used to correlate every
+// log line emitted while reconciling a single DSPA.
+func dspaLogger(log logr.Logger, namespace, name, reconcileID string) logr.Logger {
+	log = log.WithValues("dspa_namespace", namespace, "dspa_name", name)
+	if reconcileID != "" {
+		log = log.WithValues("reconcile_id", reconcileID)
+	}
+	return log
+}
+
+// namespaceSharedResources are resources KFP components look up by a fixed
+// name, as such they can only be owned by a single DSPA per namespace.
+var namespaceSharedResources = map[string]bool{
+	"Service/ml-pipeline":               true,
+	"Service/metadata-grpc-service":     true,
+	"ConfigMap/kfp-launcher":            true,
+	"ConfigMap/metadata-grpc-configmap": true,
+}
+
 // DSPAReconciler reconciles a DSPAParams object
 type DSPAReconciler struct {
 	client.Client
 	Scheme                  *runtime.Scheme
 	Log                     logr.Logger
-	TemplatesPath           string
+	Templates               fs.FS
 	MaxConcurrentReconciles int
+	Recorder                record.EventRecorder
+	// Notifier is notified when DSPAs become unready and once they recover
+	Notifier Notifier
+	// degradedDSPAs are the DSPAs notified as unready
+	degradedDSPAs sync.Map
+	// conflictsMu guards the ResourceConflicts of the params of components
+	// reconciled concurrently
+	conflictsMu sync.Mutex
+	// appliedManifests are the hashes of the manifests last applied, so that
+	// unchanged templates are not applied again
+	appliedManifests appliedManifests
+	// ConfigReloader re-reconciles the DSPAs once the operator config changes,
+	// reconcilers built without one (e.g. in unit tests) skip it
+	ConfigReloader *ConfigReloader
+}
+
+// recordEvent emits an event on the DSPA, reconcilers built without a
+// Recorder (e.g. in unit tests) skip it.
+func (r *DSPAReconciler) recordEvent(dspa *dspav1.DataSciencePipelinesApplication, eventType, reason, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(dspa, eventType, reason, message)
+	}
 }
 
 func (r *DSPAReconciler) ApplyDir(owner mf.Owner, params *DSPAParams, directory string, fns ...mf.Transformer) error {
-	templates, err := util.GetTemplatesInDir(r.TemplatesPath, directory)
+	templates, err := util.GetTemplatesInDir(r.Templates, directory)
 	if err != nil {
 		return err
 	}
@@ -78,17 +137,28 @@ func (r *DSPAReconciler) ApplyAll(owner mf.Owner, params *DSPAParams, templates
 }
 
 func (r *DSPAReconciler) Apply(owner mf.Owner, params *DSPAParams, template string, fns ...mf.Transformer) error {
-	tmplManifest, err := config.Manifest(r.Client, r.TemplatesPath+template, params)
+	err := r.applyTemplate(owner, params, template, fns...)
+	if err != nil {
+		TemplateApplyFailuresMetric.WithLabelValues(templateGroup(template)).Inc()
+	}
+	return err
+}
+
+func (r *DSPAReconciler) applyTemplate(owner mf.Owner, params *DSPAParams, template string, fns ...mf.Transformer) error {
+	renderStart := time.Now()
+	tmplManifest, err := config.Manifest(r.Client, r.Templates, template, params)
 	if err != nil {
 		return fmt.Errorf("error loading template (%s) yaml: %w", template, err)
 	}
 
 	// Apply the owner injection transformation
 	tmplManifest, err = tmplManifest.Transform(
-		mf.InjectOwner(owner),
+		injectOwner(owner),
 		// Apply dsp-version=<ver> label on all resources managed by this dspo
 		util.AddLabelTransformer(config.DSPVersionk8sLabel, params.DSPVersion),
 		util.AddDeploymentPodLabelTransformer(config.DSPVersionk8sLabel, params.DSPVersion),
+		resourceLabelsTransformer(owner, template),
+		nodeArchitectureTransformer(params.imageArchitectures),
 	)
 	if err != nil {
 		return err
@@ -99,13 +169,107 @@ func (r *DSPAReconciler) Apply(owner mf.Owner, params *DSPAParams, template stri
 	if err != nil {
 		return err
 	}
+	TemplateRenderDurationMetric.WithLabelValues(templateGroup(template)).Observe(time.Since(renderStart).Seconds())
+
+	tmplManifest, err = r.filterNamespaceSharedConflicts(tmplManifest, owner, params)
+	if err != nil {
+		return err
+	}
+
+	// Skip templates applied with the same manifests, see appliedManifests
+	resyncPeriod := config.GetDurationConfigWithDefault(config.SelectiveApplyResyncPeriodConfigName, config.DefaultSelectiveApplyResyncPeriod)
+	if resyncPeriod <= 0 || !params.FeatureEnabled(config.SelectiveApplyFeatureGate) {
+		return applyManifest(template, tmplManifest)
+	}
+	hash, err := manifestHash(tmplManifest)
+	if err != nil {
+		return err
+	}
+	// Templates applied in the tenant namespaces of spec.multiTenancy are
+	// recorded per namespace
+	appliedTemplate := template
+	if params.Namespace != owner.GetNamespace() {
+		appliedTemplate = params.Namespace + "/" + template
+	}
+	if r.appliedManifests.upToDate(owner, appliedTemplate, hash, params.ReconcileID, resyncPeriod) {
+		return nil
+	}
 
 	// Apply the manifest
-	return tmplManifest.Apply()
+	if err := applyManifest(template, tmplManifest); err != nil {
+		return err
+	}
+	r.appliedManifests.record(owner, appliedTemplate, hash, params.ReconcileID)
+	return nil
+}
+
+// applyManifest applies the manifest rendered from template, retrying on
+// conflicts with concurrent updates of its resources, as every attempt reads
+// their latest version. The apply durations, conflicts and retries are
+// recorded in the template metrics.
+func applyManifest(template string, manifest mf.Manifest) error {
+	group := templateGroup(template)
+	start := time.Now()
+	defer func() {
+		TemplateApplyDurationMetric.WithLabelValues(group).Observe(time.Since(start).Seconds())
+	}()
+
+	attempts := 0
+	return retry.OnError(retry.DefaultRetry, apierrs.IsConflict, func() error {
+		if attempts > 0 {
+			TemplateApplyRetriesMetric.WithLabelValues(group).Inc()
+		}
+		attempts++
+		err := manifest.Apply()
+		if apierrs.IsConflict(err) {
+			TemplateApplyConflictsMetric.WithLabelValues(group).Inc()
+		}
+		return err
+	})
+}
+
+// filterNamespaceSharedConflicts drops resources in namespaceSharedResources
+// from manifest if they are already owned by another DSPA, so that DSPAs
+// sharing a namespace do not take over each other's resources. Dropped
+// resources are recorded in params.ResourceConflicts.
+func (r *DSPAReconciler) filterNamespaceSharedConflicts(manifest mf.Manifest, owner mf.Owner, params *DSPAParams) (mf.Manifest, error) {
+	conflicting := map[string]bool{}
+	for _, resource := range manifest.Resources() {
+		id := resource.GetKind() + "/" + resource.GetName()
+		if !namespaceSharedResources[id] {
+			continue
+		}
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(resource.GroupVersionKind())
+		err := r.Get(context.Background(), types.NamespacedName{Name: resource.GetName(), Namespace: resource.GetNamespace()}, existing)
+		if apierrs.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return manifest, err
+		}
+
+		for _, ref := range existing.GetOwnerReferences() {
+			if ref.Kind == owner.GroupVersionKind().Kind && ref.UID != owner.GetUID() {
+				conflicting[id] = true
+				r.conflictsMu.Lock()
+				params.ResourceConflicts = append(params.ResourceConflicts, fmt.Sprintf("%s (owned by DSPA %s)", id, ref.Name))
+				r.conflictsMu.Unlock()
+				break
+			}
+		}
+	}
+
+	if len(conflicting) == 0 {
+		return manifest, nil
+	}
+	return manifest.Filter(func(u *unstructured.Unstructured) bool {
+		return !conflicting[u.GetKind()+"/"+u.GetName()]
+	}), nil
 }
 
 func (r *DSPAReconciler) ApplyWithoutOwner(params *DSPAParams, template string, fns ...mf.Transformer) error {
-	tmplManifest, err := config.Manifest(r.Client, r.TemplatesPath+template, params)
+	tmplManifest, err := config.Manifest(r.Client, r.Templates, template, params)
 	if err != nil {
 		return fmt.Errorf("error loading template (%s) yaml: %w", template, err)
 	}
@@ -115,11 +279,12 @@ func (r *DSPAReconciler) ApplyWithoutOwner(params *DSPAParams, template string,
 		return err
 	}
 
-	return tmplManifest.Apply()
+	return applyManifest(template, tmplManifest)
 }
 
 func (r *DSPAReconciler) DeleteResource(params *DSPAParams, template string, fns ...mf.Transformer) error {
-	tmplManifest, err := config.Manifest(r.Client, r.TemplatesPath+template, params)
+	r.appliedManifests.forgetTemplate(types.NamespacedName{Namespace: params.Namespace, Name: params.Name}, template)
+	tmplManifest, err := config.Manifest(r.Client, r.Templates, template, params)
 	if err != nil {
 		return fmt.Errorf("error loading template (%s) yaml: %w", template, err)
 	}
@@ -145,12 +310,48 @@ func (r *DSPAReconciler) DeleteResourceIfItExists(ctx context.Context, obj clien
 	return err
 }
 
+// deleteResourcesIfTheyExist deletes objs, named after their ObjectMeta, from
+// namespace.
+func (r *DSPAReconciler) deleteResourcesIfTheyExist(ctx context.Context, namespace string, objs ...client.Object) error {
+	for _, obj := range objs {
+		err := r.DeleteResourceIfItExists(ctx, obj, types.NamespacedName{Name: obj.GetName(), Namespace: namespace})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteMigratedSecret deletes the Secret name of dsp, that generated
+// credentials were migrated from, once they are applied to their new Secret.
+// Secrets not created for dsp are kept.
+func (r *DSPAReconciler) deleteMigratedSecret(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication, name string) error {
+	if name == "" {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: dsp.Namespace}, secret)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(secret, dsp) {
+		return nil
+	}
+	dspaLogger(r.Log, dsp.Namespace, dsp.Name, "").Info(fmt.Sprintf("Deleting Secret [%s], its credentials were migrated.", name))
+	return client.IgnoreNotFound(r.Delete(ctx, secret))
+}
+
 //+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=datasciencepipelinesapplications,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=datasciencepipelinesapplications/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=datasciencepipelinesapplications/finalizers,verbs=update
+//+kubebuilder:rbac:groups=datasciencepipelinesapplications.opendatahub.io,resources=datasciencepipelinesapplications/api,verbs=get
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=*,resources=deployments;services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=secrets;configmaps;services;serviceaccounts;persistentvolumes;persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=persistentvolumes;persistentvolumeclaims,verbs=*
@@ -165,32 +366,52 @@ func (r *DSPAReconciler) DeleteResourceIfItExists(ctx context.Context, obj clien
 //+kubebuilder:rbac:groups=core;apps;extensions,resources=deployments;replicasets,verbs=*
 //+kubebuilder:rbac:groups=kubeflow.org,resources=*,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=*
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=machinelearning.seldon.io,resources=seldondeployments,verbs=*
 //+kubebuilder:rbac:groups=ray.io,resources=rayclusters;rayjobs;rayservices,verbs=create;get;list;patch;delete
 //+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 //+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 //+kubebuilder:rbac:groups=image.openshift.io,resources=imagestreamtags,verbs=get
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch;list
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=resourcequotas;limitranges,verbs=get;list;watch
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=workload.codeflare.dev,resources=appwrappers;appwrappers/finalizers;appwrappers/status,verbs=create;delete;deletecollection;get;list;patch;update;watch
 
 func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log := r.Log.WithValues("namespace", req.Namespace).WithValues("dspa_name", req.Name)
+	params := &DSPAParams{ReconcileID: string(uuid.NewUUID())}
+
+	log := dspaLogger(r.Log, req.Namespace, req.Name, params.ReconcileID)
+
+	ctx, span := tracer.Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("dspa_namespace", req.Namespace),
+		attribute.String("dspa_name", req.Name),
+		attribute.String("reconcile_id", params.ReconcileID),
+	))
+	defer span.End()
+	if span.SpanContext().HasTraceID() {
+		log = log.WithValues("trace_id", span.SpanContext().TraceID().String())
+	}
 
 	log.V(1).Info("DataSciencePipelinesApplication Reconciler called.")
 
-	params := &DSPAParams{}
-
 	dspa := &dspav1.DataSciencePipelinesApplication{}
 	err := r.Get(ctx, req.NamespacedName, dspa)
 	if err != nil && apierrs.IsNotFound(err) {
 		log.V(1).Info("DSPA resource was not found, assuming it was recently deleted, nothing to do here")
+		r.appliedManifests.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, "Encountered error when fetching DSPA")
 		return ctrl.Result{}, err
 	}
 
+	// Templates not applied by this reconcile are applied again by the next
+	defer r.appliedManifests.sweep(req.NamespacedName, params.ReconcileID)
+
 	dspaStatus := dspastatus.NewDSPAStatus(dspa)
 
 	defer r.updateStatus(ctx, dspa, dspaStatus, log, req)
@@ -209,6 +430,38 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, nil
 	}
 
+	if dspa.ObjectMeta.DeletionTimestamp.IsZero() {
+		inScope, err := util.NamespaceInScope(ctx, dspa.Namespace, r.Client)
+		if err != nil {
+			log.Error(err, "Encountered error when evaluating namespace reconciliation scope")
+			return ctrl.Result{}, err
+		}
+		if !inScope {
+			err1 := fmt.Errorf("namespace %s is not in the reconciliation scope of this operator, "+
+				"no resources will be deployed for this DSP resource", dspa.Namespace)
+			dspaStatus.SetDSPANotReady(err1, config.NamespaceNotAllowed)
+			log.Info(err1.Error())
+			return ctrl.Result{}, nil
+		}
+
+		if config.GetBoolConfigWithDefault(config.SingleInstancePerNamespaceConfigName, config.DefaultSingleInstancePerNamespace) {
+			existing, err := r.olderDSPAInNamespace(ctx, dspa)
+			if err != nil {
+				log.Error(err, "Encountered error when listing DSPAs in namespace")
+				return ctrl.Result{}, err
+			}
+			if existing != "" {
+				err1 := fmt.Errorf("only a single DSPA is allowed per namespace, namespace %s already has DSPA %s, "+
+					"no resources will be deployed for this DSP resource", dspa.Namespace, existing)
+				dspaStatus.SetDSPANotReady(err1, config.MultipleInstancesNotAllowed)
+				log.Info(err1.Error())
+				// Requeue so this DSPA is picked up once the existing one is removed
+				requeueTime := config.GetDurationConfigWithDefault(config.RequeueTimeConfigName, config.DefaultRequeueTime)
+				return ctrl.Result{RequeueAfter: requeueTime}, nil
+			}
+		}
+	}
+
 	// FixMe: Hack for stubbing gvk during tests as these are not populated by test suite
 	// https://github.com/opendatahub-io/data-science-pipelines-operator/pull/7#discussion_r1102887037
 	// In production we expect these to be populated
@@ -230,8 +483,14 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		if controllerutil.ContainsFinalizer(dspa, finalizerName) {
 			params.Name = dspa.Name
 			params.Namespace = dspa.Namespace
-			if err := r.cleanUpResources(params); err != nil {
-				return ctrl.Result{}, err
+			// The resources of Unmanaged DSPAs are left as they are
+			if managementState(dspa) != dspav1.ManagementStateUnmanaged {
+				if err := r.cleanUpResources(ctx, dspa, params); err != nil {
+					return ctrl.Result{}, err
+				}
+				if err := r.CleanUpComponentNamespaces(ctx, dspa); err != nil {
+					return ctrl.Result{}, err
+				}
 			}
 			controllerutil.RemoveFinalizer(dspa, finalizerName)
 			if err := r.Update(ctx, dspa); err != nil {
@@ -246,92 +505,310 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 
 	requeueTime := config.GetDurationConfigWithDefault(config.RequeueTimeConfigName, config.DefaultRequeueTime)
 
-	err = params.ExtractParams(ctx, dspa, r.Client, r.Log)
+	// The resources of DSPAs that are not Managed are not written while
+	// extracting their params
+	state := managementState(dspa)
+	paramsClient := r.Client
+	if state != dspav1.ManagementStateManaged {
+		paramsClient = client.NewDryRunClient(r.Client)
+	}
+	err = traced(ctx, "ExtractParams", func(ctx context.Context) error {
+		return params.ExtractParams(ctx, dspa, paramsClient, r.Log)
+	})
 	if err != nil {
+		if errors.Is(err, ErrImageNotPinned) {
+			dspaStatus.SetDSPANotReady(err, config.ImageNotPinned)
+		} else if errors.Is(err, ErrImageSignatureNotVerified) {
+			dspaStatus.SetDSPANotReady(err, config.ImageSignatureNotVerified)
+		} else if errors.Is(err, ErrInvalidAPIServerArgs) {
+			dspaStatus.SetDSPANotReady(err, config.InvalidAPIServerArgs)
+		} else if errors.Is(err, ErrInvalidTimezone) {
+			dspaStatus.SetDSPANotReady(err, config.InvalidTimezone)
+		} else if errors.Is(err, ErrInvalidFeatureGates) {
+			dspaStatus.SetDSPANotReady(err, config.InvalidFeatureGates)
+		}
 		log.Info(fmt.Sprintf("Encountered error when parsing CR: [%s]", err))
 		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
 	}
+	dspaStatus.SetResolvedImageDigests(params.ResolvedImageDigests)
+	dspaStatus.SetFIPSEnabled(params.FIPSEnabled)
+	dspaStatus.SetFeatureGates(params.ActiveFeatureGates())
+	dspaStatus.SetComponentImages(params.ComponentImages)
 
-	err = r.ReconcileDatabase(ctx, dspa, params)
+	// Fields of another DSP version are ignored, flag them rather than deploying silently without them
+	violations, err := r.CheckVersionCompatibility(ctx, dspa)
 	if err != nil {
-		dspaStatus.SetDatabaseNotReady(err, config.FailingToDeploy)
+		log.Info(fmt.Sprintf("Encountered error when checking DSP version compatibility: [%s]", err))
+	} else if len(violations) > 0 {
+		err1 := fmt.Errorf("fields not supported by DSP %s are ignored: %s", dspa.Spec.DSPVersion, strings.Join(violations, "; "))
+		dspaStatus.SetDegraded(err1, config.IncompatibleFields)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.IncompatibleFields, err1.Error())
+		log.Info(err1.Error())
+	}
+
+	// Report the changes to apply before applying them
+	err = traced(ctx, "ReconcileDiff", func(ctx context.Context) error {
+		return r.ReconcileDiff(ctx, dspa, params, dspaStatus)
+	})
+	if err != nil {
+		log.Error(err, "Encountered error when reporting the pending changes of the DSPA")
+	}
+
+	switch state {
+	case dspav1.ManagementStateUnmanaged:
+		return r.reconcileUnmanaged(ctx, dspa, params, dspaStatus)
+	case dspav1.ManagementStateRemoved:
+		return r.reconcileRemoved(ctx, dspa, params, dspaStatus)
+	}
+
+	// Moving the runs to other buckets or another database breaks their
+	// lineage, hold the change until it is reverted or acknowledged
+	specChangeUnsupported, err := r.CheckStorageBinding(ctx, dspa, params, dspaStatus)
+	if err != nil {
+		log.Error(err, "Encountered error when checking the storage of the DSPA")
 		return ctrl.Result{}, err
-	} else {
-		dspaStatus.SetDatabaseReady()
+	} else if specChangeUnsupported != "" {
+		err1 := errors.New(specChangeUnsupported)
+		dspaStatus.SetSpecChangeUnsupported(specChangeUnsupported)
+		dspaStatus.SetDSPANotReady(err1, config.SpecChangeUnsupported)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.SpecChangeUnsupported, specChangeUnsupported)
+		log.Info(specChangeUnsupported)
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
 	}
 
-	err = r.ReconcileStorage(ctx, dspa, params)
+	// Fail before applying any manifest rather than leaving pods unschedulable
+	var quotaShortfall string
+	err = traced(ctx, "CheckResourceQuota", func(ctx context.Context) error {
+		quotaShortfall, err = r.CheckResourceQuota(ctx, dspa, params)
+		return err
+	})
 	if err != nil {
-		dspaStatus.SetObjStoreNotReady(err, config.FailingToDeploy)
+		log.Error(err, "Encountered error when checking namespace resource quotas")
 		return ctrl.Result{}, err
-	} else {
-		dspaStatus.SetObjStoreReady()
+	} else if quotaShortfall != "" {
+		err1 := fmt.Errorf("namespace %s does not have the resources to deploy the DSPA components: %s",
+			dspa.Namespace, quotaShortfall)
+		dspaStatus.SetDSPANotReady(err1, config.QuotaInsufficient)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.QuotaInsufficient, err1.Error())
+		log.Info(err1.Error())
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
 	}
 
-	// Get Prereq Status (DB and ObjStore Ready)
-	dbAvailable, err := r.isDatabaseAccessible(dspa, params)
+	var certificatesReady bool
+	err = traced(ctx, "ReconcileCertificates", func(ctx context.Context) error {
+		certificatesReady, err = r.ReconcileCertificates(ctx, dspa, params)
+		return err
+	})
+	if err != nil {
+		dspaStatus.SetDSPANotReady(err, config.CertificatesNotReady)
+		return ctrl.Result{}, err
+	} else if !certificatesReady {
+		err1 := fmt.Errorf("waiting for cert-manager to issue Certificates from %s %s",
+			params.CertManagerIssuer.Kind, params.CertManagerIssuer.Name)
+		dspaStatus.SetDSPANotReady(err1, config.CertificatesNotReady)
+		log.Info(err1.Error())
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
+	}
+
+	err = traced(ctx, "ReconcileComponentNamespaces", func(ctx context.Context) error {
+		return r.ReconcileComponentNamespaces(ctx, dspa, params, dspaStatus)
+	})
+	if err != nil {
+		log.Error(err, "Encountered error when cleaning up the namespaces no longer used by the DSPA components")
+		return ctrl.Result{}, err
+	}
 
+	err = traced(ctx, "ReconcileDatabase", func(ctx context.Context) error {
+		return r.ReconcileDatabase(ctx, dspa, params)
+	})
 	if err != nil {
 		dspaStatus.SetDatabaseNotReady(err, config.FailingToDeploy)
+		return ctrl.Result{}, err
 	} else {
 		dspaStatus.SetDatabaseReady()
 	}
 
-	objStoreAvailable, err := r.isObjectStorageAccessible(ctx, dspa, params)
+	err = traced(ctx, "ReconcileStorage", func(ctx context.Context) error {
+		if err := r.ReconcileStorage(ctx, dspa, params); err != nil {
+			return err
+		}
+		return r.ReconcileSecretPropagation(ctx, dspa, params)
+	})
 	if err != nil {
 		dspaStatus.SetObjStoreNotReady(err, config.FailingToDeploy)
+		return ctrl.Result{}, err
 	} else {
 		dspaStatus.SetObjStoreReady()
 	}
 
-	dspaPrereqsReady := dbAvailable && objStoreAvailable
+	// Credentials sourced through the External Secrets Operator may not be
+	// materialized yet, wait for them rather than failing the health check
+	if params.DBConnection.ExternalSecret != nil && params.DBConnection.Password.Empty() {
+		err1 := fmt.Errorf("waiting for ExternalSecret %s to materialize the database credentials Secret",
+			params.DBConnection.CredentialsSecret.Name)
+		dspaStatus.SetDatabaseNotReady(err1, config.ExternalSecretNotReady)
+		log.Info(err1.Error())
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
+	}
 
+	// Get Prereq Status (DB and ObjStore Ready)
+	dspaPrereqsReady, err := r.checkPrerequisites(ctx, dspa, params, dspaStatus)
 	if dspaPrereqsReady {
-		// Manage Common Manifests
-		err = r.ReconcileCommon(dspa, params)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+		recordStorageBinding(params, dspaStatus)
+	}
 
-		err = r.ReconcileAPIServer(ctx, dspa, params)
+	var upgradePhase string
+	if dspaPrereqsReady {
+		// A DSP v1 deployment is backed up and scaled down before any v2 component is applied
+		err = traced(ctx, "ReconcileUpgrade", func(ctx context.Context) error {
+			upgradePhase, err = r.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+			return err
+		})
 		if err != nil {
-			r.setStatusAsNotReady(config.APIServerReady, err, dspaStatus.SetApiServerStatus)
+			log.Error(err, "Encountered error when upgrading the DSP v1 components")
 			return ctrl.Result{}, err
-		} else {
-			r.setStatus(ctx, params.APIServerDefaultResourceName, config.APIServerReady, dspa,
-				dspaStatus.SetApiServerStatus, log)
+		}
+		switch upgradePhase {
+		case "", config.UpgradeMigratingToV2, config.UpgradeCompleted:
+		default:
+			log.Info(fmt.Sprintf("DSP v1 to v2 upgrade is %s, the DSPA components are not reconciled.", upgradePhase))
+			return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
 		}
 
-		err = r.ReconcilePersistenceAgent(dspa, params)
+		// Manage Common Manifests
+		err = traced(ctx, "ReconcileCommon", func(ctx context.Context) error {
+			return r.ReconcileCommon(ctx, dspa, params)
+		})
 		if err != nil {
-			r.setStatusAsNotReady(config.PersistenceAgentReady, err, dspaStatus.SetPersistenceAgentStatus)
 			return ctrl.Result{}, err
-		} else {
-			r.setStatus(ctx, params.PersistentAgentDefaultResourceName, config.PersistenceAgentReady, dspa,
-				dspaStatus.SetPersistenceAgentStatus, log)
 		}
 
-		err = r.ReconcileScheduledWorkflow(dspa, params)
+		// The components do not depend on each other, their status is set
+		// once all of them are reconciled
+		err = r.reconcileComponents(ctx, []componentReconcile{
+			{
+				name: "ReconcileAPIServer",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileAPIServer(ctx, dspa, params)
+				},
+				setStatus: func(err error) {
+					if err != nil {
+						r.setStatusAsNotReady(config.APIServerReady, err, dspaStatus.SetApiServerStatus)
+					} else {
+						r.setStatus(ctx, params.APIServerDefaultResourceName, config.APIServerReady, dspa,
+							dspaStatus.SetApiServerStatus, log)
+					}
+				},
+			},
+			{
+				name: "ReconcilePersistenceAgent",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcilePersistenceAgent(dspa, params)
+				},
+				setStatus: func(err error) {
+					if err != nil {
+						r.setStatusAsNotReady(config.PersistenceAgentReady, err, dspaStatus.SetPersistenceAgentStatus)
+					} else {
+						r.setStatus(ctx, params.PersistentAgentDefaultResourceName, config.PersistenceAgentReady, dspa,
+							dspaStatus.SetPersistenceAgentStatus, log)
+					}
+				},
+			},
+			{
+				name: "ReconcileScheduledWorkflow",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileScheduledWorkflow(dspa, params)
+				},
+				setStatus: func(err error) {
+					if err != nil {
+						r.setStatusAsNotReady(config.ScheduledWorkflowReady, err, dspaStatus.SetScheduledWorkflowStatus)
+					} else {
+						r.setStatus(ctx, params.ScheduledWorkflowDefaultResourceName, config.ScheduledWorkflowReady, dspa,
+							dspaStatus.SetScheduledWorkflowStatus, log)
+					}
+				},
+			},
+			{
+				name: "ReconcileUI",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileUI(ctx, dspa, params)
+				},
+			},
+			{
+				name: "ReconcileVisualizationServer",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileVisualizationServer(ctx, dspa, params)
+				},
+			},
+			{
+				name: "ReconcileCRDViewer",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileCRDViewer(dspa, params)
+				},
+				setStatus: func(err error) {
+					if err != nil {
+						r.setStatusAsNotReady(config.CRDViewerReady, err, dspaStatus.SetCRDViewerStatus)
+					} else if params.CRDViewer != nil && params.CRDViewer.Deploy {
+						r.setStatus(ctx, params.CRDViewerDefaultResourceName, config.CRDViewerReady, dspa,
+							dspaStatus.SetCRDViewerStatus, log)
+					}
+				},
+			},
+			{
+				name: "ReconcileDatabaseBackup",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileDatabaseBackup(ctx, dspa, params)
+				},
+				setStatus: func(err error) {
+					if err == nil {
+						err = r.setBackupVerifiedStatus(ctx, dspa, params, dspaStatus)
+					}
+					if err != nil && params.databaseBackupVerified() {
+						r.setStatusAsNotReady(config.BackupVerified, err, dspaStatus.SetBackupVerifiedStatus)
+					}
+				},
+			},
+			{
+				name: "ReconcileWorkflowController",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileWorkflowController(dspa, params)
+				},
+			},
+			{
+				name: "ReconcileMultiTenancy",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileMultiTenancy(ctx, dspa, params)
+				},
+			},
+			{
+				name: "ReconcileMonitoring",
+				reconcile: func(ctx context.Context) error {
+					return r.ReconcileMonitoring(ctx, dspa, params)
+				},
+			},
+		})
 		if err != nil {
-			r.setStatusAsNotReady(config.ScheduledWorkflowReady, err, dspaStatus.SetScheduledWorkflowStatus)
 			return ctrl.Result{}, err
-		} else {
-			r.setStatus(ctx, params.ScheduledWorkflowDefaultResourceName, config.ScheduledWorkflowReady, dspa,
-				dspaStatus.SetScheduledWorkflowStatus, log)
 		}
 
-		err = r.ReconcileUI(dspa, params)
+		err = traced(ctx, "ReconcileRunLimits", func(ctx context.Context) error {
+			return r.ReconcileRunLimits(ctx, dspa, params, dspaStatus)
+		})
 		if err != nil {
-			return ctrl.Result{}, err
+			log.Error(err, "Encountered error when counting the runs of the DSPA")
 		}
 
-		err = r.ReconcileWorkflowController(dspa, params)
+		err = traced(ctx, "ReconcileRunTermination", func(ctx context.Context) error {
+			return r.ReconcileRunTermination(ctx, dspa, params)
+		})
 		if err != nil {
-			return ctrl.Result{}, err
+			log.Error(err, "Encountered error when shutting down the terminated runs of the DSPA")
 		}
 
 		// MLMD should be the last to reconcile because it can cause an early exit due to the lack of the TLS secret, which may not have been created yet.
-		err = r.ReconcileMLMD(ctx, dspa, params)
+		err = traced(ctx, "ReconcileMLMD", func(ctx context.Context) error {
+			return r.ReconcileMLMD(ctx, dspa, params)
+		})
 		if err != nil {
 			r.setStatusAsNotReady(config.MLMDProxyReady, err, dspaStatus.SetMLMDProxyStatus)
 			return ctrl.Result{}, err
@@ -339,6 +816,20 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 			r.setStatus(ctx, params.MlmdProxyDefaultResourceName, config.MLMDProxyReady, dspa,
 				dspaStatus.SetMLMDProxyStatus, log)
 		}
+
+		err = traced(ctx, "ReconcileConnectionInfo", func(ctx context.Context) error {
+			return r.ReconcileConnectionInfo(ctx, dspa, params)
+		})
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if len(params.ResourceConflicts) > 0 {
+		err1 := fmt.Errorf("resources already owned by another DSPA in this namespace were not applied: [%s]",
+			strings.Join(params.ResourceConflicts, ", "))
+		dspaStatus.SetDSPANotReady(err1, config.ResourceConflict)
+		log.Info(err1.Error())
 	}
 
 	conditions := dspaStatus.GetConditions()
@@ -355,7 +846,7 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		util.GetConditionByType(config.MLMDProxyReady, conditions):         MLMDProxyReadyMetric,
 		util.GetConditionByType(config.CrReady, conditions):                CrReadyMetric,
 	}
-	r.PublishMetrics(dspa, metricsMap)
+	r.PublishMetrics(dspa, params, metricsMap)
 
 	if !dspaPrereqsReady {
 		log.Info(fmt.Sprintf("Health check for Database or Object Store failed, retrying in %d seconds.", int(requeueTime.Seconds())))
@@ -363,9 +854,99 @@ func (r *DSPAReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
 	}
 
+	// Requeue to roll the upgrade back should the v2 API server not become available,
+	// to pick up the new API server image once the canary DSPAs are verified,
+	// and to keep the run usage reported in the status current
+	if upgradePhase == config.UpgradeMigratingToV2 || params.APIServerImageHeld || params.Limits != nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: requeueTime}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// checkPrerequisites runs the database and object storage health checks of
+// dspa and reports them in dspaStatus. It returns whether both are available,
+// and the error of the object storage health check.
+func (r *DSPAReconciler) checkPrerequisites(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) (bool, error) {
+	var dbAvailable, objStoreAvailable bool
+	err := traced(ctx, "DatabaseHealthCheck", func(ctx context.Context) error {
+		var err error
+		dbAvailable, err = r.isDatabaseAccessible(dspa, params)
+		return err
+	})
+	if err != nil {
+		reason := databaseNotReadyReason(err, params.UsingExternalDB(dspa))
+		dspaStatus.SetDatabaseNotReady(err, reason)
+		if reason != config.FailingToDeploy {
+			r.recordEvent(dspa, corev1.EventTypeWarning, reason, err.Error())
+		}
+	} else {
+		dspaStatus.SetDatabaseReady()
+	}
+
+	err = traced(ctx, "ObjectStoreHealthCheck", func(ctx context.Context) error {
+		var err error
+		objStoreAvailable, err = r.isObjectStorageAccessible(ctx, dspa, params)
+		return err
+	})
+	if errors.Is(err, ErrBucketNotAccessible) {
+		dspaStatus.SetObjStoreNotReady(err, config.AccessDenied)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.AccessDenied, err.Error())
+	} else if errors.Is(err, ErrBucketNotFound) {
+		dspaStatus.SetObjStoreNotReady(err, config.BucketNotFound)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.BucketNotFound, err.Error())
+	} else if errors.Is(err, ErrBucketCreationFailed) {
+		dspaStatus.SetObjStoreNotReady(err, config.BucketCreationFailed)
+		r.recordEvent(dspa, corev1.EventTypeWarning, config.BucketCreationFailed, err.Error())
+	} else if err != nil {
+		dspaStatus.SetObjStoreNotReady(err, config.FailingToDeploy)
+	} else {
+		dspaStatus.SetObjStoreReady()
+	}
+
+	return dbAvailable && objStoreAvailable, err
+}
+
+// componentReconcile is the reconciliation of a DSPA component that does not
+// depend on the other components of its group.
+type componentReconcile struct {
+	name      string
+	reconcile func(ctx context.Context) error
+	// setStatus reports the outcome of reconcile, optional
+	setStatus func(err error)
+}
+
+// reconcileComponents runs the reconciles of components concurrently, up to
+// DSPO.ReconcileParallelism at a time, then reports their outcome in order and
+// returns their errors joined. The reconciles must not write the fields of params
+// read by the other components.
+func (r *DSPAReconciler) reconcileComponents(ctx context.Context, components []componentReconcile) error {
+	parallelism := config.GetIntConfigWithDefault(config.ReconcileParallelismConfigName, config.DefaultReconcileParallelism)
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	errs := make([]error, len(components))
+	group := errgroup.Group{}
+	group.SetLimit(parallelism)
+	for i := range components {
+		i := i
+		group.Go(func() error {
+			errs[i] = traced(ctx, components[i].name, components[i].reconcile)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	for i, component := range components {
+		if component.setStatus != nil {
+			component.setStatus(errs[i])
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (r *DSPAReconciler) setStatusAsNotReady(conditionType string, err error, setStatus func(metav1.Condition)) {
 	condition := dspastatus.BuildFalseCondition(conditionType, config.FailingToDeploy, err.Error())
 	setStatus(condition)
@@ -394,12 +975,31 @@ func (r *DSPAReconciler) updateStatus(ctx context.Context, dspa *dspav1.DataScie
 	if dspa.DeletionTimestamp != nil {
 		return
 	}
-	dspa.Status.Components = r.GetComponents(ctx, dspa)
+	previousStatus := dspa.Status.DeepCopy()
+	dspa.Status.Components = r.GetComponents(ctx, dspa, log)
+	setComponentImages(&dspa.Status.Components, dspaStatus.GetComponentImages())
+	r.setComponentImageDigests(ctx, dspa, log)
+	previousConditions := dspa.Status.Conditions
 	dspa.Status.Conditions = dspaStatus.GetConditions()
+	dspa.Status.ResolvedImageDigests = dspaStatus.GetResolvedImageDigests()
+	dspa.Status.FIPSEnabled = dspaStatus.GetFIPSEnabled()
+	dspa.Status.Runs = dspaStatus.GetRunUsage()
+	dspa.Status.ManagementState = managementState(dspa)
+	dspa.Status.Platform = platform(dspa, r.RESTMapper())
+	dspa.Status.PendingChanges = dspaStatus.GetPendingChanges()
+	dspa.Status.ComponentNamespaces = dspaStatus.GetComponentNamespaces()
+	dspa.Status.FeatureGates = dspaStatus.GetFeatureGates()
+	dspa.Status.Storage = dspaStatus.GetStorageBinding()
+	// Conditions keep their transition times, so an unchanged status is not written
+	if equality.Semantic.DeepEqual(previousStatus, &dspa.Status) {
+		return
+	}
 	err := r.Status().Update(ctx, dspa)
 	if err != nil {
 		log.Error(err, errorUpdatingDspaStatusMsg)
+		return
 	}
+	r.notifyReadyTransition(dspa, previousConditions, dspa.Status.Conditions, log)
 }
 
 // evaluateCondition evaluates if condition with "name" is in condition of type "conditionType".
@@ -525,8 +1125,8 @@ func (r *DSPAReconciler) refreshDspa(ctx context.Context, dspa *dspav1.DataScien
 	}
 }
 
-func (r *DSPAReconciler) PublishMetrics(dspa *dspav1.DataSciencePipelinesApplication, metricsMap map[metav1.Condition]*prometheus.GaugeVec) {
-	log := r.Log.WithValues("namespace", dspa.Namespace).WithValues("dspa_name", dspa.Name)
+func (r *DSPAReconciler) PublishMetrics(dspa *dspav1.DataSciencePipelinesApplication, params *DSPAParams, metricsMap map[metav1.Condition]*prometheus.GaugeVec) {
+	log := dspaLogger(r.Log, dspa.Namespace, dspa.Name, params.ReconcileID)
 	log.Info("Publishing Ready Metrics")
 
 	for conditionType, metric := range metricsMap {
@@ -541,8 +1141,7 @@ func (r *DSPAReconciler) PublishMetrics(dspa *dspav1.DataSciencePipelinesApplica
 	}
 }
 
-func (r *DSPAReconciler) GetComponents(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication) dspav1.ComponentStatus {
-	log := r.Log.WithValues("namespace", dspa.Namespace).WithValues("dspa_name", dspa.Name)
+func (r *DSPAReconciler) GetComponents(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication, log logr.Logger) dspav1.ComponentStatus {
 	mlmdProxyResourceName := fmt.Sprintf("ds-pipeline-md-%s", dspa.Name)
 	apiServerResourceName := fmt.Sprintf("ds-pipeline-%s", dspa.Name)
 
@@ -551,7 +1150,8 @@ func (r *DSPAReconciler) GetComponents(ctx context.Context, dspa *dspav1.DataSci
 		log.Error(err, "Error retrieving MLMD Proxy Service endpoint")
 	}
 
-	mlmdProxyExternalUrl, err := util.GetRouteHostname(ctx, mlmdProxyResourceName, dspa.Namespace, r.Client)
+	dspaPlatform := platform(dspa, r.RESTMapper())
+	mlmdProxyExternalUrl, err := externalRouteURL(ctx, r.Client, dspaPlatform, mlmdProxyResourceName, dspa.Namespace)
 	if err != nil {
 		log.Error(err, "Error retrieving MLMD Proxy Route endpoint")
 	}
@@ -561,7 +1161,7 @@ func (r *DSPAReconciler) GetComponents(ctx context.Context, dspa *dspav1.DataSci
 		log.Error(err, "Error retrieving API Server Service endpoint")
 	}
 
-	apiServerExternalUrl, err := util.GetRouteHostname(ctx, apiServerResourceName, dspa.Namespace, r.Client)
+	apiServerExternalUrl, err := externalRouteURL(ctx, r.Client, dspaPlatform, apiServerResourceName, dspa.Namespace)
 	if err != nil {
 		log.Error(err, "Error retrieving API Server Route endpoint")
 	}
@@ -592,27 +1192,186 @@ func (r *DSPAReconciler) GetComponents(ctx context.Context, dspa *dspav1.DataSci
 	return status
 }
 
+// setComponentImages records the image resolved for each component in status,
+// images are keyed by the json name of the component status field.
+func setComponentImages(status *dspav1.ComponentStatus, images map[string]dspav1.ComponentDetailStatus) {
+	fields := componentStatusFields(status)
+	for component, image := range images {
+		if field, ok := fields[component]; ok {
+			field.Image = image.Image
+			field.ImageSource = image.ImageSource
+			field.Architecture = image.Architecture
+		}
+	}
+}
+
+// componentStatusFields returns the component status fields of status, keyed
+// by their json name.
+func componentStatusFields(status *dspav1.ComponentStatus) map[string]*dspav1.ComponentDetailStatus {
+	return map[string]*dspav1.ComponentDetailStatus{
+		"apiServer":           &status.APIServer,
+		"persistenceAgent":    &status.PersistenceAgent,
+		"scheduledWorkflow":   &status.ScheduledWorkflow,
+		"mlPipelineUI":        &status.MlPipelineUI,
+		"visualizationServer": &status.VisualizationServer,
+		"crdViewer":           &status.CRDViewer,
+		"mlmdProxy":           &status.MLMDProxy,
+		"mlmdGRPC":            &status.MLMDGRPC,
+		"mariaDB":             &status.MariaDB,
+		"minio":               &status.Minio,
+		"workflowController":  &status.WorkflowController,
+	}
+}
+
+// setComponentImageDigests records the digest the pods of each component run
+// in status, and rolls the component images up in status.dspVersionDetail.
+// Digest pinned images are reported as is, the digests of the other images
+// are read from the container statuses of the running pods of the DSPA.
+func (r *DSPAReconciler) setComponentImageDigests(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication, log logr.Logger) {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, client.InNamespace(dspa.Namespace),
+		client.MatchingLabels{"component": "data-science-pipelines", "dspa": dspa.Name})
+	if err != nil {
+		log.Error(err, "unable to list the pods of the DSPA to report their image digests")
+	}
+
+	// The digests reported for each image, an image reported with different
+	// digests maps to an empty digest
+	digests := map[string]string{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		images := map[string]string{}
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, container := range containers {
+				images[container.Name] = container.Image
+			}
+		}
+		var containerStatuses []corev1.ContainerStatus
+		containerStatuses = append(containerStatuses, pod.Status.InitContainerStatuses...)
+		containerStatuses = append(containerStatuses, pod.Status.ContainerStatuses...)
+		for _, containerStatus := range containerStatuses {
+			image, digest := images[containerStatus.Name], util.ImageIDDigest(containerStatus.ImageID)
+			if image == "" || digest == "" {
+				continue
+			}
+			if reported, ok := digests[image]; ok && reported != digest {
+				digest = ""
+			}
+			digests[image] = digest
+		}
+	}
+
+	detail := &dspav1.DSPVersionDetail{DSPVersion: dspa.Spec.DSPVersion, Images: map[string]string{}}
+	for component, field := range componentStatusFields(&dspa.Status.Components) {
+		if field.Image == "" {
+			continue
+		}
+		if util.IsDigestPinned(field.Image) {
+			field.ImageDigest = field.Image[strings.LastIndex(field.Image, "@")+1:]
+		} else {
+			field.ImageDigest = digests[field.Image]
+		}
+		detail.Images[component] = field.Image
+		if field.ImageDigest != "" {
+			detail.Images[component] = util.PinnedImage(field.Image, field.ImageDigest)
+		}
+	}
+	dspa.Status.DSPVersionDetail = detail
+}
+
+// olderDSPAInNamespace returns the name of a DSPA in the same namespace that
+// was created before dspa, or an empty string if there is none. Ties on the
+// creation timestamp are broken by name, so exactly one DSPA is always
+// considered the oldest.
+func (r *DSPAReconciler) olderDSPAInNamespace(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication) (string, error) {
+	dspaList := &dspav1.DataSciencePipelinesApplicationList{}
+	if err := r.List(ctx, dspaList, client.InNamespace(dspa.Namespace)); err != nil {
+		return "", err
+	}
+
+	for _, other := range dspaList.Items {
+		if other.Name == dspa.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if other.CreationTimestamp.Before(&dspa.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&dspa.CreationTimestamp) && other.Name < dspa.Name) {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// namespaceInScopePredicate filters out events for resources in namespaces
+// outside the operator's reconciliation scope. DSPA events are not filtered,
+// so that out of scope DSPAs still report why they are not reconciled.
+func (r *DSPAReconciler) namespaceInScopePredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(o client.Object) bool {
+		inScope, err := util.NamespaceInScope(context.Background(), o.GetNamespace(), r.Client)
+		if err != nil {
+			r.Log.V(1).Info(fmt.Sprintf("Unable to evaluate reconciliation scope of namespace %s: %v", o.GetNamespace(), err))
+			return true
+		}
+		return inScope
+	})
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DSPAReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&dspav1.DataSciencePipelinesApplication{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Secret{}).
-		Owns(&corev1.ConfigMap{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ServiceAccount{}).
-		Owns(&corev1.PersistentVolumeClaim{}).
-		Owns(&rbacv1.Role{}).
-		Owns(&rbacv1.RoleBinding{}).
-		Owns(&routev1.Route{}).
+	// Every event is enqueued through the namespace rate limits, rather than
+	// only retries, as For and Owns would, so that the DSPAs of a namespace
+	// cannot flood the queue on an operator restart or config change
+	rateLimiter := NewNamespaceFairRateLimiter(
+		config.GetFloatConfigWithDefault(config.RateLimitNamespaceQPSConfigName, config.DefaultRateLimitNamespaceQPS),
+		config.GetIntConfigWithDefault(config.RateLimitNamespaceBurstConfigName, config.DefaultRateLimitNamespaceBurst),
+	)
+	namespaceFair := func(h handler.EventHandler) handler.EventHandler {
+		return &queueWrappingHandler{EventHandler: h, wrap: func(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+			return &namespaceFairQueue{RateLimitingInterface: q, limiter: rateLimiter}
+		}}
+	}
+	// Changes to owned resources also have their templates applied again
+	ownerHandler := namespaceFair(&queueWrappingHandler{
+		EventHandler: handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(),
+			&dspav1.DataSciencePipelinesApplication{}, handler.OnlyControllerOwner()),
+		wrap: func(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+			return &forgetAppliedQueue{RateLimitingInterface: q, applied: &r.appliedManifests}
+		},
+	})
+
+	labelledOwnerHandler := namespaceFair(&queueWrappingHandler{
+		EventHandler: enqueueLabelledOwner(),
+		wrap: func(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+			return &forgetAppliedQueue{RateLimitingInterface: q, applied: &r.appliedManifests}
+		},
+	})
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("datasciencepipelinesapplication").
+		Watches(&dspav1.DataSciencePipelinesApplication{}, namespaceFair(&handler.EnqueueRequestForObject{})).
+		Watches(&appsv1.Deployment{}, ownerHandler).
+		Watches(&appsv1.StatefulSet{}, ownerHandler).
+		Watches(&corev1.Secret{}, ownerHandler).
+		Watches(&corev1.ConfigMap{}, ownerHandler).
+		Watches(&corev1.Service{}, ownerHandler).
+		Watches(&corev1.ServiceAccount{}, ownerHandler).
+		Watches(&corev1.PersistentVolumeClaim{}, ownerHandler).
+		Watches(&rbacv1.Role{}, ownerHandler).
+		Watches(&rbacv1.RoleBinding{}, ownerHandler).
+		Watches(&networkingv1.Ingress{}, ownerHandler).
+		Watches(&batchv1.CronJob{}, ownerHandler).
+		// The components deployed in another namespace are labelled with their DSPA instead
+		Watches(&appsv1.Deployment{}, labelledOwnerHandler).
+		Watches(&appsv1.StatefulSet{}, labelledOwnerHandler).
 		// Watch for global ca bundle, if one is added to this namespace
 		// we need to reconcile on all the dspa's in this namespace
 		// so they may mount this cert in the appropriate containers
 		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.ConfigMap{}),
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+			namespaceFair(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
 				cm := o.(*corev1.ConfigMap)
 				thisNamespace := cm.Namespace
-				log := r.Log.WithValues("namespace", thisNamespace)
+				log := r.Log.WithValues("dspa_namespace", thisNamespace)
 
 				if cm.Name != "odh-trusted-ca-bundle" {
 					return nil
@@ -641,12 +1400,13 @@ func (r *DSPAReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				}
 
 				return reconcileRequests
-			}),
+			})),
+			builder.WithPredicates(r.namespaceInScopePredicate()),
 		).
 		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.Pod{}),
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+			namespaceFair(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
 				pod := o.(*corev1.Pod)
-				log := r.Log.WithValues("namespace", pod.Namespace)
+				log := r.Log.WithValues("dspa_namespace", pod.Namespace)
 
 				component, hasComponentLabel := pod.Labels["component"]
 				if !hasComponentLabel || component != "data-science-pipelines" {
@@ -673,12 +1433,23 @@ func (r *DSPAReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					Namespace: pod.Namespace,
 				}
 				return []reconcile.Request{{NamespacedName: namespacedName}}
-			}),
+			})),
+			builder.WithPredicates(r.namespaceInScopePredicate()),
 		).
 		WatchesRawSource(source.Kind(mgr.GetCache(), &corev1.Secret{}),
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+			namespaceFair(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
 				secret := o.(*corev1.Secret)
-				log := r.Log.WithValues("namespace", secret.Namespace)
+				log := r.Log.WithValues("dspa_namespace", secret.Namespace)
+
+				// Restore the object storage credentials propagated from a DSPA
+				if propagatedFrom, ok := secret.Annotations[config.PropagatedFromAnnotation]; ok {
+					namespace, name, found := strings.Cut(propagatedFrom, "/")
+					if !found {
+						return nil
+					}
+					log.V(1).Info(fmt.Sprintf("Reconcile event triggered by change on propagated Secret: %s", secret.Name))
+					return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+				}
 
 				if secret.Annotations["openshift.io/owning-component"] != "service-ca" {
 					return nil
@@ -713,15 +1484,30 @@ func (r *DSPAReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				}
 				log.V(1).Info(fmt.Sprintf("Reconcile event triggered by change on Secret: %s owned by service-ca: %s", secret.Name, serviceName))
 				return []reconcile.Request{{NamespacedName: namespacedDspaName}}
-			}),
-		).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
-		}).
+			})),
+			builder.WithPredicates(r.namespaceInScopePredicate()),
+		)
+	// Routes are only served on OpenShift, watching them elsewhere would fail
+	if DetectPlatform(mgr.GetRESTMapper()) == dspav1.PlatformOpenShift {
+		b = b.Watches(&routev1.Route{}, ownerHandler)
+	}
+	if r.ConfigReloader != nil {
+		b = b.WatchesRawSource(&source.Channel{Source: r.ConfigReloader.reloads}, namespaceFair(r.enqueueDSPAsOnConfigReload()))
+	}
+	return b.WithOptions(controller.Options{
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+		RateLimiter:             rateLimiter,
+	}).
 		Complete(r)
 }
 
 // Clean Up any resources not handled by garbage collection, like Cluster ResourceRequirements
-func (r *DSPAReconciler) cleanUpResources(params *DSPAParams) error {
+func (r *DSPAReconciler) cleanUpResources(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
+	if err := r.CleanUpSecretPropagation(ctx, dspa); err != nil {
+		return err
+	}
+	if err := r.CleanUpMultiTenancy(ctx, params); err != nil {
+		return err
+	}
 	return r.CleanUpCommon(params)
 }
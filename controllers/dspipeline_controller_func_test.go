@@ -76,6 +76,12 @@ func (s *ControllerSuite) TestDSPipelineController() {
 				}
 			})
 
+			t.Run(fmt.Sprintf("[case %x] Should report the expected conditions", caseCount), func(t *testing.T) {
+				for _, conditions := range testcase.Expected.Conditions {
+					util.CompareConditions(uc, conditions, t)
+				}
+			})
+
 			t.Run(fmt.Sprintf("[case %x] Should successfully delete the Custom Resource (and additional resources)", caseCount), func(t *testing.T) {
 				for _, path := range testcase.Deploy {
 					p := path
@@ -0,0 +1,260 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	manifests "github.com/opendatahub-io/data-science-pipelines-operator/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOlderDSPAInNamespace(t *testing.T) {
+	testNamespace := "testnamespace"
+	created := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	newDSPA := func(name string, creationTimestamp metav1.Time) *dspav1.DataSciencePipelinesApplication {
+		dspa := &dspav1.DataSciencePipelinesApplication{}
+		dspa.Name = name
+		dspa.Namespace = testNamespace
+		dspa.CreationTimestamp = creationTimestamp
+		return dspa
+	}
+
+	ctx, _, reconciler := CreateNewTestObjects()
+
+	first := newDSPA("b-first", created)
+	existing, err := reconciler.olderDSPAInNamespace(ctx, first)
+	assert.Nil(t, err)
+	assert.Empty(t, existing)
+	assert.Nil(t, reconciler.Create(ctx, first))
+
+	// A DSPA created later is not the first in the namespace
+	later := newDSPA("c-later", metav1.NewTime(created.Add(time.Minute)))
+	assert.Nil(t, reconciler.Create(ctx, later))
+	existing, err = reconciler.olderDSPAInNamespace(ctx, later)
+	assert.Nil(t, err)
+	assert.Equal(t, "b-first", existing)
+
+	// The first DSPA is unaffected by DSPAs created after it
+	existing, err = reconciler.olderDSPAInNamespace(ctx, first)
+	assert.Nil(t, err)
+	assert.Empty(t, existing)
+
+	// Ties on creation timestamp are broken by name
+	sameTime := newDSPA("a-same-time", created)
+	assert.Nil(t, reconciler.Create(ctx, sameTime))
+	existing, err = reconciler.olderDSPAInNamespace(ctx, first)
+	assert.Nil(t, err)
+	assert.Equal(t, "a-same-time", existing)
+}
+
+func TestSetComponentImages(t *testing.T) {
+	status := &dspav1.ComponentStatus{
+		APIServer: dspav1.ComponentDetailStatus{Url: "http://apiserver.svc.cluster.local"},
+	}
+	setComponentImages(status, map[string]dspav1.ComponentDetailStatus{
+		"apiServer": {Image: "quay.io/example/api-server:custom", ImageSource: config.ImageSourceDSPA},
+		"mlmdGRPC":  {Image: "quay.io/example/mlmd-grpc:latest", ImageSource: config.ImageSourceOperatorConfig},
+	})
+
+	// Images are merged with the endpoints already reported
+	assert.Equal(t, "http://apiserver.svc.cluster.local", status.APIServer.Url)
+	assert.Equal(t, "quay.io/example/api-server:custom", status.APIServer.Image)
+	assert.Equal(t, config.ImageSourceDSPA, status.APIServer.ImageSource)
+	assert.Equal(t, "quay.io/example/mlmd-grpc:latest", status.MLMDGRPC.Image)
+	assert.Empty(t, status.MariaDB.Image)
+}
+
+func TestSetComponentImageDigests(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.DSPVersion = "v2"
+	dspa.Status.Components = dspav1.ComponentStatus{
+		APIServer:        dspav1.ComponentDetailStatus{Image: "quay.io/example/api-server:custom"},
+		PersistenceAgent: dspav1.ComponentDetailStatus{Image: "quay.io/example/persistenceagent:latest"},
+		MLMDGRPC:         dspav1.ComponentDetailStatus{Image: "quay.io/example/mlmd-grpc@sha256:ccc"},
+	}
+	createPod := func(name, image, imageID string) {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Image: image, ImageID: imageID}},
+			},
+		}
+		pod.Name, pod.Namespace = name, dspa.Namespace
+		pod.Labels = map[string]string{"component": "data-science-pipelines", "dspa": dspa.Name}
+		require.Nil(t, reconciler.Create(ctx, pod))
+	}
+	createPod("apiserver-1", "quay.io/example/api-server:custom", "docker-pullable://quay.io/example/api-server@sha256:aaa")
+	createPod("apiserver-2", "quay.io/example/api-server:custom", "quay.io/example/api-server@sha256:aaa")
+	// Pods rolling out a new build of the same tag
+	createPod("persistenceagent-1", "quay.io/example/persistenceagent:latest", "quay.io/example/persistenceagent@sha256:bbb")
+	createPod("persistenceagent-2", "quay.io/example/persistenceagent:latest", "quay.io/example/persistenceagent@sha256:bb2")
+
+	reconciler.setComponentImageDigests(ctx, dspa, reconciler.Log)
+
+	// Assert the digests reported by the pods, or pinned in the images, are recorded
+	assert.Equal(t, "sha256:aaa", dspa.Status.Components.APIServer.ImageDigest)
+	assert.Equal(t, "sha256:ccc", dspa.Status.Components.MLMDGRPC.ImageDigest)
+	assert.Empty(t, dspa.Status.Components.PersistenceAgent.ImageDigest)
+	assert.Equal(t, &dspav1.DSPVersionDetail{
+		DSPVersion: "v2",
+		Images: map[string]string{
+			"apiServer":        "quay.io/example/api-server@sha256:aaa",
+			"persistenceAgent": "quay.io/example/persistenceagent:latest",
+			"mlmdGRPC":         "quay.io/example/mlmd-grpc@sha256:ccc",
+		},
+	}, dspa.Status.DSPVersionDetail)
+}
+
+func TestReconcileComponents(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	defer viper.Set(config.ReconcileParallelismConfigName, nil)
+
+	var running, maxRunning int32
+	var reported []string
+	component := func(name string, err error) componentReconcile {
+		return componentReconcile{
+			name: name,
+			reconcile: func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					current := atomic.LoadInt32(&maxRunning)
+					if n <= current || atomic.CompareAndSwapInt32(&maxRunning, current, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return err
+			},
+			setStatus: func(err error) {
+				reported = append(reported, name)
+			},
+		}
+	}
+	components := []componentReconcile{
+		component("first", nil),
+		component("second", errors.New("second failed")),
+		component("third", nil),
+		component("fourth", errors.New("fourth failed")),
+	}
+
+	// Assert the components are reconciled concurrently, bounded by the parallelism
+	viper.Set(config.ReconcileParallelismConfigName, 2)
+	err := reconciler.reconcileComponents(ctx, components)
+	assert.ErrorContains(t, err, "second failed")
+	assert.ErrorContains(t, err, "fourth failed")
+	assert.Equal(t, int32(2), maxRunning)
+	// Assert the status is reported in order, including after an error
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, reported)
+
+	// Assert a parallelism of 1 reconciles the components in sequence
+	viper.Set(config.ReconcileParallelismConfigName, 1)
+	maxRunning, reported = 0, nil
+	err = reconciler.reconcileComponents(ctx, components)
+	assert.ErrorContains(t, err, "second failed")
+	assert.Equal(t, int32(1), maxRunning)
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, reported)
+}
+
+// BenchmarkReconcileDSPAs reconciles the API Server of 100 DSPAs, as on a
+// resync of a cluster running that many, with the templates parsed once and
+// on every render. Secrets are read from the client, which in the manager is
+// backed by the informer cache.
+// go test -tags=test_unit ./controllers/ -run '^$' -bench BenchmarkReconcileDSPAs -benchmem
+func BenchmarkReconcileDSPAs(b *testing.B) {
+	const dspaCount = 100
+	for name, templates := range map[string]fs.FS{
+		"parsed-once":         config.CachedTemplates(manifests.Templates()),
+		"parsed-every-render": manifests.Templates(),
+	} {
+		b.Run(name, func(b *testing.B) {
+			ctx, _, reconciler := CreateNewTestObjects()
+			reconciler.Templates = templates
+			dspas := make([]*dspav1.DataSciencePipelinesApplication, dspaCount)
+			for i := range dspas {
+				dspas[i] = testutil.CreateDefaultDSPA()
+				dspas[i].Namespace = fmt.Sprintf("testnamespace-%d", i)
+			}
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				for _, dspa := range dspas {
+					params := &DSPAParams{}
+					if err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log); err != nil {
+						b.Fatal(err)
+					}
+					if err := reconciler.ReconcileAPIServer(ctx, dspa, params); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateStatusUnchanged(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	reconciler.Client = fake.NewClientBuilder().WithScheme(reconciler.Scheme).
+		WithStatusSubresource(&dspav1.DataSciencePipelinesApplication{}).Build()
+	dspa := testutil.CreateDefaultDSPA()
+	require.Nil(t, reconciler.Create(ctx, dspa))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: dspa.Name, Namespace: dspa.Namespace}}
+	newStatus := func() dspastatus.DSPAStatus {
+		status := dspastatus.NewDSPAStatus(dspa)
+		status.SetDatabaseReady()
+		status.SetObjStoreReady()
+		return status
+	}
+
+	reconciler.updateStatus(ctx, dspa, newStatus(), reconciler.Log, req)
+	written := dspa.ResourceVersion
+	transitioned := meta.FindStatusCondition(dspa.Status.Conditions, config.DatabaseAvailable).LastTransitionTime
+
+	// Assert an identical status is not written again, and keeps its transition times
+	reconciler.updateStatus(ctx, dspa, newStatus(), reconciler.Log, req)
+	assert.Equal(t, written, dspa.ResourceVersion)
+	assert.Equal(t, transitioned, meta.FindStatusCondition(dspa.Status.Conditions, config.DatabaseAvailable).LastTransitionTime)
+
+	// Assert a changed status is written
+	status := newStatus()
+	status.SetDatabaseNotReady(errors.New("connection refused"), config.FailingToDeploy)
+	reconciler.updateStatus(ctx, dspa, status, reconciler.Log, req)
+	assert.NotEqual(t, written, dspa.ResourceVersion)
+}
@@ -26,10 +26,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	manifests "github.com/opendatahub-io/data-science-pipelines-operator/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -47,16 +50,18 @@ func NewFakeController() *DSPAReconciler {
 	utilruntime.Must(routev1.Install(FakeScheme))
 	utilruntime.Must(dspav1.AddToScheme(FakeScheme))
 	FakeBuilder.WithScheme(FakeScheme)
+	// Map the kinds of the scheme, so that the platform is detected as OpenShift
+	FakeBuilder.WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(FakeScheme))
 
 	// Build Fake Client
 	FakeClient := FakeBuilder.Build()
 
 	// Generate DSPAReconciler using Fake Client
 	r := &DSPAReconciler{
-		Client:        FakeClient,
-		Log:           ctrl.Log.WithName("controllers").WithName("ds-pipelines-controller"),
-		Scheme:        FakeScheme,
-		TemplatesPath: "../config/internal/",
+		Client:    FakeClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("ds-pipelines-controller"),
+		Scheme:    FakeScheme,
+		Templates: config.CachedTemplates(manifests.Templates()),
 	}
 
 	return r
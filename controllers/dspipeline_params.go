@@ -19,12 +19,20 @@ package controllers
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	cryptoTls "crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
+	"math/big"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,28 +44,133 @@ import (
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
 	routev1 "github.com/openshift/api/route/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const MlmdIsRequired = "MLMD explicitly disabled in DSPA, but is a required component for DSP"
 
+// ErrImageNotPinned is returned by ExtractParams when image digests are
+// required but a default image could not be pinned to a digest.
+var ErrImageNotPinned = errors.New("image is not pinned by digest")
+
+// ErrImageSignatureNotVerified is returned by ExtractParams when a cosign
+// public key is configured but a default image has no valid signature.
+var ErrImageSignatureNotVerified = errors.New("image signature is not verified")
+
+// ErrInvalidAPIServerArgs is returned by ExtractParams when the extraArgs or
+// featureFlags of the API Server are malformed or override operator managed flags.
+var ErrInvalidAPIServerArgs = errors.New("invalid API Server extraArgs or featureFlags")
+
+// ErrInvalidTimezone is returned by ExtractParams when the cronScheduleTimezone
+// of the ScheduledWorkflow is not in the IANA time zone database.
+var ErrInvalidTimezone = errors.New("invalid cronScheduleTimezone")
+
+// ErrInvalidFeatureGates is returned by ExtractParams when the feature-gates
+// annotation of the DSPA is malformed or sets unknown gates.
+var ErrInvalidFeatureGates = errors.New("invalid feature gates")
+
+var apiServerArgPattern = regexp.MustCompile(`^--?([A-Za-z][A-Za-z0-9_.-]*)(=.*)?$`)
+
+// mariaDBOptionPattern matches the my.cnf option names, e.g. max_connections
+// or character-set-server.
+var mariaDBOptionPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// mariaDBConfig merges overrides over the default MariaDB server settings, and
+// returns them with their hash.
+func mariaDBConfig(overrides map[string]string) (map[string]string, string, error) {
+	merged := map[string]string{}
+	for option, value := range config.MariaDBDefaultConfig {
+		merged[option] = value
+	}
+	for option, value := range overrides {
+		if !mariaDBOptionPattern.MatchString(option) {
+			return nil, "", fmt.Errorf("[spec.database.mariaDB.config] invalid option name %q", option)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, "", fmt.Errorf("[spec.database.mariaDB.config] the value of option %q must be a single line", option)
+		}
+		merged[option] = value
+	}
+
+	options := make([]string, 0, len(merged))
+	for option := range merged {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+	hash := sha256.New()
+	for _, option := range options {
+		fmt.Fprintf(hash, "%s=%s\n", option, merged[option])
+	}
+	return merged, fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// apiServerManagedFlags are set by the operator in the API Server deployment.
+var apiServerManagedFlags = map[string]bool{
+	"config":         true,
+	"sampleconfig":   true,
+	"logtostderr":    true,
+	"logLevel":       true,
+	"tlsCertPath":    true,
+	"tlsCertKeyPath": true,
+	"httpPortFlag":   true,
+	"rpcPortFlag":    true,
+}
+
+var imageDigestResolver = util.NewImageDigestResolver(&http.Client{Timeout: 10 * time.Second}, time.Hour)
+
 type DSPAParams struct {
-	IncludeOwnerReference                bool
-	UID                                  types.UID
-	Name                                 string
-	APIVersion                           string
-	Kind                                 string
-	Namespace                            string
-	Owner                                mf.Owner
-	DSPVersion                           string
-	APIServer                            *dspa.APIServer
-	APIServerDefaultResourceName         string
-	APIServerServiceName                 string
-	APIServerConfigHash                  string
+	ReconcileID           string
+	ImagePullSecrets      []v1.LocalObjectReference
+	ImageRegistryOverride string
+	ResolvedImageDigests  map[string]string
+	ManagementState       dspa.ManagementState
+	// PendingChanges is the report of the diff ConfigMap, see ReconcileDiff
+	PendingChanges *ManifestDiff
+	defaultImages  map[string]bool
+	// imageArchitectures maps the default images set per node architecture to
+	// the architecture they were selected for, see architectureImage.
+	imageArchitectures map[string]string
+	nodeArchitectures  map[string]int
+	// ComponentImages is the image resolved for each deployed component, keyed by its status field name.
+	ComponentImages                 map[string]dspa.ComponentDetailStatus
+	IncludeOwnerReference           bool
+	UID                             types.UID
+	Name                            string
+	APIVersion                      string
+	Kind                            string
+	Namespace                       string
+	Owner                           mf.Owner
+	DSPVersion                      string
+	APIServer                       *dspa.APIServer
+	APIServerDefaultResourceName    string
+	APIServerServiceName            string
+	APIServerConfigHash             string
+	CacheCleanupDefaultResourceName string
+	RunRetentionDefaultResourceName string
+	AuditLogDefaultResourceName     string
+	// RunRetentionTTLSeconds is the completed run TTL of the run retention job, 0 when unset.
+	RunRetentionTTLSeconds int64
+	// RunRetentionArchivedTTLSeconds is the archived run TTL of the run retention job, 0 when unset.
+	RunRetentionArchivedTTLSeconds int64
+	// AuditLogExportIntervalSeconds is the interval on which audit log entries are shipped to the object storage.
+	AuditLogExportIntervalSeconds int64
+	// APIServerRolloutPreviousImage is the image the API server reverts to
+	// should the staged rollout of its default image be aborted.
+	APIServerRolloutPreviousImage string
+	// APIServerImageHeld is true when the staged rollout keeps the API server
+	// on its current image rather than the operator config default.
+	APIServerImageHeld                   bool
 	OAuthProxy                           string
 	SampleConfigJSON                     string
 	ScheduledWorkflow                    *dspa.ScheduledWorkflow
@@ -66,15 +179,75 @@ type DSPAParams struct {
 	PersistentAgentDefaultResourceName   string
 	MlPipelineUI                         *dspa.MlPipelineUI
 	MariaDB                              *dspa.MariaDB
-	Minio                                *dspa.Minio
-	MLMD                                 *dspa.MLMD
-	MlmdProxyDefaultResourceName         string
-	MlmdGrpcCertificateContents          string
-	MlmdGrpcPrivateKeyContents           string
-	WorkflowController                   *dspa.WorkflowController
-	CustomKfpLauncherConfigMapData       string
+	// MariaDBNamespace is the namespace the managed MariaDB is deployed in,
+	// the DSPA namespace unless spec.database.mariaDB.namespace is set
+	MariaDBNamespace string
+	// MariaDBConfig are the server settings of the managed MariaDB, the
+	// operator defaults merged with spec.database.mariaDB.config
+	MariaDBConfig map[string]string
+	// MariaDBConfigHash restarts the MariaDB pod when its settings change
+	MariaDBConfigHash string
+	// MariaDBGaleraAddress lists the nodes of the Galera cluster, when
+	// spec.database.mariaDB.highAvailability is enabled
+	MariaDBGaleraAddress string
+	// MariaDBGaleraQuorum is the number of Galera nodes kept available through
+	// voluntary disruptions
+	MariaDBGaleraQuorum int32
+	Minio               *dspa.Minio
+	// MinioNamespace is the namespace the managed Minio is deployed in, the
+	// DSPA namespace unless spec.objectStorage.minio.namespace is set
+	MinioNamespace string
+	// MinioServerPool addresses the drives of the nodes of a distributed Minio
+	MinioServerPool string
+	// MinioStorageClass is the erasure coding parity of a distributed Minio
+	MinioStorageClass string
+	// MonitoringScrape is how Prometheus discovers the metrics exporters of
+	// spec.monitoring, ServiceMonitor or Annotations
+	MonitoringScrape string
+	// MariaDBExporter is set when the managed MariaDB runs a mysqld-exporter sidecar
+	MariaDBExporter *dspa.MariaDBExporter
+	// MinioMetrics is set when the managed Minio serves its metrics to Prometheus
+	MinioMetrics *dspa.MinioExporter
+	// GrafanaInstanceSelector selects the Grafana instances importing the DSPA
+	// dashboard, set when spec.monitoring.dashboards is enabled and the
+	// Grafana operator is installed
+	GrafanaInstanceSelector map[string]string
+	// AlertSeverities are the severities of the DSPA alerts by alert name, set
+	// when spec.monitoring.alerts is enabled
+	AlertSeverities map[string]string
+	// AlertLabels are added to every DSPA alert
+	AlertLabels                    map[string]string
+	MLMD                           *dspa.MLMD
+	MlmdProxyDefaultResourceName   string
+	MlmdGrpcCertificateContents    string
+	MlmdGrpcPrivateKeyContents     string
+	MlmdGRPCServiceName            string
+	WorkflowController             *dspa.WorkflowController
+	CustomKfpLauncherConfigMapData string
+	// DatabaseBackup dumps the database on a schedule, nil unless
+	// spec.database.backup is enabled
+	DatabaseBackup                         *dspa.DatabaseBackup
+	DatabaseBackupDefaultResourceName      string
+	DatabaseBackupVerificationResourceName string
+	// UpgradeResourceName names the resources of the DSP v1 to v2 upgrade.
+	UpgradeResourceName  string
+	UpgradeJobImage      string
+	UpgradeBackupPVCSize resource.Quantity
+	// VisualizationServer renders the visualizations requested through the API Server.
+	VisualizationServer                    *dspa.VisualizationServer
+	VisualizationServerDefaultResourceName string
+	// CRDViewer serves the TensorBoard viewers opened from the KFP UI.
+	CRDViewer                    *dspa.CRDViewer
+	CRDViewerDefaultResourceName string
+	// MlPipelineUICustomizationJSON is merged into the UI ConfigMap, empty when the UI is not customized.
+	MlPipelineUICustomizationJSON string
+	MlPipelineUILogoMountPath     string
+	// MlPipelineUIArtifactProxy is where the UI fetches artifacts from, nil unless spec.mlpipelineUI.artifactProxy is set.
+	MlPipelineUIArtifactProxy *UIArtifactProxyConnection
 	DBConnection
 	ObjectStorageConnection
+	// OCIRegistry stores the run artifacts, nil unless spec.objectStorage.ociRegistry is set
+	OCIRegistry *dspa.OCIRegistry
 
 	// TLS
 	// The CA bundle path used by API server
@@ -95,8 +268,100 @@ type DSPAParams struct {
 	DSPONamespace  string
 	// Use to enable tls communication between component pods.
 	PodToPodTLS bool
+	// Deploy components in a FIPS compliant configuration
+	FIPSEnabled bool
+	// FeatureGates are the enablement of the operator feature gates for the
+	// DSPA, see FeatureEnabled
+	FeatureGates map[string]bool
+	// CertManagerIssuer is set when component certificates are issued by
+	// cert-manager rather than OpenShift service-ca
+	CertManagerIssuer *dspa.CertManagerIssuerRef
+	// Certificates requested from CertManagerIssuer
+	TLSCertificates []TLSCertificate
+	// CA that issued the TLSCertificates, once they are issued
+	CertManagerCABundle []byte
 
 	APIServerServiceDNSName string
+	// Ports the API Server listens on, from spec.apiServer.ports
+	APIServerHTTPPort int32
+	APIServerGRPCPort int32
+	// Validated extraArgs and featureFlags appended to the API Server command
+	APIServerExtraArgs []string
+	// OpenShift groups authorized to use the API Server, empty unless spec.apiServer.security.rbac.mode is Groups
+	APIServerAccessGroups []string
+	// Set when spec.serviceMesh is enabled, the API Server and UI are then exposed
+	// through the mesh Gateway rather than Routes and the OAuth proxy
+	ServiceMesh                 *dspa.ServiceMesh
+	ServiceMeshGatewayNamespace string
+	// Set when spec.multiTenancy is enabled, the API Server then serves the
+	// pipelines of its namespaces, where the execution components are deployed
+	MultiTenancy *dspa.MultiTenancy
+	// Platform the DSPA is deployed on, Kubernetes is set on the kubernetes
+	// platform, where components are exposed by Ingresses and authenticated
+	// by oauth2-proxy rather than by Routes and the OpenShift OAuth proxy
+	Platform   dspa.Platform
+	Kubernetes *KubernetesPlatform
+	// Limits on the runs of the DSPA, enforced by its workflow controller
+	Limits *dspa.RunLimits
+	// Defaults of all pipeline step pods, WorkflowPodSpecPatch is the JSON podSpecPatch of the
+	// workflow defaults that applies them along with the default workspace mount
+	PodDefaults          *dspa.PodDefaults
+	WorkflowPodSpecPatch string
+	// ArtifactStepResources is the JSON of the resource requirements of the
+	// executor containers of all pipeline steps, from spec.apiServer.artifactStepResources
+	ArtifactStepResources string
+	// Endpoints published in the connection info ConfigMap, set by ReconcileConnectionInfo
+	ConnectionInfo *ConnectionInfo
+	// PVC mounted in all pipeline steps, when spec.apiServer.defaultWorkspace is set
+	DefaultWorkspace        *dspa.DefaultWorkspace
+	DefaultWorkspacePVCName string
+	// Pipeline step caching, CacheStaleness is an ISO 8601 duration
+	CacheEnabled   bool
+	CacheStaleness string
+	// Pool of the API Server database connections, the connection limits are
+	// left to the API Server defaults when nil
+	DBMaxOpenConnections    *int32
+	DBMaxIdleConnections    *int32
+	DBConnectionMaxLifetime string
+	DBInitConnectionTimeout string
+	// MLMD Envoy proxy settings rendered into its ConfigMap, the
+	// upstream timeout is in the seconds format Envoy expects
+	MlmdEnvoyUpstreamTimeout  string
+	MlmdEnvoyExtraHTTPFilters []map[string]interface{}
+	// Replicas of the API Server, UI and MLMD, more than one when spec.ha is set
+	HA       bool
+	Replicas int32
+
+	// ServiceAccounts components run as, either created by the
+	// operator or pre-existing ones set in the DSPA
+	APIServerServiceAccountName          string
+	PersistenceAgentServiceAccountName   string
+	ScheduledWorkflowServiceAccountName  string
+	MlPipelineUIServiceAccountName       string
+	WorkflowControllerServiceAccountName string
+	MlmdEnvoyServiceAccountName          string
+	MlmdGRPCServiceAccountName           string
+	MariaDBServiceAccountName            string
+	MinioServiceAccountName              string
+
+	// Namespace shared resources that were not applied because
+	// they are already owned by another DSPA in this namespace
+	ResourceConflicts []string
+}
+
+// UIArtifactProxyConnection is the object storage endpoint the UI fetches
+// artifacts from.
+type UIArtifactProxyConnection struct {
+	Host             string
+	Port             string
+	Secure           bool
+	ReuseCredentials bool
+	PathStyle        bool
+}
+
+type TLSCertificate struct {
+	SecretName string
+	DNSNames   []string
 }
 
 type DBConnection struct {
@@ -105,23 +370,125 @@ type DBConnection struct {
 	Username          string
 	DBName            string
 	CredentialsSecret *dspa.SecretKeyValue
-	Password          string
-	DecodedPassword   string
-	ExtraParams       string
+	// CredentialsSecretLabels are added to the generated credentials Secret, and
+	// MigrateCredentialsSecretFrom names the Secret of the default name that
+	// generated credentials are migrated from once the Secret is renamed
+	CredentialsSecretLabels      map[string]string
+	MigrateCredentialsSecretFrom string
+	// ExternalSecret is set when the credentials Secret is materialized
+	// by the External Secrets Operator
+	ExternalSecret *dspa.ExternalSecretRef
+	Password       Credential
+	ExtraParams    string
+	// TLS of the connections to an external database, nil for the managed MariaDB
+	TLS *DBConnectionTLS
+}
+
+type DBConnectionTLS struct {
+	Mode             string
+	VerifyServerCert bool
+	// ClientCertSecret holds the client certificate mounted into MLMD, and
+	// ClientCertificate its parsed certificate and key presented by the
+	// database health check
+	ClientCertSecret    string
+	ClientCertMountPath string
+	ClientCertificate   *cryptoTls.Certificate
+}
+
+// externalDBTLSParams are the tls DSN params of the external database TLS
+// modes, as understood by the MySQL driver of the API Server
+var externalDBTLSParams = map[string]string{
+	"disable":     "false",
+	"preferred":   "preferred",
+	"require":     "skip-verify",
+	"verify-full": "true",
 }
+
 type ObjectStorageConnection struct {
 	Bucket            string
+	ArtifactBucket    string
+	PipelineBucket    string
 	CredentialsSecret *dspa.S3CredentialSecret
-	Host              string
-	Port              string
-	Scheme            string
-	Region            string
-	BasePath          string
-	Secure            *bool
-	Endpoint          string // scheme://host:port
-	AccessKeyID       string
-	SecretAccessKey   string
-	ExternalRouteURL  string
+	// CredentialsSecretLabels and MigrateCredentialsSecretFrom as for DBConnection
+	CredentialsSecretLabels      map[string]string
+	MigrateCredentialsSecretFrom string
+	// PropagatedNamespaces are the namespaces the credentials Secret is copied into
+	PropagatedNamespaces []string
+	Host                 string
+	Port                 string
+	Scheme               string
+	Region               string
+	BasePath             string
+	Secure               *bool
+	Endpoint             string // scheme://host:port
+	AccessKeyID          Credential
+	SecretAccessKey      Credential
+	ExternalRouteURL     string
+	// Endpoints are the primary endpoint followed by the failover endpoints of
+	// spec.objectStorage.externalStorage by priority, empty without failover
+	// endpoints. ActiveEndpoint indexes the endpoint in use, see failOver.
+	Endpoints      []ObjectStorageEndpoint
+	ActiveEndpoint int
+	// MaxRetries of the API Server artifact client, 0 for its default
+	MaxRetries int32
+}
+
+// ObjectStorageEndpoint is an endpoint the object storage can fail over to.
+type ObjectStorageEndpoint struct {
+	Host     string
+	Port     string
+	Scheme   string
+	Region   string
+	Bucket   string
+	Secure   *bool
+	Endpoint string // scheme://host:port
+}
+
+// failOver points the connection at its endpoint i. The separate buckets
+// cannot be set along with the failover endpoints, the buckets are all moved
+// to the bucket of the endpoint.
+func (c *ObjectStorageConnection) failOver(i int) {
+	endpoint := c.Endpoints[i]
+	c.ActiveEndpoint = i
+	c.Host, c.Port, c.Scheme, c.Region = endpoint.Host, endpoint.Port, endpoint.Scheme, endpoint.Region
+	c.Secure, c.Endpoint = endpoint.Secure, endpoint.Endpoint
+	c.Bucket, c.ArtifactBucket, c.PipelineBucket = endpoint.Bucket, endpoint.Bucket, endpoint.Bucket
+}
+
+// StandbyEndpoints returns the endpoints other than the active one, comma
+// separated in priority order.
+func (c *ObjectStorageConnection) StandbyEndpoints() string {
+	var standby []string
+	for i, endpoint := range c.Endpoints {
+		if i != c.ActiveEndpoint {
+			standby = append(standby, endpoint.Endpoint)
+		}
+	}
+	return strings.Join(standby, ",")
+}
+
+// objectStorageEndpoint returns the scheme://host:port URL of an endpoint, the
+// port is omitted when empty.
+func objectStorageEndpoint(scheme, host, port string) string {
+	endpoint := fmt.Sprintf("%s://%s", scheme, host)
+	if port != "" {
+		endpoint = fmt.Sprintf("%s:%s", endpoint, port)
+	}
+	return endpoint
+}
+
+// DistinctBuckets returns every bucket the components use, once each.
+func (c *ObjectStorageConnection) DistinctBuckets() []string {
+	var buckets []string
+	for _, bucket := range []string{c.PipelineBucket, c.ArtifactBucket} {
+		if bucket == "" {
+			bucket = c.Bucket
+		}
+		if !slices.Contains(buckets, bucket) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
 }
 
 // UsingExternalDB will return true if an external Database is specified in the CR, otherwise false.
@@ -132,6 +499,182 @@ func (p *DSPAParams) UsingExternalDB(dsp *dspa.DataSciencePipelinesApplication)
 	return false
 }
 
+// MariaDBHighAvailability returns true when the managed MariaDB is deployed as
+// a Galera cluster.
+func (p *DSPAParams) MariaDBHighAvailability() bool {
+	return p.MariaDB != nil && p.MariaDB.HighAvailability != nil && p.MariaDB.HighAvailability.Enabled
+}
+
+// setupMariaDBGalera validates spec.database.mariaDB.highAvailability and
+// addresses the nodes of the Galera cluster through its headless Service.
+func (p *DSPAParams) setupMariaDBGalera() error {
+	if !p.MariaDBHighAvailability() {
+		return nil
+	}
+	ha := p.MariaDB.HighAvailability
+	if ha.Replicas == 0 {
+		ha.Replicas = config.MariaDBGaleraReplicas
+	}
+	if ha.Replicas < 3 || ha.Replicas%2 == 0 {
+		return fmt.Errorf("[spec.database.mariaDB.highAvailability.replicas] must be an odd number of at least 3, got %d", ha.Replicas)
+	}
+
+	name := config.MariaDBHostPrefix + "-" + p.Name
+	nodes := make([]string, 0, ha.Replicas)
+	for i := int32(0); i < ha.Replicas; i++ {
+		nodes = append(nodes, fmt.Sprintf("%s-%d.%s-galera.%s.svc.cluster.local", name, i, name, p.MariaDBNamespace))
+	}
+	p.MariaDBGaleraAddress = "gcomm://" + strings.Join(nodes, ",")
+	p.MariaDBGaleraQuorum = ha.Replicas/2 + 1
+	return nil
+}
+
+// componentNamespace validates the namespace of a component set in field, and
+// returns the namespace the component is deployed in, the DSPA namespace when
+// it is not set. The namespace must opt in to the components of the DSPA
+// namespace with the ComponentSourceNamespacesAnnotation. The certificates and
+// mesh resources of the components are only applied in the DSPA namespace, as
+// such TLS and the service mesh cannot be enabled along with it.
+func (p *DSPAParams) componentNamespace(ctx context.Context, client client.Client, field, namespace string) (string, error) {
+	if namespace == "" || namespace == p.Namespace {
+		return p.Namespace, nil
+	}
+	if p.CertManagerIssuer != nil {
+		return "", fmt.Errorf("[%s] and [spec.tls.issuerRef] must not be set together, the Certificates are issued in the DSPA namespace", field)
+	}
+	if p.ServiceMesh != nil {
+		return "", fmt.Errorf("[%s] and [spec.serviceMesh] must not be set together, the mesh resources are applied in the DSPA namespace", field)
+	}
+	inScope, err := util.NamespaceInScope(ctx, namespace, client)
+	if err != nil {
+		return "", err
+	} else if !inScope {
+		return "", fmt.Errorf("[%s] namespace %s is not in the reconciliation scope of the operator", field, namespace)
+	}
+	ns := &v1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: namespace}, ns); apierrs.IsNotFound(err) {
+		return "", fmt.Errorf("[%s] namespace %s does not exist", field, namespace)
+	} else if err != nil {
+		return "", err
+	}
+	for _, source := range strings.Split(ns.Annotations[config.ComponentSourceNamespacesAnnotation], ",") {
+		if strings.TrimSpace(source) == p.Namespace {
+			return namespace, nil
+		}
+	}
+	return "", fmt.Errorf("[%s] namespace %s does not allow the components of DSPAs in namespace %s, it must be annotated with %s=%s",
+		field, namespace, p.Namespace, config.ComponentSourceNamespacesAnnotation, p.Namespace)
+}
+
+// SetupMultiTenancy validates the namespaces of spec.multiTenancy, which are
+// set up as component namespaces and must not have a DSPA of their own, as the
+// resources of the pipeline runs are named after the namespace. The Cluster
+// workflow controller scope must be allowed by the operator config.
+func (p *DSPAParams) SetupMultiTenancy(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, cl client.Client) error {
+	p.MultiTenancy = nil
+	if dsp.Spec.MultiTenancy == nil || !dsp.Spec.MultiTenancy.Enabled {
+		return nil
+	}
+	if p.APIServer == nil || !p.APIServer.Deploy {
+		return fmt.Errorf("[spec.multiTenancy] requires [spec.apiServer] to be deployed")
+	}
+	multiTenancy := dsp.Spec.MultiTenancy.DeepCopy()
+	setStringDefault(config.WorkflowControllerScopeNamespace, &multiTenancy.WorkflowControllerScope)
+	if multiTenancy.WorkflowControllerScope == config.WorkflowControllerScopeCluster && (p.WorkflowController == nil || !p.WorkflowController.Deploy) {
+		return fmt.Errorf("[spec.multiTenancy.workflowControllerScope] %s requires [spec.workflowController] to be deployed", config.WorkflowControllerScopeCluster)
+	}
+	if multiTenancy.WorkflowControllerScope == config.WorkflowControllerScopeCluster &&
+		!config.GetBoolConfigWithDefault(config.MultiTenancyAllowClusterScopeConfigName, config.DefaultMultiTenancyAllowClusterScope) {
+		return fmt.Errorf("[spec.multiTenancy.workflowControllerScope] %s is not allowed, the operator config %s must be enabled",
+			config.WorkflowControllerScopeCluster, config.MultiTenancyAllowClusterScopeConfigName)
+	}
+	for _, namespace := range multiTenancy.Namespaces {
+		if namespace == p.Namespace {
+			return fmt.Errorf("[spec.multiTenancy.namespaces] must not list the DSPA namespace %s", namespace)
+		}
+		if _, err := p.componentNamespace(ctx, cl, "spec.multiTenancy.namespaces", namespace); err != nil {
+			return err
+		}
+		dspas := &dspa.DataSciencePipelinesApplicationList{}
+		if err := cl.List(ctx, dspas, client.InNamespace(namespace)); err != nil {
+			return err
+		}
+		if len(dspas.Items) > 0 {
+			return fmt.Errorf("[spec.multiTenancy.namespaces] namespace %s has a DSPA of its own, %s", namespace, dspas.Items[0].Name)
+		}
+	}
+	p.MultiTenancy = multiTenancy
+	return nil
+}
+
+// FeatureEnabled returns true when the feature gate name is enabled for the
+// DSPA. Params not extracted from a DSPA resolve the operator feature gates.
+func (p *DSPAParams) FeatureEnabled(name string) bool {
+	if p.FeatureGates == nil {
+		gates, _ := config.ResolveFeatureGates("")
+		return gates[name]
+	}
+	return p.FeatureGates[name]
+}
+
+// DeclarativePipelinesEnabled returns true when the operator syncs the
+// Pipeline and RecurringRun resources of the DSPA into its API server, which
+// it then reaches directly.
+func (p *DSPAParams) DeclarativePipelinesEnabled() bool {
+	return p.FeatureEnabled(config.DeclarativePipelinesFeatureGate)
+}
+
+// ActiveFeatureGates returns the names of the feature gates enabled for the
+// DSPA, sorted.
+func (p *DSPAParams) ActiveFeatureGates() []string {
+	var active []string
+	for _, name := range config.FeatureGateNames() {
+		if p.FeatureEnabled(name) {
+			active = append(active, name)
+		}
+	}
+	return active
+}
+
+// WorkflowControllerClusterScoped returns true when the workflow controller of
+// the DSPA watches the workflows of all namespaces.
+func (p *DSPAParams) WorkflowControllerClusterScoped() bool {
+	return p.MultiTenancy != nil && p.MultiTenancy.WorkflowControllerScope == config.WorkflowControllerScopeCluster
+}
+
+// MinioDistributed returns true when the managed Minio is deployed as a
+// distributed StatefulSet.
+func (p *DSPAParams) MinioDistributed() bool {
+	return p.Minio != nil && p.Minio.Replicas > 1
+}
+
+// setupMinioDistributed validates spec.objectStorage.minio.replicas, addresses
+// the drive of each node through the headless Service and sets the erasure
+// coding parity to the Minio default for their number.
+func (p *DSPAParams) setupMinioDistributed() error {
+	if !p.MinioDistributed() {
+		return nil
+	}
+	replicas := p.Minio.Replicas
+	if replicas < config.MinioDistributedMinReplicas {
+		return fmt.Errorf("[spec.objectStorage.minio.replicas] a distributed Minio requires at least %d nodes, got %d",
+			config.MinioDistributedMinReplicas, replicas)
+	}
+
+	scheme := config.MinioScheme
+	if p.CertManagerIssuer != nil {
+		scheme = "https"
+	}
+	name := config.MinioHostPrefix + "-" + p.Name
+	p.MinioServerPool = fmt.Sprintf("%s://%s-{0...%d}.%s-hl.%s.svc.cluster.local/data", scheme, name, replicas-1, name, p.MinioNamespace)
+	parity := replicas / 2
+	if parity > 4 {
+		parity = 4
+	}
+	p.MinioStorageClass = fmt.Sprintf("EC:%d", parity)
+	return nil
+}
+
 // DatabaseHealthCheckDisabled will return the value if the Database has disableHealthCheck specified in the CR, otherwise false.
 func (p *DSPAParams) DatabaseHealthCheckDisabled(dsp *dspa.DataSciencePipelinesApplication) bool {
 	if dsp.Spec.Database != nil {
@@ -156,6 +699,19 @@ func (p *DSPAParams) ObjectStorageHealthCheckDisabled(dsp *dspa.DataSciencePipel
 	return false
 }
 
+// EnsureBucketMode will return the ensureBucket mode specified in the CR, otherwise Skip.
+func (p *DSPAParams) EnsureBucketMode(dsp *dspa.DataSciencePipelinesApplication) dspa.EnsureBucketMode {
+	// Buckets are not created for Unmanaged DSPAs
+	if p.ManagementState == dspa.ManagementStateUnmanaged && dsp.Spec.ObjectStorage != nil &&
+		dsp.Spec.ObjectStorage.EnsureBucket == dspa.EnsureBucketCreate {
+		return dspa.EnsureBucketVerify
+	}
+	if dsp.Spec.ObjectStorage != nil && dsp.Spec.ObjectStorage.EnsureBucket != "" {
+		return dsp.Spec.ObjectStorage.EnsureBucket
+	}
+	return dspa.EnsureBucketSkip
+}
+
 // ExternalRouteEnabled will return true if an external route is enabled in the CR, otherwise false.
 func (p *DSPAParams) ExternalRouteEnabled(dsp *dspa.DataSciencePipelinesApplication) bool {
 	if dsp.Spec.ObjectStorage != nil {
@@ -164,29 +720,44 @@ func (p *DSPAParams) ExternalRouteEnabled(dsp *dspa.DataSciencePipelinesApplicat
 	return false
 }
 
-func (p *DSPAParams) RetrieveAndSetExternalRoute(ctx context.Context, client client.Client, log logr.Logger) (*routev1.Route, error) {
-	// Retrieve the external route
-	route := &routev1.Route{}
+// RetrieveExternalRouteHost returns the host of the Minio Route, or of its
+// Ingress on kubernetes.
+func (p *DSPAParams) RetrieveExternalRouteHost(ctx context.Context, client client.Client) (string, error) {
 	namespacedName := types.NamespacedName{
 		Name:      "minio-" + p.Name,
-		Namespace: p.Namespace,
+		Namespace: p.MinioNamespace,
+	}
+	if p.Kubernetes != nil {
+		ingress := &networkingv1.Ingress{}
+		if err := client.Get(ctx, namespacedName, ingress); err != nil || len(ingress.Spec.Rules) == 0 {
+			return "", err
+		}
+		return ingress.Spec.Rules[0].Host, nil
 	}
+	route := &routev1.Route{}
 	err := client.Get(ctx, namespacedName, route)
-
-	return route, err
+	return route.Spec.Host, err
 }
 
+// passwordGen generates credentials using crypto/rand, which is backed by
+// the FIPS validated DRBG when the operator runs in FIPS mode.
 func passwordGen(n int) string {
-	rand.Seed(time.Now().UnixNano())
 	var chars = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890")
 	b := make([]rune, n)
 	for i := range b {
-		b[i] = chars[rand.Intn(len(chars))]
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			panic(fmt.Sprintf("unable to read random bytes: %v", err))
+		}
+		b[i] = chars[idx.Int64()]
 	}
 	return string(b)
 }
 
-func (p *DSPAParams) RetrieveSecret(ctx context.Context, client client.Client, secretName, secretKey string, log logr.Logger) (string, error) {
+func (p *DSPAParams) RetrieveSecret(ctx context.Context, client client.Client, secretName, secretKey string, log logr.Logger) (Credential, error) {
+	ctx, span := tracer.Start(ctx, "RetrieveSecret", trace.WithAttributes(attribute.String("secret_name", secretName)))
+	defer span.End()
+
 	secret := &v1.Secret{}
 	namespacedName := types.NamespacedName{
 		Name:      secretName,
@@ -195,41 +766,96 @@ func (p *DSPAParams) RetrieveSecret(ctx context.Context, client client.Client, s
 	err := client.Get(ctx, namespacedName, secret)
 	if err != nil {
 		log.V(1).Info(fmt.Sprintf("Unable to retrieve secret [%s].", secretName))
-		return "", err
+		return Credential{}, err
 	}
-	return base64.StdEncoding.EncodeToString(secret.Data[secretKey]), nil
+	// client-go decodes the Secret data, the credential is only encoded again when rendered
+	return NewCredential(secret.Data[secretKey]), nil
 }
 
-func (p *DSPAParams) RetrieveOrCreateSecret(ctx context.Context, client client.Client, secretName, secretKey string, generatedPasswordLength int, log logr.Logger) (string, error) {
+func (p *DSPAParams) RetrieveOrCreateSecret(ctx context.Context, client client.Client, secretName, secretKey string, generatedPasswordLength int, log logr.Logger) (Credential, error) {
 	val, err := p.RetrieveSecret(ctx, client, secretName, secretKey, log)
 	if err != nil && apierrs.IsNotFound(err) {
 		generatedPass := passwordGen(generatedPasswordLength)
-		return base64.StdEncoding.EncodeToString([]byte(generatedPass)), nil
+		return NewCredential([]byte(generatedPass)), nil
 	} else if err != nil {
 		log.Error(err, "Unable to create DB secret...")
-		return "", err
+		return Credential{}, err
 	}
 	log.Info(fmt.Sprintf("Secret [%s] already exists, using stored value.", secretName))
 	return val, nil
 }
 
-func (p *DSPAParams) RetrieveOrCreateDBSecret(ctx context.Context, client client.Client, secret *dspa.SecretKeyValue, log logr.Logger) (string, error) {
-	dbPassword, err := p.RetrieveOrCreateSecret(ctx, client, secret.Name, secret.Key, config.GeneratedDBPasswordLength, log)
+// retrieveOrMigrateSecret retrieves secretKey of the Secret secretName, or of
+// the Secret migrateFrom while secretName does not exist, so that generated
+// credentials are kept once their Secret is renamed. The credentials are
+// generated when neither Secret exists.
+func (p *DSPAParams) retrieveOrMigrateSecret(ctx context.Context, client client.Client, secretName, migrateFrom, secretKey string, generatedPasswordLength int, log logr.Logger) (Credential, error) {
+	if migrateFrom != "" {
+		_, err := p.RetrieveSecret(ctx, client, secretName, secretKey, log)
+		if apierrs.IsNotFound(err) {
+			val, err := p.RetrieveSecret(ctx, client, migrateFrom, secretKey, log)
+			if err == nil && !val.Empty() {
+				log.Info(fmt.Sprintf("Migrating the credentials of Secret [%s] to Secret [%s].", migrateFrom, secretName))
+				return val, nil
+			} else if err != nil && !apierrs.IsNotFound(err) {
+				return Credential{}, err
+			}
+		}
+	}
+	return p.RetrieveOrCreateSecret(ctx, client, secretName, secretKey, generatedPasswordLength, log)
+}
+
+// generatedSecret returns the name and labels of a Secret holding generated
+// credentials, as overridden by spec, and the default name to migrate the
+// credentials from when the name is overridden.
+func generatedSecret(field string, spec *dspa.GeneratedSecret, defaultName string) (string, map[string]string, string, error) {
+	if spec == nil {
+		return defaultName, nil, "", nil
+	}
+	if err := validateGeneratedSecretLabels(field, spec.Labels); err != nil {
+		return "", nil, "", err
+	}
+	if spec.Name == "" || spec.Name == defaultName {
+		return defaultName, spec.Labels, "", nil
+	}
+	if errs := validation.IsDNS1123Subdomain(spec.Name); len(errs) > 0 {
+		return "", nil, "", fmt.Errorf("[%s.name] %s", field, strings.Join(errs, ", "))
+	}
+	return spec.Name, spec.Labels, defaultName, nil
+}
+
+// validateGeneratedSecretLabels rejects invalid labels, and the labels set by
+// the operator.
+func validateGeneratedSecretLabels(field string, labels map[string]string) error {
+	for key, value := range labels {
+		if key == "app" || key == "component" {
+			return fmt.Errorf("[%s.labels] label %s is set by the operator", field, key)
+		}
+		if errs := append(validation.IsQualifiedName(key), validation.IsValidLabelValue(value)...); len(errs) > 0 {
+			return fmt.Errorf("[%s.labels] invalid label %s=%s: %s", field, key, value, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+func (p *DSPAParams) RetrieveOrCreateDBSecret(ctx context.Context, client client.Client, secret *dspa.SecretKeyValue, log logr.Logger) (Credential, error) {
+	dbPassword, err := p.retrieveOrMigrateSecret(ctx, client, secret.Name, p.DBConnection.MigrateCredentialsSecretFrom, secret.Key, config.GeneratedDBPasswordLength, log)
 	if err != nil {
-		return "", err
+		return Credential{}, err
 	}
 	return dbPassword, nil
 
 }
 
-func (p *DSPAParams) RetrieveOrCreateObjectStoreSecret(ctx context.Context, client client.Client, secret *dspa.S3CredentialSecret, log logr.Logger) (string, string, error) {
-	accessKey, err := p.RetrieveOrCreateSecret(ctx, client, secret.SecretName, secret.AccessKey, config.GeneratedObjectStorageAccessKeyLength, log)
+func (p *DSPAParams) RetrieveOrCreateObjectStoreSecret(ctx context.Context, client client.Client, secret *dspa.S3CredentialSecret, log logr.Logger) (Credential, Credential, error) {
+	migrateFrom := p.ObjectStorageConnection.MigrateCredentialsSecretFrom
+	accessKey, err := p.retrieveOrMigrateSecret(ctx, client, secret.SecretName, migrateFrom, secret.AccessKey, config.GeneratedObjectStorageAccessKeyLength, log)
 	if err != nil {
-		return "", "", err
+		return Credential{}, Credential{}, err
 	}
-	secretKey, err := p.RetrieveOrCreateSecret(ctx, client, secret.SecretName, secret.SecretKey, config.GeneratedObjectStorageSecretKeyLength, log)
+	secretKey, err := p.retrieveOrMigrateSecret(ctx, client, secret.SecretName, migrateFrom, secret.SecretKey, config.GeneratedObjectStorageSecretKeyLength, log)
 	if err != nil {
-		return "", "", err
+		return Credential{}, Credential{}, err
 	}
 	return accessKey, secretKey, nil
 }
@@ -238,6 +864,7 @@ func (p *DSPAParams) RetrieveOrCreateObjectStoreSecret(ctx context.Context, clie
 // If an external secret is specified, SetupDBParams will retrieve DB credentials from it.
 // If DSPO is managing a dynamically created secret, then SetupDBParams generates the creds.
 func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, client client.Client, log logr.Logger) error {
+	p.MariaDBNamespace = p.Namespace
 
 	usingExternalDB := p.UsingExternalDB(dsp)
 	if usingExternalDB {
@@ -247,11 +874,27 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 		p.DBConnection.Username = dsp.Spec.Database.ExternalDB.Username
 		p.DBConnection.DBName = dsp.Spec.Database.ExternalDB.DBName
 		p.DBConnection.CredentialsSecret = dsp.Spec.Database.ExternalDB.PasswordSecret
+		if dsp.Spec.Database.ExternalDB.PasswordSecretRef != nil {
+			p.DBConnection.ExternalSecret = dsp.Spec.Database.ExternalDB.PasswordSecretRef.DeepCopy()
+			setStringDefault(config.DefaultExternalSecretProvider, &p.DBConnection.ExternalSecret.Provider)
+			setStringDefault(config.DefaultExternalSecretStoreKind, &p.DBConnection.ExternalSecret.SecretStoreKind)
+			setStringDefault(config.DefaultExternalSecretRefreshInterval, &p.DBConnection.ExternalSecret.RefreshInterval)
+			p.DBConnection.CredentialsSecret = &dspa.SecretKeyValue{
+				Name: config.ExternalDBSecretNamePrefix + p.Name,
+				Key:  config.DefaultDBSecretKey,
+			}
+		} else if p.DBConnection.CredentialsSecret == nil {
+			return fmt.Errorf("either [spec.database.externalDB.passwordSecret] or " +
+				"[spec.database.externalDB.passwordSecretRef] need to be specified in DSPA spec")
+		}
 
 		// Assume default external connection is tls enabled
 		// user can override this via CustomExtraParams field
+		if err := p.setupExternalDBTLS(ctx, dsp.Spec.Database.ExternalDB, client); err != nil {
+			return err
+		}
 		tlsParams := config.DBExtraParams{
-			"tls": "true",
+			"tls": externalDBTLSParams[p.DBConnection.TLS.Mode],
 		}
 		dbExtraParams, err := config.GetDefaultDBExtraParams(tlsParams, log)
 		if err != nil {
@@ -267,15 +910,13 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 			return err
 		}
 		p.DBConnection.Password = password
-		decodedPasswordBytes, _ := base64.StdEncoding.DecodeString(password)
-		p.DBConnection.DecodedPassword = string(decodedPasswordBytes)
 	} else {
 		// If no externalDB or mariaDB is specified, DSPO assumes
 		// MariaDB deployment with defaults.
 		if p.MariaDB == nil {
 			p.MariaDB = &dspa.MariaDB{
 				Deploy:    true,
-				Image:     config.GetStringConfigWithDefault(config.MariaDBImagePath, config.DefaultImageValue),
+				Image:     p.defaultImage(config.MariaDBImagePath),
 				Resources: config.MariaDBResourceRequirements.DeepCopy(),
 				Username:  config.MariaDBUser,
 				DBName:    config.MariaDBName,
@@ -286,16 +927,33 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 		// If MariaDB was specified, ensure missing fields are
 		// populated with defaults.
 		if p.MariaDB.Image == "" {
-			p.MariaDB.Image = config.GetStringConfigWithDefault(config.MariaDBImagePath, config.DefaultImageValue)
+			p.MariaDB.Image = p.defaultImage(config.MariaDBImagePath)
 		}
 		setStringDefault(config.MariaDBUser, &p.MariaDB.Username)
 		setStringDefault(config.MariaDBName, &p.MariaDB.DBName)
 		setResourcesDefault(config.MariaDBResourceRequirements, &p.MariaDB.Resources)
+		setProbesDefault(config.MariaDBProbes, &p.MariaDB.Probes)
+		if p.MariaDB.DeploymentStrategy == "" {
+			p.MariaDB.DeploymentStrategy = appsv1.RecreateDeploymentStrategyType
+		}
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.MariaDB.PodSecurityContext, &p.MariaDB.SecurityContext)
+		mariaDBConfig, mariaDBConfigHash, err := mariaDBConfig(p.MariaDB.Config)
+		if err != nil {
+			return err
+		}
+		p.MariaDBConfig, p.MariaDBConfigHash = mariaDBConfig, mariaDBConfigHash
+		p.MariaDBNamespace, err = p.componentNamespace(ctx, client, "spec.database.mariaDB.namespace", p.MariaDB.Namespace)
+		if err != nil {
+			return err
+		}
+		if err := p.setupMariaDBGalera(); err != nil {
+			return err
+		}
 
 		p.DBConnection.Host = fmt.Sprintf(
 			"%s.%s.svc.cluster.local",
 			config.MariaDBHostPrefix+"-"+p.Name,
-			p.Namespace,
+			p.MariaDBNamespace,
 		)
 		p.DBConnection.Port = config.MariaDBHostPort
 		p.DBConnection.Username = p.MariaDB.Username
@@ -307,6 +965,11 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 		if p.PodToPodTLS {
 			tlsParams["tls"] = "true"
 		}
+		if p.MariaDBHighAvailability() {
+			for param, value := range config.MariaDBGaleraDBExtraParams {
+				tlsParams[param] = value
+			}
+		}
 		dbExtraParams, err := config.GetDefaultDBExtraParams(tlsParams, log)
 		if err != nil {
 			log.Error(err, "Unexpected error encountered while retrieving DBExtraparams")
@@ -316,20 +979,28 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 
 		// If custom DB Secret provided, use its values.  Otherwise generate a default
 		if p.MariaDB.PasswordSecret != nil {
+			if p.MariaDB.GeneratedSecret != nil {
+				return fmt.Errorf("[spec.database.mariaDB.generatedSecret] cannot be set along with a passwordSecret")
+			}
 			p.DBConnection.CredentialsSecret = p.MariaDB.PasswordSecret
 		} else {
+			name, labels, migrateFrom, err := generatedSecret("spec.database.mariaDB.generatedSecret",
+				p.MariaDB.GeneratedSecret, config.DefaultDBSecretNamePrefix+p.Name)
+			if err != nil {
+				return err
+			}
 			p.DBConnection.CredentialsSecret = &dspa.SecretKeyValue{
-				Name: config.DefaultDBSecretNamePrefix + p.Name,
+				Name: name,
 				Key:  config.DefaultDBSecretKey,
 			}
+			p.DBConnection.CredentialsSecretLabels = labels
+			p.DBConnection.MigrateCredentialsSecretFrom = migrateFrom
 		}
 		dbPassword, err := p.RetrieveOrCreateDBSecret(ctx, client, p.DBConnection.CredentialsSecret, log)
 		if err != nil {
 			return err
 		}
 		p.DBConnection.Password = dbPassword
-		decodedPasswordBytes, _ := base64.StdEncoding.DecodeString(dbPassword)
-		p.DBConnection.DecodedPassword = string(decodedPasswordBytes)
 	}
 
 	// User specified custom Extra parameters will always take precedence
@@ -344,43 +1015,166 @@ func (p *DSPAParams) SetupDBParams(ctx context.Context, dsp *dspa.DataSciencePip
 		p.DBConnection.ExtraParams = *dsp.Spec.Database.CustomExtraParams
 	}
 
-	if p.DBConnection.Password == "" {
+	// Secrets materialized by the External Secrets Operator are waited on during reconciliation
+	if p.DBConnection.Password.Empty() && p.DBConnection.ExternalSecret == nil {
 		return fmt.Errorf("db password from secret [%s] for key [%s] was not successfully retrieved, ensure that the secret with this key exist",
 			p.DBConnection.CredentialsSecret.Name, p.DBConnection.CredentialsSecret.Key)
 	}
 	return nil
 }
 
+// setupExternalDBTLS validates the TLS settings of externalDB, and loads the
+// client certificate presented by the database health check.
+func (p *DSPAParams) setupExternalDBTLS(ctx context.Context, externalDB *dspa.ExternalDB, client client.Client) error {
+	p.DBConnection.TLS = &DBConnectionTLS{Mode: config.ExternalDBTLSModeVerifyFull}
+	if externalDB.TLS != nil {
+		setStringDefault(config.ExternalDBTLSModeVerifyFull, &externalDB.TLS.Mode)
+		if _, ok := externalDBTLSParams[externalDB.TLS.Mode]; !ok {
+			return fmt.Errorf("[spec.database.externalDB.tls.mode] unsupported mode %q", externalDB.TLS.Mode)
+		}
+		p.DBConnection.TLS.Mode = externalDB.TLS.Mode
+		p.DBConnection.TLS.ClientCertSecret = externalDB.TLS.ClientCertSecret
+		// MLMD only verifies the server certificate once TLS is configured, so
+		// that external databases set up before it keep connecting
+		p.DBConnection.TLS.VerifyServerCert = externalDB.TLS.Mode == config.ExternalDBTLSModeVerifyFull
+	}
+	if externalDB.ServerVersion == config.ExternalDBMySQL8 && p.DBConnection.TLS.Mode == config.ExternalDBTLSModeDisable {
+		return fmt.Errorf("[spec.database.externalDB.tls.mode] MySQL 8 authenticates with caching_sha2_password, " +
+			"which MLMD only supports over TLS, TLS cannot be disabled")
+	}
+
+	if p.DBConnection.TLS.ClientCertSecret == "" {
+		return nil
+	}
+	secret, err := util.GetSecret(ctx, p.DBConnection.TLS.ClientCertSecret, p.Namespace, client)
+	if err != nil {
+		return fmt.Errorf("[spec.database.externalDB.tls.clientCertSecret] unable to retrieve secret %s: %w", p.DBConnection.TLS.ClientCertSecret, err)
+	}
+	certificate, err := cryptoTls.X509KeyPair(secret.Data[v1.TLSCertKey], secret.Data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("[spec.database.externalDB.tls.clientCertSecret] secret %s does not hold a valid %s and %s: %w",
+			p.DBConnection.TLS.ClientCertSecret, v1.TLSCertKey, v1.TLSPrivateKeyKey, err)
+	}
+	p.DBConnection.TLS.ClientCertMountPath = config.ExternalDBClientCertMountPath
+	p.DBConnection.TLS.ClientCertificate = &certificate
+	return nil
+}
+
+// externalDBCACertificate returns the CA certificate of the external database
+// TLS settings of dsp, nil when they set none.
+func externalDBCACertificate(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, client client.Client) ([]byte, error) {
+	if dsp.Spec.Database == nil || dsp.Spec.Database.ExternalDB == nil || dsp.Spec.Database.ExternalDB.TLS == nil ||
+		dsp.Spec.Database.ExternalDB.TLS.CASecret == nil {
+		return nil, nil
+	}
+	caSecret := dsp.Spec.Database.ExternalDB.TLS.CASecret
+	secret, err := util.GetSecret(ctx, caSecret.Name, dsp.Namespace, client)
+	if err != nil {
+		return nil, fmt.Errorf("[spec.database.externalDB.tls.caSecret] unable to retrieve secret %s: %w", caSecret.Name, err)
+	}
+	ca := secret.Data[caSecret.Key]
+	if len(bytes.TrimSpace(ca)) == 0 {
+		return nil, fmt.Errorf("[spec.database.externalDB.tls.caSecret] secret %s has no key %s", caSecret.Name, caSecret.Key)
+	}
+	return ca, nil
+}
+
+// ComposeDataConnection returns a copy of the externalStorage spec of dsp, with
+// the fields left unset composed from the keys of its ODH Dashboard data
+// connection Secret.
+func (p *DSPAParams) ComposeDataConnection(ctx context.Context, client client.Client, externalStorage *dspa.ExternalStorage) (*dspa.ExternalStorage, error) {
+	secret := &v1.Secret{}
+	err := client.Get(ctx, types.NamespacedName{Name: externalStorage.DataConnectionRef, Namespace: p.Namespace}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve data connection [%s]: %w", externalStorage.DataConnectionRef, err)
+	}
+
+	composed := externalStorage.DeepCopy()
+	if endpoint := string(secret.Data[config.DataConnectionEndpoint]); endpoint != "" {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || endpointURL.Host == "" {
+			return nil, fmt.Errorf("[%s] of data connection [%s] must be a URL, got %s",
+				config.DataConnectionEndpoint, externalStorage.DataConnectionRef, endpoint)
+		}
+		setStringDefault(endpointURL.Hostname(), &composed.Host)
+		setStringDefault(endpointURL.Scheme, &composed.Scheme)
+		setStringDefault(endpointURL.Port(), &composed.Port)
+	}
+	setStringDefault(string(secret.Data[config.DataConnectionBucket]), &composed.Bucket)
+	setStringDefault(string(secret.Data[config.DataConnectionRegion]), &composed.Region)
+	if composed.S3CredentialSecret == nil {
+		composed.S3CredentialSecret = &dspa.S3CredentialSecret{
+			SecretName: externalStorage.DataConnectionRef,
+			AccessKey:  config.DataConnectionAccessKey,
+			SecretKey:  config.DataConnectionSecretKey,
+		}
+	}
+	return composed, nil
+}
+
 // SetupObjectParams Populates the Object Storage connection Parameters.
 // If an external secret is specified, SetupObjectParams will retrieve storage credentials from it.
 // If DSPO is managing a dynamically created secret, then SetupObjectParams generates the creds.
 func (p *DSPAParams) SetupObjectParams(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, client client.Client, log logr.Logger) error {
+	p.MinioNamespace = p.Namespace
 
 	usingExternalObjectStorage := p.UsingExternalStorage(dsp)
 	if usingExternalObjectStorage {
-		// Assume validation for CR ensures these values exist
-		p.ObjectStorageConnection.Bucket = dsp.Spec.ObjectStorage.ExternalStorage.Bucket
-		p.ObjectStorageConnection.Host = dsp.Spec.ObjectStorage.ExternalStorage.Host
-		p.ObjectStorageConnection.Scheme = dsp.Spec.ObjectStorage.ExternalStorage.Scheme
-		p.ObjectStorageConnection.BasePath = dsp.Spec.ObjectStorage.ExternalStorage.BasePath
-		p.ObjectStorageConnection.Region = dsp.Spec.ObjectStorage.ExternalStorage.Region
+		externalStorage := dsp.Spec.ObjectStorage.ExternalStorage
+		if externalStorage.DataConnectionRef != "" {
+			composed, err := p.ComposeDataConnection(ctx, client, externalStorage)
+			if err != nil {
+				log.Error(err, "Unable to compose the Object Storage connection from its data connection")
+				return err
+			}
+			externalStorage = composed
+		}
+		if externalStorage.Host == "" {
+			return fmt.Errorf("[spec.objectStorage.externalStorage.host] must be set, or composed from a dataConnectionRef")
+		}
+		if externalStorage.S3CredentialSecret == nil {
+			return fmt.Errorf("[spec.objectStorage.externalStorage.s3CredentialsSecret] must be set, or composed from a dataConnectionRef")
+		}
+
+		p.ObjectStorageConnection.Bucket = externalStorage.Bucket
+		p.ObjectStorageConnection.Host = externalStorage.Host
+		p.ObjectStorageConnection.Scheme = externalStorage.Scheme
+		p.ObjectStorageConnection.BasePath = externalStorage.BasePath
+		p.ObjectStorageConnection.Region = externalStorage.Region
 		if p.ObjectStorageConnection.Region == "" {
 			p.ObjectStorageConnection.Region = "auto"
 		}
 
-		if dsp.Spec.ObjectStorage.ExternalStorage.Secure == nil {
+		if externalStorage.Secure == nil {
 			if p.ObjectStorageConnection.Scheme == "https" {
 				p.ObjectStorageConnection.Secure = util.BoolPointer(true)
 			} else {
 				p.ObjectStorageConnection.Secure = util.BoolPointer(false)
 			}
 		} else {
-			p.ObjectStorageConnection.Secure = dsp.Spec.ObjectStorage.ExternalStorage.Secure
+			p.ObjectStorageConnection.Secure = externalStorage.Secure
 		}
 
 		// Port can be empty, which is fine.
-		p.ObjectStorageConnection.Port = dsp.Spec.ObjectStorage.ExternalStorage.Port
-		p.ObjectStorageConnection.CredentialsSecret = dsp.Spec.ObjectStorage.ExternalStorage.S3CredentialSecret
+		p.ObjectStorageConnection.Port = externalStorage.Port
+		p.ObjectStorageConnection.CredentialsSecret = externalStorage.S3CredentialSecret
+
+		if externalStorage.TransferAcceleration {
+			if !strings.HasSuffix(externalStorage.Host, ".amazonaws.com") {
+				return fmt.Errorf("[spec.objectStorage.externalStorage.transferAcceleration] is only supported for AWS S3 hosts, got %s", externalStorage.Host)
+			}
+			if strings.Contains(externalStorage.Bucket, ".") {
+				return fmt.Errorf("[spec.objectStorage.externalStorage.transferAcceleration] is not supported for bucket names containing dots, got %s", externalStorage.Bucket)
+			}
+			p.ObjectStorageConnection.Host = config.S3TransferAccelerationHost
+			p.ObjectStorageConnection.Port = ""
+		}
+		if externalStorage.MaxRetries != nil {
+			p.ObjectStorageConnection.MaxRetries = *externalStorage.MaxRetries
+		}
+		if err := p.setupFailoverEndpoints(dsp, externalStorage); err != nil {
+			return err
+		}
 
 		// Retrieve ObjStore Creds from specified secret.  Ignore error if the secret simply doesn't exist (will be created later)
 		accesskey, err := p.RetrieveSecret(ctx, client, p.ObjectStorageConnection.CredentialsSecret.SecretName, p.ObjectStorageConnection.CredentialsSecret.AccessKey, log)
@@ -412,26 +1206,53 @@ func (p *DSPAParams) SetupObjectParams(ctx context.Context, dsp *dspa.DataScienc
 
 		setStringDefault(config.MinioDefaultBucket, &p.Minio.Bucket)
 		setResourcesDefault(config.MinioResourceRequirements, &p.Minio.Resources)
+		setProbesDefault(config.MinioProbes, &p.Minio.Probes)
+		if p.Minio.DeploymentStrategy == "" {
+			p.Minio.DeploymentStrategy = appsv1.RecreateDeploymentStrategyType
+		}
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.Minio.PodSecurityContext, &p.Minio.SecurityContext)
+		namespace, err := p.componentNamespace(ctx, client, "spec.objectStorage.minio.namespace", p.Minio.Namespace)
+		if err != nil {
+			return err
+		}
+		p.MinioNamespace = namespace
+		if err := p.setupMinioDistributed(); err != nil {
+			return err
+		}
 
 		p.ObjectStorageConnection.Bucket = config.MinioDefaultBucket
 		p.ObjectStorageConnection.Host = fmt.Sprintf(
 			"%s.%s.svc.cluster.local",
 			config.MinioHostPrefix+"-"+p.Name,
-			p.Namespace,
+			p.MinioNamespace,
 		)
 		p.ObjectStorageConnection.Port = config.MinioPort
 		p.ObjectStorageConnection.Scheme = config.MinioScheme
 		p.ObjectStorageConnection.Secure = util.BoolPointer(false)
+		if p.CertManagerIssuer != nil {
+			p.ObjectStorageConnection.Scheme = "https"
+			p.ObjectStorageConnection.Secure = util.BoolPointer(true)
+		}
 		p.ObjectStorageConnection.Region = "minio"
 
 		if p.Minio.S3CredentialSecret != nil {
+			if p.Minio.GeneratedSecret != nil {
+				return fmt.Errorf("[spec.objectStorage.minio.generatedSecret] cannot be set along with a s3CredentialsSecret")
+			}
 			p.ObjectStorageConnection.CredentialsSecret = p.Minio.S3CredentialSecret
 		} else {
+			name, labels, migrateFrom, err := generatedSecret("spec.objectStorage.minio.generatedSecret",
+				p.Minio.GeneratedSecret, config.DefaultObjectStorageSecretNamePrefix+p.Name)
+			if err != nil {
+				return err
+			}
 			p.ObjectStorageConnection.CredentialsSecret = &dspa.S3CredentialSecret{
-				SecretName: config.DefaultObjectStorageSecretNamePrefix + p.Name,
+				SecretName: name,
 				AccessKey:  config.DefaultObjectStorageAccessKey,
 				SecretKey:  config.DefaultObjectStorageSecretKey,
 			}
+			p.ObjectStorageConnection.CredentialsSecretLabels = labels
+			p.ObjectStorageConnection.MigrateCredentialsSecretFrom = migrateFrom
 		}
 
 		accessKey, secretKey, err := p.RetrieveOrCreateObjectStoreSecret(ctx, client, p.ObjectStorageConnection.CredentialsSecret, log)
@@ -443,36 +1264,56 @@ func (p *DSPAParams) SetupObjectParams(ctx context.Context, dsp *dspa.DataScienc
 
 	}
 
+	if dsp.Spec.ObjectStorage != nil && dsp.Spec.ObjectStorage.SecretPropagation != nil {
+		for _, namespace := range dsp.Spec.ObjectStorage.SecretPropagation.Namespaces {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				return fmt.Errorf("[spec.objectStorage.secretPropagation.namespaces] invalid namespace %s: %s", namespace, strings.Join(errs, ", "))
+			}
+			if namespace == p.Namespace {
+				return fmt.Errorf("[spec.objectStorage.secretPropagation.namespaces] the namespace of the DSPA cannot be listed")
+			}
+		}
+		p.ObjectStorageConnection.PropagatedNamespaces = dsp.Spec.ObjectStorage.SecretPropagation.Namespaces
+	}
+
+	if err := p.setupOCIRegistry(ctx, dsp, client); err != nil {
+		return err
+	}
+
+	// Separate buckets default to the storage bucket
+	p.ObjectStorageConnection.ArtifactBucket = p.ObjectStorageConnection.Bucket
+	p.ObjectStorageConnection.PipelineBucket = p.ObjectStorageConnection.Bucket
+	if dsp.Spec.ObjectStorage != nil && dsp.Spec.ObjectStorage.Buckets != nil {
+		if dsp.Spec.ObjectStorage.Buckets.Artifacts != "" {
+			p.ObjectStorageConnection.ArtifactBucket = dsp.Spec.ObjectStorage.Buckets.Artifacts
+		}
+		if dsp.Spec.ObjectStorage.Buckets.PipelineDefinitions != "" {
+			p.ObjectStorageConnection.PipelineBucket = dsp.Spec.ObjectStorage.Buckets.PipelineDefinitions
+		}
+	}
+
 	if p.ExternalRouteEnabled(dsp) {
-		route, err := p.RetrieveAndSetExternalRoute(ctx, client, log)
+		host, err := p.RetrieveExternalRouteHost(ctx, client)
 		if err != nil {
 			log.Info("Unable to retrieve route", "error", err)
 		}
-		p.ObjectStorageConnection.ExternalRouteURL = route.Spec.Host
-		p.ObjectStorageConnection.Endpoint = route.Spec.Host
+		p.ObjectStorageConnection.ExternalRouteURL = host
+		p.ObjectStorageConnection.Endpoint = host
 		p.ObjectStorageConnection.Secure = util.BoolPointer(true)
-		p.ObjectStorageConnection.Host = route.Spec.Host
+		p.ObjectStorageConnection.Host = host
 		p.ObjectStorageConnection.Scheme = "https"
 		//port should be empty when external route is specified
 		p.ObjectStorageConnection.Port = ""
 	}
-	endpoint := fmt.Sprintf(
-		"%s://%s",
-		p.ObjectStorageConnection.Scheme,
-		p.ObjectStorageConnection.Host,
-	)
-
-	if p.ObjectStorageConnection.Port != "" {
-		endpoint = fmt.Sprintf(
-			"%s:%s",
-			endpoint,
-			p.ObjectStorageConnection.Port,
-		)
+	p.ObjectStorageConnection.Endpoint = objectStorageEndpoint(p.ObjectStorageConnection.Scheme,
+		p.ObjectStorageConnection.Host, p.ObjectStorageConnection.Port)
+	if len(p.ObjectStorageConnection.Endpoints) > 0 {
+		c := p.ObjectStorageConnection
+		p.ObjectStorageConnection.Endpoints[0] = ObjectStorageEndpoint{Host: c.Host, Port: c.Port, Scheme: c.Scheme,
+			Region: c.Region, Bucket: c.Bucket, Secure: c.Secure, Endpoint: c.Endpoint}
 	}
 
-	p.ObjectStorageConnection.Endpoint = endpoint
-
-	if p.ObjectStorageConnection.AccessKeyID == "" || p.ObjectStorageConnection.SecretAccessKey == "" {
+	if p.ObjectStorageConnection.AccessKeyID.Empty() || p.ObjectStorageConnection.SecretAccessKey.Empty() {
 		return fmt.Errorf("object storage password from secret [%s] for keys [%s, %s] was not "+
 			"successfully retrieved, ensure that the secret with this key exist",
 			p.ObjectStorageConnection.CredentialsSecret.SecretName,
@@ -482,48 +1323,341 @@ func (p *DSPAParams) SetupObjectParams(ctx context.Context, dsp *dspa.DataScienc
 
 }
 
-func (p *DSPAParams) SetupMLMD(dsp *dspa.DataSciencePipelinesApplication, log logr.Logger) error {
-	if p.MLMD == nil {
-		log.Info("MLMD not specified, but is a required component for Pipelines. Including MLMD with default specs.")
-		p.MLMD = &dspa.MLMD{
-			Deploy: true,
-			Envoy: &dspa.Envoy{
-				DeployRoute: true,
-			},
-		}
-	} else if !p.MLMD.Deploy {
-		return errors.New(MlmdIsRequired)
+// setupDatabaseBackup defaults spec.database.backup, the backups and their
+// verification run the MariaDB image unless another one is set.
+func (p *DSPAParams) setupDatabaseBackup(dsp *dspa.DataSciencePipelinesApplication) {
+	p.DatabaseBackupDefaultResourceName = databaseBackupDefaultResourceNamePrefix + dsp.Name
+	p.DatabaseBackupVerificationResourceName = databaseBackupVerificationResourceNamePrefix + dsp.Name
+	p.DatabaseBackup = nil
+	if dsp.Spec.Database == nil || dsp.Spec.Database.Backup == nil || !dsp.Spec.Database.Backup.Enabled {
+		return
+	}
+	p.DatabaseBackup = dsp.Spec.Database.Backup.DeepCopy()
+	setStringDefault(p.defaultImage(config.MariaDBImagePath), &p.DatabaseBackup.Image)
+	setStringDefault(config.DefaultDatabaseBackupSchedule, &p.DatabaseBackup.Schedule)
+	if p.DatabaseBackup.PVCSize.IsZero() {
+		p.DatabaseBackup.PVCSize = resource.MustParse(config.DefaultDatabaseBackupPVCSize)
 	}
+	if p.DatabaseBackup.Verification != nil {
+		setStringDefault(config.DefaultDatabaseBackupVerificationSchedule, &p.DatabaseBackup.Verification.Schedule)
+	}
+}
 
-	if p.MLMD != nil {
-		if p.MLMD.Envoy == nil {
-			p.MLMD.Envoy = &dspa.Envoy{
-				Image:       config.GetStringConfigWithDefault(config.MlmdEnvoyImagePath, config.DefaultImageValue),
-				DeployRoute: true,
-			}
+// setupFailoverEndpoints orders the failover endpoints of externalStorage by
+// priority after the primary endpoint, which is filled in once its endpoint
+// URL is known, defaulting their fields to those of the primary endpoint.
+func (p *DSPAParams) setupFailoverEndpoints(dsp *dspa.DataSciencePipelinesApplication, externalStorage *dspa.ExternalStorage) error {
+	p.ObjectStorageConnection.Endpoints = nil
+	p.ObjectStorageConnection.ActiveEndpoint = 0
+	if len(externalStorage.FailoverEndpoints) == 0 {
+		return nil
+	}
+	if dsp.Spec.ObjectStorage.Buckets != nil {
+		return fmt.Errorf("[spec.objectStorage.buckets] cannot be set along with [spec.objectStorage.externalStorage.failoverEndpoints]")
+	}
+
+	failovers := slices.Clone(externalStorage.FailoverEndpoints)
+	sort.SliceStable(failovers, func(i, j int) bool { return failovers[i].Priority < failovers[j].Priority })
+	endpoints := []ObjectStorageEndpoint{{}}
+	for _, failover := range failovers {
+		if failover.Host == "" {
+			return fmt.Errorf("[spec.objectStorage.externalStorage.failoverEndpoints.host] must be set")
 		}
-		if p.MLMD.GRPC == nil {
-			p.MLMD.GRPC = &dspa.GRPC{
-				Image: config.GetStringConfigWithDefault(config.MlmdGRPCImagePath, config.DefaultImageValue),
-			}
+		endpoint := ObjectStorageEndpoint{
+			Host:   failover.Host,
+			Port:   failover.Port,
+			Scheme: failover.Scheme,
+			Region: failover.Region,
+			Bucket: failover.Bucket,
 		}
-
-		mlmdEnvoyImageFromConfig := config.GetStringConfigWithDefault(config.MlmdEnvoyImagePath, config.DefaultImageValue)
-		mlmdGRPCImageFromConfig := config.GetStringConfigWithDefault(config.MlmdGRPCImagePath, config.DefaultImageValue)
-
-		setStringDefault(mlmdEnvoyImageFromConfig, &p.MLMD.Envoy.Image)
-		setStringDefault(mlmdGRPCImageFromConfig, &p.MLMD.GRPC.Image)
-
-		setResourcesDefault(config.MlmdEnvoyResourceRequirements, &p.MLMD.Envoy.Resources)
-		setResourcesDefault(config.MlmdGRPCResourceRequirements, &p.MLMD.GRPC.Resources)
-
-		setStringDefault(config.MlmdGrpcPort, &p.MLMD.GRPC.Port)
+		setStringDefault(p.ObjectStorageConnection.Scheme, &endpoint.Scheme)
+		setStringDefault(p.ObjectStorageConnection.Region, &endpoint.Region)
+		setStringDefault(p.ObjectStorageConnection.Bucket, &endpoint.Bucket)
+		endpoint.Secure = util.BoolPointer(endpoint.Scheme == "https")
+		endpoint.Endpoint = objectStorageEndpoint(endpoint.Scheme, endpoint.Host, endpoint.Port)
+		endpoints = append(endpoints, endpoint)
 	}
+	p.ObjectStorageConnection.Endpoints = endpoints
 	return nil
 }
 
-func (p *DSPAParams) SetupOwner(dsp *dspa.DataSciencePipelinesApplication) {
-	p.IncludeOwnerReference = config.GetBoolConfigWithDefault(config.ApiServerIncludeOwnerReferenceConfigName, config.DefaultApiServerIncludeOwnerReferenceConfigName)
+// setupOCIRegistry validates spec.objectStorage.ociRegistry, and that its
+// push/pull Secret holds registry credentials.
+func (p *DSPAParams) setupOCIRegistry(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, client client.Client) error {
+	p.OCIRegistry = nil
+	if dsp.Spec.ObjectStorage == nil || dsp.Spec.ObjectStorage.OCIRegistry == nil {
+		return nil
+	}
+	registry := dsp.Spec.ObjectStorage.OCIRegistry.DeepCopy()
+	if registry.Host == "" || strings.Contains(registry.Host, "://") {
+		return fmt.Errorf("[spec.objectStorage.ociRegistry.host] must be set to the host of the registry, without a scheme")
+	}
+	registry.Repository = strings.Trim(registry.Repository, "/")
+	if registry.Repository == "" {
+		return fmt.Errorf("[spec.objectStorage.ociRegistry.repository] must be set")
+	}
+	if dsp.Spec.ObjectStorage.Buckets != nil && dsp.Spec.ObjectStorage.Buckets.Artifacts != "" {
+		return fmt.Errorf("[spec.objectStorage.buckets.artifacts] cannot be set along with [spec.objectStorage.ociRegistry], the run artifacts are stored in the registry")
+	}
+	if registry.PushPullSecret != "" {
+		secret := &v1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Name: registry.PushPullSecret, Namespace: p.Namespace}, secret); err != nil {
+			return fmt.Errorf("[spec.objectStorage.ociRegistry.pushPullSecret] unable to retrieve the Secret %s: %w", registry.PushPullSecret, err)
+		}
+		if len(secret.Data[v1.DockerConfigJsonKey]) == 0 {
+			return fmt.Errorf("[spec.objectStorage.ociRegistry.pushPullSecret] the Secret %s has no %s key", registry.PushPullSecret, v1.DockerConfigJsonKey)
+		}
+	}
+	p.OCIRegistry = registry
+	return nil
+}
+
+// SetupWorkflowDefaults builds the podSpecPatch of the workflow controller
+// workflowDefaults, that mounts the default workspace and applies the pod
+// defaults to the main container of all pipeline steps.
+func (p *DSPAParams) SetupWorkflowDefaults(dsp *dspa.DataSciencePipelinesApplication) error {
+	p.PodDefaults = dsp.Spec.PodDefaults.DeepCopy()
+	p.WorkflowPodSpecPatch = ""
+	if p.PodDefaults != nil && (p.WorkflowController == nil || !p.WorkflowController.Deploy || p.WorkflowController.CustomConfig != "") {
+		return fmt.Errorf("[spec.podDefaults] requires [spec.workflowController] to be deployed without a customConfig")
+	}
+
+	volumes := map[string]bool{}
+	if p.PodDefaults != nil {
+		for _, volume := range p.PodDefaults.Volumes {
+			if volume.Name == defaultWorkspaceVolumeName {
+				return fmt.Errorf("[spec.podDefaults.volumes] must not use the reserved name %s", defaultWorkspaceVolumeName)
+			}
+			volumes[volume.Name] = true
+		}
+		for _, volumeMount := range p.PodDefaults.VolumeMounts {
+			if !volumes[volumeMount.Name] {
+				return fmt.Errorf("[spec.podDefaults.volumeMounts] must mount one of [spec.podDefaults.volumes], got %s", volumeMount.Name)
+			}
+		}
+	}
+
+	mainContainer := map[string]interface{}{"name": "main"}
+	var volumeMounts []v1.VolumeMount
+	if p.DefaultWorkspace != nil {
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: defaultWorkspaceVolumeName, MountPath: p.DefaultWorkspace.MountPath})
+	}
+	patch := map[string]interface{}{}
+	if p.PodDefaults != nil {
+		volumeMounts = append(volumeMounts, p.PodDefaults.VolumeMounts...)
+		if len(p.PodDefaults.Env) > 0 {
+			mainContainer["env"] = p.PodDefaults.Env
+		}
+		if len(p.PodDefaults.EnvFrom) > 0 {
+			mainContainer["envFrom"] = p.PodDefaults.EnvFrom
+		}
+		if p.PodDefaults.RuntimeClassName != "" {
+			patch["runtimeClassName"] = p.PodDefaults.RuntimeClassName
+		}
+	}
+	if len(volumeMounts) > 0 {
+		mainContainer["volumeMounts"] = volumeMounts
+	}
+	if len(mainContainer) > 1 {
+		patch["containers"] = []interface{}{mainContainer}
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	p.WorkflowPodSpecPatch = string(b)
+	return nil
+}
+
+// SetupArtifactStepResources converts spec.apiServer.artifactStepResources to
+// the resources of the executor the workflow controller injects in the pods of
+// all pipeline steps, quantities left unset are not limited nor requested.
+func (p *DSPAParams) SetupArtifactStepResources() error {
+	p.ArtifactStepResources = ""
+	if p.APIServer == nil || p.APIServer.ArtifactStepResources == nil {
+		return nil
+	}
+	if p.WorkflowController == nil || !p.WorkflowController.Deploy || p.WorkflowController.CustomConfig != "" {
+		return fmt.Errorf("[spec.apiServer.artifactStepResources] requires [spec.workflowController] to be deployed without a customConfig")
+	}
+
+	toResourceList := func(resources *dspa.Resources) v1.ResourceList {
+		if resources == nil {
+			return nil
+		}
+		list := v1.ResourceList{}
+		if !resources.CPU.IsZero() {
+			list[v1.ResourceCPU] = resources.CPU
+		}
+		if !resources.Memory.IsZero() {
+			list[v1.ResourceMemory] = resources.Memory
+		}
+		return list
+	}
+	resources := v1.ResourceRequirements{
+		Limits:   toResourceList(p.APIServer.ArtifactStepResources.Limits),
+		Requests: toResourceList(p.APIServer.ArtifactStepResources.Requests),
+	}
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("[spec.apiServer.artifactStepResources] the %s request %s must not exceed its limit %s", name, request.String(), limit.String())
+		}
+	}
+
+	b, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+	p.ArtifactStepResources = string(b)
+	return nil
+}
+
+func (p *DSPAParams) SetupMLMD(dsp *dspa.DataSciencePipelinesApplication, log logr.Logger) error {
+	if p.MLMD != nil && !p.MLMD.Deploy {
+		return errors.New(MlmdIsRequired)
+	}
+
+	if p.MLMD != nil {
+		if p.MLMD.Envoy == nil {
+			p.MLMD.Envoy = &dspa.Envoy{
+				Image:       p.defaultImage(config.MlmdEnvoyImagePath),
+				DeployRoute: true,
+			}
+		}
+		if p.MLMD.GRPC == nil {
+			p.MLMD.GRPC = &dspa.GRPC{
+				Image: p.defaultImage(config.MlmdGRPCImagePath),
+			}
+		}
+
+		mlmdEnvoyImageFromConfig := p.defaultImage(config.MlmdEnvoyImagePath)
+		mlmdGRPCImageFromConfig := p.defaultImage(config.MlmdGRPCImagePath)
+
+		setStringDefault(mlmdEnvoyImageFromConfig, &p.MLMD.Envoy.Image)
+		setStringDefault(mlmdGRPCImageFromConfig, &p.MLMD.GRPC.Image)
+
+		setResourcesDefault(config.MlmdEnvoyResourceRequirements, &p.MLMD.Envoy.Resources)
+		setProbesDefault(config.MlmdEnvoyProbes, &p.MLMD.Envoy.Probes)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.MLMD.Envoy.PodSecurityContext, &p.MLMD.Envoy.SecurityContext)
+		setResourcesDefault(config.MlmdGRPCResourceRequirements, &p.MLMD.GRPC.Resources)
+		setProbesDefault(config.MlmdGRPCProbes, &p.MLMD.GRPC.Probes)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.MLMD.GRPC.PodSecurityContext, &p.MLMD.GRPC.SecurityContext)
+
+		setStringDefault(config.MlmdGrpcPort, &p.MLMD.GRPC.Port)
+
+		if err := p.validateMLMDComponents(); err != nil {
+			return err
+		}
+		if err := p.setupMLMDGRPCExposure(); err != nil {
+			return err
+		}
+		if err := p.SetupMLMDEnvoy(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MLMDEnvoyDeployed returns true when the MLMD Envoy proxy is deployed, unless
+// spec.mlmd.envoy.deploy is false.
+func (p *DSPAParams) MLMDEnvoyDeployed() bool {
+	return p.MLMD != nil && p.MLMD.Deploy && p.MLMD.Envoy != nil && (p.MLMD.Envoy.Deploy == nil || *p.MLMD.Envoy.Deploy)
+}
+
+// MLMDGRPCDeployed returns true when the MLMD gRPC server is deployed, unless
+// spec.mlmd.grpc.deploy is false.
+func (p *DSPAParams) MLMDGRPCDeployed() bool {
+	return p.MLMD != nil && p.MLMD.Deploy && p.MLMD.GRPC != nil && (p.MLMD.GRPC.Deploy == nil || *p.MLMD.GRPC.Deploy)
+}
+
+// validateMLMDComponents rejects the MLMD components disabled while the
+// components depending on them are deployed.
+func (p *DSPAParams) validateMLMDComponents() error {
+	if !p.MLMDGRPCDeployed() {
+		if p.APIServer != nil && p.APIServer.Deploy {
+			return fmt.Errorf("[spec.mlmd.grpc.deploy] the API server records the pipeline run metadata in the MLMD gRPC server, it cannot be disabled while [spec.apiServer] is deployed")
+		}
+		if p.MLMDEnvoyDeployed() {
+			return fmt.Errorf("[spec.mlmd.envoy.deploy] the MLMD Envoy proxy requires the MLMD gRPC server, set [spec.mlmd.grpc.deploy] to true or disable the proxy")
+		}
+		if p.MLMD.GRPC.EnableRoute {
+			return fmt.Errorf("[spec.mlmd.grpc.enableRoute] requires [spec.mlmd.grpc.deploy] to be true")
+		}
+	}
+	if !p.MLMDEnvoyDeployed() && p.MlPipelineUI != nil && p.MlPipelineUI.Deploy {
+		return fmt.Errorf("[spec.mlmd.envoy.deploy] the UI reads the pipeline run metadata through the MLMD Envoy proxy, it cannot be disabled while [spec.mlpipelineUI] is deployed")
+	}
+	return nil
+}
+
+// setupMLMDGRPCExposure names the additional Service of the MLMD gRPC server,
+// which must not collide with the Services the operator creates for it, and
+// validates it can be exposed by a Route.
+func (p *DSPAParams) setupMLMDGRPCExposure() error {
+	p.MlmdGRPCServiceName = ""
+	grpc := p.MLMD.GRPC
+	if grpc.Service != nil {
+		p.MlmdGRPCServiceName = grpc.Service.Name
+		if p.MlmdGRPCServiceName == "" {
+			if !grpc.Service.Headless {
+				return fmt.Errorf("[spec.mlmd.grpc.service.name] must be set unless the Service is headless")
+			}
+			p.MlmdGRPCServiceName = config.MlmdGRPCHeadlessServiceNamePrefix + p.Name
+		}
+		if errs := validation.IsDNS1035Label(p.MlmdGRPCServiceName); len(errs) > 0 {
+			return fmt.Errorf("[spec.mlmd.grpc.service.name] %s is not a valid Service name: %s", p.MlmdGRPCServiceName, strings.Join(errs, ", "))
+		}
+		if p.MlmdGRPCServiceName == config.MlmdGRPCServiceNamePrefix+p.Name || p.MlmdGRPCServiceName == config.MlmdGRPCSharedServiceName {
+			return fmt.Errorf("[spec.mlmd.grpc.service.name] %s is the name of a Service created by the operator", p.MlmdGRPCServiceName)
+		}
+	}
+	if grpc.EnableRoute && !p.PodToPodTLS {
+		return fmt.Errorf("[spec.mlmd.grpc.enableRoute] requires pod to pod TLS, as the Route re-encrypts the connections to the MLMD gRPC server")
+	}
+	return nil
+}
+
+// SetupMLMDEnvoy defaults the Envoy listener settings and parses any extra
+// HTTP filter snippets so they can be rendered into the Envoy ConfigMap.
+func (p *DSPAParams) SetupMLMDEnvoy() error {
+	envoy := p.MLMD.Envoy
+	if envoy.Port == 0 {
+		envoy.Port = config.MlmdEnvoyPort
+	}
+
+	upstreamTimeout := config.MlmdEnvoyUpstreamTimeout
+	if envoy.UpstreamTimeout != nil {
+		if envoy.UpstreamTimeout.Duration <= 0 {
+			return fmt.Errorf("[spec.mlmd.envoy.upstreamTimeout] must be a positive duration, got %s", envoy.UpstreamTimeout.Duration)
+		}
+		upstreamTimeout = envoy.UpstreamTimeout.Duration
+	}
+	p.MlmdEnvoyUpstreamTimeout = fmt.Sprintf("%gs", upstreamTimeout.Seconds())
+
+	if envoy.TLS != nil && envoy.TLS.SecretName == "" {
+		return errors.New("[spec.mlmd.envoy.tls.secretName] must be set when TLS termination is enabled")
+	}
+
+	p.MlmdEnvoyExtraHTTPFilters = nil
+	for i, snippet := range envoy.ExtraHTTPFilters {
+		filter := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(snippet), &filter); err != nil {
+			return fmt.Errorf("[spec.mlmd.envoy.extraHttpFilters[%d]] is not a valid YAML object: %w", i, err)
+		}
+		if name, ok := filter["name"].(string); !ok || name == "" {
+			return fmt.Errorf("[spec.mlmd.envoy.extraHttpFilters[%d]] must set the filter name", i)
+		}
+		p.MlmdEnvoyExtraHTTPFilters = append(p.MlmdEnvoyExtraHTTPFilters, filter)
+	}
+	return nil
+}
+
+func (p *DSPAParams) SetupOwner(dsp *dspa.DataSciencePipelinesApplication) {
+	p.IncludeOwnerReference = config.GetBoolConfigWithDefault(config.ApiServerIncludeOwnerReferenceConfigName, config.DefaultApiServerIncludeOwnerReferenceConfigName)
 
 	if p.IncludeOwnerReference {
 		p.UID = dsp.UID
@@ -533,20 +1667,588 @@ func (p *DSPAParams) SetupOwner(dsp *dspa.DataSciencePipelinesApplication) {
 	}
 }
 
+// defaultImage returns the image set in the DSPO config at configPath, with
+// its registry rewritten to ImageRegistryOverride when one is set.
+func (p *DSPAParams) defaultImage(configPath string) string {
+	image, arch := p.architectureImage(configPath, config.DefaultImageValue, "")
+	if p.FIPSEnabled {
+		fipsConfigPath := config.FIPSImagesPathPrefix + strings.TrimPrefix(configPath, config.ImagesPathPrefix)
+		image, arch = p.architectureImage(fipsConfigPath, image, arch)
+	}
+	if p.ImageRegistryOverride != "" && image != config.DefaultImageValue {
+		image = util.OverrideImageRegistry(image, p.ImageRegistryOverride)
+	}
+	if p.defaultImages != nil {
+		p.defaultImages[image] = true
+	}
+	if arch != "" && p.imageArchitectures != nil {
+		p.imageArchitectures[image] = arch
+	}
+	return image
+}
+
+// optionalImage returns the image of configPath, an image the operator config
+// may leave unset, or else the upstream image.
+func (p *DSPAParams) optionalImage(configPath, upstreamImage string) string {
+	if image, _ := p.architectureImage(configPath, "", ""); image != "" {
+		return p.defaultImage(configPath)
+	}
+	image := upstreamImage
+	if p.ImageRegistryOverride != "" {
+		image = util.OverrideImageRegistry(image, p.ImageRegistryOverride)
+	}
+	if p.defaultImages != nil {
+		p.defaultImages[image] = true
+	}
+	return image
+}
+
+// imageFields returns the image fields of all configured components.
+func (p *DSPAParams) imageFields() []*string {
+	images := []*string{&p.OAuthProxy}
+	if p.APIServer != nil {
+		images = append(images, &p.APIServer.Image, &p.APIServer.ArgoLauncherImage, &p.APIServer.ArgoDriverImage,
+			&p.APIServer.RuntimeGenericImage, &p.APIServer.ToolboxImage, &p.APIServer.RHELAIImage)
+		if p.APIServer.CacheCleanup != nil {
+			images = append(images, &p.APIServer.CacheCleanup.Image)
+		}
+		if p.APIServer.RunRetention != nil {
+			images = append(images, &p.APIServer.RunRetention.Image)
+		}
+		if p.APIServer.AuditLog != nil {
+			images = append(images, &p.APIServer.AuditLog.Image)
+		}
+	}
+	if p.DatabaseBackup != nil {
+		images = append(images, &p.DatabaseBackup.Image)
+	}
+	if p.PersistenceAgent != nil {
+		images = append(images, &p.PersistenceAgent.Image)
+	}
+	if p.ScheduledWorkflow != nil {
+		images = append(images, &p.ScheduledWorkflow.Image)
+	}
+	if p.MLMD != nil && p.MLMD.Envoy != nil {
+		images = append(images, &p.MLMD.Envoy.Image)
+	}
+	if p.MLMD != nil && p.MLMD.GRPC != nil {
+		images = append(images, &p.MLMD.GRPC.Image)
+	}
+	if p.MariaDB != nil {
+		images = append(images, &p.MariaDB.Image)
+	}
+	if p.MariaDBExporter != nil {
+		images = append(images, &p.MariaDBExporter.Image)
+	}
+	if p.WorkflowController != nil {
+		images = append(images, &p.WorkflowController.Image, &p.WorkflowController.ArgoExecImage)
+	}
+	return images
+}
+
+// pinImageDigests rewrites default images to reference the digest their tag
+// currently resolves to, when enabled in the DSPO config. Images set in the
+// DSPA are left as is. When digests are required, a default image that is
+// not pinned by digest returns ErrImageNotPinned. With a cosign public key
+// configured, default images must also be pinned and carry a signature valid
+// for the key, or ErrImageSignatureNotVerified is returned.
+func (p *DSPAParams) pinImageDigests(ctx context.Context, log logr.Logger) error {
+	resolve := config.GetBoolConfigWithDefault(config.ResolveImageDigestsConfigName, config.DefaultResolveImageDigests)
+	required := config.GetBoolConfigWithDefault(config.RequireImageDigestsConfigName, config.DefaultRequireImageDigests)
+	var publicKey crypto.PublicKey
+	if publicKeyPEM := config.GetStringConfigWithDefault(config.CosignPublicKeyConfigName, ""); publicKeyPEM != "" {
+		key, err := util.ParseCosignPublicKey(publicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrImageSignatureNotVerified, err)
+		}
+		publicKey = key
+		required = true
+	}
+	if !resolve && !required {
+		return nil
+	}
+
+	p.ResolvedImageDigests = map[string]string{}
+	for _, image := range p.imageFields() {
+		if !p.defaultImages[*image] {
+			continue
+		}
+		if resolve && !util.IsDigestPinned(*image) {
+			digest, err := imageDigestResolver.Resolve(ctx, *image)
+			if err != nil {
+				log.Info(err.Error())
+			} else {
+				p.ResolvedImageDigests[*image] = digest
+				pinned := util.PinnedImage(*image, digest)
+				if arch, ok := p.imageArchitectures[*image]; ok {
+					p.imageArchitectures[pinned] = arch
+				}
+				*image = pinned
+				p.defaultImages[*image] = true
+			}
+		}
+		if required && !util.IsDigestPinned(*image) {
+			return fmt.Errorf("%w: %s", ErrImageNotPinned, *image)
+		}
+		if publicKey != nil {
+			if err := imageDigestResolver.VerifyCosignSignature(ctx, *image, publicKey); err != nil {
+				return fmt.Errorf("%w: %s", ErrImageSignatureNotVerified, err)
+			}
+		}
+	}
+	return nil
+}
+
+// SetupUpgrade populates the parameters of the database backup taken before
+// upgrading a DSP v1 deployment, sized after the managed MariaDB PVC.
+func (p *DSPAParams) SetupUpgrade(dsp *dspa.DataSciencePipelinesApplication) {
+	p.UpgradeResourceName = upgradeResourceNamePrefix + dsp.Name
+	p.UpgradeBackupPVCSize = resource.MustParse(config.MariaDBNamePVCSize)
+	if p.MariaDB != nil && p.MariaDB.Deploy {
+		p.UpgradeJobImage = p.MariaDB.Image
+		p.UpgradeBackupPVCSize = p.MariaDB.PVCSize
+	} else {
+		p.UpgradeJobImage = p.defaultImage(config.MariaDBImagePath)
+	}
+}
+
+// SetupMlPipelineUICustomization renders the UI customization into the JSON
+// document merged into the UI ConfigMap, the logo is referenced by the path
+// it is mounted at in the UI pod.
+func (p *DSPAParams) SetupMlPipelineUICustomization() error {
+	p.MlPipelineUICustomizationJSON = ""
+	p.MlPipelineUILogoMountPath = config.MlPipelineUILogoMountPath
+	customization := p.MlPipelineUI.Customization
+	if customization == nil {
+		return nil
+	}
+	for _, link := range customization.DocumentationLinks {
+		parsed, err := url.Parse(link.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("[spec.mlpipelineUI.customization.documentationLinks] must be http or https URLs, got %s", link.URL)
+		}
+	}
+	document := map[string]interface{}{}
+	if customization.Title != "" {
+		document["title"] = customization.Title
+	}
+	if customization.Logo != nil {
+		document["logoPath"] = p.MlPipelineUILogoMountPath + "/" + customization.Logo.ConfigMapKey
+	}
+	if len(customization.DocumentationLinks) > 0 {
+		document["documentationLinks"] = customization.DocumentationLinks
+	}
+	rendered, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	p.MlPipelineUICustomizationJSON = string(rendered)
+	return nil
+}
+
+// SetupMlPipelineUIArtifactProxy resolves the endpoint the UI fetches
+// artifacts from, defaulting to the object storage of the DSPA.
+func (p *DSPAParams) SetupMlPipelineUIArtifactProxy() error {
+	p.MlPipelineUIArtifactProxy = nil
+	if p.MlPipelineUI == nil || p.MlPipelineUI.ArtifactProxy == nil {
+		return nil
+	}
+	proxy := p.MlPipelineUI.ArtifactProxy
+	connection := &UIArtifactProxyConnection{
+		Host:             p.ObjectStorageConnection.Host,
+		Port:             p.ObjectStorageConnection.Port,
+		Secure:           p.ObjectStorageConnection.Scheme == "https",
+		ReuseCredentials: proxy.ReuseCredentials == nil || *proxy.ReuseCredentials,
+		PathStyle:        proxy.PathStyle,
+	}
+	if proxy.Endpoint != "" {
+		endpoint, err := url.Parse(proxy.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Hostname() == "" {
+			return fmt.Errorf("[spec.mlpipelineUI.artifactProxy.endpoint] must be an http or https URL, got %s", proxy.Endpoint)
+		}
+		connection.Host = endpoint.Hostname()
+		connection.Port = endpoint.Port()
+		connection.Secure = endpoint.Scheme == "https"
+	}
+	p.MlPipelineUIArtifactProxy = connection
+	return nil
+}
+
+// SetupComponentImages records the image each deployed component runs,
+// and whether it was set in the DSPA or defaulted from the DSPO config.
+func (p *DSPAParams) SetupComponentImages() {
+	p.ComponentImages = map[string]dspa.ComponentDetailStatus{}
+	record := func(component string, deployed bool, image string) {
+		if !deployed || image == "" {
+			return
+		}
+		source := config.ImageSourceDSPA
+		if p.defaultImages[image] {
+			source = config.ImageSourceOperatorConfig
+		}
+		p.ComponentImages[component] = dspa.ComponentDetailStatus{Image: image, ImageSource: source, Architecture: p.imageArchitectures[image]}
+	}
+	if p.APIServer != nil {
+		record("apiServer", p.APIServer.Deploy, p.APIServer.Image)
+	}
+	if p.PersistenceAgent != nil {
+		record("persistenceAgent", p.PersistenceAgent.Deploy, p.PersistenceAgent.Image)
+	}
+	if p.ScheduledWorkflow != nil {
+		record("scheduledWorkflow", p.ScheduledWorkflow.Deploy, p.ScheduledWorkflow.Image)
+	}
+	if p.MlPipelineUI != nil {
+		record("mlPipelineUI", p.MlPipelineUI.Deploy, p.MlPipelineUI.Image)
+	}
+	if p.VisualizationServer != nil {
+		record("visualizationServer", p.VisualizationServer.Deploy, p.VisualizationServer.Image)
+	}
+	if p.CRDViewer != nil {
+		record("crdViewer", p.CRDViewer.Deploy, p.CRDViewer.Image)
+	}
+	if p.MLMD != nil && p.MLMD.Envoy != nil {
+		record("mlmdProxy", p.MLMDEnvoyDeployed(), p.MLMD.Envoy.Image)
+	}
+	if p.MLMD != nil && p.MLMD.GRPC != nil {
+		record("mlmdGRPC", p.MLMDGRPCDeployed(), p.MLMD.GRPC.Image)
+	}
+	if p.MariaDB != nil {
+		record("mariaDB", p.MariaDB.Deploy, p.MariaDB.Image)
+	}
+	if p.Minio != nil {
+		record("minio", p.Minio.Deploy, p.Minio.Image)
+	}
+	if p.WorkflowController != nil {
+		record("workflowController", p.WorkflowController.Deploy, p.WorkflowController.Image)
+	}
+}
+
+// SetupServiceAccountNames resolves the ServiceAccount each component runs
+// as, using the operator created ServiceAccount unless one is set in the DSPA.
+func (p *DSPAParams) SetupServiceAccountNames() {
+	p.APIServerServiceAccountName = p.APIServerDefaultResourceName
+	p.PersistenceAgentServiceAccountName = p.PersistentAgentDefaultResourceName
+	p.ScheduledWorkflowServiceAccountName = p.ScheduledWorkflowDefaultResourceName
+	p.MlPipelineUIServiceAccountName = "ds-pipeline-ui-" + p.Name
+	p.WorkflowControllerServiceAccountName = "ds-pipeline-workflow-controller-" + p.Name
+	p.MlmdEnvoyServiceAccountName = "ds-pipeline-metadata-envoy-" + p.Name
+	p.MlmdGRPCServiceAccountName = "ds-pipeline-metadata-grpc-" + p.Name
+	p.MariaDBServiceAccountName = "ds-pipelines-mariadb-sa-" + p.Name
+	p.MinioServiceAccountName = "ds-pipelines-minio-sa-" + p.Name
+
+	if p.APIServer != nil && p.APIServer.ServiceAccountName != "" {
+		p.APIServerServiceAccountName = p.APIServer.ServiceAccountName
+	}
+	if p.PersistenceAgent != nil && p.PersistenceAgent.ServiceAccountName != "" {
+		p.PersistenceAgentServiceAccountName = p.PersistenceAgent.ServiceAccountName
+	}
+	if p.ScheduledWorkflow != nil && p.ScheduledWorkflow.ServiceAccountName != "" {
+		p.ScheduledWorkflowServiceAccountName = p.ScheduledWorkflow.ServiceAccountName
+	}
+	if p.MlPipelineUI != nil && p.MlPipelineUI.ServiceAccountName != "" {
+		p.MlPipelineUIServiceAccountName = p.MlPipelineUI.ServiceAccountName
+	}
+	if p.WorkflowController != nil && p.WorkflowController.ServiceAccountName != "" {
+		p.WorkflowControllerServiceAccountName = p.WorkflowController.ServiceAccountName
+	}
+	if p.MLMD != nil && p.MLMD.Envoy != nil && p.MLMD.Envoy.ServiceAccountName != "" {
+		p.MlmdEnvoyServiceAccountName = p.MLMD.Envoy.ServiceAccountName
+	}
+	if p.MLMD != nil && p.MLMD.GRPC != nil && p.MLMD.GRPC.ServiceAccountName != "" {
+		p.MlmdGRPCServiceAccountName = p.MLMD.GRPC.ServiceAccountName
+	}
+	if p.MariaDB != nil && p.MariaDB.ServiceAccountName != "" {
+		p.MariaDBServiceAccountName = p.MariaDB.ServiceAccountName
+	}
+	if p.Minio != nil && p.Minio.ServiceAccountName != "" {
+		p.MinioServiceAccountName = p.Minio.ServiceAccountName
+	}
+}
+
 func setStringDefault(defaultValue string, value *string) {
 	if *value == "" {
 		*value = defaultValue
 	}
 }
 
+func setSecurityContextDefaults(defaultPodValue v1.PodSecurityContext, defaultValue v1.SecurityContext, podValue **v1.PodSecurityContext, value **v1.SecurityContext) {
+	if *podValue == nil {
+		*podValue = defaultPodValue.DeepCopy()
+	}
+	if *value == nil {
+		*value = defaultValue.DeepCopy()
+	}
+}
+
 func setResourcesDefault(defaultValue dspa.ResourceRequirements, value **dspa.ResourceRequirements) {
 	if *value == nil {
 		*value = defaultValue.DeepCopy()
 	}
 }
 
+// SetupHA scales the stateless servers when spec.ha is set, spreading their
+// pods across zones and nodes unless the component sets its own constraints.
+func (p *DSPAParams) SetupHA(dsp *dspa.DataSciencePipelinesApplication) {
+	p.HA = dsp.Spec.HA != nil && *dsp.Spec.HA
+	p.Replicas = 1
+	if !p.HA {
+		return
+	}
+	p.Replicas = config.DefaultHAReplicas
+
+	if p.APIServer != nil {
+		p.setTopologySpreadDefault(p.APIServerDefaultResourceName, &p.APIServer.TopologySpreadConstraints)
+	}
+	if p.MlPipelineUI != nil {
+		p.setTopologySpreadDefault("ds-pipeline-ui-"+p.Name, &p.MlPipelineUI.TopologySpreadConstraints)
+	}
+	p.setTopologySpreadDefault("ds-pipeline-metadata-envoy-"+p.Name, &p.MLMD.Envoy.TopologySpreadConstraints)
+	p.setTopologySpreadDefault("ds-pipeline-metadata-grpc-"+p.Name, &p.MLMD.GRPC.TopologySpreadConstraints)
+}
+
+func (p *DSPAParams) setTopologySpreadDefault(app string, value *[]v1.TopologySpreadConstraint) {
+	if len(*value) == 0 {
+		*value = config.DefaultTopologySpreadConstraints(map[string]string{
+			"app":       app,
+			"component": "data-science-pipelines",
+			"dspa":      p.Name,
+		})
+	}
+}
+
+// setProbesDefault fills any probe timing not set in value from defaultValue.
+func setProbesDefault(defaultValue dspa.Probes, value **dspa.Probes) {
+	if *value == nil {
+		*value = defaultValue.DeepCopy()
+		return
+	}
+	setProbeTimingDefault(defaultValue.Liveness, &(*value).Liveness)
+	setProbeTimingDefault(defaultValue.Readiness, &(*value).Readiness)
+}
+
+func setProbeTimingDefault(defaultValue *dspa.ProbeTiming, value **dspa.ProbeTiming) {
+	defaults := defaultValue.DeepCopy()
+	if *value == nil {
+		*value = defaults
+		return
+	}
+	if (*value).InitialDelaySeconds == nil {
+		(*value).InitialDelaySeconds = defaults.InitialDelaySeconds
+	}
+	if (*value).PeriodSeconds == nil {
+		(*value).PeriodSeconds = defaults.PeriodSeconds
+	}
+	if (*value).FailureThreshold == nil {
+		(*value).FailureThreshold = defaults.FailureThreshold
+	}
+}
+
+// SetupMonitoring enables the metrics exporters of spec.monitoring for the
+// managed MariaDB and Minio, exporters of external ones are ignored, the
+// dashboard when the Grafana operator is installed, and the alerts.
+func (p *DSPAParams) SetupMonitoring(dsp *dspa.DataSciencePipelinesApplication, mapper meta.RESTMapper, log logr.Logger) error {
+	p.MonitoringScrape = config.MonitoringScrapeMonitor
+	p.MariaDBExporter = nil
+	p.MinioMetrics = nil
+	p.GrafanaInstanceSelector = nil
+	p.AlertSeverities = nil
+	p.AlertLabels = nil
+	monitoring := dsp.Spec.Monitoring
+	if monitoring == nil {
+		return nil
+	}
+	if monitoring.Scrape != "" {
+		p.MonitoringScrape = monitoring.Scrape
+	}
+	if monitoring.Dashboards {
+		if grafanaOperatorInstalled(mapper) {
+			p.GrafanaInstanceSelector = config.GetStringMapConfigWithDefault(config.GrafanaInstanceSelectorConfigName, config.DefaultGrafanaInstanceSelector)
+		} else {
+			log.Info("The Grafana operator is not installed, skipping the DSPA dashboard")
+		}
+	}
+	if monitoring.Alerts != nil && monitoring.Alerts.Enabled {
+		p.AlertSeverities = map[string]string{}
+		for alert, severity := range config.DefaultAlertSeverities {
+			p.AlertSeverities[alert] = severity
+		}
+		for alert, severity := range monitoring.Alerts.Severities {
+			if _, ok := p.AlertSeverities[alert]; !ok {
+				return fmt.Errorf("[spec.monitoring.alerts.severities] unknown alert %s", alert)
+			}
+			if severity == "" {
+				return fmt.Errorf("[spec.monitoring.alerts.severities] the severity of %s must not be empty", alert)
+			}
+			p.AlertSeverities[alert] = severity
+		}
+		for label := range monitoring.Alerts.Labels {
+			if label == "severity" || label == "dspa" || label == "dspa_namespace" {
+				return fmt.Errorf("[spec.monitoring.alerts.labels] the %s label is set by the operator", label)
+			}
+		}
+		p.AlertLabels = monitoring.Alerts.Labels
+	}
+	if monitoring.Exporters == nil {
+		return nil
+	}
+
+	exporters := monitoring.Exporters
+	if exporters.MariaDB != nil && exporters.MariaDB.Enabled && !p.UsingExternalDB(dsp) && p.MariaDB != nil && p.MariaDB.Deploy {
+		p.MariaDBExporter = exporters.MariaDB.DeepCopy()
+		if p.MariaDBExporter.Image == "" {
+			p.MariaDBExporter.Image = p.optionalImage(config.MysqldExporterImagePath, config.DefaultMysqldExporterImage)
+		}
+		setResourcesDefault(config.MysqldExporterResourceRequirements, &p.MariaDBExporter.Resources)
+	}
+	if exporters.Minio != nil && exporters.Minio.Enabled && !p.UsingExternalStorage(dsp) && p.Minio != nil && p.Minio.Deploy {
+		p.MinioMetrics = exporters.Minio.DeepCopy()
+		setStringDefault(config.DefaultMinioMetricsPath, &p.MinioMetrics.MetricsPath)
+	}
+	return nil
+}
+
+// SetupTLSCertificates lists the Certificates to request from cert-manager
+// for the deployed components that serve TLS.
+func (p *DSPAParams) SetupTLSCertificates() {
+	p.TLSCertificates = nil
+	if p.CertManagerIssuer == nil {
+		return
+	}
+	addCertificate := func(secretName string, serviceNames ...string) {
+		var dnsNames []string
+		for _, serviceName := range serviceNames {
+			dnsNames = append(dnsNames,
+				serviceName,
+				fmt.Sprintf("%s.%s.svc", serviceName, p.Namespace),
+				fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, p.Namespace),
+			)
+		}
+		p.TLSCertificates = append(p.TLSCertificates, TLSCertificate{SecretName: secretName, DNSNames: dnsNames})
+	}
+	if p.APIServer != nil && p.APIServer.Deploy {
+		addCertificate(config.APIServerTLSSecretNamePrefix+p.Name, p.APIServerServiceName)
+	}
+	if p.MariaDB != nil && p.MariaDB.Deploy {
+		addCertificate(config.MariaDBTLSSecretNamePrefix+p.Name, config.MariaDBHostPrefix+"-"+p.Name)
+	}
+	if p.Minio != nil && p.Minio.Deploy {
+		serviceNames := []string{config.MinioHostPrefix + "-" + p.Name, "minio-service-" + p.Name}
+		if p.MinioDistributed() {
+			// The nodes of a distributed Minio reach each other through the headless Service
+			serviceNames = append(serviceNames, "*."+config.MinioHostPrefix+"-"+p.Name+"-hl")
+		}
+		addCertificate(config.MinioTLSSecretNamePrefix+p.Name, serviceNames...)
+	}
+	if p.MLMD != nil && p.MLMD.Deploy {
+		serviceNames := []string{config.MlmdGRPCServiceNamePrefix + p.Name}
+		if p.MlmdGRPCServiceName != "" {
+			serviceNames = append(serviceNames, p.MlmdGRPCServiceName)
+			if p.MLMD.GRPC.Service.Headless {
+				// The pods are also reached at their own names in the subdomain of the headless Service
+				serviceNames = append(serviceNames, "*."+p.MlmdGRPCServiceName)
+			}
+		}
+		addCertificate(config.MlmdGRPCTLSSecretNamePrefix+p.Name, serviceNames...)
+	}
+}
+
+// setupAPIServerDBConfig validates spec.apiServer.dbConfig, the durations
+// default to those of the API Server configuration.
+func (p *DSPAParams) setupAPIServerDBConfig() error {
+	p.DBMaxOpenConnections, p.DBMaxIdleConnections = nil, nil
+	p.DBConnectionMaxLifetime = config.DefaultDBConnectionMaxLifetime
+	p.DBInitConnectionTimeout = config.DefaultDBInitConnectionTimeout
+	dbConfig := p.APIServer.DBConfig
+	if dbConfig == nil {
+		return nil
+	}
+
+	if dbConfig.MaxOpenConnections != nil && *dbConfig.MaxOpenConnections < 1 {
+		return fmt.Errorf("[spec.apiServer.dbConfig.maxOpenConnections] must be at least 1, got %d", *dbConfig.MaxOpenConnections)
+	}
+	if dbConfig.MaxIdleConnections != nil && *dbConfig.MaxIdleConnections < 0 {
+		return fmt.Errorf("[spec.apiServer.dbConfig.maxIdleConnections] must not be negative, got %d", *dbConfig.MaxIdleConnections)
+	}
+	if dbConfig.MaxOpenConnections != nil && dbConfig.MaxIdleConnections != nil && *dbConfig.MaxIdleConnections > *dbConfig.MaxOpenConnections {
+		return fmt.Errorf("[spec.apiServer.dbConfig.maxIdleConnections] must be at most maxOpenConnections (%d), got %d",
+			*dbConfig.MaxOpenConnections, *dbConfig.MaxIdleConnections)
+	}
+	p.DBMaxOpenConnections, p.DBMaxIdleConnections = dbConfig.MaxOpenConnections, dbConfig.MaxIdleConnections
+
+	if dbConfig.ConnectionMaxLifetime != nil {
+		if dbConfig.ConnectionMaxLifetime.Duration <= 0 {
+			return fmt.Errorf("[spec.apiServer.dbConfig.connectionMaxLifetime] must be a positive duration, got %s", dbConfig.ConnectionMaxLifetime.Duration)
+		}
+		p.DBConnectionMaxLifetime = dbConfig.ConnectionMaxLifetime.Duration.String()
+	}
+	if dbConfig.ConnectionTimeout != nil {
+		if dbConfig.ConnectionTimeout.Duration <= 0 {
+			return fmt.Errorf("[spec.apiServer.dbConfig.connectionTimeout] must be a positive duration, got %s", dbConfig.ConnectionTimeout.Duration)
+		}
+		p.DBInitConnectionTimeout = dbConfig.ConnectionTimeout.Duration.String()
+	}
+	return nil
+}
+
+// setupAPIServerPorts resolves the ports of the API Server, which must differ
+// from each other and from the port of the oauth proxy sharing its pods.
+func (p *DSPAParams) setupAPIServerPorts() error {
+	p.APIServerHTTPPort = config.DefaultAPIServerHTTPPort
+	p.APIServerGRPCPort = config.DefaultAPIServerGRPCPort
+	if p.APIServer == nil || p.APIServer.Ports == nil {
+		return nil
+	}
+	if p.APIServer.Ports.HTTP != 0 {
+		p.APIServerHTTPPort = p.APIServer.Ports.HTTP
+	}
+	if p.APIServer.Ports.GRPC != 0 {
+		p.APIServerGRPCPort = p.APIServer.Ports.GRPC
+	}
+	if p.APIServerHTTPPort == p.APIServerGRPCPort {
+		return fmt.Errorf("[spec.apiServer.ports] the http and grpc ports must differ, both are %d", p.APIServerHTTPPort)
+	}
+	if p.APIServerHTTPPort == config.OAuthProxyPort || p.APIServerGRPCPort == config.OAuthProxyPort {
+		return fmt.Errorf("[spec.apiServer.ports] port %d is used by the oauth proxy", config.OAuthProxyPort)
+	}
+	return nil
+}
+
+// SetupAPIServerExtraArgs validates the API Server extraArgs and
+// featureFlags, feature flags are rendered first in a stable order.
+func (p *DSPAParams) SetupAPIServerExtraArgs() error {
+	var args []string
+	flags := map[string]bool{}
+
+	var featureFlags []string
+	for name := range p.APIServer.FeatureFlags {
+		featureFlags = append(featureFlags, name)
+	}
+	sort.Strings(featureFlags)
+	for _, name := range featureFlags {
+		args = append(args, fmt.Sprintf("--%s=%t", name, p.APIServer.FeatureFlags[name]))
+	}
+	args = append(args, p.APIServer.ExtraArgs...)
+
+	for _, arg := range args {
+		match := apiServerArgPattern.FindStringSubmatch(arg)
+		if match == nil {
+			return fmt.Errorf("%w: [%s] is not a flag", ErrInvalidAPIServerArgs, arg)
+		}
+		name := match[1]
+		if apiServerManagedFlags[name] {
+			return fmt.Errorf("%w: flag [%s] is managed by the operator", ErrInvalidAPIServerArgs, name)
+		}
+		if flags[name] {
+			return fmt.Errorf("%w: flag [%s] is set more than once", ErrInvalidAPIServerArgs, name)
+		}
+		flags[name] = true
+	}
+	p.APIServerExtraArgs = args
+	return nil
+}
+
 func (p *DSPAParams) LoadMlmdCertificates(ctx context.Context, client client.Client) (bool, error) {
-	secret, err := util.GetSecret(ctx, "ds-pipeline-metadata-grpc-tls-certs-"+p.Name, p.Namespace, client)
+	secret, err := util.GetSecret(ctx, config.MlmdGRPCTLSSecretNamePrefix+p.Name, p.Namespace, client)
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			return false, nil
@@ -559,24 +2261,112 @@ func (p *DSPAParams) LoadMlmdCertificates(ctx context.Context, client client.Cli
 	return true, nil
 }
 
+// componentDefaults instantiates, per DSP version, the components the version
+// requires when they are omitted from the DSPA spec, where the CRD does not
+// default them. Their images, resources and other fields are then defaulted
+// from the operator config like those of the components that are set.
+var componentDefaults = map[string]func(spec *dspa.DSPASpec) []string{
+	config.DSPV2VersionString: func(spec *dspa.DSPASpec) []string {
+		var defaulted []string
+		if spec.WorkflowController == nil {
+			spec.WorkflowController = &dspa.WorkflowController{Deploy: true}
+			defaulted = append(defaulted, "workflowController")
+		}
+		if spec.MLMD == nil {
+			spec.MLMD = &dspa.MLMD{Deploy: true}
+			defaulted = append(defaulted, "mlmd")
+		}
+		if spec.Database == nil {
+			// The MariaDB defaults are set up along with the database connection
+			spec.Database = &dspa.Database{}
+			defaulted = append(defaulted, "database")
+		}
+		return defaulted
+	},
+}
+
+// withComponentDefaults returns dsp with the components omitted from its spec
+// instantiated according to its DSP version, so that omitting a required
+// component does not skip it. The defaults are set on a copy of dsp, only read
+// by the params, so that they are neither written back to the DSPA nor
+// reported as set by the user in its diff and status.
+func withComponentDefaults(dsp *dspa.DataSciencePipelinesApplication, log logr.Logger) *dspa.DataSciencePipelinesApplication {
+	// An omitted version is defaulted to v2 by the CRD
+	version := dsp.Spec.DSPVersion
+	if version == "" {
+		version = config.DSPV2VersionString
+	}
+	setDefaults, ok := componentDefaults[version]
+	if !ok {
+		return dsp
+	}
+	defaultedDSPA := dsp.DeepCopy()
+	defaulted := setDefaults(&defaultedDSPA.Spec)
+	if len(defaulted) == 0 {
+		return dsp
+	}
+	log.V(1).Info(fmt.Sprintf("Components not specified, deploying them with the DSP %s defaults: %s", version, strings.Join(defaulted, ", ")))
+	return defaultedDSPA
+}
+
 func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePipelinesApplication, client client.Client, loggr logr.Logger) error {
 	p.Name = dsp.Name
 	p.Namespace = dsp.Namespace
 	p.DSPONamespace = os.Getenv("DSPO_NAMESPACE")
 	p.DSPVersion = dsp.Spec.DSPVersion
+	p.ManagementState = managementState(dsp)
 	p.Owner = dsp
+	featureGates, err := config.ResolveFeatureGates(dsp.Annotations[config.FeatureGatesAnnotation])
+	if err != nil {
+		return fmt.Errorf("%w: [metadata.annotations.%s] %s", ErrInvalidFeatureGates, config.FeatureGatesAnnotation, err)
+	}
+	p.FeatureGates = featureGates
+	if dsp.Spec.ObjectStorage == nil {
+		return fmt.Errorf("[spec.objectStorage] is required")
+	}
+	dsp = withComponentDefaults(dsp, dspaLogger(loggr, p.Namespace, p.Name, p.ReconcileID))
+	p.ImagePullSecrets = dsp.Spec.ImagePullSecrets
+	p.ImageRegistryOverride = dsp.Spec.ImageRegistryOverride
+	p.defaultImages = map[string]bool{}
+	p.imageArchitectures = map[string]string{}
+	p.nodeArchitectures = nil
+	if config.HasArchitectureImages() {
+		nodeArchitectures, err := retrieveNodeArchitectures(ctx, client)
+		if err != nil {
+			dspaLogger(loggr, p.Namespace, p.Name, p.ReconcileID).Info(fmt.Sprintf("Unable to list the node architectures, selecting images by architecture name: %v", err))
+		}
+		p.nodeArchitectures = nodeArchitectures
+	}
+	p.FIPSEnabled = util.IsFIPSEnabled()
+	if dsp.Spec.FIPSMode != nil {
+		p.FIPSEnabled = *dsp.Spec.FIPSMode
+	}
 	p.APIServer = dsp.Spec.APIServer.DeepCopy()
 	p.APIServerDefaultResourceName = apiServerDefaultResourceNamePrefix + dsp.Name
 	p.APIServerServiceName = fmt.Sprintf("%s-%s", config.DSPServicePrefix, p.Name)
 	p.APIServerServiceDNSName = fmt.Sprintf("%s.%s.svc.cluster.local", p.APIServerServiceName, p.Namespace)
+	if err := p.setupAPIServerPorts(); err != nil {
+		return err
+	}
+	p.APIServerExtraArgs = nil
+	p.APIServerAccessGroups = nil
+	p.DefaultWorkspace = nil
+	p.DefaultWorkspacePVCName = defaultWorkspacePVCNamePrefix + dsp.Name
+	p.CacheCleanupDefaultResourceName = cacheCleanupDefaultResourceNamePrefix + dsp.Name
+	p.RunRetentionDefaultResourceName = runRetentionDefaultResourceNamePrefix + dsp.Name
+	p.AuditLogDefaultResourceName = auditLogDefaultResourceNamePrefix + dsp.Name
 	p.ScheduledWorkflow = dsp.Spec.ScheduledWorkflow.DeepCopy()
 	p.ScheduledWorkflowDefaultResourceName = scheduledWorkflowDefaultResourceNamePrefix + dsp.Name
 	p.PersistenceAgent = dsp.Spec.PersistenceAgent.DeepCopy()
 	p.PersistentAgentDefaultResourceName = persistenceAgentDefaultResourceNamePrefix + dsp.Name
 	p.MlPipelineUI = dsp.Spec.MlPipelineUI.DeepCopy()
+	p.VisualizationServer = dsp.Spec.VisualizationServer.DeepCopy()
+	p.VisualizationServerDefaultResourceName = visualizationServerDefaultResourceNamePrefix + dsp.Name
+	p.CRDViewer = dsp.Spec.CRDViewer.DeepCopy()
+	p.CRDViewerDefaultResourceName = crdViewerDefaultResourceNamePrefix + dsp.Name
 	p.MariaDB = dsp.Spec.Database.MariaDB.DeepCopy()
 	p.Minio = dsp.Spec.ObjectStorage.Minio.DeepCopy()
-	p.OAuthProxy = config.GetStringConfigWithDefault(config.OAuthProxyImagePath, config.DefaultImageValue)
+	p.OAuthProxy = p.defaultImage(config.OAuthProxyImagePath)
 	p.MLMD = dsp.Spec.MLMD.DeepCopy()
 	p.MlmdProxyDefaultResourceName = mlmdProxyDefaultResourceNamePrefix + dsp.Name
 	p.CustomCABundleRootMountPath = config.CustomCABundleRootMountPath
@@ -591,15 +2381,46 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 		p.PodToPodTLS = *dsp.Spec.PodToPodTLS
 	}
 
-	log := loggr.WithValues("namespace", p.Namespace).WithValues("dspa_name", p.Name)
+	// Certificates issued by cert-manager enable TLS on all connections
+	if dsp.Spec.TLS != nil && dsp.Spec.TLS.IssuerRef != nil {
+		p.CertManagerIssuer = dsp.Spec.TLS.IssuerRef.DeepCopy()
+		setStringDefault(config.DefaultCertManagerIssuerKind, &p.CertManagerIssuer.Kind)
+		setStringDefault(config.DefaultCertManagerIssuerGroup, &p.CertManagerIssuer.Group)
+		p.PodToPodTLS = true
+	}
+
+	p.ServiceMesh = nil
+	p.ServiceMeshGatewayNamespace = ""
+	if dsp.Spec.ServiceMesh != nil && dsp.Spec.ServiceMesh.Enabled {
+		if dsp.Spec.ServiceMesh.Gateway == "" || dsp.Spec.ServiceMesh.Domain == "" {
+			return fmt.Errorf("[spec.serviceMesh.gateway] and [spec.serviceMesh.domain] must be set when the service mesh is enabled")
+		}
+		if p.CertManagerIssuer != nil {
+			return fmt.Errorf("[spec.serviceMesh] and [spec.tls.issuerRef] must not be set together, the mesh mTLS secures connections between pods")
+		}
+		p.ServiceMesh = dsp.Spec.ServiceMesh.DeepCopy()
+		setStringDefault(config.DefaultServiceMeshMTLSMode, &p.ServiceMesh.MTLSMode)
+		p.ServiceMeshGatewayNamespace = strings.SplitN(p.ServiceMesh.Gateway, "/", 2)[0]
+		// The mesh mTLS secures connections between pods instead
+		p.PodToPodTLS = false
+	}
+
+	if err := p.setupPlatform(dsp, client.RESTMapper()); err != nil {
+		return err
+	}
+	if p.Kubernetes != nil {
+		p.OAuthProxy = p.optionalImage(config.OAuth2ProxyImagePath, config.DefaultOAuth2ProxyImage)
+	}
+
+	log := dspaLogger(loggr, p.Namespace, p.Name, p.ReconcileID)
 
 	if p.APIServer != nil {
-		serverImageFromConfig := config.GetStringConfigWithDefault(config.APIServerImagePath, config.DefaultImageValue)
-		argoLauncherImageFromConfig := config.GetStringConfigWithDefault(config.LauncherImagePath, config.DefaultImageValue)
-		argoDriverImageFromConfig := config.GetStringConfigWithDefault(config.DriverImagePath, config.DefaultImageValue)
-		runtimeGenericImageFromConfig := config.GetStringConfigWithDefault(config.RuntimeGenericPath, config.DefaultImageValue)
-		toolboxImageFromConfig := config.GetStringConfigWithDefault(config.ToolboxImagePath, config.DefaultImageValue)
-		rhelAIImageFromConfig := config.GetStringConfigWithDefault(config.RHELAIImagePath, config.DefaultImageValue)
+		serverImageFromConfig := p.defaultImage(config.APIServerImagePath)
+		argoLauncherImageFromConfig := p.defaultImage(config.LauncherImagePath)
+		argoDriverImageFromConfig := p.defaultImage(config.DriverImagePath)
+		runtimeGenericImageFromConfig := p.defaultImage(config.RuntimeGenericPath)
+		toolboxImageFromConfig := p.defaultImage(config.ToolboxImagePath)
+		rhelAIImageFromConfig := p.defaultImage(config.RHELAIImagePath)
 
 		setStringDefault(serverImageFromConfig, &p.APIServer.Image)
 		setStringDefault(argoLauncherImageFromConfig, &p.APIServer.ArgoLauncherImage)
@@ -609,7 +2430,107 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 		setStringDefault(rhelAIImageFromConfig, &p.APIServer.RHELAIImage)
 
 		setResourcesDefault(config.APIServerResourceRequirements, &p.APIServer.Resources)
+		setProbesDefault(config.APIServerProbes, &p.APIServer.Probes)
 		setResourcesDefault(config.APIServerInitResourceRequirements, &p.APIServer.InitResources)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.APIServer.PodSecurityContext, &p.APIServer.SecurityContext)
+
+		err := p.SetupAPIServerExtraArgs()
+		if err != nil {
+			return err
+		}
+
+		if security := p.APIServer.Security; security != nil && security.RBAC != nil && security.RBAC.Mode == config.APIServerRBACModeGroups {
+			if len(security.RBAC.Groups) == 0 {
+				return fmt.Errorf("[spec.apiServer.security.rbac.groups] must list at least one group in the %s mode", config.APIServerRBACModeGroups)
+			}
+			p.APIServerAccessGroups = security.RBAC.Groups
+		}
+
+		if p.APIServer.DefaultWorkspace != nil {
+			p.DefaultWorkspace = p.APIServer.DefaultWorkspace.DeepCopy()
+			if p.DefaultWorkspace.Size.IsZero() {
+				p.DefaultWorkspace.Size = resource.MustParse(config.DefaultWorkspacePVCSize)
+			}
+			if p.DefaultWorkspace.AccessMode == "" {
+				p.DefaultWorkspace.AccessMode = config.DefaultWorkspaceAccessMode
+			}
+			setStringDefault(config.DefaultWorkspaceMountPath, &p.DefaultWorkspace.MountPath)
+		}
+
+		p.CacheEnabled = p.APIServer.CacheEnabled == nil || *p.APIServer.CacheEnabled
+		p.CacheStaleness = ""
+		if p.APIServer.DefaultCacheTTL != nil {
+			if p.APIServer.DefaultCacheTTL.Duration <= 0 {
+				return fmt.Errorf("[spec.apiServer.defaultCacheTTL] must be a positive duration, got %s", p.APIServer.DefaultCacheTTL.Duration)
+			}
+			p.CacheStaleness = fmt.Sprintf("PT%dS", int64(p.APIServer.DefaultCacheTTL.Seconds()))
+		}
+
+		if err := p.setupAPIServerDBConfig(); err != nil {
+			return err
+		}
+
+		if p.APIServer.CacheCleanup != nil {
+			mariaDBImageFromConfig := p.defaultImage(config.MariaDBImagePath)
+			setStringDefault(mariaDBImageFromConfig, &p.APIServer.CacheCleanup.Image)
+			setStringDefault(config.DefaultCacheCleanupSchedule, &p.APIServer.CacheCleanup.Schedule)
+			if p.APIServer.CacheCleanup.MaxAgeHours <= 0 {
+				p.APIServer.CacheCleanup.MaxAgeHours = config.DefaultCacheCleanupMaxAgeHours
+			}
+			setResourcesDefault(config.CacheCleanupResourceRequirements, &p.APIServer.CacheCleanup.Resources)
+		}
+
+		p.RunRetentionTTLSeconds = 0
+		p.RunRetentionArchivedTTLSeconds = 0
+		if p.APIServer.RunRetention != nil {
+			retention := p.APIServer.RunRetention
+			if retention.Enabled && retention.CompletedRunTTL == nil && retention.MaxRunsPerExperiment <= 0 && retention.ArchivedRunTTL == nil {
+				return fmt.Errorf("[spec.apiServer.runRetention] requires completedRunTTL, maxRunsPerExperiment or archivedRunTTL to be set")
+			}
+			if retention.CompletedRunTTL != nil {
+				if retention.CompletedRunTTL.Duration <= 0 {
+					return fmt.Errorf("[spec.apiServer.runRetention.completedRunTTL] must be a positive duration, got %s", retention.CompletedRunTTL.Duration)
+				}
+				p.RunRetentionTTLSeconds = int64(retention.CompletedRunTTL.Seconds())
+			}
+			if retention.ArchivedRunTTL != nil {
+				if retention.ArchivedRunTTL.Duration <= 0 {
+					return fmt.Errorf("[spec.apiServer.runRetention.archivedRunTTL] must be a positive duration, got %s", retention.ArchivedRunTTL.Duration)
+				}
+				// Runs would be deleted as soon as they are archived
+				if retention.Action != "Delete" && retention.CompletedRunTTL != nil &&
+					retention.ArchivedRunTTL.Duration <= retention.CompletedRunTTL.Duration {
+					return fmt.Errorf("[spec.apiServer.runRetention.archivedRunTTL] must be longer than completedRunTTL %s, got %s",
+						retention.CompletedRunTTL.Duration, retention.ArchivedRunTTL.Duration)
+				}
+				p.RunRetentionArchivedTTLSeconds = int64(retention.ArchivedRunTTL.Seconds())
+			}
+			if retention.PruneExperiments && retention.CompletedRunTTL == nil {
+				return fmt.Errorf("[spec.apiServer.runRetention.pruneExperiments] requires completedRunTTL to be set")
+			}
+			setStringDefault(toolboxImageFromConfig, &retention.Image)
+			setStringDefault(config.DefaultRunRetentionSchedule, &retention.Schedule)
+			setStringDefault(config.DefaultRunRetentionAction, &retention.Action)
+			setResourcesDefault(config.RunRetentionResourceRequirements, &retention.Resources)
+		}
+
+		p.AuditLogExportIntervalSeconds = 0
+		if p.APIServer.AuditLog != nil {
+			auditLog := p.APIServer.AuditLog
+			if auditLog.Enabled && (!p.APIServer.EnableRoute || p.ServiceMesh != nil) {
+				return fmt.Errorf("[spec.apiServer.auditLog] requires [spec.apiServer.enableOauth] without [spec.serviceMesh], users are identified by the OAuth proxy")
+			}
+			exportInterval := config.DefaultAuditLogExportInterval
+			if auditLog.ExportInterval != nil {
+				if auditLog.ExportInterval.Duration < time.Second {
+					return fmt.Errorf("[spec.apiServer.auditLog.exportInterval] must be at least 1s, got %s", auditLog.ExportInterval.Duration)
+				}
+				exportInterval = auditLog.ExportInterval.Duration
+			}
+			p.AuditLogExportIntervalSeconds = int64(exportInterval.Seconds())
+			setStringDefault(toolboxImageFromConfig, &auditLog.Image)
+			setResourcesDefault(config.AuditLogResourceRequirements, &auditLog.Resources)
+		}
 
 		if p.APIServer.CustomServerConfig == nil {
 			p.APIServer.CustomServerConfig = &dspa.ScriptConfigMap{
@@ -694,10 +2615,32 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 			}
 		}
 
-		// If PodToPodTLS is enabled, we need to include service-ca ca-bundles to recognize the certs
-		// that are signed by service-ca. These can be accessed via "openshift-service-ca.crt"
-		// configmap.
-		if p.PodToPodTLS {
+		// The CA of the external database is trusted along with the DSPA CA bundle
+		externalDBCA, err := externalDBCACertificate(ctx, dsp, client)
+		if err != nil {
+			return err
+		}
+		if externalDBCA != nil {
+			p.APICustomPemCerts = append(p.APICustomPemCerts, externalDBCA)
+		}
+
+		// Certificates issued by cert-manager carry the issuing CA in the
+		// certificate secret, it is not available until the certificates
+		// are issued (the reconciler waits for them before deploying).
+		if p.CertManagerIssuer != nil {
+			certSecret, certSecretErr := util.GetSecret(ctx, config.APIServerTLSSecretNamePrefix+p.Name, p.Namespace, client)
+			if certSecretErr != nil && !apierrs.IsNotFound(certSecretErr) {
+				log.Info(fmt.Sprintf("Encountered error when attempting to fetch Secret: [%s]. Error: %v", config.APIServerTLSSecretNamePrefix+p.Name, certSecretErr))
+				return certSecretErr
+			}
+			if certSecretErr == nil && len(bytes.TrimSpace(certSecret.Data[config.CertManagerCASecretKey])) != 0 {
+				p.CertManagerCABundle = certSecret.Data[config.CertManagerCASecretKey]
+				p.APICustomPemCerts = append(p.APICustomPemCerts, p.CertManagerCABundle)
+			}
+		} else if p.PodToPodTLS {
+			// If PodToPodTLS is enabled, we need to include service-ca ca-bundles to recognize the certs
+			// that are signed by service-ca. These can be accessed via "openshift-service-ca.crt"
+			// configmap.
 			serviceCA, serviceCACfgErr := util.GetConfigMap(ctx, config.OpenshiftServiceCAConfigMapName, p.Namespace, client)
 			if serviceCACfgErr != nil {
 				log.Info(fmt.Sprintf("Encountered error when attempting to fetch ConfigMap: [%s]. Error: %v", config.OpenshiftServiceCAConfigMapName, serviceCA))
@@ -800,14 +2743,26 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 	}
 
 	if p.PersistenceAgent != nil {
-		persistenceAgentImageFromConfig := config.GetStringConfigWithDefault(config.PersistenceAgentImagePath, config.DefaultImageValue)
+		if p.PersistenceAgent.ResyncInterval != nil && p.PersistenceAgent.ResyncInterval.Duration <= 0 {
+			return fmt.Errorf("[spec.persistenceAgent.resyncInterval] must be a positive duration, got %s", p.PersistenceAgent.ResyncInterval.Duration)
+		}
+		persistenceAgentImageFromConfig := p.defaultImage(config.PersistenceAgentImagePath)
 		setStringDefault(persistenceAgentImageFromConfig, &p.PersistenceAgent.Image)
 		setResourcesDefault(config.PersistenceAgentResourceRequirements, &p.PersistenceAgent.Resources)
+		setProbesDefault(config.PersistenceAgentProbes, &p.PersistenceAgent.Probes)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.PersistenceAgent.PodSecurityContext, &p.PersistenceAgent.SecurityContext)
 	}
 	if p.ScheduledWorkflow != nil {
-		scheduledWorkflowImageFromConfig := config.GetStringConfigWithDefault(config.ScheduledWorkflowImagePath, config.DefaultImageValue)
+		// "Local" is accepted by Go but means the timezone of the controller pod
+		if _, err := time.LoadLocation(p.ScheduledWorkflow.CronScheduleTimezone); err != nil || p.ScheduledWorkflow.CronScheduleTimezone == "Local" {
+			return fmt.Errorf("%w: [%s] is not a timezone of the IANA time zone database, e.g. UTC or America/New_York",
+				ErrInvalidTimezone, p.ScheduledWorkflow.CronScheduleTimezone)
+		}
+		scheduledWorkflowImageFromConfig := p.defaultImage(config.ScheduledWorkflowImagePath)
 		setStringDefault(scheduledWorkflowImageFromConfig, &p.ScheduledWorkflow.Image)
 		setResourcesDefault(config.ScheduledWorkflowResourceRequirements, &p.ScheduledWorkflow.Resources)
+		setProbesDefault(config.ScheduledWorkflowProbes, &p.ScheduledWorkflow.Probes)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.ScheduledWorkflow.PodSecurityContext, &p.ScheduledWorkflow.SecurityContext)
 	}
 	if p.MlPipelineUI != nil {
 		if dsp.Spec.MlPipelineUI.Image == "" {
@@ -816,25 +2771,59 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 		p.MlPipelineUI.Image = dsp.Spec.MlPipelineUI.Image
 		setStringDefault(config.MLPipelineUIConfigMapPrefix+dsp.Name, &p.MlPipelineUI.ConfigMapName)
 		setResourcesDefault(config.MlPipelineUIResourceRequirements, &p.MlPipelineUI.Resources)
+		setProbesDefault(config.MlPipelineUIProbes, &p.MlPipelineUI.Probes)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.MlPipelineUI.PodSecurityContext, &p.MlPipelineUI.SecurityContext)
+		if err := p.SetupMlPipelineUICustomization(); err != nil {
+			return err
+		}
 	}
-
-	// If user did not specify WorkflowController
-	if dsp.Spec.WorkflowController == nil {
-		dsp.Spec.WorkflowController = &dspa.WorkflowController{
-			Deploy: true,
+	if p.VisualizationServer != nil {
+		if dsp.Spec.VisualizationServer.Image == "" {
+			return fmt.Errorf("visualizationServer specified, but no image provided in the DSPA CR Spec")
 		}
+		setResourcesDefault(config.VisualizationResourceRequirements, &p.VisualizationServer.Resources)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.VisualizationServer.PodSecurityContext, &p.VisualizationServer.SecurityContext)
 	}
+	if p.CRDViewer != nil {
+		if dsp.Spec.CRDViewer.Image == "" {
+			return fmt.Errorf("crdViewer specified, but no image provided in the DSPA CR Spec")
+		}
+		setResourcesDefault(config.CRDViewerResourceRequirements, &p.CRDViewer.Resources)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.DefaultContainerSecurityContext, &p.CRDViewer.PodSecurityContext, &p.CRDViewer.SecurityContext)
+	}
+
 	p.WorkflowController = dsp.Spec.WorkflowController.DeepCopy()
 
+	p.Limits = dsp.Spec.Limits.DeepCopy()
+	if p.Limits != nil {
+		if p.WorkflowController == nil || !p.WorkflowController.Deploy {
+			return fmt.Errorf("[spec.limits] requires [spec.workflowController] to be deployed")
+		}
+		if p.WorkflowController.CustomConfig != "" && p.Limits.MaxConcurrentRuns > 0 {
+			return fmt.Errorf("[spec.limits.maxConcurrentRuns] and [spec.workflowController.customConfig] must not be set together, set parallelism in the custom config instead")
+		}
+	}
+
+	err = p.SetupWorkflowDefaults(dsp)
+	if err != nil {
+		return err
+	}
+
+	err = p.SetupArtifactStepResources()
+	if err != nil {
+		return err
+	}
+
 	if p.WorkflowController != nil {
-		argoWorkflowImageFromConfig := config.GetStringConfigWithDefault(config.ArgoWorkflowControllerImagePath, config.DefaultImageValue)
-		argoExecImageFromConfig := config.GetStringConfigWithDefault(config.ArgoExecImagePath, config.DefaultImageValue)
+		argoWorkflowImageFromConfig := p.defaultImage(config.ArgoWorkflowControllerImagePath)
+		argoExecImageFromConfig := p.defaultImage(config.ArgoExecImagePath)
 		setStringDefault(argoWorkflowImageFromConfig, &p.WorkflowController.Image)
 		setStringDefault(argoExecImageFromConfig, &p.WorkflowController.ArgoExecImage)
 		setResourcesDefault(config.WorkflowControllerResourceRequirements, &p.WorkflowController.Resources)
+		setSecurityContextDefaults(config.DefaultPodSecurityContext, config.WorkflowControllerSecurityContext, &p.WorkflowController.PodSecurityContext, &p.WorkflowController.SecurityContext)
 	}
 
-	err := p.SetupMLMD(dsp, log)
+	err = p.SetupMLMD(dsp, log)
 	if err != nil {
 		return err
 	}
@@ -844,11 +2833,43 @@ func (p *DSPAParams) ExtractParams(ctx context.Context, dsp *dspa.DataSciencePip
 		return err
 	}
 
+	p.setupDatabaseBackup(dsp)
+
 	err = p.SetupObjectParams(ctx, dsp, client, log)
 	if err != nil {
 		return err
 	}
 
+	err = p.SetupMultiTenancy(ctx, dsp, client)
+	if err != nil {
+		return err
+	}
+
+	err = p.SetupMonitoring(dsp, client.RESTMapper(), log)
+	if err != nil {
+		return err
+	}
+
+	err = p.SetupMlPipelineUIArtifactProxy()
+	if err != nil {
+		return err
+	}
+
+	p.SetupServiceAccountNames()
+	p.SetupTLSCertificates()
+	p.SetupHA(dsp)
+
+	err = p.pinImageDigests(ctx, log)
+	if err != nil {
+		return err
+	}
+	err = p.SetupAPIServerRollout(ctx, dsp, client, log)
+	if err != nil {
+		return err
+	}
+	p.SetupComponentImages()
+	p.SetupUpgrade(dsp)
+
 	p.SetupOwner(dsp)
 
 	return nil
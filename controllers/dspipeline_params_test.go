@@ -18,11 +18,23 @@ limitations under the License.
 package controllers
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
@@ -41,6 +53,44 @@ func TestExtractParams_WithEmptyDSPA(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestExtractParams_ComponentDefaults(t *testing.T) {
+	viper.Set(config.MlmdGRPCImagePath, "quay.io/opendatahub/ds-pipelines-metadata-grpc:latest")
+	viper.Set(config.ArgoWorkflowControllerImagePath, "quay.io/opendatahub/ds-pipelines-argo-workflowcontroller:latest")
+	defer viper.Reset()
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.MLMD = nil
+	dspa.Spec.WorkflowController = nil
+	dspa.Spec.Database = nil
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+
+	// Assert the omitted components required by DSP v2 are deployed with the config defaults
+	require.NotNil(t, params.MLMD)
+	assert.True(t, params.MLMD.Deploy)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-metadata-grpc:latest", params.MLMD.GRPC.Image)
+	require.NotNil(t, params.WorkflowController)
+	assert.True(t, params.WorkflowController.Deploy)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-argo-workflowcontroller:latest", params.WorkflowController.Image)
+	require.NotNil(t, params.MariaDB)
+	assert.True(t, params.MariaDB.Deploy)
+
+	// Assert the defaults are not set on the DSPA, which is diffed and written back
+	assert.Nil(t, dspa.Spec.MLMD)
+	assert.Nil(t, dspa.Spec.WorkflowController)
+	assert.Nil(t, dspa.Spec.Database)
+	assert.Same(t, dspa, params.Owner)
+
+	// Assert the components set in the spec are left as is
+	dspa = testutil.CreateEmptyDSPA()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.False(t, params.WorkflowController.Deploy)
+	assert.False(t, params.MariaDB.Deploy)
+
+	// Assert the object storage is still required
+	dspa.Spec.ObjectStorage = nil
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.objectStorage]")
+}
+
 func TestExtractParams_CABundle(t *testing.T) {
 
 	ctx, _, client := CreateNewTestObjects()
@@ -284,3 +334,253 @@ func TestExtractParams_WithCustomKfpLauncherConfigMap(t *testing.T) {
 	cmDataExpectedJson, err := json.Marshal(cmDataExpected)
 	require.Equal(t, string(cmDataExpectedJson), params.CustomKfpLauncherConfigMapData)
 }
+
+func TestExtractParams_ImageRegistryOverride(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server:latest")
+	defer viper.Reset()
+
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	dspa.Spec.ImageRegistryOverride = "mirror.example.com"
+	dspa.Spec.ImagePullSecrets = []v1.LocalObjectReference{{Name: "mirror-pull-secret"}}
+	err := params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+
+	// Default images are pulled from the mirror
+	assert.Equal(t, "mirror.example.com/opendatahub/ds-pipelines-api-server:latest", params.APIServer.Image)
+	// Images explicitly set in the DSPA are left as is
+	assert.Equal(t, "testimage-MlPipelineUI:test", params.MlPipelineUI.Image)
+	assert.Equal(t, dspa.Spec.ImagePullSecrets, params.ImagePullSecrets)
+}
+
+func TestExtractParams_FIPSMode(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server:latest")
+	viper.Set("ImagesFIPS.ApiServer", "quay.io/opendatahub/ds-pipelines-api-server:latest-fips")
+	defer viper.Reset()
+
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	dspa.Spec.FIPSMode = boolPtr(false)
+	err := params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.False(t, params.FIPSEnabled)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:latest", params.APIServer.Image)
+
+	// FIPS image variants are used when configured, other images are left as is
+	dspa = testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	dspa.Spec.FIPSMode = boolPtr(true)
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.True(t, params.FIPSEnabled)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server:latest-fips", params.APIServer.Image)
+	assert.Equal(t, "testimage-MlPipelineUI:test", params.MlPipelineUI.Image)
+}
+
+func TestExtractParams_RequireImageDigests(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	viper.Set(config.RequireImageDigestsConfigName, true)
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server:latest")
+	defer viper.Reset()
+
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	err := params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.ErrorIs(t, err, ErrImageNotPinned)
+
+	// Images pinned by digest in the operator config are accepted as is
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server@sha256:abc")
+	for _, path := range []string{config.PersistenceAgentImagePath, config.ScheduledWorkflowImagePath,
+		config.MlmdEnvoyImagePath, config.MlmdGRPCImagePath, config.LauncherImagePath, config.DriverImagePath,
+		config.ArgoExecImagePath, config.ArgoWorkflowControllerImagePath, config.MariaDBImagePath,
+		config.OAuthProxyImagePath, config.RuntimeGenericPath, config.ToolboxImagePath, config.RHELAIImagePath} {
+		viper.Set(path, "quay.io/opendatahub/image@sha256:abc")
+	}
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.Nil(t, err)
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-api-server@sha256:abc", params.APIServer.Image)
+}
+
+func TestExtractParams_CosignPublicKey(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.Nil(t, err)
+	viper.Set(config.CosignPublicKeyConfigName, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server:latest")
+	defer viper.Reset()
+
+	// A configured public key requires the default images to be pinned by digest
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.ErrorIs(t, err, ErrImageNotPinned)
+
+	// Pinned images without a signature in their registry are rejected
+	registry := httptest.NewTLSServer(http.NotFoundHandler())
+	defer registry.Close()
+	resolver := imageDigestResolver
+	imageDigestResolver = util.NewImageDigestResolver(registry.Client(), time.Hour)
+	defer func() { imageDigestResolver = resolver }()
+	image := strings.TrimPrefix(registry.URL, "https://") + "/opendatahub/image@sha256:" + strings.Repeat("a", 64)
+	for _, path := range []string{config.APIServerImagePath, config.PersistenceAgentImagePath, config.ScheduledWorkflowImagePath,
+		config.MlmdEnvoyImagePath, config.MlmdGRPCImagePath, config.LauncherImagePath, config.DriverImagePath,
+		config.ArgoExecImagePath, config.ArgoWorkflowControllerImagePath, config.MariaDBImagePath,
+		config.OAuthProxyImagePath, config.RuntimeGenericPath, config.ToolboxImagePath, config.RHELAIImagePath} {
+		viper.Set(path, image)
+	}
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.ErrorIs(t, err, ErrImageSignatureNotVerified)
+
+	// An invalid public key fails verification rather than skipping it
+	viper.Set(config.CosignPublicKeyConfigName, "not a key")
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.ErrorIs(t, err, ErrImageSignatureNotVerified)
+}
+
+func TestExtractParams_ImagePrecedence(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	viper.Set(config.APIServerImagePath, "quay.io/opendatahub/ds-pipelines-api-server:latest")
+	viper.Set(config.PersistenceAgentImagePath, "quay.io/opendatahub/ds-pipelines-persistenceagent:latest")
+	defer viper.Reset()
+
+	// Images set in the DSPA take precedence over the operator config
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.APIServer.Deploy = true
+	dspa.Spec.APIServer.Image = "quay.io/example/api-server:custom"
+	dspa.Spec.PersistenceAgent.Deploy = true
+	err := params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "quay.io/example/api-server:custom", params.APIServer.Image)
+	assert.Equal(t, dspav1.ComponentDetailStatus{Image: "quay.io/example/api-server:custom", ImageSource: config.ImageSourceDSPA},
+		params.ComponentImages["apiServer"])
+
+	// Images left unset fall back to the operator config
+	assert.Equal(t, "quay.io/opendatahub/ds-pipelines-persistenceagent:latest", params.PersistenceAgent.Image)
+	assert.Equal(t, dspav1.ComponentDetailStatus{Image: "quay.io/opendatahub/ds-pipelines-persistenceagent:latest", ImageSource: config.ImageSourceOperatorConfig},
+		params.ComponentImages["persistenceAgent"])
+
+	// The registry override only applies to images from the operator config
+	dspa.Spec.ImageRegistryOverride = "mirror.example.com"
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "quay.io/example/api-server:custom", params.ComponentImages["apiServer"].Image)
+	assert.Equal(t, "mirror.example.com/opendatahub/ds-pipelines-persistenceagent:latest", params.ComponentImages["persistenceAgent"].Image)
+	assert.Equal(t, config.ImageSourceOperatorConfig, params.ComponentImages["persistenceAgent"].ImageSource)
+
+	// Components that are not deployed are not reported
+	_, ok := params.ComponentImages["workflowController"]
+	assert.False(t, ok)
+}
+
+func TestExtractParams_DataConnectionRef(t *testing.T) {
+	ctx, params, client := CreateNewTestObjects()
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.ObjectStorage = &dspav1.ObjectStorage{
+		ExternalStorage: &dspav1.ExternalStorage{
+			BasePath:          "some/path",
+			DataConnectionRef: "aws-connection-testdspa",
+		},
+	}
+	dataConnection := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-connection-testdspa", Namespace: dspa.Namespace},
+		Data: map[string][]byte{
+			config.DataConnectionAccessKey: []byte("accesskey"),
+			config.DataConnectionSecretKey: []byte("secretkey"),
+			config.DataConnectionEndpoint:  []byte("https://s3.example.com:9000"),
+			config.DataConnectionBucket:    []byte("mybucket"),
+			config.DataConnectionRegion:    []byte("us-east-1"),
+		},
+	}
+	require.Nil(t, client.Create(ctx, dataConnection))
+
+	// The connection is composed from the data connection, alongside the fields set in the DSPA
+	err := params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "s3.example.com", params.ObjectStorageConnection.Host)
+	assert.Equal(t, "9000", params.ObjectStorageConnection.Port)
+	assert.Equal(t, "https://s3.example.com:9000", params.ObjectStorageConnection.Endpoint)
+	assert.True(t, *params.ObjectStorageConnection.Secure)
+	assert.Equal(t, "mybucket", params.ObjectStorageConnection.Bucket)
+	assert.Equal(t, "us-east-1", params.ObjectStorageConnection.Region)
+	assert.Equal(t, "some/path", params.ObjectStorageConnection.BasePath)
+	assert.Equal(t, "aws-connection-testdspa", params.ObjectStorageConnection.CredentialsSecret.SecretName)
+	assert.Equal(t, config.DataConnectionAccessKey, params.ObjectStorageConnection.CredentialsSecret.AccessKey)
+
+	// Fields set in the DSPA take precedence over the data connection
+	dspa.Spec.ObjectStorage.ExternalStorage.Bucket = "otherbucket"
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "otherbucket", params.ObjectStorageConnection.Bucket)
+
+	// A missing data connection is an error
+	dspa.Spec.ObjectStorage.ExternalStorage.DataConnectionRef = "missing"
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, client.Client, client.Log)
+	assert.NotNil(t, err)
+}
+
+func TestExtractParams_Credentials(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+
+	// Assert the credentials of external secrets are held as stored, not encoded again
+	dspa := testutil.CreateEmptyDSPA()
+	dspa.Spec.Database = &dspav1.Database{ExternalDB: &dspav1.ExternalDB{
+		Host:           "mysql.example.com",
+		Port:           "3306",
+		Username:       "mlpipeline",
+		DBName:         "mlpipeline",
+		PasswordSecret: &dspav1.SecretKeyValue{Name: "external-credentials", Key: "password"},
+	}}
+	dspa.Spec.ObjectStorage = &dspav1.ObjectStorage{ExternalStorage: &dspav1.ExternalStorage{
+		Host:   "s3.example.com",
+		Bucket: "mybucket",
+		Scheme: "https",
+		S3CredentialSecret: &dspav1.S3CredentialSecret{
+			SecretName: "external-credentials",
+			AccessKey:  "accesskey",
+			SecretKey:  "secretkey",
+		},
+	}}
+	externalCredentials := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "external-credentials", Namespace: dspa.Namespace},
+		Data: map[string][]byte{
+			"password":  []byte("p@ss:w0rd="),
+			"accesskey": []byte("AKIAEXAMPLE"),
+			"secretkey": []byte("c2VjcmV0a2V5"),
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, externalCredentials))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "p@ss:w0rd=", params.DBConnection.Password.Value())
+	assert.Equal(t, "AKIAEXAMPLE", params.ObjectStorageConnection.AccessKeyID.Value())
+	assert.Equal(t, "c2VjcmV0a2V5", params.ObjectStorageConnection.SecretAccessKey.Value())
+	assert.Equal(t, "cEBzczp3MHJkPQ==", params.DBConnection.Password.Base64())
+	assert.Equal(t, "[redacted]", params.DBConnection.Password.String())
+
+	// Assert generated credentials are stored as generated, and read back unchanged
+	dspa = testutil.CreateDefaultDSPA()
+	_, params, _ = CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	generated := &v1.Secret{}
+	created, err := reconciler.IsResourceCreated(ctx, generated, config.DefaultDBSecretNamePrefix+dspa.Name, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, params.DBConnection.Password.Bytes(), generated.Data[config.DefaultDBSecretKey])
+	assert.Len(t, params.DBConnection.Password.Value(), config.GeneratedDBPasswordLength)
+
+	generatedPassword := params.DBConnection.Password.Value()
+	_, params, _ = CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, generatedPassword, params.DBConnection.Password.Value())
+}
@@ -0,0 +1,115 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFeatureGates(t *testing.T) {
+	defer func() { require.Nil(t, config.SetFeatureGates("")) }()
+
+	tests := map[string]struct {
+		operatorGates string
+		annotation    string
+		expected      bool
+		expectedErr   string
+	}{
+		"default": {
+			expected: true,
+		},
+		"disabled by the operator": {
+			operatorGates: "SelectiveApply=false",
+			expected:      false,
+		},
+		"disabled by the DSPA": {
+			annotation: "SelectiveApply=false",
+			expected:   false,
+		},
+		"DSPA overrides the operator": {
+			operatorGates: "SelectiveApply=false",
+			annotation:    " SelectiveApply = true ,",
+			expected:      true,
+		},
+		"unknown gate": {
+			annotation:  "SelectiveApply=true,Unknown=true",
+			expectedErr: "unknown feature gate Unknown",
+		},
+		"missing value": {
+			annotation:  "SelectiveApply",
+			expectedErr: "must be set as SelectiveApply=true or SelectiveApply=false",
+		},
+		"invalid value": {
+			annotation:  "SelectiveApply=maybe",
+			expectedErr: "must be set to true or false",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Nil(t, config.SetFeatureGates(test.operatorGates))
+			dspa := testutil.CreateDefaultDSPA()
+			if test.annotation != "" {
+				dspa.Annotations = map[string]string{config.FeatureGatesAnnotation: test.annotation}
+			}
+
+			ctx, params, reconciler := CreateNewTestObjects()
+			err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+			if test.expectedErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidFeatureGates)
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expected, params.FeatureEnabled(config.SelectiveApplyFeatureGate))
+			if test.expected {
+				assert.Equal(t, []string{config.SelectiveApplyFeatureGate}, params.ActiveFeatureGates())
+			} else {
+				assert.Empty(t, params.ActiveFeatureGates())
+			}
+		})
+	}
+
+	// Assert the operator gates are validated
+	assert.ErrorContains(t, config.SetFeatureGates("Unknown=true"), "unknown feature gate Unknown")
+}
+
+func TestSelectiveApplyFeatureGate(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Annotations = map[string]string{config.FeatureGatesAnnotation: "SelectiveApply=false"}
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	deployment := &appsv1.Deployment{}
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: expectedAPIServerName, Namespace: dspa.Namespace}, deployment))
+	require.Nil(t, reconciler.Delete(ctx, deployment))
+
+	// Assert unchanged templates are applied again with the gate disabled
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedAPIServerName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+}
@@ -0,0 +1,191 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DSPAInventory counts the DSPAs of the cluster, for platform admins to get a
+// single view of them without listing every namespace.
+type DSPAInventory struct {
+	Total int `json:"total"`
+	// DSPAs counts the DSPAs by version, readiness and storage types
+	DSPAs []DSPAInventoryEntry `json:"dspas"`
+	// Components counts the DSPA components by condition type and readiness
+	Components []ComponentInventoryEntry `json:"components"`
+}
+
+type DSPAInventoryEntry struct {
+	DSPVersion   string `json:"dspVersion"`
+	Ready        bool   `json:"ready"`
+	StorageType  string `json:"storageType"`
+	DatabaseType string `json:"databaseType"`
+	Count        int    `json:"count"`
+}
+
+type ComponentInventoryEntry struct {
+	Component string `json:"component"`
+	Ready     bool   `json:"ready"`
+	Count     int    `json:"count"`
+}
+
+// dspaStorageTypes returns the object storage and database types of dspa
+func dspaStorageTypes(dspa *dspav1.DataSciencePipelinesApplication) (string, string) {
+	storageType, databaseType := "none", "mariadb"
+	if dspa.Spec.ObjectStorage != nil && dspa.Spec.ObjectStorage.ExternalStorage != nil {
+		storageType = "external"
+	} else if dspa.Spec.ObjectStorage != nil && dspa.Spec.ObjectStorage.Minio != nil {
+		storageType = "minio"
+	}
+	if dspa.Spec.Database != nil && dspa.Spec.Database.ExternalDB != nil {
+		databaseType = "external"
+	}
+	return storageType, databaseType
+}
+
+// ListDSPAInventory counts the DSPAs of every namespace from their status
+func ListDSPAInventory(ctx context.Context, reader client.Reader) (*DSPAInventory, error) {
+	dspaList := &dspav1.DataSciencePipelinesApplicationList{}
+	if err := reader.List(ctx, dspaList); err != nil {
+		return nil, err
+	}
+
+	dspas := map[DSPAInventoryEntry]int{}
+	components := map[ComponentInventoryEntry]int{}
+	for i := range dspaList.Items {
+		dspa := &dspaList.Items[i]
+		entry := DSPAInventoryEntry{DSPVersion: dspa.Spec.DSPVersion}
+		entry.StorageType, entry.DatabaseType = dspaStorageTypes(dspa)
+		for _, condition := range dspa.Status.Conditions {
+			ready := condition.Status == metav1.ConditionTrue
+			switch condition.Type {
+			case config.CrReady:
+				entry.Ready = ready
+			case config.Degraded, config.UpgradeProgressing:
+				// Not the readiness of a component
+			default:
+				components[ComponentInventoryEntry{Component: condition.Type, Ready: ready}]++
+			}
+		}
+		dspas[entry]++
+	}
+
+	inventory := &DSPAInventory{Total: len(dspaList.Items), DSPAs: []DSPAInventoryEntry{}, Components: []ComponentInventoryEntry{}}
+	for entry, count := range dspas {
+		entry.Count = count
+		inventory.DSPAs = append(inventory.DSPAs, entry)
+	}
+	for entry, count := range components {
+		entry.Count = count
+		inventory.Components = append(inventory.Components, entry)
+	}
+	// Sort the entries for stable responses
+	sort.Slice(inventory.DSPAs, func(i, j int) bool {
+		a, b := inventory.DSPAs[i], inventory.DSPAs[j]
+		if a.DSPVersion != b.DSPVersion {
+			return a.DSPVersion < b.DSPVersion
+		} else if a.StorageType != b.StorageType {
+			return a.StorageType < b.StorageType
+		} else if a.DatabaseType != b.DatabaseType {
+			return a.DatabaseType < b.DatabaseType
+		}
+		return !a.Ready && b.Ready
+	})
+	sort.Slice(inventory.Components, func(i, j int) bool {
+		a, b := inventory.Components[i], inventory.Components[j]
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return !a.Ready && b.Ready
+	})
+	return inventory, nil
+}
+
+// DSPAInventoryHandler serves the DSPAInventory of the cluster as JSON
+type DSPAInventoryHandler struct {
+	Client client.Reader
+}
+
+func (h *DSPAInventoryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	inventory, err := ListDSPAInventory(req.Context(), h.Client)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(inventory)
+}
+
+var (
+	dspaInventoryDesc = prometheus.NewDesc(
+		"data_science_pipelines_application_inventory",
+		"Data Science Pipelines Application - Number of DSPAs by version, readiness and storage types",
+		[]string{"dsp_version", "ready", "storage_type", "database_type"}, nil,
+	)
+	componentInventoryDesc = prometheus.NewDesc(
+		"data_science_pipelines_application_component_inventory",
+		"Data Science Pipelines Application - Number of DSPA components by condition type and readiness",
+		[]string{"component", "ready"}, nil,
+	)
+)
+
+// DSPAInventoryCollector exports the DSPAInventory of the cluster as metrics,
+// computed from the manager cache on every scrape, so that the counts never
+// go stale as DSPAs are deleted.
+type DSPAInventoryCollector struct {
+	Client client.Reader
+	Log    logr.Logger
+}
+
+func (c *DSPAInventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dspaInventoryDesc
+	ch <- componentInventoryDesc
+}
+
+func (c *DSPAInventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	inventory, err := ListDSPAInventory(ctx, c.Client)
+	if err != nil {
+		c.Log.Error(err, "unable to list the DSPAs of the inventory metrics")
+		return
+	}
+	for _, entry := range inventory.DSPAs {
+		ch <- prometheus.MustNewConstMetric(dspaInventoryDesc, prometheus.GaugeValue, float64(entry.Count),
+			entry.DSPVersion, strconv.FormatBool(entry.Ready), entry.StorageType, entry.DatabaseType)
+	}
+	for _, entry := range inventory.Components {
+		ch <- prometheus.MustNewConstMetric(componentInventoryDesc, prometheus.GaugeValue, float64(entry.Count),
+			entry.Component, strconv.FormatBool(entry.Ready))
+	}
+}
@@ -0,0 +1,88 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDSPAInventory(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	createDSPA := func(namespace string, ready bool, external bool) {
+		dspa := testutil.CreateEmptyDSPA()
+		dspa.Namespace = namespace
+		dspa.Spec.DSPVersion = "v2"
+		if external {
+			dspa.Spec.ObjectStorage = &dspav1.ObjectStorage{ExternalStorage: &dspav1.ExternalStorage{Host: "s3.example.com"}}
+		}
+		require.Nil(t, reconciler.Create(ctx, dspa))
+		status := metav1.ConditionFalse
+		if ready {
+			status = metav1.ConditionTrue
+		}
+		dspa.Status.Conditions = []metav1.Condition{
+			{Type: config.APIServerReady, Status: status, Reason: config.MinimumReplicasAvailable},
+			{Type: config.CrReady, Status: status, Reason: config.MinimumReplicasAvailable},
+			{Type: config.Degraded, Status: metav1.ConditionFalse, Reason: config.MinimumReplicasAvailable},
+		}
+		require.Nil(t, reconciler.Update(ctx, dspa))
+	}
+	createDSPA("first", true, false)
+	createDSPA("second", true, false)
+	createDSPA("third", false, true)
+
+	// Assert the DSPAs of every namespace are counted
+	recorder := httptest.NewRecorder()
+	(&DSPAInventoryHandler{Client: reconciler.Client}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/inventory/dspa", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	inventory := DSPAInventory{}
+	require.Nil(t, json.NewDecoder(recorder.Body).Decode(&inventory))
+	assert.Equal(t, 3, inventory.Total)
+	assert.Equal(t, []DSPAInventoryEntry{
+		{DSPVersion: "v2", Ready: false, StorageType: "external", DatabaseType: "mariadb", Count: 1},
+		{DSPVersion: "v2", Ready: true, StorageType: "minio", DatabaseType: "mariadb", Count: 2},
+	}, inventory.DSPAs)
+	assert.Equal(t, []ComponentInventoryEntry{
+		{Component: config.APIServerReady, Ready: false, Count: 1},
+		{Component: config.APIServerReady, Ready: true, Count: 2},
+	}, inventory.Components)
+
+	// Assert the same counts are exported as metrics
+	expected := `
+# HELP data_science_pipelines_application_inventory Data Science Pipelines Application - Number of DSPAs by version, readiness and storage types
+# TYPE data_science_pipelines_application_inventory gauge
+data_science_pipelines_application_inventory{database_type="mariadb",dsp_version="v2",ready="false",storage_type="external"} 1
+data_science_pipelines_application_inventory{database_type="mariadb",dsp_version="v2",ready="true",storage_type="minio"} 2
+`
+	collector := &DSPAInventoryCollector{Client: reconciler.Client, Log: logr.Discard()}
+	assert.Nil(t, promtestutil.CollectAndCompare(collector, strings.NewReader(expected), "data_science_pipelines_application_inventory"))
+	assert.Equal(t, 4, promtestutil.CollectAndCount(collector))
+}
@@ -0,0 +1,55 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	mf "github.com/manifestival/manifestival"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+)
+
+// resourceLabels returns the labels of the resources rendered from template
+// for the DSPA owner: the standard app.kubernetes.io labels naming the
+// component of the template and its DSPA, and the other labels of the
+// DSPO.ResourceLabels operator config. The operator config may only override
+// the part-of label among the standard labels, so that the resources of a DSPA
+// are always found by their instance label.
+func resourceLabels(owner mf.Owner, template string) (standard, configured map[string]string) {
+	standard = map[string]string{config.AppPartOfLabel: config.DefaultAppPartOf}
+	configured = map[string]string{}
+	for key, value := range config.GetStringMapConfigWithDefault(config.ResourceLabelsConfigName, nil) {
+		if key == config.AppPartOfLabel {
+			standard[key] = value
+		} else {
+			configured[key] = value
+		}
+	}
+	standard[config.AppNameLabel] = strings.SplitN(template, "/", 2)[0]
+	standard[config.AppInstanceLabel] = owner.GetName()
+	standard[config.AppManagedByLabel] = config.AppManagedBy
+	return standard, configured
+}
+
+// resourceLabelsTransformer sets the resourceLabels of template on resources
+// and on the pods of deployments. The standard labels replace those set by the
+// template, the configured labels do not, as selectors rely on the labels of
+// the templates.
+func resourceLabelsTransformer(owner mf.Owner, template string) mf.Transformer {
+	standard, configured := resourceLabels(owner, template)
+	return util.AddLabelsTransformer(standard, configured)
+}
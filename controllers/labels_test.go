@@ -0,0 +1,109 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResourceLabels(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assertLabels := func(t *testing.T, resources []unstructured.Unstructured, expected map[string]string) {
+		for _, resource := range resources {
+			id := resource.GetKind() + "/" + resource.GetName()
+			if resource.GetNamespace() == "" {
+				// Cluster-scoped resources are not owned by their DSPA
+				continue
+			}
+			labels := resource.GetLabels()
+			podLabels, _, err := unstructured.NestedStringMap(resource.Object, "spec", "template", "metadata", "labels")
+			require.Nil(t, err)
+			for key, value := range expected {
+				assert.Equal(t, value, labels[key], "%s label of %s", key, id)
+				if resource.GetKind() == "Deployment" {
+					assert.Equal(t, value, podLabels[key], "%s pod label of %s", key, id)
+				}
+			}
+			assert.NotEmpty(t, labels[config.AppNameLabel], "name label of %s", id)
+		}
+	}
+
+	// Assert every rendered resource has the standard labels
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	assertLabels(t, resources, map[string]string{
+		config.AppInstanceLabel:  "testdspa",
+		config.AppPartOfLabel:    "data-science-pipelines",
+		config.AppManagedByLabel: "data-science-pipelines-operator",
+	})
+	names, components := map[string]string{}, map[string]string{}
+	for _, resource := range resources {
+		names[resource.GetKind()+"/"+resource.GetName()] = resource.GetLabels()[config.AppNameLabel]
+		components[resource.GetKind()+"/"+resource.GetName()] = resource.GetLabels()["component"]
+	}
+	assert.Equal(t, "apiserver", names["Deployment/ds-pipeline-testdspa"])
+	assert.Equal(t, "mariadb", names["Deployment/mariadb-testdspa"])
+	assert.Equal(t, "minio", names["Deployment/minio-testdspa"])
+	assert.Equal(t, "ml-metadata", names["Deployment/ds-pipeline-metadata-grpc-testdspa"])
+
+	// Assert the configured labels are added, without overriding those of the
+	// templates or the standard labels other than part-of
+	viper.Set(config.ResourceLabelsConfigName, map[string]string{
+		"team":                   "ml-platform",
+		"component":              "overridden",
+		config.AppPartOfLabel:    "opendatahub",
+		config.AppInstanceLabel:  "overridden",
+		config.AppManagedByLabel: "overridden",
+	})
+	defer viper.Set(config.ResourceLabelsConfigName, nil)
+	resources, err = RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	assertLabels(t, resources, map[string]string{
+		"team":                   "ml-platform",
+		config.AppInstanceLabel:  "testdspa",
+		config.AppPartOfLabel:    "opendatahub",
+		config.AppManagedByLabel: "data-science-pipelines-operator",
+	})
+	for _, resource := range resources {
+		if component := components[resource.GetKind()+"/"+resource.GetName()]; component != "" {
+			assert.Equal(t, component, resource.GetLabels()["component"])
+		}
+	}
+
+	// Assert the applied resources have the same labels as the rendered ones
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	deployment := &appsv1.Deployment{}
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: "ds-pipeline-testdspa", Namespace: dspa.Namespace}, deployment))
+	assert.Equal(t, "ml-platform", deployment.Labels["team"])
+	assert.Equal(t, "apiserver", deployment.Labels[config.AppNameLabel])
+	assert.Equal(t, "testdspa", deployment.Spec.Template.Labels[config.AppInstanceLabel])
+	assert.Equal(t, "opendatahub", deployment.Spec.Template.Labels[config.AppPartOfLabel])
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, config.AppInstanceLabel)
+}
@@ -0,0 +1,121 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// managementState returns the management state of dspa, from its spec or
+// else from the DSPO config. Unknown config values default to Managed.
+func managementState(dspa *dspav1.DataSciencePipelinesApplication) dspav1.ManagementState {
+	if dspa.Spec.ManagementState != "" {
+		return dspa.Spec.ManagementState
+	}
+	state := dspav1.ManagementState(config.GetStringConfigWithDefault(config.ManagementStateConfigName, config.DefaultManagementState))
+	switch state {
+	case dspav1.ManagementStateUnmanaged, dspav1.ManagementStateRemoved:
+		return state
+	}
+	return dspav1.ManagementStateManaged
+}
+
+// reconcileUnmanaged reports the health of the components of an Unmanaged
+// DSPA, as they were deployed, without creating, updating or deleting any of
+// its resources. The DSPA is requeued to keep its status current.
+func (r *DSPAReconciler) reconcileUnmanaged(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) (ctrl.Result, error) {
+	log := dspaLogger(r.Log, dspa.Namespace, dspa.Name, params.ReconcileID)
+	log.Info("DSPA is Unmanaged, reporting the health of its components without reconciling their resources.")
+
+	_, _ = r.checkPrerequisites(ctx, dspa, params, dspaStatus)
+	r.setStatus(ctx, params.APIServerDefaultResourceName, config.APIServerReady, dspa, dspaStatus.SetApiServerStatus, log)
+	r.setStatus(ctx, params.PersistentAgentDefaultResourceName, config.PersistenceAgentReady, dspa, dspaStatus.SetPersistenceAgentStatus, log)
+	r.setStatus(ctx, params.ScheduledWorkflowDefaultResourceName, config.ScheduledWorkflowReady, dspa, dspaStatus.SetScheduledWorkflowStatus, log)
+	r.setStatus(ctx, params.MlmdProxyDefaultResourceName, config.MLMDProxyReady, dspa, dspaStatus.SetMLMDProxyStatus, log)
+	if params.CRDViewer != nil && params.CRDViewer.Deploy {
+		r.setStatus(ctx, params.CRDViewerDefaultResourceName, config.CRDViewerReady, dspa, dspaStatus.SetCRDViewerStatus, log)
+	}
+
+	requeueTime := config.GetDurationConfigWithDefault(config.RequeueTimeConfigName, config.DefaultRequeueTime)
+	return ctrl.Result{RequeueAfter: requeueTime}, nil
+}
+
+// reconcileRemoved deletes the components of a Removed DSPA, see RemoveComponents
+func (r *DSPAReconciler) reconcileRemoved(ctx context.Context, dspa *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) (ctrl.Result, error) {
+	log := dspaLogger(r.Log, dspa.Namespace, dspa.Name, params.ReconcileID)
+	if err := r.RemoveComponents(ctx, dspa, params); err != nil {
+		dspaStatus.SetDSPANotReady(err, config.FailingToDeploy)
+		return ctrl.Result{}, err
+	}
+
+	err := fmt.Errorf("DSPA is Removed, its components are deleted")
+	dspaStatus.SetDatabaseNotReady(err, config.ComponentsRemoved)
+	dspaStatus.SetObjStoreNotReady(err, config.ComponentsRemoved)
+	dspaStatus.SetApiServerStatus(dspastatus.BuildFalseCondition(config.APIServerReady, config.ComponentsRemoved, err.Error()))
+	dspaStatus.SetPersistenceAgentStatus(dspastatus.BuildFalseCondition(config.PersistenceAgentReady, config.ComponentsRemoved, err.Error()))
+	dspaStatus.SetScheduledWorkflowStatus(dspastatus.BuildFalseCondition(config.ScheduledWorkflowReady, config.ComponentsRemoved, err.Error()))
+	dspaStatus.SetMLMDProxyStatus(dspastatus.BuildFalseCondition(config.MLMDProxyReady, config.ComponentsRemoved, err.Error()))
+	dspaStatus.SetDSPANotReady(err, config.ComponentsRemoved)
+	log.Info(err.Error())
+	return ctrl.Result{}, nil
+}
+
+// RemoveComponents deletes the resources rendered for dsp that are controlled
+// by it, and the resources not garbage collected along with it. The
+// PersistentVolumeClaims and Secrets of dsp are kept, so that its components
+// are redeployed with their data and credentials once it is Managed again.
+func (r *DSPAReconciler) RemoveComponents(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
+	resources, err := RenderAll(r.Templates, dsp, params)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		if kind := resource.GetKind(); kind == "PersistentVolumeClaim" || kind == "Secret" || resource.GetNamespace() == "" {
+			continue
+		}
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(resource.GroupVersionKind())
+		err := r.Get(ctx, types.NamespacedName{Name: resource.GetName(), Namespace: resource.GetNamespace()}, existing)
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !ownedBy(existing, dsp) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, existing)); err != nil {
+			return err
+		}
+	}
+
+	// Apply every template again once the DSPA is Managed again
+	r.appliedManifests.forget(types.NamespacedName{Namespace: dsp.Namespace, Name: dsp.Name})
+	return r.cleanUpResources(ctx, dsp, params)
+}
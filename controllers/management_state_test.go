@@ -0,0 +1,92 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestManagementState(t *testing.T) {
+	defer viper.Set(config.ManagementStateConfigName, nil)
+	dspa := testutil.CreateDefaultDSPA()
+	assert.Equal(t, dspav1.ManagementStateManaged, managementState(dspa))
+
+	// Assert the operator config applies to the DSPAs that do not set it
+	viper.Set(config.ManagementStateConfigName, "Unmanaged")
+	assert.Equal(t, dspav1.ManagementStateUnmanaged, managementState(dspa))
+	dspa.Spec.ManagementState = dspav1.ManagementStateRemoved
+	assert.Equal(t, dspav1.ManagementStateRemoved, managementState(dspa))
+
+	// Assert unknown config values are Managed
+	dspa.Spec.ManagementState = ""
+	viper.Set(config.ManagementStateConfigName, "Observed")
+	assert.Equal(t, dspav1.ManagementStateManaged, managementState(dspa))
+}
+
+func TestEnsureBucketModeUnmanaged(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.EnsureBucket = dspav1.EnsureBucketCreate
+	params := &DSPAParams{ManagementState: dspav1.ManagementStateManaged}
+	assert.Equal(t, dspav1.EnsureBucketCreate, params.EnsureBucketMode(dspa))
+
+	// Assert buckets are only verified for Unmanaged DSPAs
+	params.ManagementState = dspav1.ManagementStateUnmanaged
+	assert.Equal(t, dspav1.EnsureBucketVerify, params.EnsureBucketMode(dspa))
+}
+
+func TestRemoveComponents(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.UID = "testdspa-uid"
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	created := func(obj client.Object, name string) bool {
+		created, err := reconciler.IsResourceCreated(ctx, obj, name, dspa.Namespace)
+		require.Nil(t, err)
+		return created
+	}
+	require.True(t, created(&appsv1.Deployment{}, "ds-pipeline-testdspa"))
+	require.True(t, created(&appsv1.Deployment{}, "mariadb-testdspa"))
+	require.True(t, created(&corev1.PersistentVolumeClaim{}, "mariadb-testdspa"))
+
+	// A resource of the same kind not controlled by the DSPA
+	unowned := &corev1.ConfigMap{}
+	unowned.Name, unowned.Namespace = "ds-pipeline-server-config-testdspa", dspa.Namespace
+	require.Nil(t, reconciler.Delete(ctx, unowned))
+	require.Nil(t, reconciler.Create(ctx, unowned))
+
+	require.Nil(t, reconciler.RemoveComponents(ctx, dspa, params))
+
+	// Assert the components are deleted, while their data and credentials are kept
+	assert.False(t, created(&appsv1.Deployment{}, "ds-pipeline-testdspa"))
+	assert.False(t, created(&appsv1.Deployment{}, "mariadb-testdspa"))
+	assert.True(t, created(&corev1.PersistentVolumeClaim{}, "mariadb-testdspa"))
+	assert.True(t, created(&corev1.Secret{}, params.DBConnection.CredentialsSecret.Name))
+	assert.True(t, created(&corev1.ConfigMap{}, "ds-pipeline-server-config-testdspa"))
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package controllers
 
 import (
+	"strings"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -95,6 +97,102 @@ var (
 	)
 )
 
+// Prometheus metrics of the templates applied by the reconciles, labelled by
+// their template group, see templateGroup
+var (
+	TemplateRenderDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "data_science_pipelines_operator_template_render_duration_seconds",
+			Help:    "Data Science Pipelines Operator - Duration of rendering and transforming the manifests of a template",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		},
+		[]string{
+			"template_group",
+		},
+	)
+	TemplateApplyDurationMetric = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "data_science_pipelines_operator_template_apply_duration_seconds",
+			Help:    "Data Science Pipelines Operator - Duration of applying the manifests of a template, including its conflict retries",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+		},
+		[]string{
+			"template_group",
+		},
+	)
+	TemplateApplyFailuresMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "data_science_pipelines_operator_template_apply_failures_total",
+			Help: "Data Science Pipelines Operator - Templates that failed to render or apply",
+		},
+		[]string{
+			"template_group",
+		},
+	)
+	TemplateApplyConflictsMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "data_science_pipelines_operator_template_apply_conflicts_total",
+			Help: "Data Science Pipelines Operator - Template applies that failed with a conflict",
+		},
+		[]string{
+			"template_group",
+		},
+	)
+	TemplateApplyRetriesMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "data_science_pipelines_operator_template_apply_retries_total",
+			Help: "Data Science Pipelines Operator - Template applies retried after a conflict",
+		},
+		[]string{
+			"template_group",
+		},
+	)
+	ConfigReloadsMetric = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "data_science_pipelines_operator_config_reloads_total",
+			Help: "Data Science Pipelines Operator - Changes of the operator config file, by result (reloaded, unchanged or failed)",
+		},
+		[]string{
+			"result",
+		},
+	)
+)
+
+// Prometheus metrics of the run retention jobs of the DSPAs, see
+// recordRunRetentionMetrics
+var (
+	RunRetentionPrunedMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "data_science_pipelines_application_run_retention_pruned",
+			Help: "Data Science Pipelines Application - Runs and experiments pruned by the latest run retention job, by resource (runs or experiments) and action (archived or deleted)",
+		},
+		[]string{
+			"dspa_name",
+			"dspa_namespace",
+			"resource",
+			"action",
+		},
+	)
+	RunRetentionLastSuccessMetric = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "data_science_pipelines_application_run_retention_last_success_timestamp_seconds",
+			Help: "Data Science Pipelines Application - Time the latest run retention job succeeded",
+		},
+		[]string{
+			"dspa_name",
+			"dspa_namespace",
+		},
+	)
+)
+
+// templateGroup returns the component directory of template, e.g. apiserver
+// for apiserver/default/deployment.yaml.tmpl, so that the template metrics
+// have one series per component rather than per template.
+func templateGroup(template string) string {
+	group, _, _ := strings.Cut(template, "/")
+	return strings.TrimSuffix(group, ".yaml.tmpl")
+}
+
 // InitMetrics initialize prometheus metrics
 func InitMetrics() {
 	metrics.Registry.MustRegister(DBAvailableMetric,
@@ -103,5 +201,13 @@ func InitMetrics() {
 		PersistenceAgentReadyMetric,
 		ScheduledWorkflowReadyMetric,
 		MLMDProxyReadyMetric,
-		CrReadyMetric)
+		CrReadyMetric,
+		TemplateRenderDurationMetric,
+		TemplateApplyDurationMetric,
+		TemplateApplyFailuresMetric,
+		TemplateApplyConflictsMetric,
+		TemplateApplyRetriesMetric,
+		ConfigReloadsMetric,
+		RunRetentionPrunedMetric,
+		RunRetentionLastSuccessMetric)
 }
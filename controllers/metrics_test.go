@@ -0,0 +1,90 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictingClient fails the next conflicts updates with a conflict
+type conflictingClient struct {
+	client.Client
+	conflicts int
+}
+
+func (c *conflictingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.conflicts > 0 {
+		c.conflicts--
+		return apierrs.NewConflict(schema.GroupResource{Resource: "deployments"}, obj.GetName(), nil)
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestTemplateGroup(t *testing.T) {
+	assert.Equal(t, "apiserver", templateGroup("apiserver/default/deployment.yaml.tmpl"))
+	assert.Equal(t, "monitoring", templateGroup("monitoring/prometheus-rule.yaml.tmpl"))
+	assert.Equal(t, "configmap", templateGroup("configmap.yaml.tmpl"))
+}
+
+func TestTemplateApplyMetrics(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	samples := func(histogram *prometheus.HistogramVec) uint64 {
+		metric := &dto.Metric{}
+		require.Nil(t, histogram.WithLabelValues("apiserver").(prometheus.Histogram).Write(metric))
+		return metric.GetHistogram().GetSampleCount()
+	}
+	renders, applies := samples(TemplateRenderDurationMetric), samples(TemplateApplyDurationMetric)
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	conflicts := promtestutil.ToFloat64(TemplateApplyConflictsMetric.WithLabelValues("apiserver"))
+	retries := promtestutil.ToFloat64(TemplateApplyRetriesMetric.WithLabelValues("apiserver"))
+	failures := promtestutil.ToFloat64(TemplateApplyFailuresMetric.WithLabelValues("apiserver"))
+
+	// Assert the render and apply durations are recorded per template group
+	assert.Greater(t, samples(TemplateRenderDurationMetric), renders)
+	assert.Greater(t, samples(TemplateApplyDurationMetric), applies)
+
+	// Assert conflicting updates are retried and counted
+	conflicting := &conflictingClient{Client: reconciler.Client, conflicts: 1}
+	reconciler.Client = conflicting
+	dspa.Spec.APIServer.Image = "quay.io/opendatahub/ds-pipelines-api-server:changed"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	assert.Equal(t, conflicts+1, promtestutil.ToFloat64(TemplateApplyConflictsMetric.WithLabelValues("apiserver")))
+	assert.Equal(t, retries+1, promtestutil.ToFloat64(TemplateApplyRetriesMetric.WithLabelValues("apiserver")))
+	assert.Equal(t, failures, promtestutil.ToFloat64(TemplateApplyFailuresMetric.WithLabelValues("apiserver")))
+
+	// Assert the templates still conflicting once the retries are exhausted fail
+	conflicting.conflicts = 100
+	dspa.Spec.APIServer.Image = "quay.io/opendatahub/ds-pipelines-api-server:changed-again"
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.True(t, apierrs.IsConflict(reconciler.ReconcileAPIServer(ctx, dspa, params)))
+	assert.Equal(t, failures+1, promtestutil.ToFloat64(TemplateApplyFailuresMetric.WithLabelValues("apiserver")))
+}
@@ -18,12 +18,23 @@ package controllers
 import (
 	"context"
 	"errors"
+	"io/fs"
+	"path"
+	"strings"
+
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	mlmdTemplatesDir                   = "ml-metadata"
 	mlmdEnvoyRoute                     = mlmdTemplatesDir + "/route/metadata-envoy.route.yaml.tmpl"
+	mlmdGRPCRoute                      = mlmdTemplatesDir + "/route/metadata-grpc.route.yaml.tmpl"
 	mlmdProxyDefaultResourceNamePrefix = "ds-pipeline-scheduledworkflow-"
 	mlmdGrpcService                    = "grpc-service"
 )
@@ -31,7 +42,7 @@ const (
 func (r *DSPAReconciler) ReconcileMLMD(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) error {
 
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	if (params.MLMD == nil || !params.MLMD.Deploy) && (dsp.Spec.MLMD == nil || !dsp.Spec.MLMD.Deploy) {
 		r.Log.Info("Skipping Application of ML-Metadata (MLMD) Resources")
@@ -40,36 +51,131 @@ func (r *DSPAReconciler) ReconcileMLMD(ctx context.Context, dsp *dspav1.DataScie
 
 	log.Info("Applying ML-Metadata (MLMD) Resources")
 
-	// We need to create the service first so OpenShift creates the certificate that we'll use later.
-	err := r.ApplyDir(dsp, params, mlmdTemplatesDir+"/"+mlmdGrpcService)
+	envoyTemplates, grpcTemplates, err := mlmdComponentTemplates(r.Templates)
+	if err != nil {
+		return err
+	}
+	grpcServiceTemplates, err := util.GetTemplatesInDir(r.Templates, mlmdTemplatesDir+"/"+mlmdGrpcService)
 	if err != nil {
 		return err
 	}
 
-	if params.PodToPodTLS {
-		var certificatesExist bool
-		certificatesExist, err = params.LoadMlmdCertificates(ctx, r.Client)
+	if params.MLMDGRPCDeployed() {
+		// We need to create the service first so OpenShift creates the certificate that we'll use later.
+		err = r.ApplyAll(dsp, params, grpcServiceTemplates)
 		if err != nil {
 			return err
 		}
+		err = r.deleteStaleMLMDGRPCServices(ctx, dsp, params)
+		if err != nil {
+			return err
+		}
+
+		if params.PodToPodTLS {
+			var certificatesExist bool
+			certificatesExist, err = params.LoadMlmdCertificates(ctx, r.Client)
+			if err != nil {
+				return err
+			}
 
-		if !certificatesExist {
-			return errors.New("secret containing the certificate for MLMD gRPC Server was not created yet")
+			if !certificatesExist {
+				return errors.New("secret containing the certificate for MLMD gRPC Server was not created yet")
+			}
 		}
+
+		err = r.ApplyAll(dsp, params, grpcTemplates)
+	} else {
+		log.Info("Deleting the MLMD gRPC Server Resources, spec.mlmd.grpc.deploy is false")
+		err = r.deleteTemplates(params, append(grpcServiceTemplates, grpcTemplates...))
+	}
+	if err != nil {
+		return err
 	}
 
-	err = r.ApplyDir(dsp, params, mlmdTemplatesDir)
+	if params.MLMDEnvoyDeployed() {
+		err = r.ApplyAll(dsp, params, envoyTemplates)
+	} else {
+		log.Info("Deleting the MLMD Envoy Proxy Resources, spec.mlmd.envoy.deploy is false")
+		err = r.deleteTemplates(params, envoyTemplates)
+	}
 	if err != nil {
 		return err
 	}
 
-	if dsp.Spec.MLMD == nil || dsp.Spec.MLMD.Envoy == nil || dsp.Spec.MLMD.Envoy.DeployRoute {
+	if params.MLMDEnvoyDeployed() && (dsp.Spec.MLMD == nil || dsp.Spec.MLMD.Envoy == nil || dsp.Spec.MLMD.Envoy.DeployRoute) {
 		err = r.Apply(dsp, params, mlmdEnvoyRoute)
 		if err != nil {
 			return err
 		}
+	} else if !params.MLMDEnvoyDeployed() {
+		err = r.deleteExternalRoute(ctx, params, types.NamespacedName{Name: "ds-pipeline-md-" + dsp.Name, Namespace: dsp.Namespace})
+		if err != nil {
+			return err
+		}
+	}
+
+	if params.MLMD.GRPC.EnableRoute {
+		err = r.Apply(dsp, params, mlmdGRPCRoute)
+	} else {
+		err = r.deleteExternalRoute(ctx, params, types.NamespacedName{Name: config.MlmdGRPCServiceNamePrefix + dsp.Name, Namespace: dsp.Namespace})
+	}
+	if err != nil {
+		return err
 	}
 
 	log.Info("Finished applying MLMD Resources")
 	return nil
 }
+
+// mlmdComponentTemplates returns the templates of the MLMD Envoy proxy and
+// of the MLMD gRPC server, apart from the Services and Routes of the server.
+func mlmdComponentTemplates(templates fs.FS) (envoy, grpc []string, err error) {
+	mlmdTemplates, err := util.GetTemplatesInDir(templates, mlmdTemplatesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, template := range mlmdTemplates {
+		if strings.HasPrefix(path.Base(template), "metadata-envoy") {
+			envoy = append(envoy, template)
+		} else {
+			grpc = append(grpc, template)
+		}
+	}
+	return envoy, grpc, nil
+}
+
+// deleteTemplates deletes the resources of templates, e.g. of a component
+// that is no longer deployed.
+func (r *DSPAReconciler) deleteTemplates(params *DSPAParams, templates []string) error {
+	for _, template := range templates {
+		if err := r.DeleteResource(params, template); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteStaleMLMDGRPCServices deletes the additional Services of the MLMD gRPC
+// server the DSPA no longer sets, e.g. once spec.mlmd.grpc.service is renamed
+// or removed.
+func (r *DSPAReconciler) deleteStaleMLMDGRPCServices(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) error {
+	services := &corev1.ServiceList{}
+	err := r.List(ctx, services, client.InNamespace(dsp.Namespace), client.MatchingLabels{"app": config.MlmdGRPCServiceNamePrefix + dsp.Name})
+	if err != nil {
+		return err
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		switch service.Name {
+		case config.MlmdGRPCServiceNamePrefix + dsp.Name, config.MlmdGRPCSharedServiceName, params.MlmdGRPCServiceName:
+			continue
+		}
+		if !metav1.IsControlledBy(service, dsp) {
+			continue
+		}
+		if err := r.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
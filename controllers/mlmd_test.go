@@ -19,13 +19,18 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	v1 "github.com/openshift/api/route/v1"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestDeployMLMD(t *testing.T) {
@@ -402,6 +407,99 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func TestDeployEnvoyConfig(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedMLMDEnvoyName := "ds-pipeline-metadata-envoy-testdspa"
+	expectedMLMDEnvoyConfigName := "ds-pipeline-metadata-envoy-config-testdspa"
+	expectedMLMDEnvoyServiceName := "ds-pipeline-md-testdspa"
+
+	// Construct DSPA Spec with a customized MLMD Envoy
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			DSPVersion:  "v2",
+			PodToPodTLS: boolPtr(false),
+			APIServer:   &dspav1.APIServer{},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+				Envoy: &dspav1.Envoy{
+					DeployRoute:     true,
+					Port:            9443,
+					UpstreamTimeout: &metav1.Duration{Duration: 90 * time.Second},
+					TLS:             &dspav1.EnvoyTLS{SecretName: "envoy-certs"},
+					ExtraHTTPFilters: []string{
+						"name: envoy.filters.http.health_check\ntyped_config:\n  pass_through_mode: false",
+					},
+				},
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "90s", params.MlmdEnvoyUpstreamTimeout)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileMLMD(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Ensure the Envoy ConfigMap is rendered from the DSPA settings
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedMLMDEnvoyConfigName, testNamespace)
+	require.True(t, created)
+	assert.Nil(t, err)
+	envoyConfig := configMap.Data["envoy.yaml"]
+	assert.Contains(t, envoyConfig, "port_value: 9443")
+	assert.Contains(t, envoyConfig, "connect_timeout: 90s")
+	assert.Contains(t, envoyConfig, "DownstreamTlsContext")
+	assert.Contains(t, envoyConfig, `{"name":"envoy.filters.http.health_check","typed_config":{"pass_through_mode":false}}`)
+
+	// Ensure the Service and Deployment expose the listener port and mount the TLS secret
+	service := &corev1.Service{}
+	created, err = reconciler.IsResourceCreated(ctx, service, expectedMLMDEnvoyServiceName, testNamespace)
+	require.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(9443), service.Spec.Ports[0].Port)
+
+	deployment := &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedMLMDEnvoyName, testNamespace)
+	require.True(t, created)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(9443), deployment.Spec.Template.Spec.Containers[0].Ports[0].ContainerPort)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[1].Args, "--upstream=https://localhost:9443")
+	assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         "envoy-tls",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "envoy-certs"}},
+	})
+
+	// Ensure malformed filter snippets are rejected
+	for _, filter := range []string{"- not an object", "typed_config: {}"} {
+		dspa.Spec.MLMD.Envoy.ExtraHTTPFilters = []string{filter}
+		_, params, _ = CreateNewTestObjects()
+		err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+		assert.NotNil(t, err)
+	}
+}
+
 func TestGetEndpointsMLMD(t *testing.T) {
 	testNamespace := "testnamespace"
 	testDSPAName := "testdspa"
@@ -479,3 +577,154 @@ func TestGetEndpointsMLMD(t *testing.T) {
 	require.NotNil(t, dspa_created.Status.Components.MLMDProxy.Url)
 	require.NotNil(t, dspa_created.Status.Components.MLMDProxy.ExternalUrl)
 }
+
+func TestDeployMLMDGRPCExposure(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.PodToPodTLS = boolPtr(true)
+	dspa.Spec.MLMD.GRPC = &dspav1.GRPC{
+		Service:          &dspav1.GRPCService{Headless: true},
+		ClientNamespaces: []string{"metadata-clients"},
+		EnableRoute:      true,
+	}
+	expectedMLMDGRPCName := "ds-pipeline-metadata-grpc-testdspa"
+	expectedHeadlessName := "ds-pipeline-metadata-grpc-headless-testdspa"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	serviceCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "openshift-service-ca.crt", Namespace: dspa.Namespace},
+		Data:       map[string]string{"service-ca.crt": "service-ca-contents"},
+	}
+	require.Nil(t, reconciler.Create(ctx, serviceCA))
+	certs := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ds-pipeline-metadata-grpc-tls-certs-testdspa", Namespace: dspa.Namespace}}
+	require.Nil(t, reconciler.Create(ctx, certs))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+
+	// Assert the headless Service is created, and the pods are resolved in its subdomain
+	service := &corev1.Service{}
+	created, err := reconciler.IsResourceCreated(ctx, service, expectedHeadlessName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	deployment := &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, expectedHeadlessName, deployment.Spec.Template.Spec.Subdomain)
+
+	// Assert the client namespaces and the router are allowed to connect
+	policy := &networkingv1.NetworkPolicy{}
+	created, err = reconciler.IsResourceCreated(ctx, policy, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	peers := policy.Spec.Ingress[0].From
+	require.Len(t, peers, 4)
+	assert.Equal(t, []string{"metadata-clients"}, peers[2].NamespaceSelector.MatchExpressions[0].Values)
+	assert.Equal(t, "ingress", peers[3].NamespaceSelector.MatchLabels["network.openshift.io/policy-group"])
+
+	// Assert the Route re-encrypts to the gRPC server
+	route := &v1.Route{}
+	created, err = reconciler.IsResourceCreated(ctx, route, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "Reencrypt", string(route.Spec.TLS.Termination))
+	assert.Equal(t, "grpc-api", route.Spec.Port.TargetPort.String())
+
+	// Assert renamed Services and disabled Routes are deleted
+	dspa.Spec.MLMD.GRPC.Service = &dspav1.GRPCService{Name: "mlmd"}
+	dspa.Spec.MLMD.GRPC.EnableRoute = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, "mlmd", dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedHeadlessName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &v1.Route{}, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert invalid Services and Routes without TLS are rejected
+	for _, grpc := range []dspav1.GRPC{
+		{Service: &dspav1.GRPCService{}},
+		{Service: &dspav1.GRPCService{Name: "metadata-grpc-service"}},
+		{Service: &dspav1.GRPCService{Name: "Not_A_Name"}},
+	} {
+		dspa.Spec.MLMD.GRPC = grpc.DeepCopy()
+		_, params, _ = CreateNewTestObjects()
+		assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.mlmd.grpc.service.name]")
+	}
+	dspa.Spec.PodToPodTLS = boolPtr(false)
+	dspa.Spec.MLMD.GRPC = &dspav1.GRPC{EnableRoute: true}
+	_, params, _ = CreateNewTestObjects()
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.mlmd.grpc.enableRoute]")
+}
+
+func TestDeployMLMDComponents(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	expectedMLMDEnvoyName := "ds-pipeline-metadata-envoy-testdspa"
+	expectedMLMDEnvoyRouteName := "ds-pipeline-md-testdspa"
+	expectedMLMDGRPCName := "ds-pipeline-metadata-grpc-testdspa"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedMLMDEnvoyName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+
+	// Assert a disabled Envoy proxy is deleted, without the gRPC server
+	dspa.Spec.MLMD.Envoy = &dspav1.Envoy{Deploy: boolPtr(false), DeployRoute: true}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedMLMDEnvoyName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedMLMDEnvoyRouteName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &v1.Route{}, expectedMLMDEnvoyRouteName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+
+	// Assert the gRPC server is deleted once the API server no longer requires it
+	dspa.Spec.APIServer.Deploy = false
+	dspa.Spec.MLMD.GRPC = &dspav1.GRPC{Deploy: boolPtr(false)}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMLMD(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedMLMDGRPCName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the components required by the deployed ones cannot be disabled
+	tests := map[string]func(dspa *dspav1.DataSciencePipelinesApplication){
+		"[spec.mlmd.grpc.deploy]": func(dspa *dspav1.DataSciencePipelinesApplication) {
+			dspa.Spec.MLMD.GRPC = &dspav1.GRPC{Deploy: boolPtr(false)}
+			dspa.Spec.MLMD.Envoy = &dspav1.Envoy{Deploy: boolPtr(false)}
+		},
+		"[spec.mlmd.envoy.deploy] the MLMD Envoy proxy requires the MLMD gRPC server": func(dspa *dspav1.DataSciencePipelinesApplication) {
+			dspa.Spec.APIServer.Deploy = false
+			dspa.Spec.MLMD.GRPC = &dspav1.GRPC{Deploy: boolPtr(false)}
+		},
+		"[spec.mlmd.envoy.deploy] the UI": func(dspa *dspav1.DataSciencePipelinesApplication) {
+			dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{Deploy: true, Image: "quay.io/opendatahub/ds-pipelines-frontend:latest"}
+			dspa.Spec.MLMD.Envoy = &dspav1.Envoy{Deploy: boolPtr(false)}
+		},
+	}
+	for expectedErr, configure := range tests {
+		dspa := testutil.CreateDefaultDSPA()
+		configure(dspa)
+		_, params, _ := CreateNewTestObjects()
+		assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), expectedErr)
+	}
+}
@@ -17,15 +17,22 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var mlPipelineUITemplatesDir = "mlpipelines-ui"
 
-func (r *DSPAReconciler) ReconcileUI(dsp *dspav1.DataSciencePipelinesApplication,
+// mlPipelineUIRoute is a resource deployed conditionally
+// as such it is handled separately
+const mlPipelineUIRoute = "mlpipelines-ui/route/route.yaml.tmpl"
+
+func (r *DSPAReconciler) ReconcileUI(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) error {
 
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	if dsp.Spec.MlPipelineUI == nil || !dsp.Spec.MlPipelineUI.Deploy {
 		log.Info("Skipping Application of MlPipelineUI Resources")
@@ -38,6 +45,26 @@ func (r *DSPAReconciler) ReconcileUI(dsp *dspav1.DataSciencePipelinesApplication
 		return err
 	}
 
+	// In the service mesh the UI is exposed by a VirtualService instead of a Route
+	name := "ds-pipeline-ui-" + dsp.Name
+	if params.ServiceMesh != nil {
+		log.Info("Applying MlPipelineUI Service Mesh Resources")
+		err = r.ApplyDir(dsp, params, mlPipelineUIServiceMeshTemplatesDir)
+		if err != nil {
+			return err
+		}
+		err = r.deleteExternalRoute(ctx, params, types.NamespacedName{Name: name, Namespace: dsp.Namespace})
+	} else {
+		err = r.Apply(dsp, params, mlPipelineUIRoute)
+		if err != nil {
+			return err
+		}
+		err = r.deleteServiceMeshResources(ctx, name, dsp.Namespace, virtualServiceGVK, destinationRuleGVK, authorizationPolicyGVK)
+	}
+	if err != nil {
+		return err
+	}
+
 	log.Info("Finished applying MlPipelineUI Resources")
 	return nil
 }
@@ -21,8 +21,11 @@ import (
 	"testing"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestDeployUI(t *testing.T) {
@@ -69,7 +72,7 @@ func TestDeployUI(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Run test reconciliation
-	err = reconciler.ReconcileUI(dspa, params)
+	err = reconciler.ReconcileUI(ctx, dspa, params)
 	assert.Nil(t, err)
 
 	// Ensure UI Deployment now exists
@@ -123,7 +126,7 @@ func TestDontDeployUI(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Run test reconciliation
-	err = reconciler.ReconcileUI(dspa, params)
+	err = reconciler.ReconcileUI(ctx, dspa, params)
 	assert.Nil(t, err)
 
 	// Ensure UI Deployment still doesn't exist
@@ -172,7 +175,7 @@ func TestDefaultDeployBehaviorUI(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Run test reconciliation
-	err = reconciler.ReconcileUI(dspa, params)
+	err = reconciler.ReconcileUI(ctx, dspa, params)
 	assert.Nil(t, err)
 
 	// Ensure UI Deployment still doesn't exist
@@ -181,3 +184,109 @@ func TestDefaultDeployBehaviorUI(t *testing.T) {
 	assert.False(t, created)
 	assert.Nil(t, err)
 }
+
+func TestDeployUICustomization(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedUIName := "ds-pipeline-ui-testdspa"
+	expectedConfigMapName := "ds-pipeline-ui-configmap-testdspa"
+
+	// Construct DSPASpec with a customized UI
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{
+		Deploy: true,
+		Image:  "test-image:latest",
+		Customization: &dspav1.UICustomization{
+			Title: "Example Pipelines",
+			Logo:  &dspav1.UILogo{ConfigMapName: "ui-logo", ConfigMapKey: "logo.svg"},
+			DocumentationLinks: []dspav1.UILink{
+				{Name: "Docs", URL: "https://example.com/docs"},
+			},
+		},
+	}
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileUI(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Ensure the customization is merged into the UI ConfigMap
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedConfigMapName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, configMap.Data, "viewer-pod-template.json")
+	assert.JSONEq(t,
+		`{"title":"Example Pipelines","logoPath":"/etc/ui-customization/logo.svg","documentationLinks":[{"name":"Docs","url":"https://example.com/docs"}]}`,
+		configMap.Data["customization.json"])
+
+	// Ensure the logo is mounted into the UI
+	deployment := &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedUIName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: "ui-logo", MountPath: "/etc/ui-customization", ReadOnly: true})
+
+	// Ensure links other than http or https are rejected
+	dspa.Spec.MlPipelineUI.Customization.DocumentationLinks[0].URL = "javascript:alert(1)"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.mlpipelineUI.customization.documentationLinks")
+}
+
+func TestDeployUIArtifactProxy(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedUIName := "ds-pipeline-ui-testdspa"
+
+	// Construct DSPASpec with a UI fetching artifacts anonymously from a private endpoint
+	reuseCredentials := false
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{
+		Deploy: true,
+		Image:  "test-image:latest",
+		ArtifactProxy: &dspav1.UIArtifactProxy{
+			Endpoint:         "https://s3.example.com:9000",
+			ReuseCredentials: &reuseCredentials,
+			PathStyle:        true,
+		},
+	}
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileUI(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Ensure the UI fetches artifacts from the endpoint, without the object storage credentials
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedUIName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	env := map[string]string{}
+	for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "s3.example.com", env["AWS_S3_ENDPOINT"])
+	assert.Equal(t, "9000", env["AWS_PORT"])
+	assert.Equal(t, "true", env["AWS_SSL"])
+	assert.Equal(t, "true", env["AWS_S3_FORCE_PATH_STYLE"])
+	assert.NotContains(t, env, "AWS_ACCESS_KEY_ID")
+	assert.NotContains(t, env, "MINIO_ACCESS_KEY")
+
+	// Ensure endpoints other than http or https URLs are rejected
+	dspa.Spec.MlPipelineUI.ArtifactProxy.Endpoint = "s3.example.com"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.mlpipelineUI.artifactProxy.endpoint")
+}
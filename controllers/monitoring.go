@@ -0,0 +1,144 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// mariadbMonitoringTemplates are applied with the MariaDB templates when its
+// mysqld-exporter sidecar is scraped through a ServiceMonitor.
+var mariadbMonitoringTemplates = []string{
+	"mariadb/monitoring/service.yaml.tmpl",
+	"mariadb/monitoring/servicemonitor.yaml.tmpl",
+}
+
+// minioMonitoringTemplates are applied with the Minio templates when its
+// metrics are scraped through a ServiceMonitor.
+var minioMonitoringTemplates = []string{
+	"minio/monitoring/service.yaml.tmpl",
+	"minio/monitoring/servicemonitor.yaml.tmpl",
+}
+
+const (
+	grafanaDashboardTemplate = "monitoring/grafana-dashboard.yaml.tmpl"
+	prometheusRuleTemplate   = "monitoring/prometheus-rule.yaml.tmpl"
+)
+
+var (
+	serviceMonitorGVK   = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	prometheusRuleGVK   = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+	grafanaDashboardGVK = schema.GroupVersionKind{Group: "grafana.integreatly.org", Version: "v1beta1", Kind: "GrafanaDashboard"}
+)
+
+// monitoringResources are the DSPA wide monitoring resources of
+// spec.monitoring, named ds-pipelines-<DSPA name>.
+var monitoringResources = []struct {
+	template string
+	gvk      schema.GroupVersionKind
+	enabled  func(params *DSPAParams) bool
+}{
+	{grafanaDashboardTemplate, grafanaDashboardGVK, func(params *DSPAParams) bool { return params.GrafanaInstanceSelector != nil }},
+	{prometheusRuleTemplate, prometheusRuleGVK, func(params *DSPAParams) bool { return params.AlertSeverities != nil }},
+}
+
+//+kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadashboards,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
+
+// grafanaOperatorInstalled returns false when mapper does not know
+// GrafanaDashboards. Other failures report it installed, so that a discovery
+// outage does not delete the dashboards.
+func grafanaOperatorInstalled(mapper meta.RESTMapper) bool {
+	_, err := mapper.RESTMapping(grafanaDashboardGVK.GroupKind(), grafanaDashboardGVK.Version)
+	return !meta.IsNoMatchError(err)
+}
+
+// monitoringTemplates returns the templates of the DSPA wide monitoring
+// resources of spec.monitoring.
+func monitoringTemplates(params *DSPAParams) []string {
+	var templates []string
+	for _, resource := range monitoringResources {
+		if resource.enabled(params) {
+			templates = append(templates, resource.template)
+		}
+	}
+	return templates
+}
+
+// ReconcileMonitoring applies the dashboard and alerts of spec.monitoring,
+// and deletes them once they are disabled.
+func (r *DSPAReconciler) ReconcileMonitoring(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+	dspaNN := types.NamespacedName{Name: dsp.Name, Namespace: dsp.Namespace}
+
+	for _, resource := range monitoringResources {
+		if resource.enabled(params) {
+			if err := r.Apply(dsp, params, resource.template); err != nil {
+				return err
+			}
+			continue
+		}
+		r.appliedManifests.forgetTemplate(dspaNN, resource.template)
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(resource.gvk)
+		err := r.DeleteResourceIfItExists(ctx, obj, types.NamespacedName{Name: "ds-pipelines-" + dsp.Name, Namespace: dsp.Namespace})
+		if err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+
+	log.Info("Finished applying Monitoring Resources")
+	return nil
+}
+
+// scrapedByServiceMonitor returns true when an exporter is enabled and
+// Prometheus discovers it through a ServiceMonitor, rather than through the
+// annotations of its pods.
+func (p *DSPAParams) scrapedByServiceMonitor(exporterEnabled bool) bool {
+	return exporterEnabled && p.MonitoringScrape == config.MonitoringScrapeMonitor
+}
+
+// deleteExporterMonitoring deletes the metrics Service and ServiceMonitor of
+// the exporter of component from its namespace, once it is disabled or scraped
+// through its pod annotations. Clusters without the ServiceMonitor CRD have none.
+func (r *DSPAReconciler) deleteExporterMonitoring(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	component, namespace string, templates []string) error {
+	dspaNN := types.NamespacedName{Name: dsp.Name, Namespace: dsp.Namespace}
+	for _, template := range templates {
+		r.appliedManifests.forgetTemplate(dspaNN, template)
+	}
+	err := r.DeleteResourceIfItExists(ctx, &corev1.Service{},
+		types.NamespacedName{Name: component + "-metrics-" + dsp.Name, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(serviceMonitorGVK)
+	err = r.DeleteResourceIfItExists(ctx, monitor, types.NamespacedName{Name: component + "-" + dsp.Name, Namespace: namespace})
+	if err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func monitoringTestDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	dspa.Spec.Monitoring = &dspav1.Monitoring{
+		Exporters: &dspav1.MonitoringExporters{
+			MariaDB: &dspav1.MariaDBExporter{Enabled: true},
+			Minio:   &dspav1.MinioExporter{Enabled: true},
+		},
+	}
+	return dspa
+}
+
+func TestDeployMonitoringExporters(t *testing.T) {
+	dspa := monitoringTestDSPA()
+	expectedDatabaseName := "mariadb-" + dspa.Name
+	expectedStorageName := "minio-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+
+	// Assert MariaDB runs the mysqld-exporter sidecar with the DSPA database credentials
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	require.Len(t, deployment.Spec.Template.Spec.Containers, 2)
+	exporter := deployment.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, "mysqld-exporter", exporter.Name)
+	assert.Equal(t, config.DefaultMysqldExporterImage, exporter.Image)
+	assert.Contains(t, exporter.Args, "--mysqld.username=mlpipeline")
+	assert.Equal(t, params.DBConnection.CredentialsSecret.Name, exporter.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, int32(9104), exporter.Ports[0].ContainerPort)
+
+	// Assert Minio serves its metrics without authentication
+	deployment = &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "MINIO_PROMETHEUS_AUTH_TYPE", Value: "public"})
+
+	// Assert the exporters are scraped through ServiceMonitors of their metrics Services
+	for _, exported := range []struct{ name, service, path string }{
+		{expectedDatabaseName, "mariadb-metrics-" + dspa.Name, "/metrics"},
+		{expectedStorageName, "minio-metrics-" + dspa.Name, config.DefaultMinioMetricsPath},
+	} {
+		created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, exported.service, dspa.Namespace)
+		require.Nil(t, err)
+		assert.True(t, created)
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(serviceMonitorGVK)
+		created, err = reconciler.IsResourceCreated(ctx, monitor, exported.name, dspa.Namespace)
+		require.Nil(t, err)
+		require.True(t, created)
+		endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+		assert.Equal(t, exported.path, endpoints[0].(map[string]interface{})["path"])
+	}
+
+	// Assert scraping through annotations annotates the pods, and deletes the ServiceMonitors
+	dspa.Spec.Monitoring.Scrape = config.MonitoringScrapeAnnotation
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	deployment = &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "true", deployment.Spec.Template.Annotations["prometheus.io/scrape"])
+	assert.Equal(t, "9104", deployment.Spec.Template.Annotations["prometheus.io/port"])
+	deployment = &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, config.DefaultMinioMetricsPath, deployment.Spec.Template.Annotations["prometheus.io/path"])
+	for _, name := range []string{expectedDatabaseName, expectedStorageName} {
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(serviceMonitorGVK)
+		created, err = reconciler.IsResourceCreated(ctx, monitor, name, dspa.Namespace)
+		require.Nil(t, err)
+		assert.False(t, created)
+	}
+
+	// Assert disabling the exporters removes the sidecar
+	dspa.Spec.Monitoring.Exporters.MariaDB.Enabled = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+	deployment = &appsv1.Deployment{}
+	created, err = reconciler.IsResourceCreated(ctx, deployment, expectedDatabaseName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 1)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, "prometheus.io/scrape")
+
+	// Assert the exporters of an external database are ignored
+	dspa = monitoringTestDSPA()
+	dspa.Spec.Database = &dspav1.Database{ExternalDB: &dspav1.ExternalDB{
+		Host: "mysql.example.com", Port: "3306", Username: "mlpipeline", DBName: "mlpipeline",
+		PasswordSecret: &dspav1.SecretKeyValue{Name: "external-db", Key: "password"},
+	}}
+	require.Nil(t, params.SetupMonitoring(dspa, reconciler.RESTMapper(), reconciler.Log))
+	assert.Nil(t, params.MariaDBExporter)
+	assert.NotNil(t, params.MinioMetrics)
+}
+
+func TestDeployMonitoringDashboard(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Monitoring = &dspav1.Monitoring{Dashboards: true}
+	expectedDashboardName := "ds-pipelines-" + dspa.Name
+	defer viper.Set(config.GrafanaInstanceSelectorConfigName, nil)
+	dashboardCreated := func(reconciler *DSPAReconciler) (*unstructured.Unstructured, bool) {
+		dashboard := &unstructured.Unstructured{}
+		dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+		created, err := reconciler.IsResourceCreated(context.Background(), dashboard, expectedDashboardName, dspa.Namespace)
+		require.Nil(t, err)
+		return dashboard, created
+	}
+
+	// Assert the dashboard is skipped without the Grafana operator
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Nil(t, params.GrafanaInstanceSelector)
+	require.Nil(t, reconciler.ReconcileMonitoring(ctx, dspa, params))
+	_, created := dashboardCreated(reconciler)
+	assert.False(t, created)
+
+	// Assert the dashboard is imported by the configured Grafana instances
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(grafanaDashboardGVK, meta.RESTScopeNamespace)
+	viper.Set(config.GrafanaInstanceSelectorConfigName, map[string]string{"dashboards": "ml-platform"})
+	require.Nil(t, params.SetupMonitoring(dspa, mapper, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMonitoring(ctx, dspa, params))
+	dashboard, created := dashboardCreated(reconciler)
+	require.True(t, created)
+	selector, _, _ := unstructured.NestedStringMap(dashboard.Object, "spec", "instanceSelector", "matchLabels")
+	assert.Equal(t, map[string]string{"dashboards": "ml-platform"}, selector)
+	dashboardJSON, _, _ := unstructured.NestedString(dashboard.Object, "spec", "json")
+	var parsed map[string]interface{}
+	require.Nil(t, json.Unmarshal([]byte(dashboardJSON), &parsed))
+	assert.Equal(t, "dspa-testnamespace-testdspa", parsed["uid"])
+	assert.Contains(t, dashboardJSON, `job=\"ds-pipeline-testdspa\"`)
+
+	// Assert disabling the dashboards deletes it
+	dspa.Spec.Monitoring.Dashboards = false
+	require.Nil(t, params.SetupMonitoring(dspa, mapper, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMonitoring(ctx, dspa, params))
+	_, created = dashboardCreated(reconciler)
+	assert.False(t, created)
+}
+
+func TestDeployMonitoringAlerts(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Monitoring = &dspav1.Monitoring{Alerts: &dspav1.MonitoringAlerts{
+		Enabled:    true,
+		Severities: map[string]string{"DSPAPersistenceAgentDown": "critical"},
+		Labels:     map[string]string{"team": "ml-platform"},
+	}}
+	expectedRuleName := "ds-pipelines-" + dspa.Name
+	ruleCreated := func(reconciler *DSPAReconciler) (*unstructured.Unstructured, bool) {
+		rule := &unstructured.Unstructured{}
+		rule.SetGroupVersionKind(prometheusRuleGVK)
+		created, err := reconciler.IsResourceCreated(context.Background(), rule, expectedRuleName, dspa.Namespace)
+		require.Nil(t, err)
+		return rule, created
+	}
+
+	// Assert the alerts are labelled with their severities and the DSPA labels
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMonitoring(ctx, dspa, params))
+	rule, created := ruleCreated(reconciler)
+	require.True(t, created)
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	require.Len(t, groups, 1)
+	rules, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+	alerts := map[string]map[string]string{}
+	for _, r := range rules {
+		alert := r.(map[string]interface{})
+		labels, _, _ := unstructured.NestedStringMap(alert, "labels")
+		alerts[alert["alert"].(string)] = labels
+		assert.Contains(t, alert["expr"], `dspa_namespace="testnamespace",dspa_name="testdspa"`)
+	}
+	assert.Len(t, alerts, len(config.DefaultAlertSeverities))
+	assert.Equal(t, map[string]string{"severity": "critical", "dspa": "testdspa", "dspa_namespace": "testnamespace", "team": "ml-platform"},
+		alerts["DSPAPersistenceAgentDown"])
+	assert.Equal(t, "warning", alerts["DSPAArtifactStoreErrors"]["severity"])
+
+	// Assert unknown alerts and the labels set by the operator are rejected
+	dspa.Spec.Monitoring.Alerts.Severities = map[string]string{"DSPAUnknown": "critical"}
+	assert.ErrorContains(t, params.SetupMonitoring(dspa, reconciler.RESTMapper(), reconciler.Log), "unknown alert DSPAUnknown")
+	dspa.Spec.Monitoring.Alerts.Severities = nil
+	dspa.Spec.Monitoring.Alerts.Labels = map[string]string{"dspa": "other"}
+	assert.ErrorContains(t, params.SetupMonitoring(dspa, reconciler.RESTMapper(), reconciler.Log), "the dspa label is set by the operator")
+
+	// Assert disabling the alerts deletes their rule
+	dspa.Spec.Monitoring.Alerts = &dspav1.MonitoringAlerts{Enabled: false}
+	require.Nil(t, params.SetupMonitoring(dspa, reconciler.RESTMapper(), reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMonitoring(ctx, dspa, params))
+	_, created = ruleCreated(reconciler)
+	assert.False(t, created)
+}
@@ -0,0 +1,141 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	multiTenancyRoleBindingTemplate = "multi-tenancy/rolebinding.yaml.tmpl"
+	// The ClusterRole of a cluster scoped workflow controller is not owned by
+	// the DSPA, as owner references cannot cross namespaces
+	multiTenancyClusterRoleTemplate         = "multi-tenancy/no-owner/workflow-controller-clusterrole.yaml.tmpl"
+	workflowControllerClusterRoleNamePrefix = "ds-pipeline-workflow-controller-"
+)
+
+// tenantAPIServerTemplates are the API Server resources the pipeline runs of a
+// namespace rely on, applied in each namespace of spec.multiTenancy
+var tenantAPIServerTemplates = []string{
+	"apiserver/default/kfp_launcher_config.yaml.tmpl",
+	"apiserver/default/role_ds-pipeline.yaml.tmpl",
+	"apiserver/default/role_pipeline-runner.yaml.tmpl",
+	"apiserver/default/rolebinding_pipeline-runner.yaml.tmpl",
+	"apiserver/default/sa_pipeline-runner.yaml.tmpl",
+}
+
+// tenantParams returns the params of the templates applied in namespace, a
+// namespace of spec.multiTenancy.
+func (p *DSPAParams) tenantParams(namespace string) *DSPAParams {
+	tenant := *p
+	tenant.Namespace = namespace
+	return &tenant
+}
+
+// tenantTemplates selects the templates applied in each namespace of
+// spec.multiTenancy, the execution components enabled for dsp along with the
+// resources of the pipeline runs.
+func tenantTemplates(templatesFS fs.FS, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) ([]string, error) {
+	templates := append([]string{}, tenantAPIServerTemplates...)
+	var dirs []string
+	if dsp.Spec.PersistenceAgent != nil && dsp.Spec.PersistenceAgent.Deploy {
+		dirs = append(dirs, persistenceAgentTemplatesDir)
+	}
+	if dsp.Spec.ScheduledWorkflow != nil && dsp.Spec.ScheduledWorkflow.Deploy {
+		dirs = append(dirs, scheduledWorkflowTemplatesDir)
+	}
+	if params.WorkflowController != nil && params.WorkflowController.Deploy && !params.WorkflowControllerClusterScoped() {
+		dirs = append(dirs, workflowControllerTemplatesDir)
+	}
+	for _, dir := range dirs {
+		dirTemplates, err := util.GetTemplatesInDir(templatesFS, dir)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, dirTemplates...)
+	}
+	return templates, nil
+}
+
+// ReconcileMultiTenancy deploys the execution components of dsp, its pipeline
+// runner ServiceAccount and RBAC, its kfp-launcher ConfigMap and a copy of its
+// object storage and OCI registry credentials in each namespace of
+// spec.multiTenancy, and binds its API Server to them. The resources of the
+// namespaces no longer listed are deleted by ReconcileComponentNamespaces.
+func (r *DSPAReconciler) ReconcileMultiTenancy(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	if params.WorkflowControllerClusterScoped() {
+		log.Info("Applying the ClusterRole of the cluster scoped WorkflowController")
+		if err := r.ApplyWithoutOwner(params, multiTenancyClusterRoleTemplate); err != nil {
+			return err
+		}
+	} else if err := r.CleanUpMultiTenancy(ctx, params); err != nil {
+		return err
+	}
+
+	if params.MultiTenancy == nil {
+		log.Info("Skipping Application of MultiTenancy Resources")
+		return nil
+	}
+
+	log.Info("Applying MultiTenancy Resources")
+	templates, err := tenantTemplates(r.Templates, dsp, params)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range params.MultiTenancy.Namespaces {
+		if params.ObjectStorageConnection.CredentialsSecret != nil {
+			err := r.copySecretToComponentNamespace(ctx, dsp, params.ObjectStorageConnection.CredentialsSecret.SecretName, namespace)
+			if err != nil {
+				return err
+			}
+		}
+		if params.OCIRegistry != nil && params.OCIRegistry.PushPullSecret != "" {
+			if err := r.copySecretToComponentNamespace(ctx, dsp, params.OCIRegistry.PushPullSecret, namespace); err != nil {
+				return err
+			}
+		}
+		if err := r.ApplyAll(dsp, params.tenantParams(namespace), templates); err != nil {
+			return fmt.Errorf("unable to apply the resources of tenant namespace %s: %w", namespace, err)
+		}
+	}
+	if err := r.Apply(dsp, params, multiTenancyRoleBindingTemplate); err != nil {
+		return err
+	}
+
+	log.Info("Finished applying MultiTenancy Resources")
+	return nil
+}
+
+// CleanUpMultiTenancy deletes the ClusterRole and ClusterRoleBinding of the
+// cluster scoped workflow controller of the DSPA, if any.
+func (r *DSPAReconciler) CleanUpMultiTenancy(ctx context.Context, params *DSPAParams) error {
+	nn := types.NamespacedName{Name: workflowControllerClusterRoleNamePrefix + params.Namespace + "-" + params.Name}
+	if err := r.DeleteResourceIfItExists(ctx, &rbacv1.ClusterRoleBinding{}, nn); err != nil {
+		return err
+	}
+	return r.DeleteResourceIfItExists(ctx, &rbacv1.ClusterRole{}, nn)
+}
@@ -0,0 +1,194 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func multiTenancyTestDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.UID = types.UID("testdspa-uid")
+	dspa.Spec.PersistenceAgent = &dspav1.PersistenceAgent{Deploy: true}
+	dspa.Spec.ScheduledWorkflow = &dspav1.ScheduledWorkflow{Deploy: true}
+	dspa.Spec.WorkflowController = &dspav1.WorkflowController{Deploy: true}
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	dspa.Spec.MultiTenancy = &dspav1.MultiTenancy{
+		Enabled:    true,
+		Namespaces: []string{"tenant-a"},
+	}
+	return dspa
+}
+
+func TestDeployMultiTenancy(t *testing.T) {
+	dspa := multiTenancyTestDSPA()
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, componentNamespace("tenant-a")))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, config.WorkflowControllerScopeNamespace, params.MultiTenancy.WorkflowControllerScope)
+	assert.Equal(t, []string{"tenant-a"}, params.componentNamespaces())
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileMultiTenancy(ctx, dspa, params))
+
+	// Assert the execution components are deployed in the tenant namespace, watching it
+	for _, name := range []string{"ds-pipeline-persistenceagent-testdspa", "ds-pipeline-scheduledworkflow-testdspa", "ds-pipeline-workflow-controller-testdspa"} {
+		deployment := &appsv1.Deployment{}
+		created, err := reconciler.IsResourceCreated(ctx, deployment, name, "tenant-a")
+		require.Nil(t, err)
+		require.True(t, created, name)
+		assert.Empty(t, deployment.OwnerReferences)
+		assert.Equal(t, "testdspa-uid", deployment.Labels[config.OwnerUIDLabel])
+	}
+	persistenceAgent := &appsv1.Deployment{}
+	_, err := reconciler.IsResourceCreated(ctx, persistenceAgent, "ds-pipeline-persistenceagent-testdspa", "tenant-a")
+	require.Nil(t, err)
+	assert.Contains(t, persistenceAgent.Spec.Template.Spec.Containers[0].Command, "--namespace=tenant-a")
+	assert.Contains(t, persistenceAgent.Spec.Template.Spec.Containers[0].Command, "--mlPipelineAPIServerName="+params.APIServerServiceDNSName)
+
+	// Assert the pipeline runs of the tenant namespace are set up
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.ServiceAccount{}, "pipeline-runner-testdspa", "tenant-a")
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, "kfp-launcher", "tenant-a")
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Secret{}, params.ObjectStorageConnection.CredentialsSecret.SecretName, "tenant-a")
+	require.Nil(t, err)
+	assert.True(t, created)
+
+	// Assert the API Server of the DSPA namespace is bound to the tenant namespace
+	roleBinding := &rbacv1.RoleBinding{}
+	created, err = reconciler.IsResourceCreated(ctx, roleBinding, "ds-pipeline-testdspa", "tenant-a")
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Namespace: dspa.Namespace, Name: params.APIServerServiceAccountName}}, roleBinding.Subjects)
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.Role{}, "ds-pipeline-testdspa", "tenant-a")
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.ClusterRole{}, "ds-pipeline-workflow-controller-testnamespace-testdspa", "")
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the tenant resources are rendered along with the DSPA resources
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	rendered := map[string]bool{}
+	for _, resource := range resources {
+		rendered[resource.GetNamespace()+"/"+resource.GetKind()+"/"+resource.GetName()] = true
+		if resource.GetKind() == "Deployment" && resource.GetName() == "ds-pipeline-testdspa" {
+			// Assert the API Server runs in multi-user mode
+			apiServer := &appsv1.Deployment{}
+			require.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, apiServer))
+			assert.Contains(t, apiServer.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "MULTIUSER", Value: "true"})
+		}
+	}
+	assert.True(t, rendered["testnamespace/Deployment/ds-pipeline-testdspa"])
+	assert.True(t, rendered["tenant-a/Deployment/ds-pipeline-persistenceagent-testdspa"])
+	assert.True(t, rendered["tenant-a/RoleBinding/ds-pipeline-testdspa"])
+}
+
+func TestDeployMultiTenancyClusterScope(t *testing.T) {
+	dspa := multiTenancyTestDSPA()
+	dspa.Spec.MultiTenancy.WorkflowControllerScope = config.WorkflowControllerScopeCluster
+	clusterRoleName := "ds-pipeline-workflow-controller-testnamespace-testdspa"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, componentNamespace("tenant-a")))
+	// Assert the cluster scope is refused unless the operator config allows it
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log),
+		"[spec.multiTenancy.workflowControllerScope] Cluster is not allowed, the operator config DSPO.MultiTenancy.AllowClusterScope must be enabled")
+
+	viper.Set(config.MultiTenancyAllowClusterScopeConfigName, true)
+	defer viper.Set(config.MultiTenancyAllowClusterScopeConfigName, nil)
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileWorkflowController(dspa, params))
+	require.Nil(t, reconciler.ReconcileMultiTenancy(ctx, dspa, params))
+
+	// Assert the workflow controller of the DSPA namespace watches all namespaces
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, "ds-pipeline-workflow-controller-testdspa", dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.NotContains(t, deployment.Spec.Template.Spec.Containers[0].Args, "--namespaced")
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, "ds-pipeline-workflow-controller-testdspa", "tenant-a")
+	require.Nil(t, err)
+	assert.False(t, created)
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	created, err = reconciler.IsResourceCreated(ctx, clusterRoleBinding, clusterRoleName, "")
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, clusterRoleName, clusterRoleBinding.RoleRef.Name)
+
+	// Assert the ClusterRole is deleted once the workflow controller is namespace scoped again
+	dspa.Spec.MultiTenancy.WorkflowControllerScope = config.WorkflowControllerScopeNamespace
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileMultiTenancy(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.ClusterRole{}, clusterRoleName, "")
+	require.Nil(t, err)
+	assert.False(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &rbacv1.ClusterRoleBinding{}, clusterRoleName, "")
+	require.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestMultiTenancyValidation(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, componentNamespace("tenant-a")))
+
+	// Assert the cluster scope requires the workflow controller
+	dspa := multiTenancyTestDSPA()
+	dspa.Spec.WorkflowController.Deploy = false
+	dspa.Spec.MultiTenancy.WorkflowControllerScope = config.WorkflowControllerScopeCluster
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log),
+		"[spec.multiTenancy.workflowControllerScope] Cluster requires [spec.workflowController] to be deployed")
+
+	// Assert the DSPA namespace and missing namespaces are rejected
+	dspa = multiTenancyTestDSPA()
+	dspa.Spec.MultiTenancy.Namespaces = []string{dspa.Namespace}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "must not list the DSPA namespace")
+	dspa.Spec.MultiTenancy.Namespaces = []string{"tenant-b"}
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "namespace tenant-b does not exist")
+
+	// Assert namespaces that did not opt in to the components of the DSPA namespace are rejected
+	tenant := &corev1.Namespace{}
+	tenant.Name = "tenant-b"
+	require.Nil(t, reconciler.Create(ctx, tenant))
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log),
+		"[spec.multiTenancy.namespaces] namespace tenant-b does not allow the components of DSPAs in namespace testnamespace")
+
+	// Assert namespaces with a DSPA of their own are rejected
+	other := testutil.CreateDefaultDSPA()
+	other.Name, other.Namespace = "other", "tenant-a"
+	require.Nil(t, reconciler.Create(ctx, other))
+	dspa = multiTenancyTestDSPA()
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "namespace tenant-a has a DSPA of its own, other")
+}
@@ -0,0 +1,264 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NotificationDegraded  = "Degraded"
+	NotificationRecovered = "Recovered"
+)
+
+// Notification describes a DSPA becoming unready, or recovering.
+type Notification struct {
+	Event     string `json:"event"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	// FailingComponents are the conditions of the DSPA that are not met
+	FailingComponents []string `json:"failingComponents,omitempty"`
+}
+
+func (n Notification) String() string {
+	summary := fmt.Sprintf("DSPA %s/%s is %s: %s", n.Namespace, n.Name, strings.ToLower(n.Event), n.Message)
+	if len(n.FailingComponents) > 0 {
+		summary = fmt.Sprintf("%s (failing: %s)", summary, strings.Join(n.FailingComponents, ", "))
+	}
+	return summary
+}
+
+// Notifier delivers notifications of DSPA state changes.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// WebhookNotifier posts notifications as JSON to a URL.
+type WebhookNotifier struct {
+	URL string
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, w.URL, notification)
+}
+
+// SlackNotifier posts notifications to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+func (s *SlackNotifier) Notify(ctx context.Context, notification Notification) error {
+	return postJSON(ctx, s.WebhookURL, map[string]string{"text": notification.String()})
+}
+
+// SMTPNotifier emails notifications, authenticating when a Username is set.
+type SMTPNotifier struct {
+	Address  string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+// Notify delivers the notification within the deadline of ctx, which the SMTP
+// session is bound to as net/smtp has no deadline of its own.
+func (s *SMTPNotifier) Notify(ctx context.Context, notification Notification) error {
+	host, _, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		return err
+	}
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	for _, to := range s.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: DSPA %s/%s %s\r\n\r\n%s\r\n",
+		s.From, strings.Join(s.To, ", "), notification.Namespace, notification.Name,
+		strings.ToLower(notification.Event), notification.String())
+	if _, err := w.Write([]byte(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// MultiNotifier delivers notifications with each of its notifiers.
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Notify(ctx context.Context, notification Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifierFromConfig returns the notifiers configured in the DSPO config, or
+// nil if none are.
+func NotifierFromConfig() Notifier {
+	var notifiers MultiNotifier
+	if url := config.GetStringConfigWithDefault(config.NotificationsWebhookURLConfigName, ""); url != "" {
+		notifiers = append(notifiers, &WebhookNotifier{URL: url})
+	}
+	if url := config.GetStringConfigWithDefault(config.NotificationsSlackWebhookURLConfigName, ""); url != "" {
+		notifiers = append(notifiers, &SlackNotifier{WebhookURL: url})
+	}
+	if address := config.GetStringConfigWithDefault(config.NotificationsSMTPAddressConfigName, ""); address != "" {
+		notifiers = append(notifiers, &SMTPNotifier{
+			Address:  address,
+			From:     config.GetStringConfigWithDefault(config.NotificationsSMTPFromConfigName, ""),
+			To:       config.GetStringSliceConfigWithDefault(config.NotificationsSMTPToConfigName, nil),
+			Username: config.GetStringConfigWithDefault(config.NotificationsSMTPUsernameConfigName, ""),
+			Password: config.GetStringConfigWithDefault(config.NotificationsSMTPPasswordConfigName, ""),
+		})
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return notifiers
+}
+
+func postJSON(ctx context.Context, url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("notification to %s was rejected with status %s", url, response.Status)
+	}
+	return nil
+}
+
+// readyTransition returns the notification for a transition of the Ready
+// condition of dspa from previous to conditions, or nil if it did not
+// transition. Recoveries are only notified for DSPAs that were notified as
+// degraded, so that new DSPAs becoming ready are not notified.
+func (r *DSPAReconciler) readyTransition(dspa *dspav1.DataSciencePipelinesApplication, previous, conditions []metav1.Condition) *Notification {
+	key := dspa.Namespace + "/" + dspa.Name
+	wasReady := util.GetConditionByType(config.CrReady, previous)
+	ready := util.GetConditionByType(config.CrReady, conditions)
+	notification := &Notification{
+		Namespace: dspa.Namespace,
+		Name:      dspa.Name,
+		Reason:    ready.Reason,
+		Message:   ready.Message,
+	}
+	switch {
+	case wasReady.Status == metav1.ConditionTrue && ready.Status == metav1.ConditionFalse:
+		notification.Event = NotificationDegraded
+		for _, condition := range conditions {
+			if condition.Type != config.CrReady && condition.Type != config.Degraded &&
+				condition.Type != config.UpgradeProgressing && condition.Status != metav1.ConditionTrue {
+				notification.FailingComponents = append(notification.FailingComponents, condition.Type)
+			}
+		}
+		r.degradedDSPAs.Store(key, true)
+		return notification
+	case ready.Status == metav1.ConditionTrue:
+		if _, degraded := r.degradedDSPAs.LoadAndDelete(key); degraded {
+			notification.Event = NotificationRecovered
+			return notification
+		}
+	}
+	return nil
+}
+
+// notifyReadyTransition notifies a transition of the Ready condition of dspa
+// in the background, so that slow notifiers do not hold up reconciliation.
+func (r *DSPAReconciler) notifyReadyTransition(dspa *dspav1.DataSciencePipelinesApplication, previous, conditions []metav1.Condition, log logr.Logger) {
+	if r.Notifier == nil {
+		return
+	}
+	notification := r.readyTransition(dspa, previous, conditions)
+	if notification == nil {
+		return
+	}
+	timeout := config.GetDurationConfigWithDefault(config.NotificationsTimeoutConfigName, config.DefaultNotificationsTimeout)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := r.Notifier.Notify(ctx, *notification); err != nil {
+			log.Error(err, fmt.Sprintf("Unable to deliver the %s notification", notification.Event))
+		}
+	}()
+}
@@ -0,0 +1,161 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReadyTransition(t *testing.T) {
+	_, _, reconciler := CreateNewTestObjects()
+	dspa := testutil.CreateEmptyDSPA()
+	ready := []metav1.Condition{
+		{Type: config.APIServerReady, Status: metav1.ConditionTrue},
+		{Type: config.CrReady, Status: metav1.ConditionTrue},
+	}
+	unready := []metav1.Condition{
+		{Type: config.APIServerReady, Status: metav1.ConditionFalse},
+		{Type: config.CrReady, Status: metav1.ConditionFalse, Reason: config.MinimumReplicasAvailable, Message: "APIServer is not ready"},
+		{Type: config.Degraded, Status: metav1.ConditionFalse},
+	}
+
+	// Assert new DSPAs becoming ready are not notified
+	assert.Nil(t, reconciler.readyTransition(dspa, nil, unready))
+	assert.Nil(t, reconciler.readyTransition(dspa, unready, ready))
+	assert.Nil(t, reconciler.readyTransition(dspa, ready, ready))
+
+	// Assert DSPAs becoming unready are notified with their failing components
+	notification := reconciler.readyTransition(dspa, ready, unready)
+	require.NotNil(t, notification)
+	assert.Equal(t, NotificationDegraded, notification.Event)
+	assert.Equal(t, "testnamespace", notification.Namespace)
+	assert.Equal(t, "testdspa", notification.Name)
+	assert.Equal(t, []string{config.APIServerReady}, notification.FailingComponents)
+	assert.Nil(t, reconciler.readyTransition(dspa, unready, unready))
+
+	// Assert their recovery is notified once
+	notification = reconciler.readyTransition(dspa, unready, ready)
+	require.NotNil(t, notification)
+	assert.Equal(t, NotificationRecovered, notification.Event)
+	assert.Nil(t, reconciler.readyTransition(dspa, ready, ready))
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	notification := Notification{Event: NotificationDegraded, Namespace: "testnamespace", Name: "testdspa"}
+	notifier := MultiNotifier{&WebhookNotifier{URL: server.URL}}
+	require.Nil(t, notifier.Notify(context.Background(), notification))
+	assert.Equal(t, notification, received)
+
+	// Assert rejected notifications are reported
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer rejecting.Close()
+	notifier = append(notifier, &SlackNotifier{WebhookURL: rejecting.URL})
+	assert.NotNil(t, notifier.Notify(context.Background(), notification))
+}
+
+// serveSMTP accepts one SMTP session on listener and returns the message it
+// was sent, answering no command when hang is set.
+func serveSMTP(listener net.Listener, hang bool) <-chan string {
+	messages := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if hang {
+			_, _ = conn.Read(make([]byte, 1))
+			return
+		}
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		var message strings.Builder
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case command == "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					message.WriteString(line)
+				}
+				messages <- message.String()
+				reply("250 OK")
+			case command == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return messages
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer listener.Close()
+	notification := Notification{Event: NotificationDegraded, Namespace: "testnamespace", Name: "testdspa", Message: "down"}
+	notifier := &SMTPNotifier{Address: listener.Addr().String(), From: "dspo@example.com", To: []string{"admin@example.com"}}
+
+	// Assert the notification is emailed
+	messages := serveSMTP(listener, false)
+	require.Nil(t, notifier.Notify(context.Background(), notification))
+	assert.Contains(t, <-messages, "Subject: DSPA testnamespace/testdspa degraded")
+
+	// Assert an unresponsive server does not hold the notification past the deadline of the context
+	serveSMTP(listener, true)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.NotNil(t, notifier.Notify(ctx, notification))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -20,10 +20,12 @@ package controllers
 import (
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	"testing"
+	"time"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestDeployPersistenceAgent(t *testing.T) {
@@ -121,3 +123,62 @@ func TestDontDeployPersistenceAgent(t *testing.T) {
 	assert.False(t, created)
 	assert.Nil(t, err)
 }
+
+func TestDeployPersistenceAgentTuning(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedPersistenceAgentName := persistenceAgentDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with a tuned PersistenceAgent
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PersistenceAgent: &dspav1.PersistenceAgent{
+				Deploy:         true,
+				ClientQPS:      50,
+				ClientBurst:    100,
+				ResyncInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	err = reconciler.ReconcilePersistenceAgent(dspa, params)
+	assert.Nil(t, err)
+
+	// Ensure the tuning is passed to the PersistenceAgent
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedPersistenceAgentName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	command := deployment.Spec.Template.Spec.Containers[0].Command
+	assert.Contains(t, command, "--clientQPS=50")
+	assert.Contains(t, command, "--clientBurst=100")
+	assert.Contains(t, command, "--resyncInterval=5m0s")
+
+	// Ensure non-positive resync intervals are rejected
+	dspa.Spec.PersistenceAgent.ResyncInterval = &metav1.Duration{}
+	_, params, _ = CreateNewTestObjects()
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.persistenceAgent.resyncInterval")
+}
@@ -0,0 +1,321 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pipelineAPIClient calls the v2beta1 REST API of the API server of a DSPA,
+// on the HTTP port its NetworkPolicy opens to the operator.
+type pipelineAPIClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// pipelineAPIError is a response of the API server with an error status.
+type pipelineAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *pipelineAPIError) Error() string {
+	return fmt.Sprintf("API server responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+func isPipelineAPIStatus(err error, statusCode int) bool {
+	var apiErr *pipelineAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// newPipelineAPIClient returns the client of the API server of dsp, reached
+// through its Service. Over pod to pod TLS, the certificates of the
+// DSPA CA bundle are trusted along with the system ones.
+func newPipelineAPIClient(ctx context.Context, cl client.Client, dsp *dspav1.DataSciencePipelinesApplication) (*pipelineAPIClient, error) {
+	scheme := "http"
+	tlsEnabled := (dsp.Spec.PodToPodTLS == nil || *dsp.Spec.PodToPodTLS) || (dsp.Spec.TLS != nil && dsp.Spec.TLS.IssuerRef != nil)
+	if tlsEnabled {
+		scheme = "https"
+	}
+	port := config.DefaultAPIServerHTTPPort
+	if dsp.Spec.APIServer != nil && dsp.Spec.APIServer.Ports != nil && dsp.Spec.APIServer.Ports.HTTP != 0 {
+		port = dsp.Spec.APIServer.Ports.HTTP
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsEnabled {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		key := config.CustomDSPTrustedCAConfigMapKey
+		if dsp.Spec.APIServer != nil && dsp.Spec.APIServer.CABundleFileName != "" {
+			key = dsp.Spec.APIServer.CABundleFileName
+		}
+		caBundle, err := util.GetConfigMap(ctx, fmt.Sprintf("%s-%s", config.CustomDSPTrustedCAConfigMapNamePrefix, dsp.Name), dsp.Namespace, cl)
+		if err != nil && !apierrs.IsNotFound(err) {
+			return nil, err
+		} else if err == nil {
+			roots.AppendCertsFromPEM([]byte(util.GetConfigMapValue(key, caBundle)))
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	}
+
+	return &pipelineAPIClient{
+		baseURL:    fmt.Sprintf("%s://%s%s.%s.svc.cluster.local:%d", scheme, apiServerDefaultResourceNamePrefix, dsp.Name, dsp.Namespace, port),
+		httpClient: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (c *pipelineAPIClient) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader, out any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		apiErr := &pipelineAPIError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &status) == nil && status.Message != "" {
+			apiErr.Message = status.Message
+		}
+		return apiErr
+	}
+	if out != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
+
+func (c *pipelineAPIClient) doJSON(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	return c.do(ctx, method, path, nil, "application/json", body, out)
+}
+
+type apiPipeline struct {
+	PipelineID  string `json:"pipeline_id,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type apiPipelineVersion struct {
+	PipelineID        string `json:"pipeline_id,omitempty"`
+	PipelineVersionID string `json:"pipeline_version_id,omitempty"`
+	DisplayName       string `json:"display_name,omitempty"`
+}
+
+type apiPipelineVersionReference struct {
+	PipelineID        string `json:"pipeline_id"`
+	PipelineVersionID string `json:"pipeline_version_id"`
+}
+
+type apiRecurringRun struct {
+	RecurringRunID           string                       `json:"recurring_run_id,omitempty"`
+	DisplayName              string                       `json:"display_name"`
+	Description              string                       `json:"description,omitempty"`
+	PipelineVersionReference *apiPipelineVersionReference `json:"pipeline_version_reference,omitempty"`
+	RuntimeConfig            struct {
+		Parameters map[string]apiextensionsv1.JSON `json:"parameters,omitempty"`
+	} `json:"runtime_config"`
+	Trigger struct {
+		CronSchedule struct {
+			Cron string `json:"cron"`
+		} `json:"cron_schedule"`
+	} `json:"trigger"`
+	MaxConcurrency int64  `json:"max_concurrency,string"`
+	NoCatchup      bool   `json:"no_catchup"`
+	Mode           string `json:"mode,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
+
+// Modes and statuses of the recurring runs
+const (
+	recurringRunModeEnable    = "ENABLE"
+	recurringRunModeDisable   = "DISABLE"
+	recurringRunStatusEnabled = "ENABLED"
+)
+
+func (c *pipelineAPIClient) getPipeline(ctx context.Context, pipelineID string) (*apiPipeline, error) {
+	pipeline := &apiPipeline{}
+	return pipeline, c.doJSON(ctx, http.MethodGet, "/apis/v2beta1/pipelines/"+url.PathEscape(pipelineID), nil, pipeline)
+}
+
+func (c *pipelineAPIClient) getPipelineByName(ctx context.Context, name string) (*apiPipeline, error) {
+	pipeline := &apiPipeline{}
+	return pipeline, c.doJSON(ctx, http.MethodGet, "/apis/v2beta1/pipelines/names/"+url.PathEscape(name), nil, pipeline)
+}
+
+// createPipeline creates a pipeline without any version, or returns the
+// pipeline of the same name.
+func (c *pipelineAPIClient) createPipeline(ctx context.Context, name, description string) (*apiPipeline, error) {
+	pipeline := &apiPipeline{}
+	err := c.doJSON(ctx, http.MethodPost, "/apis/v2beta1/pipelines", &apiPipeline{DisplayName: name, Description: description}, pipeline)
+	if isPipelineAPIStatus(err, http.StatusConflict) {
+		return c.getPipelineByName(ctx, name)
+	}
+	return pipeline, err
+}
+
+func (c *pipelineAPIClient) listPipelineVersions(ctx context.Context, pipelineID string) ([]apiPipelineVersion, error) {
+	var versions []apiPipelineVersion
+	query := url.Values{"page_size": {"100"}}
+	for {
+		var page struct {
+			PipelineVersions []apiPipelineVersion `json:"pipeline_versions"`
+			NextPageToken    string               `json:"next_page_token"`
+		}
+		err := c.do(ctx, http.MethodGet, "/apis/v2beta1/pipelines/"+url.PathEscape(pipelineID)+"/versions", query, "", nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, page.PipelineVersions...)
+		if page.NextPageToken == "" {
+			return versions, nil
+		}
+		query.Set("page_token", page.NextPageToken)
+	}
+}
+
+func (c *pipelineAPIClient) getPipelineVersion(ctx context.Context, pipelineID, versionID string) (*apiPipelineVersion, error) {
+	version := &apiPipelineVersion{}
+	return version, c.doJSON(ctx, http.MethodGet,
+		"/apis/v2beta1/pipelines/"+url.PathEscape(pipelineID)+"/versions/"+url.PathEscape(versionID), nil, version)
+}
+
+// uploadPipelineVersion uploads spec as the version name of the pipeline,
+// or returns the version of the same name.
+func (c *pipelineAPIClient) uploadPipelineVersion(ctx context.Context, pipelineID, name, spec string) (*apiPipelineVersion, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("uploadfile", "pipeline.yaml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write([]byte(spec)); err != nil {
+		return nil, err
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	version := &apiPipelineVersion{}
+	query := url.Values{"pipelineid": {pipelineID}, "name": {name}, "display_name": {name}}
+	err = c.do(ctx, http.MethodPost, "/apis/v2beta1/pipelines/upload_version", query, form.FormDataContentType(), &body, version)
+	if isPipelineAPIStatus(err, http.StatusConflict) {
+		versions, listErr := c.listPipelineVersions(ctx, pipelineID)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for i := range versions {
+			if versions[i].DisplayName == name {
+				return &versions[i], nil
+			}
+		}
+	}
+	return version, err
+}
+
+// deletePipeline deletes the pipeline and its versions, the API server does not
+// delete pipelines that have versions.
+func (c *pipelineAPIClient) deletePipeline(ctx context.Context, pipelineID string) error {
+	versions, err := c.listPipelineVersions(ctx, pipelineID)
+	if isPipelineAPIStatus(err, http.StatusNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		err := c.doJSON(ctx, http.MethodDelete,
+			"/apis/v2beta1/pipelines/"+url.PathEscape(pipelineID)+"/versions/"+url.PathEscape(version.PipelineVersionID), nil, nil)
+		if err != nil && !isPipelineAPIStatus(err, http.StatusNotFound) {
+			return err
+		}
+	}
+	err = c.doJSON(ctx, http.MethodDelete, "/apis/v2beta1/pipelines/"+url.PathEscape(pipelineID), nil, nil)
+	if isPipelineAPIStatus(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (c *pipelineAPIClient) getRecurringRun(ctx context.Context, recurringRunID string) (*apiRecurringRun, error) {
+	recurringRun := &apiRecurringRun{}
+	return recurringRun, c.doJSON(ctx, http.MethodGet, "/apis/v2beta1/recurringruns/"+url.PathEscape(recurringRunID), nil, recurringRun)
+}
+
+func (c *pipelineAPIClient) createRecurringRun(ctx context.Context, recurringRun *apiRecurringRun) (*apiRecurringRun, error) {
+	created := &apiRecurringRun{}
+	return created, c.doJSON(ctx, http.MethodPost, "/apis/v2beta1/recurringruns", recurringRun, created)
+}
+
+// setRecurringRunEnabled enables or disables the scheduling of the runs.
+func (c *pipelineAPIClient) setRecurringRunEnabled(ctx context.Context, recurringRunID string, enabled bool) error {
+	action := ":disable"
+	if enabled {
+		action = ":enable"
+	}
+	return c.doJSON(ctx, http.MethodPost, "/apis/v2beta1/recurringruns/"+url.PathEscape(recurringRunID)+action, nil, nil)
+}
+
+func (c *pipelineAPIClient) deleteRecurringRun(ctx context.Context, recurringRunID string) error {
+	err := c.doJSON(ctx, http.MethodDelete, "/apis/v2beta1/recurringruns/"+url.PathEscape(recurringRunID), nil, nil)
+	if isPipelineAPIStatus(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
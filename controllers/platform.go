@@ -0,0 +1,144 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	routev1 "github.com/openshift/api/route/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// routeGroupKind is only served on OpenShift
+var routeGroupKind = schema.GroupKind{Group: routev1.GroupName, Kind: "Route"}
+
+// KubernetesPlatform configures the Ingresses and oauth2-proxy sidecars
+// exposing the components of the DSPAs on the kubernetes platform, from the
+// DSPO.Kubernetes operator config.
+type KubernetesPlatform struct {
+	// IngressDomain the components are exposed on the subdomains of, the
+	// Ingresses match any host when it is not set
+	IngressDomain      string
+	IngressClassName   string
+	IngressAnnotations map[string]string
+	// IngressTLS serves the Ingresses with TLS, from the <ingress name>-tls
+	// Secret, e.g. issued by cert-manager from the IngressAnnotations
+	IngressTLS bool
+
+	OAuth2ProxyProvider  string
+	OAuth2ProxyIssuerURL string
+	// OAuth2ProxySecretName holds the client-id, client-secret and
+	// cookie-secret of oauth2-proxy, in the DSPA namespace
+	OAuth2ProxySecretName string
+	// OAuth2ProxyAllowedGroups are the groups, from the groups claim of the
+	// OIDC provider, whose members oauth2-proxy lets through. Unlike the
+	// OpenShift oauth-proxy it does not check the RBAC of the users.
+	OAuth2ProxyAllowedGroups []string
+}
+
+// DetectPlatform returns openshift when mapper knows Routes, else
+// kubernetes. Failures other than Routes being unknown report openshift, so
+// that a discovery outage does not switch the DSPAs to Ingresses.
+func DetectPlatform(mapper meta.RESTMapper) dspav1.Platform {
+	_, err := mapper.RESTMapping(routeGroupKind, routev1.GroupVersion.Version)
+	if meta.IsNoMatchError(err) {
+		return dspav1.PlatformKubernetes
+	}
+	return dspav1.PlatformOpenShift
+}
+
+// platform returns the platform of dsp, from its spec or else detected
+func platform(dsp *dspav1.DataSciencePipelinesApplication, mapper meta.RESTMapper) dspav1.Platform {
+	if dsp.Spec.Platform != "" {
+		return dsp.Spec.Platform
+	}
+	return DetectPlatform(mapper)
+}
+
+// setupPlatform sets the platform of dsp, and on kubernetes configures its
+// Ingresses and oauth2-proxy sidecars. Without the OpenShift service CA pod to
+// pod TLS requires certificates issued by cert-manager.
+func (p *DSPAParams) setupPlatform(dsp *dspav1.DataSciencePipelinesApplication, mapper meta.RESTMapper) error {
+	p.Platform = platform(dsp, mapper)
+	p.Kubernetes = nil
+	if p.Platform != dspav1.PlatformKubernetes {
+		return nil
+	}
+
+	if p.CertManagerIssuer == nil && p.PodToPodTLS {
+		if dsp.Spec.PodToPodTLS != nil && *dsp.Spec.PodToPodTLS {
+			return fmt.Errorf("[spec.podToPodTLS] requires [spec.tls.issuerRef] on the kubernetes platform, the OpenShift service CA is not available")
+		}
+		p.PodToPodTLS = false
+	}
+	p.Kubernetes = &KubernetesPlatform{
+		IngressDomain:            config.GetStringConfigWithDefault(config.KubernetesIngressDomainConfigName, ""),
+		IngressClassName:         config.GetStringConfigWithDefault(config.KubernetesIngressClassNameConfigName, ""),
+		IngressAnnotations:       config.GetStringMapConfigWithDefault(config.KubernetesIngressAnnotationsConfigName, nil),
+		IngressTLS:               config.GetBoolConfigWithDefault(config.KubernetesIngressTLSConfigName, false),
+		OAuth2ProxyProvider:      config.GetStringConfigWithDefault(config.KubernetesOAuth2ProxyProviderConfigName, config.DefaultOAuth2ProxyProvider),
+		OAuth2ProxyIssuerURL:     config.GetStringConfigWithDefault(config.KubernetesOAuth2ProxyIssuerURLConfigName, ""),
+		OAuth2ProxySecretName:    config.GetStringConfigWithDefault(config.KubernetesOAuth2ProxySecretConfigName, config.DefaultOAuth2ProxySecretName),
+		OAuth2ProxyAllowedGroups: config.GetStringSliceConfigWithDefault(config.KubernetesOAuth2ProxyGroupsConfigName, nil),
+	}
+	if p.Kubernetes.OAuth2ProxyProvider == config.DefaultOAuth2ProxyProvider && p.Kubernetes.OAuth2ProxyIssuerURL == "" {
+		return fmt.Errorf("[spec.platform] the %s operator config must be set on the kubernetes platform, for oauth2-proxy to authenticate users", config.KubernetesOAuth2ProxyIssuerURLConfigName)
+	}
+	if len(p.Kubernetes.OAuth2ProxyAllowedGroups) == 0 {
+		return fmt.Errorf("[spec.platform] the %s operator config must list at least one group on the kubernetes platform, otherwise oauth2-proxy authorizes any authenticated user", config.KubernetesOAuth2ProxyGroupsConfigName)
+	}
+	return nil
+}
+
+// IngressHost returns the host the Ingress name is served on, empty without
+// an ingress domain.
+func (k *KubernetesPlatform) IngressHost(name, namespace string) string {
+	if k.IngressDomain == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s.%s", name, namespace, k.IngressDomain)
+}
+
+// externalRouteObject returns an empty object of the kind exposing the
+// components on platform.
+func externalRouteObject(platform dspav1.Platform) client.Object {
+	if platform == dspav1.PlatformKubernetes {
+		return &networkingv1.Ingress{}
+	}
+	return &routev1.Route{}
+}
+
+// deleteExternalRoute deletes the Route, or the Ingress on kubernetes, named nn
+func (r *DSPAReconciler) deleteExternalRoute(ctx context.Context, params *DSPAParams, nn types.NamespacedName) error {
+	return r.DeleteResourceIfItExists(ctx, externalRouteObject(params.Platform), nn)
+}
+
+// externalRouteURL returns the URL of the Route, or the Ingress on
+// kubernetes, named name.
+func externalRouteURL(ctx context.Context, cl client.Client, platform dspav1.Platform, name, namespace string) (string, error) {
+	if platform == dspav1.PlatformKubernetes {
+		return util.GetIngressHostname(ctx, name, namespace, cl)
+	}
+	return util.GetRouteHostname(ctx, name, namespace, cl)
+}
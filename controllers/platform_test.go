@@ -0,0 +1,179 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func kubernetesTestDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.Platform = dspav1.PlatformKubernetes
+	dspa.Spec.PodToPodTLS = nil
+	dspa.Spec.APIServer.EnableRoute = true
+	dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{Deploy: true, Image: "quay.io/opendatahub/ds-pipelines-frontend:latest"}
+	return dspa
+}
+
+func setKubernetesPlatformConfig(t *testing.T) {
+	viper.Set(config.KubernetesOAuth2ProxyIssuerURLConfigName, "https://idp.example.com")
+	viper.Set(config.KubernetesOAuth2ProxyGroupsConfigName, []string{"ml-engineers"})
+	viper.Set(config.KubernetesIngressDomainConfigName, "apps.example.com")
+	viper.Set(config.KubernetesIngressClassNameConfigName, "nginx")
+	viper.Set(config.KubernetesIngressTLSConfigName, true)
+	t.Cleanup(func() {
+		for _, name := range []string{config.KubernetesOAuth2ProxyIssuerURLConfigName, config.KubernetesOAuth2ProxyGroupsConfigName, config.KubernetesIngressDomainConfigName,
+			config.KubernetesIngressClassNameConfigName, config.KubernetesIngressTLSConfigName} {
+			viper.Set(name, nil)
+		}
+	})
+}
+
+func TestDetectPlatform(t *testing.T) {
+	_, _, reconciler := CreateNewTestObjects()
+	assert.Equal(t, dspav1.PlatformOpenShift, DetectPlatform(reconciler.RESTMapper()))
+	assert.Equal(t, dspav1.PlatformKubernetes, DetectPlatform(meta.NewDefaultRESTMapper(nil)))
+
+	// Assert the spec overrides the detected platform
+	dspa := kubernetesTestDSPA()
+	assert.Equal(t, dspav1.PlatformKubernetes, platform(dspa, reconciler.RESTMapper()))
+	dspa.Spec.Platform = ""
+	assert.Equal(t, dspav1.PlatformOpenShift, platform(dspa, reconciler.RESTMapper()))
+}
+
+func TestExtractParams_KubernetesPlatform(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+
+	// Assert oauth2-proxy needs an OIDC issuer
+	err := params.ExtractParams(ctx, kubernetesTestDSPA(), reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, config.KubernetesOAuth2ProxyIssuerURLConfigName)
+
+	// Assert oauth2-proxy needs the groups it authorizes, rather than letting through any authenticated user
+	viper.Set(config.KubernetesOAuth2ProxyIssuerURLConfigName, "https://idp.example.com")
+	err = params.ExtractParams(ctx, kubernetesTestDSPA(), reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, config.KubernetesOAuth2ProxyGroupsConfigName)
+
+	setKubernetesPlatformConfig(t)
+	require.Nil(t, params.ExtractParams(ctx, kubernetesTestDSPA(), reconciler.Client, reconciler.Log))
+	assert.Equal(t, dspav1.PlatformKubernetes, params.Platform)
+	require.NotNil(t, params.Kubernetes)
+	assert.Equal(t, "nginx", params.Kubernetes.IngressClassName)
+	assert.Equal(t, config.DefaultOAuth2ProxySecretName, params.Kubernetes.OAuth2ProxySecretName)
+	assert.Equal(t, config.DefaultOAuth2ProxyImage, params.OAuthProxy)
+	// Assert pod to pod TLS defaults to disabled without the OpenShift service CA
+	assert.False(t, params.PodToPodTLS)
+
+	// Assert pod to pod TLS requires certificates issued by cert-manager
+	dspa := kubernetesTestDSPA()
+	dspa.Spec.PodToPodTLS = boolPtr(true)
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "[spec.podToPodTLS]")
+	dspa.Spec.TLS = &dspav1.TLS{IssuerRef: &dspav1.CertManagerIssuerRef{Name: "issuer"}}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.True(t, params.PodToPodTLS)
+
+	// Assert OpenShift DSPAs are not affected
+	require.Nil(t, params.ExtractParams(ctx, testutil.CreateDefaultDSPA(), reconciler.Client, reconciler.Log))
+	assert.Equal(t, dspav1.PlatformOpenShift, params.Platform)
+	assert.Nil(t, params.Kubernetes)
+}
+
+func TestRenderAll_KubernetesPlatform(t *testing.T) {
+	setKubernetesPlatformConfig(t)
+	dspa := kubernetesTestDSPA()
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	ingresses := map[string]*networkingv1.Ingress{}
+	for _, resource := range resources {
+		// Assert nothing depends on OpenShift
+		assert.NotEqual(t, "Route", resource.GetKind(), resource.GetName())
+		switch resource.GetKind() {
+		case "Ingress":
+			ingress := &networkingv1.Ingress{}
+			require.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, ingress))
+			ingresses[ingress.Name] = ingress
+		case "Deployment":
+			deployment := &appsv1.Deployment{}
+			require.Nil(t, runtime.DefaultUnstructuredConverter.FromUnstructured(resource.Object, deployment))
+			for _, volume := range deployment.Spec.Template.Spec.Volumes {
+				assert.False(t, strings.HasSuffix(volume.Name, "proxy-tls"), "%s mounts the service CA secret %s", deployment.Name, volume.Name)
+			}
+			for _, container := range deployment.Spec.Template.Spec.Containers {
+				if container.Name != "oauth-proxy" {
+					continue
+				}
+				assert.Equal(t, config.DefaultOAuth2ProxyImage, container.Image)
+				assert.Contains(t, container.Args, "--provider=oidc")
+				assert.Contains(t, container.Args, "--oidc-issuer-url=https://idp.example.com")
+				assert.Contains(t, container.Args, "--allowed-group=ml-engineers", deployment.Name)
+				assert.Equal(t, "/ping", container.ReadinessProbe.HTTPGet.Path)
+				assert.Equal(t, config.DefaultOAuth2ProxySecretName, container.Env[0].ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+
+	// Assert the components are exposed by Ingresses on the ingress domain
+	require.Contains(t, ingresses, "ds-pipeline-testdspa")
+	require.Contains(t, ingresses, "ds-pipeline-ui-testdspa")
+	require.Contains(t, ingresses, "ds-pipeline-md-testdspa")
+	apiServer := ingresses["ds-pipeline-testdspa"]
+	assert.Equal(t, "nginx", *apiServer.Spec.IngressClassName)
+	assert.Equal(t, "ds-pipeline-testdspa-testnamespace.apps.example.com", apiServer.Spec.Rules[0].Host)
+	assert.Equal(t, "ds-pipeline-testdspa", apiServer.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, int32(8443), apiServer.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+	assert.Equal(t, "ds-pipeline-testdspa-tls", apiServer.Spec.TLS[0].SecretName)
+	assert.Equal(t, "ds-pipeline-metadata-envoy-testdspa", ingresses["ds-pipeline-md-testdspa"].Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+}
+
+func TestReconcileAPIServer_KubernetesPlatform(t *testing.T) {
+	setKubernetesPlatformConfig(t)
+	dspa := kubernetesTestDSPA()
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+
+	// Assert the Ingress is created, and reported as the external URL
+	created, err := reconciler.IsResourceCreated(ctx, &networkingv1.Ingress{}, "ds-pipeline-testdspa", dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	url, err := externalRouteURL(ctx, reconciler.Client, params.Platform, "ds-pipeline-testdspa", dspa.Namespace)
+	require.Nil(t, err)
+	assert.Equal(t, "https://ds-pipeline-testdspa-testnamespace.apps.example.com", url)
+
+	// Assert the Ingress is deleted once the route is disabled
+	dspa.Spec.APIServer.EnableRoute = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	err = reconciler.Get(ctx, types.NamespacedName{Name: "ds-pipeline-testdspa", Namespace: dspa.Namespace}, &networkingv1.Ingress{})
+	assert.True(t, err != nil)
+}
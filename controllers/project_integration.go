@@ -0,0 +1,133 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+// ProjectIntegrationReconciler provisions a default DSPA in every project
+// namespace, those matching the project integration namespace selector, and
+// deletes it once the namespace no longer matches or opts out with
+// config.ProjectIntegrationOptOutAnnotation. Only DSPAs it provisioned,
+// labelled with config.ProjectIntegrationLabel, are ever deleted.
+type ProjectIntegrationReconciler struct {
+	client.Client
+	Log logr.Logger
+}
+
+// projectIntegrationFromConfig returns the namespace selector, name and spec
+// of the DSPAs provisioned in project namespaces.
+func projectIntegrationFromConfig() (labels.Selector, string, *dspav1.DSPASpec, error) {
+	selectorString := config.GetStringConfigWithDefault(config.ProjectIntegrationSelectorConfigName, config.DefaultProjectIntegrationSelector)
+	selector, err := labels.Parse(selectorString)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("invalid project integration namespace selector [%s] in operator config: %w", selectorString, err)
+	}
+	name := config.GetStringConfigWithDefault(config.ProjectIntegrationDSPANameConfigName, config.DefaultProjectIntegrationDSPAName)
+	spec := &dspav1.DSPASpec{}
+	if err := yaml.UnmarshalStrict([]byte(config.GetStringConfigWithDefault(config.ProjectIntegrationDefaultSpecConfigName, "")), spec); err != nil {
+		return nil, "", nil, fmt.Errorf("invalid project integration default DSPA spec in operator config: %w", err)
+	}
+	return selector, name, spec, nil
+}
+
+func (r *ProjectIntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The reconciled Namespace is the namespace of the provisioned DSPA
+	selector, name, spec, err := projectIntegrationFromConfig()
+	if err != nil {
+		r.Log.WithValues("dspa_namespace", req.Name).Error(err, "Encountered error when reading the project integration config")
+		return ctrl.Result{}, nil
+	}
+	log := dspaLogger(r.Log, req.Name, name, "")
+
+	ns := &corev1.Namespace{}
+	err = r.Get(ctx, types.NamespacedName{Name: req.Name}, ns)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	isProject := err == nil && ns.DeletionTimestamp == nil && selector.Matches(labels.Set(ns.Labels)) &&
+		ns.Annotations[config.ProjectIntegrationOptOutAnnotation] != "true"
+	if isProject {
+		isProject, err = util.NamespaceInScope(ctx, req.Name, r.Client)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	dspa := &dspav1.DataSciencePipelinesApplication{}
+	err = r.Get(ctx, types.NamespacedName{Name: name, Namespace: req.Name}, dspa)
+	exists := err == nil
+	if err != nil && !apierrs.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	switch {
+	case isProject && !exists:
+		dspa = &dspav1.DataSciencePipelinesApplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: req.Name,
+				Labels:    map[string]string{config.ProjectIntegrationLabel: "true"},
+			},
+			Spec: *spec,
+		}
+		log.Info(fmt.Sprintf("Provisioning DSPA %s in project namespace.", name))
+		if err := r.Create(ctx, dspa); err != nil && !apierrs.IsAlreadyExists(err) {
+			return ctrl.Result{}, err
+		}
+	case !isProject && exists && dspa.Labels[config.ProjectIntegrationLabel] == "true":
+		log.Info(fmt.Sprintf("Deleting DSPA %s provisioned in a namespace that is no longer a project, or opted out.", name))
+		if err := r.Delete(ctx, dspa); err != nil && !apierrs.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProjectIntegrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("project-integration").
+		For(&corev1.Namespace{}).
+		// Provision the DSPA again if it is deleted while its namespace is a
+		// project, namespaces opt out with config.ProjectIntegrationOptOutAnnotation
+		Watches(&dspav1.DataSciencePipelinesApplication{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+				if o.GetLabels()[config.ProjectIntegrationLabel] != "true" {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: o.GetNamespace()}}}
+			}),
+		).
+		Complete(r)
+}
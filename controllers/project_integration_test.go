@@ -0,0 +1,98 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestProjectIntegrationReconcile(t *testing.T) {
+	ctx, _, dspaReconciler := CreateNewTestObjects()
+	viper.Set(config.ProjectIntegrationDefaultSpecConfigName, "apiServer:\n  deploy: true\n")
+	defer viper.Reset()
+	reconciler := &ProjectIntegrationReconciler{Client: dspaReconciler.Client, Log: dspaReconciler.Log}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "project"}}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "project",
+		Labels: map[string]string{"opendatahub.io/dashboard": "true"},
+	}}
+	require.Nil(t, reconciler.Create(ctx, ns))
+
+	// Assert a DSPA is provisioned in project namespaces
+	_, err := reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	dspa := &dspav1.DataSciencePipelinesApplication{}
+	created, err := dspaReconciler.IsResourceCreated(ctx, dspa, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "true", dspa.Labels[config.ProjectIntegrationLabel])
+	assert.True(t, dspa.Spec.APIServer.Deploy)
+
+	// Assert a deleted DSPA is provisioned again, unless the namespace opts out
+	require.Nil(t, reconciler.Delete(ctx, dspa))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	created, err = dspaReconciler.IsResourceCreated(ctx, &dspav1.DataSciencePipelinesApplication{}, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	assert.True(t, created)
+	ns.Annotations = map[string]string{config.ProjectIntegrationOptOutAnnotation: "true"}
+	require.Nil(t, reconciler.Update(ctx, ns))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	created, err = dspaReconciler.IsResourceCreated(ctx, &dspav1.DataSciencePipelinesApplication{}, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	assert.False(t, created)
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	created, err = dspaReconciler.IsResourceCreated(ctx, &dspav1.DataSciencePipelinesApplication{}, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	assert.False(t, created)
+	ns.Annotations = nil
+
+	// Assert the provisioned DSPA is deleted once the namespace is no longer a project
+	ns.Labels = nil
+	require.Nil(t, reconciler.Update(ctx, ns))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	created, err = dspaReconciler.IsResourceCreated(ctx, &dspav1.DataSciencePipelinesApplication{}, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert DSPAs created by users are never deleted
+	userDSPA := &dspav1.DataSciencePipelinesApplication{ObjectMeta: metav1.ObjectMeta{
+		Name:      config.DefaultProjectIntegrationDSPAName,
+		Namespace: "project",
+	}}
+	require.Nil(t, reconciler.Create(ctx, userDSPA))
+	_, err = reconciler.Reconcile(ctx, request)
+	require.Nil(t, err)
+	created, err = dspaReconciler.IsResourceCreated(ctx, &dspav1.DataSciencePipelinesApplication{}, config.DefaultProjectIntegrationDSPAName, "project")
+	require.Nil(t, err)
+	assert.True(t, created)
+}
@@ -0,0 +1,229 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// componentFootprint is the compute a component's Deployment requests from
+// the namespace, counting only its main container.
+type componentFootprint struct {
+	deployment string
+	replicas   int32
+	resources  *dspav1.ResourceRequirements
+}
+
+// quotaResourceNames lists the ResourceQuota resources that constrain each
+// requested resource, "cpu" and "memory" being aliases of the requests.
+var quotaResourceNames = map[corev1.ResourceName][]corev1.ResourceName{
+	corev1.ResourceRequestsCPU:    {corev1.ResourceRequestsCPU, corev1.ResourceCPU},
+	corev1.ResourceRequestsMemory: {corev1.ResourceRequestsMemory, corev1.ResourceMemory},
+	corev1.ResourceLimitsCPU:      {corev1.ResourceLimitsCPU},
+	corev1.ResourceLimitsMemory:   {corev1.ResourceLimitsMemory},
+	corev1.ResourcePods:           {corev1.ResourcePods},
+}
+
+// componentFootprints returns the resources of the components deployed in the
+// DSPA namespace, the components deployed in another namespace are bound by
+// its quotas instead.
+func (p *DSPAParams) componentFootprints() []componentFootprint {
+	var footprints []componentFootprint
+	if p.APIServer != nil && p.APIServer.Deploy {
+		footprints = append(footprints, componentFootprint{p.APIServerDefaultResourceName, p.Replicas, p.APIServer.Resources})
+	}
+	if p.PersistenceAgent != nil && p.PersistenceAgent.Deploy {
+		footprints = append(footprints, componentFootprint{p.PersistentAgentDefaultResourceName, 1, p.PersistenceAgent.Resources})
+	}
+	if p.ScheduledWorkflow != nil && p.ScheduledWorkflow.Deploy {
+		footprints = append(footprints, componentFootprint{p.ScheduledWorkflowDefaultResourceName, 1, p.ScheduledWorkflow.Resources})
+	}
+	if p.MlPipelineUI != nil && p.MlPipelineUI.Deploy {
+		footprints = append(footprints, componentFootprint{"ds-pipeline-ui-" + p.Name, p.Replicas, p.MlPipelineUI.Resources})
+	}
+	if p.VisualizationServer != nil && p.VisualizationServer.Deploy {
+		footprints = append(footprints, componentFootprint{p.VisualizationServerDefaultResourceName, 1, p.VisualizationServer.Resources})
+	}
+	if p.CRDViewer != nil && p.CRDViewer.Deploy {
+		footprints = append(footprints, componentFootprint{p.CRDViewerDefaultResourceName, 1, p.CRDViewer.Resources})
+	}
+	if p.WorkflowController != nil && p.WorkflowController.Deploy {
+		footprints = append(footprints, componentFootprint{"ds-pipeline-workflow-controller-" + p.Name, 1, p.WorkflowController.Resources})
+	}
+	if p.MariaDB != nil && p.MariaDB.Deploy && p.MariaDBNamespace == p.Namespace {
+		replicas := int32(1)
+		if p.MariaDBHighAvailability() {
+			replicas = p.MariaDB.HighAvailability.Replicas
+		}
+		footprints = append(footprints, componentFootprint{"mariadb-" + p.Name, replicas, p.MariaDB.Resources})
+	}
+	if p.Minio != nil && p.Minio.Deploy && p.MinioNamespace == p.Namespace {
+		replicas := int32(1)
+		if p.MinioDistributed() {
+			replicas = p.Minio.Replicas
+		}
+		footprints = append(footprints, componentFootprint{"minio-" + p.Name, replicas, p.Minio.Resources})
+	}
+	if p.MLMDEnvoyDeployed() {
+		footprints = append(footprints, componentFootprint{"ds-pipeline-metadata-envoy-" + p.Name, p.Replicas, p.MLMD.Envoy.Resources})
+	}
+	if p.MLMDGRPCDeployed() {
+		footprints = append(footprints, componentFootprint{"ds-pipeline-metadata-grpc-" + p.Name, p.Replicas, p.MLMD.GRPC.Resources})
+	}
+	return footprints
+}
+
+// deployedAsStatefulSet returns true for the workloads of the Galera cluster and
+// of the distributed Minio.
+func (p *DSPAParams) deployedAsStatefulSet(workload string) bool {
+	return (p.MariaDBHighAvailability() && workload == "mariadb-"+p.Name) ||
+		(p.MinioDistributed() && workload == "minio-"+p.Name)
+}
+
+// CheckResourceQuota compares the resources of the components not yet
+// deployed against the ResourceQuotas of the namespace, and the resources of
+// all components against its LimitRanges. It returns a description of every
+// shortfall found, or an empty string when the components can be scheduled.
+func (r *DSPAReconciler) CheckResourceQuota(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) (string, error) {
+
+	limitRanges := &corev1.LimitRangeList{}
+	if err := r.List(ctx, limitRanges, client.InNamespace(dsp.Namespace)); err != nil {
+		return "", err
+	}
+
+	var shortfalls []string
+	needed := corev1.ResourceList{}
+	for _, footprint := range params.componentFootprints() {
+		if footprint.resources != nil {
+			shortfalls = append(shortfalls, limitRangeShortfalls(limitRanges.Items, footprint)...)
+		}
+
+		var workload client.Object = &appsv1.Deployment{}
+		if params.deployedAsStatefulSet(footprint.deployment) {
+			workload = &appsv1.StatefulSet{}
+		}
+		err := r.Get(ctx, types.NamespacedName{Name: footprint.deployment, Namespace: dsp.Namespace}, workload)
+		if err == nil {
+			// Already counted in the quota usage
+			continue
+		} else if !apierrs.IsNotFound(err) {
+			return "", err
+		}
+
+		// Components without resources set still count against the pods quota
+		pods := needed[corev1.ResourcePods]
+		pods.Add(*resource.NewQuantity(int64(footprint.replicas), resource.DecimalSI))
+		needed[corev1.ResourcePods] = pods
+		if footprint.resources != nil {
+			addResources(needed, footprint.replicas, corev1.ResourceRequestsCPU, corev1.ResourceRequestsMemory, footprint.resources.Requests)
+			addResources(needed, footprint.replicas, corev1.ResourceLimitsCPU, corev1.ResourceLimitsMemory, footprint.resources.Limits)
+		}
+	}
+
+	quotas := &corev1.ResourceQuotaList{}
+	if err := r.List(ctx, quotas, client.InNamespace(dsp.Namespace)); err != nil {
+		return "", err
+	}
+	for _, quota := range quotas.Items {
+		// Scoped quotas only apply to a subset of pods, e.g. BestEffort
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, quantity := range needed {
+			for _, quotaName := range quotaResourceNames[name] {
+				hard, ok := quota.Status.Hard[quotaName]
+				if !ok {
+					continue
+				}
+				available := hard.DeepCopy()
+				available.Sub(quota.Status.Used[quotaName])
+				if quantity.Cmp(available) > 0 {
+					shortfalls = append(shortfalls, fmt.Sprintf("ResourceQuota %s %s: %s requested, %s available",
+						quota.Name, quotaName, quantity.String(), available.String()))
+				}
+			}
+		}
+	}
+
+	sort.Strings(shortfalls)
+	return strings.Join(shortfalls, "; "), nil
+}
+
+// addResources adds the cpu and memory of resources, scaled by replicas, to list.
+func addResources(list corev1.ResourceList, replicas int32, cpuName, memoryName corev1.ResourceName, resources *dspav1.Resources) {
+	if resources == nil {
+		return
+	}
+	for name, quantity := range map[corev1.ResourceName]resource.Quantity{cpuName: resources.CPU, memoryName: resources.Memory} {
+		if quantity.IsZero() {
+			continue
+		}
+		total := list[name]
+		total.Add(*resource.NewMilliQuantity(quantity.MilliValue()*int64(replicas), quantity.Format))
+		list[name] = total
+	}
+}
+
+// limitRangeShortfalls reports the container resources of footprint that fall
+// outside the Container min/max of limitRanges.
+func limitRangeShortfalls(limitRanges []corev1.LimitRange, footprint componentFootprint) []string {
+	var shortfalls []string
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for name, max := range item.Max {
+				if limit, ok := footprintQuantity(footprint.resources.Limits, name); ok && limit.Cmp(max) > 0 {
+					shortfalls = append(shortfalls, fmt.Sprintf("LimitRange %s: %s %s limit %s exceeds the maximum of %s",
+						limitRange.Name, footprint.deployment, name, limit.String(), max.String()))
+				}
+			}
+			for name, min := range item.Min {
+				if request, ok := footprintQuantity(footprint.resources.Requests, name); ok && request.Cmp(min) < 0 {
+					shortfalls = append(shortfalls, fmt.Sprintf("LimitRange %s: %s %s request %s is below the minimum of %s",
+						limitRange.Name, footprint.deployment, name, request.String(), min.String()))
+				}
+			}
+		}
+	}
+	return shortfalls
+}
+
+func footprintQuantity(resources *dspav1.Resources, name corev1.ResourceName) (resource.Quantity, bool) {
+	if resources == nil {
+		return resource.Quantity{}, false
+	}
+	switch name {
+	case corev1.ResourceCPU:
+		return resources.CPU, !resources.CPU.IsZero()
+	case corev1.ResourceMemory:
+		return resources.Memory, !resources.Memory.IsZero()
+	}
+	return resource.Quantity{}, false
+}
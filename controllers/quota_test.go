@@ -0,0 +1,135 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckResourceQuotaNoQuota(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Assert namespaces without quotas are not constrained
+	shortfall, err := reconciler.CheckResourceQuota(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.Empty(t, shortfall)
+}
+
+func TestCheckResourceQuotaInsufficient(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Create a quota with less memory left than the default components request
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "tight", Namespace: dspa.Namespace},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("4Gi")},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("4Gi")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("3Gi")},
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, quota))
+
+	shortfall, err := reconciler.CheckResourceQuota(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.Contains(t, shortfall, "ResourceQuota tight requests.memory")
+	assert.Contains(t, shortfall, "1Gi available")
+
+	// Assert the quota is sufficient once enough of it is free
+	quota.Status.Used = corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("0")}
+	require.Nil(t, reconciler.Update(ctx, quota))
+
+	shortfall, err = reconciler.CheckResourceQuota(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.Empty(t, shortfall)
+}
+
+func TestCheckResourceQuotaPods(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	params.APIServer.Resources = nil
+	pods := int64(0)
+	for _, footprint := range params.componentFootprints() {
+		pods += int64(footprint.replicas)
+	}
+
+	// Create a quota with one pod less left than the components need
+	available := *resource.NewQuantity(pods-1, resource.DecimalSI)
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "pods", Namespace: dspa.Namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: available}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: available},
+			Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, quota))
+
+	// Assert the pods of components without resources set are counted
+	shortfall, err := reconciler.CheckResourceQuota(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.Contains(t, shortfall, fmt.Sprintf("ResourceQuota pods pods: %d requested, %d available", pods, pods-1))
+}
+
+func TestCheckResourceQuotaLimitRange(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Create a LimitRange capping containers below the MariaDB default limits
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "small-containers", Namespace: dspa.Namespace},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type: corev1.LimitTypeContainer,
+				Max:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}},
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, limitRange))
+
+	shortfall, err := reconciler.CheckResourceQuota(ctx, dspa, params)
+	assert.Nil(t, err)
+	assert.Contains(t, shortfall, "LimitRange small-containers: mariadb-testdspa cpu limit 1 exceeds the maximum of 500m")
+}
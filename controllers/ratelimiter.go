@@ -0,0 +1,186 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NamespaceFairRateLimiter is a workqueue rate limiter giving the DSPAs of each
+// namespace their own token bucket, so that the requests of a namespace with
+// many DSPAs, or with DSPAs that keep failing, do not hold back the requests of
+// the others, e.g. when every DSPA is reconciled on an operator restart.
+// Requests are also bound by an overall bucket, and failures are retried with
+// an exponential backoff per DSPA, as by the controller-runtime default.
+type NamespaceFairRateLimiter struct {
+	failures workqueue.RateLimiter
+	overall  *rate.Limiter
+
+	namespaceQPS   rate.Limit
+	namespaceBurst int
+
+	mu         sync.Mutex
+	namespaces map[string]*rate.Limiter
+}
+
+// NewNamespaceFairRateLimiter limits the requests of each namespace to
+// namespaceQPS, in bursts of up to namespaceBurst, a namespaceQPS of 0 or less
+// does not limit them.
+func NewNamespaceFairRateLimiter(namespaceQPS float64, namespaceBurst int) *NamespaceFairRateLimiter {
+	limit := rate.Limit(namespaceQPS)
+	if namespaceQPS <= 0 {
+		limit = rate.Inf
+	}
+	return &NamespaceFairRateLimiter{
+		failures:       workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+		overall:        rate.NewLimiter(rate.Limit(10), 100),
+		namespaceQPS:   limit,
+		namespaceBurst: namespaceBurst,
+		namespaces:     map[string]*rate.Limiter{},
+	}
+}
+
+// When returns how long to wait before retrying item
+func (l *NamespaceFairRateLimiter) When(item interface{}) time.Duration {
+	backoff := l.failures.When(item)
+	if delay := l.admit(item); delay > backoff {
+		return delay
+	}
+	return backoff
+}
+
+// Forget resets the failure backoff of item
+func (l *NamespaceFairRateLimiter) Forget(item interface{}) {
+	l.failures.Forget(item)
+}
+
+// NumRequeues returns how many times item failed since it was last forgotten
+func (l *NamespaceFairRateLimiter) NumRequeues(item interface{}) int {
+	return l.failures.NumRequeues(item)
+}
+
+// admit returns how long item waits for a token of its namespace bucket, and
+// then of the overall bucket. Reserving the overall token only once the
+// namespace token is available keeps a busy namespace from draining it.
+func (l *NamespaceFairRateLimiter) admit(item interface{}) time.Duration {
+	namespace := ""
+	if req, ok := item.(reconcile.Request); ok {
+		namespace = req.Namespace
+	}
+
+	l.mu.Lock()
+	limiter, ok := l.namespaces[namespace]
+	if !ok {
+		limiter = rate.NewLimiter(l.namespaceQPS, l.namespaceBurst)
+		l.namespaces[namespace] = limiter
+	}
+	l.mu.Unlock()
+
+	now := time.Now()
+	namespaceDelay := limiter.ReserveN(now, 1).DelayFrom(now)
+	return l.overall.ReserveN(now.Add(namespaceDelay), 1).DelayFrom(now)
+}
+
+// queueWrappingHandler passes the events of its EventHandler to the queue
+// returned by wrap, e.g. to enqueue them through the namespace rate limits.
+type queueWrappingHandler struct {
+	handler.EventHandler
+	wrap func(workqueue.RateLimitingInterface) workqueue.RateLimitingInterface
+}
+
+func (h *queueWrappingHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Create(ctx, e, h.wrap(q))
+}
+
+func (h *queueWrappingHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Update(ctx, e, h.wrap(q))
+}
+
+func (h *queueWrappingHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Delete(ctx, e, h.wrap(q))
+}
+
+func (h *queueWrappingHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.EventHandler.Generic(ctx, e, h.wrap(q))
+}
+
+// namespaceFairQueue enqueues requests through the rate limits of limiter,
+// rather than immediately.
+type namespaceFairQueue struct {
+	workqueue.RateLimitingInterface
+	limiter *NamespaceFairRateLimiter
+}
+
+func (q *namespaceFairQueue) Add(item interface{}) {
+	q.AddAfter(item, q.limiter.admit(item))
+}
+
+// applyRateLimitedClient throttles the writes of its Client, reads are served
+// from the manager cache and are not throttled.
+type applyRateLimitedClient struct {
+	client.Client
+	limiter *rate.Limiter
+}
+
+// NewApplyRateLimitedClient returns cl with its creates, updates, patches and
+// deletes limited to qps overall, in bursts of up to burst requests.
+func NewApplyRateLimitedClient(cl client.Client, qps float64, burst int) client.Client {
+	return &applyRateLimitedClient{Client: cl, limiter: rate.NewLimiter(rate.Limit(qps), burst)}
+}
+
+func (c *applyRateLimitedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *applyRateLimitedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *applyRateLimitedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *applyRateLimitedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *applyRateLimitedClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
@@ -0,0 +1,112 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNamespaceFairRateLimiter(t *testing.T) {
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+	limiter := NewNamespaceFairRateLimiter(1, 2)
+
+	// Assert a namespace is throttled once its burst is used, without delaying the others
+	assert.Zero(t, limiter.admit(request("busy", "dspa-1")))
+	assert.Zero(t, limiter.admit(request("busy", "dspa-2")))
+	assert.Greater(t, limiter.admit(request("busy", "dspa-3")), 500*time.Millisecond)
+	assert.Zero(t, limiter.admit(request("quiet", "dspa-1")))
+
+	// Assert failures are retried with an exponential backoff until forgotten
+	item := request("failing", "dspa")
+	first := limiter.When(item)
+	assert.Greater(t, limiter.When(item), first)
+	assert.Equal(t, 2, limiter.NumRequeues(item))
+	limiter.Forget(item)
+	assert.Equal(t, 0, limiter.NumRequeues(item))
+
+	// Assert a namespace QPS of 0 does not limit the namespaces
+	unlimited := NewNamespaceFairRateLimiter(0, 0)
+	for i := 0; i < 50; i++ {
+		assert.Zero(t, unlimited.admit(request("busy", "dspa")))
+	}
+}
+
+func TestNamespaceFairQueue(t *testing.T) {
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	limiter := NewNamespaceFairRateLimiter(1, 1)
+	h := &queueWrappingHandler{
+		EventHandler: &handler.EnqueueRequestForObject{},
+		wrap: func(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+			return &namespaceFairQueue{RateLimitingInterface: q, limiter: limiter}
+		},
+	}
+	created := func(namespace, name string) {
+		dspa := &dspav1.DataSciencePipelinesApplication{}
+		dspa.Namespace, dspa.Name = namespace, name
+		h.Create(context.Background(), event.CreateEvent{Object: dspa}, queue)
+	}
+
+	// Assert the events past the burst of a namespace are delayed
+	created("busy", "dspa-1")
+	created("busy", "dspa-2")
+	created("quiet", "dspa-1")
+	assert.Equal(t, 2, queue.Len())
+	assert.Eventually(t, func() bool { return queue.Len() == 3 }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestApplyRateLimitedClient(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	cl := NewApplyRateLimitedClient(reconciler.Client, 0.001, 1)
+	newConfigMap := func(name string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		cm.Namespace, cm.Name = "testnamespace", name
+		return cm
+	}
+
+	// Assert writes pass through within the burst
+	require.Nil(t, cl.Create(ctx, newConfigMap("first")))
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, "first", "testnamespace")
+	require.Nil(t, err)
+	assert.True(t, created)
+
+	// Assert writes past the burst wait, and give up with their context
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, cl.Create(timeoutCtx, newConfigMap("second")))
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, "second", "testnamespace")
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert reads are not throttled
+	assert.Nil(t, cl.Get(ctx, types.NamespacedName{Namespace: "testnamespace", Name: "first"}, &corev1.ConfigMap{}))
+}
@@ -0,0 +1,102 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DSPAReadiness is the aggregated readiness of a DSPA, as served by the
+// DSPAReadinessHandler.
+type DSPAReadiness struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Ready     bool   `json:"ready"`
+	// Components holds the readiness of the DSPA components by condition type
+	Components map[string]ComponentReadiness `json:"components"`
+}
+
+type ComponentReadiness struct {
+	Ready   bool   `json:"ready"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// DSPAReadinessHandler serves the readiness of the DSPA at
+// <Prefix><namespace>/<name> as JSON, with a 200 status code when its full
+// stack is ready and 503 otherwise, so that load balancers and smoke tests
+// can check a DSPA without reading its status.
+type DSPAReadinessHandler struct {
+	Client client.Reader
+	Prefix string
+}
+
+func (h *DSPAReadinessHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, h.Prefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "expected a path of the form "+h.Prefix+"<namespace>/<name>", http.StatusBadRequest)
+		return
+	}
+
+	dspa := &dspav1.DataSciencePipelinesApplication{}
+	err := h.Client.Get(req.Context(), types.NamespacedName{Namespace: parts[0], Name: parts[1]}, dspa)
+	if apierrs.IsNotFound(err) {
+		http.Error(w, "DSPA not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	readiness := DSPAReadiness{
+		Namespace:  dspa.Namespace,
+		Name:       dspa.Name,
+		Components: map[string]ComponentReadiness{},
+	}
+	for _, condition := range dspa.Status.Conditions {
+		switch condition.Type {
+		case config.CrReady:
+			readiness.Ready = condition.Status == metav1.ConditionTrue
+		case config.Degraded, config.UpgradeProgressing:
+			// Not the readiness of a component
+		default:
+			readiness.Components[condition.Type] = ComponentReadiness{
+				Ready:   condition.Status == metav1.ConditionTrue,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !readiness.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(readiness)
+}
@@ -0,0 +1,72 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDSPAReadinessHandler(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	handler := &DSPAReadinessHandler{Client: reconciler.Client, Prefix: "/readyz/dspa/"}
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get("/readyz/dspa/testnamespace").Code)
+	assert.Equal(t, http.StatusNotFound, get("/readyz/dspa/testnamespace/testdspa").Code)
+
+	dspa := testutil.CreateEmptyDSPA()
+	require.Nil(t, reconciler.Create(ctx, dspa))
+	dspa.Status.Conditions = []metav1.Condition{
+		{Type: config.DatabaseAvailable, Status: metav1.ConditionTrue, Reason: config.DatabaseAvailable},
+		{Type: config.ObjectStoreAvailable, Status: metav1.ConditionTrue, Reason: config.ObjectStoreAvailable},
+		{Type: config.APIServerReady, Status: metav1.ConditionFalse, Reason: config.FailingToDeploy, Message: "failing"},
+		{Type: config.CrReady, Status: metav1.ConditionFalse},
+		{Type: config.Degraded, Status: metav1.ConditionFalse},
+	}
+	require.Nil(t, reconciler.Update(ctx, dspa))
+
+	// Assert unready DSPAs are served with the detail of their components
+	response := get("/readyz/dspa/testnamespace/testdspa")
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	readiness := DSPAReadiness{}
+	require.Nil(t, json.NewDecoder(response.Body).Decode(&readiness))
+	assert.False(t, readiness.Ready)
+	assert.Len(t, readiness.Components, 3)
+	assert.True(t, readiness.Components[config.DatabaseAvailable].Ready)
+	assert.Equal(t, ComponentReadiness{Ready: false, Reason: config.FailingToDeploy, Message: "failing"}, readiness.Components[config.APIServerReady])
+
+	// Assert ready DSPAs are served with a 200 status code
+	dspa.Status.Conditions[2].Status = metav1.ConditionTrue
+	dspa.Status.Conditions[3].Status = metav1.ConditionTrue
+	require.Nil(t, reconciler.Update(ctx, dspa))
+	response = get("/readyz/dspa/testnamespace/testdspa")
+	assert.Equal(t, http.StatusOK, response.Code)
+}
@@ -0,0 +1,283 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+
+	mf "github.com/manifestival/manifestival"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderAll renders the manifests the reconcilers apply for dsp with params,
+// in the order they are applied, without reaching the cluster. Owner
+// references are not injected, as they are only known once dsp is stored.
+// Values the reconcilers look up or generate at apply time (e.g. the sample
+// config or the connection info endpoints) are rendered from params as they are.
+func RenderAll(templates fs.FS, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) ([]unstructured.Unstructured, error) {
+	templatePaths, err := renderedTemplates(templates, dsp, params)
+	if err != nil {
+		return nil, err
+	}
+	// The endpoints are only resolved once the components are deployed
+	if params.ConnectionInfo == nil {
+		withConnectionInfo := *params
+		withConnectionInfo.ConnectionInfo = &ConnectionInfo{}
+		params = &withConnectionInfo
+	}
+
+	var resources []unstructured.Unstructured
+	render := func(template string, params *DSPAParams) error {
+		rendered, err := config.Render(templates, template, params)
+		if err != nil {
+			return fmt.Errorf("error loading template (%s) yaml: %w", template, err)
+		}
+		manifest, err := mf.ManifestFrom(mf.Slice(rendered))
+		if err != nil {
+			return err
+		}
+		if template != commonCusterRolebindingTemplate && template != multiTenancyClusterRoleTemplate {
+			manifest, err = manifest.Transform(
+				util.AddLabelTransformer(config.DSPVersionk8sLabel, params.DSPVersion),
+				util.AddDeploymentPodLabelTransformer(config.DSPVersionk8sLabel, params.DSPVersion),
+				resourceLabelsTransformer(dsp, template),
+				nodeArchitectureTransformer(params.imageArchitectures),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		resources = append(resources, manifest.Resources()...)
+		return nil
+	}
+	for _, template := range templatePaths {
+		if err := render(template, params); err != nil {
+			return nil, err
+		}
+	}
+
+	// The resources of the tenant namespaces, see ReconcileMultiTenancy
+	if params.MultiTenancy != nil {
+		tenantTemplates, err := tenantTemplates(templates, dsp, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range params.MultiTenancy.Namespaces {
+			for _, template := range tenantTemplates {
+				if err := render(template, params.tenantParams(namespace)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// renderedTemplates selects the templates of the components enabled for dsp,
+// following the decisions of their Reconcile functions.
+func renderedTemplates(templatesFS fs.FS, dsp *dspav1.DataSciencePipelinesApplication, params *DSPAParams) ([]string, error) {
+	var templates []string
+	addDir := func(directory string) error {
+		dirTemplates, err := util.GetTemplatesInDir(templatesFS, directory)
+		templates = append(templates, dirTemplates...)
+		return err
+	}
+
+	if params.CertManagerIssuer != nil {
+		templates = append(templates, certificatesTemplate)
+	}
+
+	// Database
+	databaseSpecified := dsp.Spec.Database != nil
+	mariaDBSpecified := databaseSpecified && dsp.Spec.Database.MariaDB != nil
+	if params.UsingExternalDB(dsp) {
+		if params.DBConnection.ExternalSecret != nil {
+			templates = append(templates, dbExternalSecret)
+		}
+	} else if !mariaDBSpecified || dsp.Spec.Database.MariaDB.Deploy {
+		if !mariaDBSpecified || dsp.Spec.Database.MariaDB.PasswordSecret == nil {
+			templates = append(templates, dbSecret)
+		}
+		templates = append(templates, mariaDBTemplates(params)...)
+	}
+
+	// Object Storage
+	storageSpecified := dsp.Spec.ObjectStorage != nil
+	minioSpecified := !storageSpecified || dsp.Spec.ObjectStorage.Minio != nil
+	if !params.UsingExternalStorage(dsp) && (!storageSpecified || (minioSpecified && dsp.Spec.ObjectStorage.Minio.Deploy)) {
+		if !storageSpecified || dsp.Spec.ObjectStorage.Minio.S3CredentialSecret == nil {
+			templates = append(templates, storageSecret)
+		}
+		for _, template := range deployedMinioTemplates(params) {
+			if (storageSpecified && dsp.Spec.ObjectStorage.EnableExternalRoute) || template != storageRoute {
+				templates = append(templates, template)
+			}
+		}
+	}
+
+	// Common
+	if err := addDir(commonTemplatesDir); err != nil {
+		return nil, err
+	}
+	templates = append(templates, commonCusterRolebindingTemplate)
+	if params.ServiceMesh != nil {
+		if err := addDir(commonServiceMeshTemplatesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// API Server
+	if dsp.Spec.APIServer != nil && dsp.Spec.APIServer.Deploy {
+		if err := addDir(apiServerTemplatesDir); err != nil {
+			return nil, err
+		}
+		if dsp.Spec.APIServer.EnableRoute && params.ServiceMesh == nil {
+			templates = append(templates, serverRoute)
+		}
+		if params.ServiceMesh != nil {
+			if err := addDir(apiServerServiceMeshTemplatesDir); err != nil {
+				return nil, err
+			}
+			if dsp.Spec.APIServer.EnableRoute {
+				templates = append(templates, apiServerVirtualService)
+			}
+		}
+		if len(params.APIServerAccessGroups) > 0 {
+			if err := addDir(apiServerGroupAccessTemplatesDir); err != nil {
+				return nil, err
+			}
+		}
+		if params.CacheEnabled && params.APIServer.CacheCleanup != nil && params.APIServer.CacheCleanup.Enabled {
+			if err := addDir(apiServerCacheCleanupTemplatesDir); err != nil {
+				return nil, err
+			}
+		}
+		if params.APIServer.RunRetention != nil && params.APIServer.RunRetention.Enabled {
+			if err := addDir(apiServerRunRetentionTemplatesDir); err != nil {
+				return nil, err
+			}
+		}
+		if params.APIServer.AuditLog != nil && params.APIServer.AuditLog.Enabled {
+			if err := addDir(apiServerAuditLogTemplatesDir); err != nil {
+				return nil, err
+			}
+		}
+		if params.DefaultWorkspace != nil {
+			templates = append(templates, defaultWorkspacePVCTemplate)
+		}
+		var sampleTemplates []string
+		for _, template := range samplePipelineTemplates {
+			sampleTemplates = append(sampleTemplates, template)
+		}
+		sort.Strings(sampleTemplates)
+		templates = append(templates, sampleTemplates...)
+	}
+
+	if dsp.Spec.PersistenceAgent != nil && dsp.Spec.PersistenceAgent.Deploy {
+		if err := addDir(persistenceAgentTemplatesDir); err != nil {
+			return nil, err
+		}
+	}
+	if dsp.Spec.ScheduledWorkflow != nil && dsp.Spec.ScheduledWorkflow.Deploy {
+		if err := addDir(scheduledWorkflowTemplatesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// MlPipelineUI
+	if dsp.Spec.MlPipelineUI != nil && dsp.Spec.MlPipelineUI.Deploy {
+		if err := addDir(mlPipelineUITemplatesDir); err != nil {
+			return nil, err
+		}
+		if params.ServiceMesh != nil {
+			if err := addDir(mlPipelineUIServiceMeshTemplatesDir); err != nil {
+				return nil, err
+			}
+		} else {
+			templates = append(templates, mlPipelineUIRoute)
+		}
+	}
+
+	if dsp.Spec.VisualizationServer != nil && dsp.Spec.VisualizationServer.Deploy {
+		if err := addDir(visualizationServerTemplatesDir); err != nil {
+			return nil, err
+		}
+		if dsp.Spec.VisualizationServer.EnableRoute {
+			templates = append(templates, visualizationServerRoute)
+		}
+	}
+	if dsp.Spec.CRDViewer != nil && dsp.Spec.CRDViewer.Deploy {
+		if err := addDir(crdViewerTemplatesDir); err != nil {
+			return nil, err
+		}
+	}
+	if params.DatabaseBackup != nil {
+		if err := addDir(databaseBackupTemplatesDir); err != nil {
+			return nil, err
+		}
+		if params.databaseBackupVerified() {
+			if err := addDir(databaseBackupVerificationTemplatesDir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if params.WorkflowController != nil && params.WorkflowController.Deploy {
+		if err := addDir(workflowControllerTemplatesDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// MLMD
+	if (params.MLMD != nil && params.MLMD.Deploy) || (dsp.Spec.MLMD != nil && dsp.Spec.MLMD.Deploy) {
+		envoyTemplates, grpcTemplates, err := mlmdComponentTemplates(templatesFS)
+		if err != nil {
+			return nil, err
+		}
+		if params.MLMDGRPCDeployed() {
+			if err := addDir(mlmdTemplatesDir + "/" + mlmdGrpcService); err != nil {
+				return nil, err
+			}
+		}
+		if params.MLMDEnvoyDeployed() {
+			templates = append(templates, envoyTemplates...)
+		}
+		if params.MLMDGRPCDeployed() {
+			templates = append(templates, grpcTemplates...)
+		}
+		if params.MLMDEnvoyDeployed() && (dsp.Spec.MLMD == nil || dsp.Spec.MLMD.Envoy == nil || dsp.Spec.MLMD.Envoy.DeployRoute) {
+			templates = append(templates, mlmdEnvoyRoute)
+		}
+		if params.MLMD != nil && params.MLMD.GRPC != nil && params.MLMD.GRPC.EnableRoute {
+			templates = append(templates, mlmdGRPCRoute)
+		}
+	}
+
+	if params.WorkflowControllerClusterScoped() {
+		templates = append(templates, multiTenancyClusterRoleTemplate)
+	}
+	if params.MultiTenancy != nil {
+		templates = append(templates, multiTenancyRoleBindingTemplate)
+	}
+
+	templates = append(templates, monitoringTemplates(params)...)
+	templates = append(templates, connectionInfoTemplate)
+	return templates, nil
+}
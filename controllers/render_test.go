@@ -0,0 +1,167 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	manifests "github.com/opendatahub-io/data-science-pipelines-operator/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Regenerate the golden files with:
+// go test -tags=test_unit ./controllers/ -run TestRenderAllGolden -update-golden
+var updateGolden = flag.Bool("update-golden", false, "update the golden files of the rendered manifests")
+
+const goldenDir = "testdata/golden"
+
+func TestRenderAllGolden(t *testing.T) {
+	allComponents := func() *dspav1.DataSciencePipelinesApplication {
+		dspa := testutil.CreateDefaultDSPA()
+		dspa.Spec.APIServer.EnableRoute = true
+		dspa.Spec.APIServer.EnableSamplePipeline = true
+		dspa.Spec.PersistenceAgent = &dspav1.PersistenceAgent{Deploy: true}
+		dspa.Spec.ScheduledWorkflow = &dspav1.ScheduledWorkflow{Deploy: true}
+		dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{Deploy: true, Image: "quay.io/opendatahub/ds-pipelines-frontend:latest"}
+		dspa.Spec.WorkflowController = &dspav1.WorkflowController{Deploy: true}
+		dspa.Spec.ObjectStorage.Minio.Deploy = true
+		return dspa
+	}
+
+	tests := map[string]*dspav1.DataSciencePipelinesApplication{
+		"minimal":        testutil.CreateDefaultDSPA(),
+		"all-components": allComponents(),
+		"service-mesh":   serviceMeshTestDSPA(),
+	}
+	for name, dspa := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, params, reconciler := CreateNewTestObjects()
+			require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+			// Generated credentials differ on every run
+			params.DBConnection.Password = NewCredential([]byte("generated-password"))
+			params.ObjectStorageConnection.AccessKeyID = NewCredential([]byte("generated-access-key"))
+			params.ObjectStorageConnection.SecretAccessKey = NewCredential([]byte("generated-secret-key"))
+
+			resources, err := RenderAll(reconciler.Templates, dspa, params)
+			require.Nil(t, err)
+			var rendered bytes.Buffer
+			for _, resource := range resources {
+				out, err := yaml.Marshal(resource.Object)
+				require.Nil(t, err)
+				rendered.WriteString("---\n")
+				rendered.Write(out)
+			}
+
+			goldenPath := filepath.Join(goldenDir, name+".yaml")
+			if *updateGolden {
+				require.Nil(t, os.MkdirAll(goldenDir, 0755))
+				require.Nil(t, os.WriteFile(goldenPath, rendered.Bytes(), 0644))
+			}
+			golden, err := os.ReadFile(goldenPath)
+			require.Nil(t, err, "missing golden file, run the test with -update-golden")
+			assert.Equal(t, string(golden), rendered.String(), "rendered manifests differ from %s, run the test with -update-golden and review the diff", goldenPath)
+		})
+	}
+}
+
+func TestRenderAllComponents(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+
+	resources, err := RenderAll(reconciler.Templates, dspa, params)
+	require.Nil(t, err)
+	rendered := map[string]bool{}
+	for _, resource := range resources {
+		rendered[resource.GetKind()+"/"+resource.GetName()] = true
+	}
+
+	// Assert only the enabled components are rendered
+	assert.True(t, rendered["Deployment/ds-pipeline-testdspa"])
+	assert.True(t, rendered["Deployment/mariadb-testdspa"])
+	assert.True(t, rendered["ConfigMap/ds-pipeline-connection-testdspa"])
+	assert.False(t, rendered["Deployment/minio-testdspa"])
+	assert.False(t, rendered["Route/ds-pipeline-testdspa"])
+	assert.False(t, rendered["Deployment/ds-pipeline-ui-testdspa"])
+
+	// Assert the rendering does not reach the cluster
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, "ds-pipeline-testdspa", dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestTemplateFields(t *testing.T) {
+	templates := manifests.Templates()
+	err := fs.WalkDir(templates, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		assert.Nil(t, config.ValidateTemplateFields(templates, path, reflect.TypeOf(&DSPAParams{})))
+		return nil
+	})
+	require.Nil(t, err)
+
+	// Assert mistyped fields are reported, including in branches not taken
+	mistyped := fstest.MapFS{"mistyped.yaml.tmpl": {Data: []byte(
+		"{{ if .APIServer }}{{ .APIServer.Deployy }}{{ end }}\n" +
+			"{{ range .ImagePullSecrets }}{{ .Nam }}{{ end }}\n" +
+			"{{ with .ServiceMesh }}{{ $.Namespac }}{{ end }}\n")}}
+	err = config.ValidateTemplateFields(mistyped, "mistyped.yaml.tmpl", reflect.TypeOf(&DSPAParams{}))
+	assert.ErrorContains(t, err, "can't evaluate field Deployy")
+	assert.ErrorContains(t, err, "can't evaluate field Nam")
+	assert.ErrorContains(t, err, "can't evaluate field Namespac")
+}
+
+func TestCachedTemplates(t *testing.T) {
+	templates := fstest.MapFS{"configmap.yaml.tmpl": {Data: []byte(
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first-{{ .Name }}\n")}}
+	cached := config.CachedTemplates(templates)
+	params := &DSPAParams{Name: "testdspa"}
+
+	rendered, err := config.Render(cached, "configmap.yaml.tmpl", params)
+	require.Nil(t, err)
+	assert.Equal(t, "first-testdspa", rendered[0].GetName())
+
+	// Assert the cached templates are not parsed again, unlike the uncached ones
+	templates["configmap.yaml.tmpl"].Data = []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second-{{ .Name }}\n")
+	rendered, err = config.Render(cached, "configmap.yaml.tmpl", params)
+	require.Nil(t, err)
+	assert.Equal(t, "first-testdspa", rendered[0].GetName())
+	rendered, err = config.Render(templates, "configmap.yaml.tmpl", params)
+	require.Nil(t, err)
+	assert.Equal(t, "second-testdspa", rendered[0].GetName())
+
+	// Assert the cached templates list their directories
+	dirTemplates, err := util.GetTemplatesInDir(cached, ".")
+	require.Nil(t, err)
+	assert.Equal(t, []string{"configmap.yaml.tmpl"}, dirTemplates)
+}
@@ -0,0 +1,167 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const apiServerRolloutPreviousImageAnnotation = "opendatahub.io/rollout-previous-image"
+
+// apiServerRollout is the staged rollout of the default API server image, as
+// configured in the DSPO config.
+type apiServerRollout struct {
+	canarySelector labels.Selector
+	canaryPercent  uint32
+	paused         bool
+	aborted        bool
+}
+
+func apiServerRolloutFromConfig() (*apiServerRollout, error) {
+	rollout := &apiServerRollout{
+		canaryPercent: uint32(config.GetIntConfigWithDefault(config.APIServerRolloutCanaryPercentConfigName, config.DefaultAPIServerRolloutCanaryPercentage)),
+		paused:        config.GetBoolConfigWithDefault(config.APIServerRolloutPausedConfigName, false),
+		aborted:       config.GetBoolConfigWithDefault(config.APIServerRolloutAbortedConfigName, false),
+	}
+	if selector := config.GetStringConfigWithDefault(config.APIServerRolloutCanarySelectorConfigName, ""); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API server image rollout canary selector %q: %w", selector, err)
+		}
+		rollout.canarySelector = parsed
+	}
+	return rollout, nil
+}
+
+// isCanary returns true if dspa is in the first wave of the rollout, either
+// matching the canary selector or, without one, hashed into the canary share.
+func (r *apiServerRollout) isCanary(dspa *dspav1.DataSciencePipelinesApplication) bool {
+	if r.canarySelector != nil {
+		return r.canarySelector.Matches(labels.Set(dspa.Labels))
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(dspa.Namespace + "/" + dspa.Name))
+	return hash.Sum32()%100 < r.canaryPercent
+}
+
+// SetupAPIServerRollout stages updates of the default API server image, so
+// that a change to the DSPO config does not roll every DSPA at once. Canary
+// DSPAs are updated first, the others keep their current image until every
+// canary runs the new image with a ready API server. Pausing the rollout
+// holds all DSPAs on their current image, aborting it also reverts updated
+// DSPAs to their previous image. API servers with an image set in the DSPA,
+// or not yet deployed, are not part of the rollout.
+func (p *DSPAParams) SetupAPIServerRollout(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	client client.Client, log logr.Logger) error {
+	p.APIServerRolloutPreviousImage = ""
+	p.APIServerImageHeld = false
+	enabled := config.GetBoolConfigWithDefault(config.APIServerRolloutEnabledConfigName, config.DefaultAPIServerRolloutEnabled)
+	if !enabled || p.APIServer == nil || !p.APIServer.Deploy || dsp.Spec.APIServer.Image != "" {
+		return nil
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := client.Get(ctx, types.NamespacedName{Name: p.APIServerDefaultResourceName, Namespace: dsp.Namespace}, deployment)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	current := ""
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "ds-pipeline-api-server" {
+			current = container.Image
+		}
+	}
+	previous := deployment.Annotations[apiServerRolloutPreviousImageAnnotation]
+
+	rollout, err := apiServerRolloutFromConfig()
+	if err != nil {
+		return err
+	}
+	target := p.APIServer.Image
+	hold := func(image, reason string) {
+		log.Info(fmt.Sprintf("Holding API server on image %s rather than %s, %s.", image, target, reason))
+		p.APIServer.Image = image
+		p.APIServerImageHeld = true
+		if p.defaultImages != nil {
+			p.defaultImages[image] = true
+		}
+		if arch, ok := p.imageArchitectures[target]; ok {
+			p.imageArchitectures[image] = arch
+		}
+	}
+
+	switch {
+	case current == "":
+		return nil
+	case current == target:
+		if rollout.aborted && previous != "" {
+			hold(previous, "the image rollout was aborted")
+		} else {
+			p.APIServerRolloutPreviousImage = previous
+		}
+	case rollout.aborted:
+		hold(current, "the image rollout was aborted")
+	case rollout.paused:
+		hold(current, "the image rollout is paused")
+	case rollout.isCanary(dsp):
+		p.APIServerRolloutPreviousImage = current
+	default:
+		verified, err := rollout.canariesVerified(ctx, client, target)
+		if err != nil {
+			return err
+		} else if !verified {
+			hold(current, "waiting for the canary DSPAs to become ready on the new image")
+		} else {
+			p.APIServerRolloutPreviousImage = current
+		}
+	}
+	return nil
+}
+
+// canariesVerified returns true once every canary DSPA that takes part in the
+// rollout runs image with a ready API server.
+func (r *apiServerRollout) canariesVerified(ctx context.Context, client client.Client, image string) (bool, error) {
+	dspas := &dspav1.DataSciencePipelinesApplicationList{}
+	if err := client.List(ctx, dspas); err != nil {
+		return false, err
+	}
+	for i := range dspas.Items {
+		dspa := &dspas.Items[i]
+		if !r.isCanary(dspa) || dspa.Spec.APIServer == nil || !dspa.Spec.APIServer.Deploy || dspa.Spec.APIServer.Image != "" {
+			continue
+		}
+		ready := util.GetConditionByType(config.APIServerReady, dspa.Status.Conditions)
+		if dspa.Status.Components.APIServer.Image != image || ready.Status != metav1.ConditionTrue {
+			return false, nil
+		}
+	}
+	return true, nil
+}
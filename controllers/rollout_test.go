@@ -0,0 +1,121 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	rolloutOldImage = "quay.io/opendatahub/ds-pipelines-api-server:v1"
+	rolloutNewImage = "quay.io/opendatahub/ds-pipelines-api-server:v2"
+)
+
+func rolloutTestDeployment(dspa *dspav1.DataSciencePipelinesApplication, image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiServerDefaultResourceNamePrefix + dspa.Name,
+			Namespace: dspa.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "ds-pipeline-api-server", Image: image}},
+				},
+			},
+		},
+	}
+}
+
+func TestSetupAPIServerRollout(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	viper.Set(config.APIServerImagePath, rolloutNewImage)
+	viper.Set(config.APIServerRolloutEnabledConfigName, true)
+	viper.Set(config.APIServerRolloutCanarySelectorConfigName, "opendatahub.io/canary=true")
+	defer viper.Reset()
+
+	canary := testutil.CreateDefaultDSPA()
+	canary.Name = "canary"
+	canary.Labels = map[string]string{"opendatahub.io/canary": "true"}
+	other := testutil.CreateDefaultDSPA()
+	other.Name = "other"
+	require.Nil(t, reconciler.Create(ctx, canary))
+	require.Nil(t, reconciler.Create(ctx, other))
+	require.Nil(t, reconciler.Create(ctx, rolloutTestDeployment(canary, rolloutOldImage)))
+	require.Nil(t, reconciler.Create(ctx, rolloutTestDeployment(other, rolloutOldImage)))
+
+	// Assert canaries are updated first, recording the image to revert to
+	params := &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, canary, reconciler.Client, reconciler.Log))
+	assert.Equal(t, rolloutNewImage, params.APIServer.Image)
+	assert.Equal(t, rolloutOldImage, params.APIServerRolloutPreviousImage)
+	assert.False(t, params.APIServerImageHeld)
+
+	// Assert other DSPAs are held until the canaries are verified
+	params = &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, other, reconciler.Client, reconciler.Log))
+	assert.Equal(t, rolloutOldImage, params.APIServer.Image)
+	assert.True(t, params.APIServerImageHeld)
+	assert.Equal(t, config.ImageSourceOperatorConfig, params.ComponentImages["apiServer"].ImageSource)
+
+	canary.Status.Components.APIServer.Image = rolloutNewImage
+	canary.Status.Conditions = []metav1.Condition{{Type: config.APIServerReady, Status: metav1.ConditionTrue}}
+	require.Nil(t, reconciler.Update(ctx, canary))
+
+	params = &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, other, reconciler.Client, reconciler.Log))
+	assert.Equal(t, rolloutNewImage, params.APIServer.Image)
+	assert.False(t, params.APIServerImageHeld)
+
+	// Assert a paused rollout holds DSPAs on their current image
+	viper.Set(config.APIServerRolloutPausedConfigName, true)
+	params = &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, other, reconciler.Client, reconciler.Log))
+	assert.Equal(t, rolloutOldImage, params.APIServer.Image)
+
+	// Assert an aborted rollout reverts updated DSPAs to their previous image
+	viper.Set(config.APIServerRolloutAbortedConfigName, true)
+	updated := rolloutTestDeployment(canary, rolloutNewImage)
+	require.Nil(t, reconciler.Get(ctx, client.ObjectKeyFromObject(updated), updated))
+	updated.Spec.Template.Spec.Containers[0].Image = rolloutNewImage
+	updated.Annotations = map[string]string{apiServerRolloutPreviousImageAnnotation: rolloutOldImage}
+	require.Nil(t, reconciler.Update(ctx, updated))
+
+	params = &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, canary, reconciler.Client, reconciler.Log))
+	assert.Equal(t, rolloutOldImage, params.APIServer.Image)
+	assert.Empty(t, params.APIServerRolloutPreviousImage)
+
+	// Assert images set in the DSPA are not part of the rollout
+	other.Spec.APIServer.Image = "quay.io/example/api-server:custom"
+	params = &DSPAParams{}
+	require.Nil(t, params.ExtractParams(ctx, other, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "quay.io/example/api-server:custom", params.APIServer.Image)
+	assert.False(t, params.APIServerImageHeld)
+}
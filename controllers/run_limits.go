@@ -0,0 +1,75 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var workflowListGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "WorkflowList"}
+
+// GetRunUsage counts the running and pending Workflows in the DSPA namespace,
+// all of which are executed by the namespaced DSPA workflow controller.
+func (r *DSPAReconciler) GetRunUsage(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication) (*dspav1.RunUsage, error) {
+	workflows := &unstructured.UnstructuredList{}
+	workflows.SetGroupVersionKind(workflowListGVK)
+	err := r.List(ctx, workflows, client.InNamespace(dsp.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &dspav1.RunUsage{}
+	for _, workflow := range workflows.Items {
+		phase, _, _ := unstructured.NestedString(workflow.Object, "status", "phase")
+		switch phase {
+		case "Running":
+			usage.Running++
+		case "", "Pending":
+			usage.Pending++
+		}
+	}
+	return usage, nil
+}
+
+// ReconcileRunLimits reports the usage of the run limits in the DSPA status,
+// and warns when the pending runs reach spec.limits.maxPendingRuns.
+func (r *DSPAReconciler) ReconcileRunLimits(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) error {
+	if params.Limits == nil {
+		return nil
+	}
+
+	usage, err := r.GetRunUsage(ctx, dsp)
+	if err != nil {
+		return err
+	}
+	dspaStatus.SetRunUsage(usage)
+
+	if params.Limits.MaxPendingRuns > 0 && usage.Pending >= params.Limits.MaxPendingRuns {
+		r.recordEvent(dsp, corev1.EventTypeWarning, config.RunLimitExceeded,
+			fmt.Sprintf("%d runs are pending, the limit of %d pending runs is reached", usage.Pending, params.Limits.MaxPendingRuns))
+	}
+	return nil
+}
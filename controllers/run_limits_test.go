@@ -0,0 +1,72 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestReconcileRunLimits(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.WorkflowController = &dspav1.WorkflowController{Deploy: true}
+	dspa.Spec.Limits = &dspav1.RunLimits{MaxConcurrentRuns: 2, MaxPendingRuns: 1}
+	expectedWorkflowControllerName := "ds-pipeline-workflow-controller-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Assert the concurrent runs are capped by the workflow controller parallelism
+	require.Nil(t, reconciler.ReconcileWorkflowController(dspa, params))
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedWorkflowControllerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "2", configMap.Data["parallelism"])
+
+	// Assert the running and pending runs are reported
+	for i, phase := range []string{"Running", "Pending", "", "Succeeded"} {
+		workflow := &unstructured.Unstructured{}
+		workflow.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"})
+		workflow.SetName(fmt.Sprintf("run-%d", i))
+		workflow.SetNamespace(dspa.Namespace)
+		if phase != "" {
+			require.Nil(t, unstructured.SetNestedField(workflow.Object, phase, "status", "phase"))
+		}
+		require.Nil(t, reconciler.Create(ctx, workflow))
+	}
+	status := dspastatus.NewDSPAStatus(dspa)
+	require.Nil(t, reconciler.ReconcileRunLimits(ctx, dspa, params, status))
+	assert.Equal(t, &dspav1.RunUsage{Running: 1, Pending: 2}, status.GetRunUsage())
+
+	// Assert a custom workflow controller config is not silently left without the parallelism
+	dspa.Spec.WorkflowController.CustomConfig = "custom-config"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.limits.maxConcurrentRuns")
+}
@@ -0,0 +1,96 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// runRetentionContainerName is the container of the run retention job, whose
+// termination message holds the counts of the runs and experiments pruned.
+const runRetentionContainerName = "run-retention"
+
+// runRetentionPruned is the termination message of the run retention job, the
+// counts of the runs and experiments pruned by resource and action.
+type runRetentionPruned map[string]map[string]int
+
+// recordRunRetentionMetrics exports the counts of the runs and experiments
+// pruned by the latest succeeded Job of the run retention CronJob of dsp.
+// The metrics of dsp are removed once the run retention is disabled.
+func (r *DSPAReconciler) recordRunRetentionMetrics(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+	metricLabels := prometheus.Labels{"dspa_name": dsp.Name, "dspa_namespace": dsp.Namespace}
+	if params.APIServer.RunRetention == nil || !params.APIServer.RunRetention.Enabled {
+		RunRetentionPrunedMetric.DeletePartialMatch(metricLabels)
+		RunRetentionLastSuccessMetric.DeletePartialMatch(metricLabels)
+		return nil
+	}
+
+	jobs := &batchv1.JobList{}
+	err := r.List(ctx, jobs, client.InNamespace(dsp.Namespace),
+		client.MatchingLabels{"app": params.RunRetentionDefaultResourceName, "dspa": dsp.Name})
+	if err != nil {
+		return err
+	}
+	var latest *batchv1.Job
+	var finishedAt time.Time
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		at, finished := jobFinishedAt(job)
+		if finished && isJobSucceeded(job) && (latest == nil || at.After(finishedAt)) {
+			latest, finishedAt = job, at
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(dsp.Namespace), client.MatchingLabels{"job-name": latest.Name}); err != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != runRetentionContainerName || status.State.Terminated == nil || status.State.Terminated.ExitCode != 0 {
+				continue
+			}
+			pruned := runRetentionPruned{}
+			if err := json.Unmarshal([]byte(status.State.Terminated.Message), &pruned); err != nil {
+				log.Info(fmt.Sprintf("Unable to parse the termination message of run retention pod %s: %v", pod.Name, err))
+				return nil
+			}
+			for resource, actions := range pruned {
+				for action, count := range actions {
+					RunRetentionPrunedMetric.With(prometheus.Labels{"dspa_name": dsp.Name, "dspa_namespace": dsp.Namespace,
+						"resource": resource, "action": action}).Set(float64(count))
+				}
+			}
+			RunRetentionLastSuccessMetric.With(metricLabels).Set(float64(finishedAt.Unix()))
+			return nil
+		}
+	}
+	return nil
+}
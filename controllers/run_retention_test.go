@@ -0,0 +1,124 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunRetentionArchivePolicy(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.APIServer.RunRetention = &dspav1.RunRetention{
+		Enabled:          true,
+		CompletedRunTTL:  &metav1.Duration{Duration: 30 * 24 * time.Hour},
+		ArchivedRunTTL:   &metav1.Duration{Duration: 90 * 24 * time.Hour},
+		PruneExperiments: true,
+	}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+
+	// Assert the archive policy is passed to the run retention job
+	cronJob := &batchv1.CronJob{}
+	created, err := reconciler.IsResourceCreated(ctx, cronJob, params.RunRetentionDefaultResourceName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	env := map[string]string{}
+	for _, envVar := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "2592000", env["COMPLETED_RUN_TTL_SECONDS"])
+	assert.Equal(t, "7776000", env["ARCHIVED_RUN_TTL_SECONDS"])
+	assert.Equal(t, "true", env["PRUNE_EXPERIMENTS"])
+
+	// Assert archived runs cannot be deleted before they are archived
+	dspa.Spec.APIServer.RunRetention.ArchivedRunTTL = &metav1.Duration{Duration: 24 * time.Hour}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.apiServer.runRetention.archivedRunTTL] must be longer than completedRunTTL")
+	dspa.Spec.APIServer.RunRetention.Action = "Delete"
+	assert.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+
+	// Assert experiments are only pruned past the completed run TTL
+	dspa.Spec.APIServer.RunRetention = &dspav1.RunRetention{Enabled: true, MaxRunsPerExperiment: 10, PruneExperiments: true}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.apiServer.runRetention.pruneExperiments] requires completedRunTTL")
+}
+
+func TestRunRetentionMetrics(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.APIServer.RunRetention = &dspav1.RunRetention{Enabled: true, CompletedRunTTL: &metav1.Duration{Duration: time.Hour}}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	pruned := func(resource, action string) float64 {
+		return promtestutil.ToFloat64(RunRetentionPrunedMetric.WithLabelValues(dspa.Name, dspa.Namespace, resource, action))
+	}
+
+	// Assert the counts of the latest succeeded job are exported
+	labels := map[string]string{"app": params.RunRetentionDefaultResourceName, "dspa": dspa.Name}
+	finishedAt := metav1.NewTime(time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC))
+	for _, job := range []struct {
+		name       string
+		finishedAt metav1.Time
+		message    string
+	}{
+		{"run-retention-1", metav1.NewTime(finishedAt.Add(-24 * time.Hour)), `{"runs": {"archived": 1, "deleted": 1}}`},
+		{"run-retention-2", finishedAt, `{"runs": {"archived": 7, "deleted": 2}, "experiments": {"archived": 1, "deleted": 0}}`},
+	} {
+		require.Nil(t, reconciler.Create(ctx, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: job.name, Namespace: dspa.Namespace, Labels: labels},
+			Status: batchv1.JobStatus{
+				Succeeded: 1,
+				Conditions: []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: job.finishedAt},
+				},
+			},
+		}))
+		require.Nil(t, reconciler.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: job.name + "-pod", Namespace: dspa.Namespace, Labels: map[string]string{"job-name": job.name}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  runRetentionContainerName,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: job.message}},
+				}},
+			},
+		}))
+	}
+	require.Nil(t, reconciler.recordRunRetentionMetrics(ctx, dspa, params))
+	assert.Equal(t, float64(7), pruned("runs", "archived"))
+	assert.Equal(t, float64(2), pruned("runs", "deleted"))
+	assert.Equal(t, float64(1), pruned("experiments", "archived"))
+	assert.Equal(t, float64(finishedAt.Unix()),
+		promtestutil.ToFloat64(RunRetentionLastSuccessMetric.WithLabelValues(dspa.Name, dspa.Namespace)))
+
+	// Assert the metrics of the DSPA are removed once the run retention is disabled
+	dspa.Spec.APIServer.RunRetention.Enabled = false
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.recordRunRetentionMetrics(ctx, dspa, params))
+	assert.Equal(t, 0, promtestutil.CollectAndCount(RunRetentionPrunedMetric))
+	assert.Equal(t, 0, promtestutil.CollectAndCount(RunRetentionLastSuccessMetric))
+}
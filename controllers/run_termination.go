@@ -0,0 +1,67 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReconcileRunTermination applies spec.apiServer.terminationStrategy to the
+// runs terminated through the API server, which terminates a run by setting
+// the activeDeadlineSeconds of its Workflow to 0. The strategy is set as the
+// Argo shutdown of those Workflows, unless they are completed or already shut
+// down, in the DSPA namespace and the namespaces of its tenants.
+func (r *DSPAReconciler) ReconcileRunTermination(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	if params.APIServer == nil || params.APIServer.TerminationStrategy == "" {
+		return nil
+	}
+
+	namespaces := []string{dsp.Namespace}
+	if params.MultiTenancy != nil {
+		namespaces = append(namespaces, params.MultiTenancy.Namespaces...)
+	}
+	for _, namespace := range namespaces {
+		workflows := &unstructured.UnstructuredList{}
+		workflows.SetGroupVersionKind(workflowListGVK)
+		if err := r.List(ctx, workflows, client.InNamespace(namespace)); err != nil {
+			return err
+		}
+		for i := range workflows.Items {
+			workflow := &workflows.Items[i]
+			phase, _, _ := unstructured.NestedString(workflow.Object, "status", "phase")
+			deadline, found, _ := unstructured.NestedInt64(workflow.Object, "spec", "activeDeadlineSeconds")
+			shutdown, _, _ := unstructured.NestedString(workflow.Object, "spec", "shutdown")
+			if (phase != "" && phase != "Pending" && phase != "Running") || !found || deadline != 0 || shutdown != "" {
+				continue
+			}
+
+			patch := client.MergeFrom(workflow.DeepCopy())
+			if err := unstructured.SetNestedField(workflow.Object, params.APIServer.TerminationStrategy, "spec", "shutdown"); err != nil {
+				return err
+			}
+			if err := r.Patch(ctx, workflow, patch); err != nil {
+				return fmt.Errorf("unable to shut down the terminated run %s/%s: %w", namespace, workflow.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReconcileRunTermination(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+	dspa := testutil.CreateEmptyDSPA()
+	params.APIServer = &dspav1.APIServer{}
+
+	workflows := map[string]*unstructured.Unstructured{}
+	for _, run := range []struct {
+		name     string
+		phase    string
+		deadline int64
+		shutdown string
+	}{
+		{name: "terminated", phase: "Running", deadline: 0},
+		{name: "terminated-pending", phase: "", deadline: 0},
+		{name: "running", phase: "Running", deadline: 3600},
+		{name: "completed", phase: "Failed", deadline: 0},
+		{name: "shut-down", phase: "Running", deadline: 0, shutdown: "Stop"},
+	} {
+		workflow := &unstructured.Unstructured{}
+		workflow.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"})
+		workflow.SetName(run.name)
+		workflow.SetNamespace(dspa.Namespace)
+		require.Nil(t, unstructured.SetNestedField(workflow.Object, run.deadline, "spec", "activeDeadlineSeconds"))
+		if run.phase != "" {
+			require.Nil(t, unstructured.SetNestedField(workflow.Object, run.phase, "status", "phase"))
+		}
+		if run.shutdown != "" {
+			require.Nil(t, unstructured.SetNestedField(workflow.Object, run.shutdown, "spec", "shutdown"))
+		}
+		require.Nil(t, reconciler.Create(ctx, workflow))
+		workflows[run.name] = workflow
+	}
+	shutdown := func(name string) string {
+		workflow := workflows[name]
+		require.Nil(t, reconciler.Get(ctx, client.ObjectKeyFromObject(workflow), workflow))
+		value, _, _ := unstructured.NestedString(workflow.Object, "spec", "shutdown")
+		return value
+	}
+
+	// Assert the terminated runs are left to the API server without a strategy
+	require.Nil(t, reconciler.ReconcileRunTermination(ctx, dspa, params))
+	assert.Empty(t, shutdown("terminated"))
+
+	// Assert only the terminated runs still in progress are shut down with the strategy
+	params.APIServer.TerminationStrategy = "Terminate"
+	require.Nil(t, reconciler.ReconcileRunTermination(ctx, dspa, params))
+	assert.Equal(t, "Terminate", shutdown("terminated"))
+	assert.Equal(t, "Terminate", shutdown("terminated-pending"))
+	assert.Empty(t, shutdown("running"))
+	assert.Empty(t, shutdown("completed"))
+	assert.Equal(t, "Stop", shutdown("shut-down"))
+}
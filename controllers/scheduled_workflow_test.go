@@ -24,6 +24,7 @@ import (
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestDeployScheduledWorkflow(t *testing.T) {
@@ -121,3 +122,101 @@ func TestDontDeployScheduledWorkflow(t *testing.T) {
 	assert.False(t, created)
 	assert.Nil(t, err)
 }
+
+func TestDeployScheduledWorkflowCatchUp(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedScheduledWorkflowName := scheduledWorkflowDefaultResourceNamePrefix + testDSPAName
+
+	// Construct DSPASpec with ScheduledWorkflow that limits concurrency and does not catch up
+	catchUp := false
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			ScheduledWorkflow: &dspav1.ScheduledWorkflow{
+				Deploy:         true,
+				MaxConcurrency: 3,
+				CatchUp:        &catchUp,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.Nil(t, err)
+
+	err = reconciler.ReconcileScheduledWorkflow(dspa, params)
+	assert.Nil(t, err)
+
+	// Ensure the settings are passed to the ScheduledWorkflow controller
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedScheduledWorkflowName, testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	assert.Contains(t, env, corev1.EnvVar{Name: "DEFAULT_MAX_CONCURRENCY", Value: "3"})
+	assert.Contains(t, env, corev1.EnvVar{Name: "DEFAULT_CATCHUP", Value: "false"})
+}
+
+func TestScheduledWorkflowCronScheduleTimezone(t *testing.T) {
+	tests := map[string]struct {
+		timezone string
+		valid    bool
+	}{
+		"UTC":              {timezone: "UTC", valid: true},
+		"Region":           {timezone: "America/New_York", valid: true},
+		"Unknown region":   {timezone: "Mars/Olympus_Mons", valid: false},
+		"Offset":           {timezone: "UTC+2", valid: false},
+		"Controller local": {timezone: "Local", valid: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dspa := &dspav1.DataSciencePipelinesApplication{
+				Spec: dspav1.DSPASpec{
+					ScheduledWorkflow: &dspav1.ScheduledWorkflow{
+						Deploy:               true,
+						CronScheduleTimezone: test.timezone,
+					},
+					Database: &dspav1.Database{
+						MariaDB: &dspav1.MariaDB{
+							Deploy: true,
+						},
+					},
+					ObjectStorage: &dspav1.ObjectStorage{
+						Minio: &dspav1.Minio{
+							Deploy: false,
+							Image:  "someimage",
+						},
+					},
+				},
+			}
+			dspa.Namespace = "testnamespace"
+			dspa.Name = "testdspa"
+
+			ctx, params, reconciler := CreateNewTestObjects()
+			err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+			if test.valid {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidTimezone)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ReconcileSecretPropagation copies the object storage credentials Secret of
+// dsp into the namespaces of spec.objectStorage.secretPropagation, and deletes
+// the copies from the namespaces no longer listed. Owner references cannot
+// cross namespaces, the copies are labelled with the UID of dsp instead, and
+// deleted along with it.
+func (r *DSPAReconciler) ReconcileSecretPropagation(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	var desired []string
+	source := &corev1.Secret{}
+	if len(params.ObjectStorageConnection.PropagatedNamespaces) > 0 {
+		sourceName := params.ObjectStorageConnection.CredentialsSecret.SecretName
+		err := r.Get(ctx, types.NamespacedName{Name: sourceName, Namespace: dsp.Namespace}, source)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve the object storage credentials Secret %s to propagate: %w", sourceName, err)
+		}
+	}
+	for _, namespace := range params.ObjectStorageConnection.PropagatedNamespaces {
+		inScope, err := util.NamespaceInScope(ctx, namespace, r.Client)
+		if err != nil || !inScope {
+			log.Info(fmt.Sprintf("Namespace %s is not in the reconciliation scope of this operator, "+
+				"the object storage credentials are not propagated to it.", namespace))
+			continue
+		}
+		if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &corev1.Namespace{}); apierrs.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Namespace %s does not exist, the object storage credentials are not propagated to it.", namespace))
+			continue
+		} else if err != nil {
+			return err
+		}
+		propagated, err := r.propagateSecret(ctx, dsp, source, namespace)
+		if err != nil {
+			return err
+		} else if propagated {
+			desired = append(desired, namespace)
+		}
+	}
+
+	// Delete the copies in namespaces no longer listed, or of a previous credentials Secret
+	propagated := &corev1.SecretList{}
+	err := r.List(ctx, propagated, client.MatchingLabels{config.PropagatedFromLabel: string(dsp.UID)})
+	if err != nil {
+		return err
+	}
+	for i := range propagated.Items {
+		secret := &propagated.Items[i]
+		if slices.Contains(desired, secret.Namespace) && secret.Name == source.Name {
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleting the object storage credentials propagated to namespace %s.", secret.Namespace))
+		if err := client.IgnoreNotFound(r.Delete(ctx, secret)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// propagateSecret creates or updates the copy of source in namespace, and
+// reports whether it did. Secrets of the same name that are not copies of the
+// credentials of dsp are kept.
+func (r *DSPAReconciler) propagateSecret(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	source *corev1.Secret, namespace string) (bool, error) {
+	secret := &corev1.Secret{}
+	secret.Name, secret.Namespace = source.Name, namespace
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if err == nil && secret.Labels[config.PropagatedFromLabel] != string(dsp.UID) {
+		r.recordEvent(dsp, corev1.EventTypeWarning, config.SecretPropagationConflict,
+			fmt.Sprintf("Namespace %s already has a Secret %s not propagated from this DSPA, "+
+				"the object storage credentials are not propagated to it.", namespace, secret.Name))
+		return false, nil
+	} else if err != nil && !apierrs.IsNotFound(err) {
+		return false, err
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Labels["component"] = "data-science-pipelines"
+		secret.Labels[config.PropagatedFromLabel] = string(dsp.UID)
+		secret.Annotations[config.PropagatedFromAnnotation] = dsp.Namespace + "/" + dsp.Name
+		secret.Type = source.Type
+		secret.Data = source.Data
+		return nil
+	})
+	return err == nil, err
+}
+
+// CleanUpSecretPropagation deletes the object storage credentials propagated
+// from dsp.
+func (r *DSPAReconciler) CleanUpSecretPropagation(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication) error {
+	propagated := &corev1.SecretList{}
+	err := r.List(ctx, propagated, client.MatchingLabels{config.PropagatedFromLabel: string(dsp.UID)})
+	if err != nil {
+		return err
+	}
+	for i := range propagated.Items {
+		if err := client.IgnoreNotFound(r.Delete(ctx, &propagated.Items[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReconcileSecretPropagation(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.UID = "testdspa-uid"
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	dspa.Spec.ObjectStorage.SecretPropagation = &dspav1.SecretPropagation{
+		Namespaces: []string{"team-a", "team-b", "missing"},
+	}
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	for _, name := range []string{"team-a", "team-b"} {
+		namespace := &corev1.Namespace{}
+		namespace.Name = name
+		require.Nil(t, reconciler.Create(ctx, namespace))
+	}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	secretName := params.ObjectStorageConnection.CredentialsSecret.SecretName
+
+	// A Secret of the same name not copied by the operator
+	unrelated := &corev1.Secret{Data: map[string][]byte{"owner": []byte("team-b")}}
+	unrelated.Name, unrelated.Namespace = secretName, "team-b"
+	require.Nil(t, reconciler.Create(ctx, unrelated))
+
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileSecretPropagation(ctx, dspa, params))
+	getSecret := func(namespace string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret))
+		return secret
+	}
+	propagated := func(namespace string) bool {
+		created, err := reconciler.IsResourceCreated(ctx, &corev1.Secret{}, secretName, namespace)
+		require.Nil(t, err)
+		return created
+	}
+
+	// Assert the credentials are copied with the labels of their DSPA
+	source := getSecret(dspa.Namespace)
+	copied := getSecret("team-a")
+	assert.Equal(t, source.Data, copied.Data)
+	assert.Equal(t, "testdspa-uid", copied.Labels[config.PropagatedFromLabel])
+	assert.Equal(t, "testnamespace/testdspa", copied.Annotations[config.PropagatedFromAnnotation])
+
+	// Assert Secrets not copied by the operator, and missing namespaces, are skipped
+	assert.Equal(t, []byte("team-b"), getSecret("team-b").Data["owner"])
+	assert.False(t, propagated("missing"))
+
+	// Assert the copies are kept in sync
+	copied.Data = map[string][]byte{"accesskey": []byte("changed")}
+	require.Nil(t, reconciler.Update(ctx, copied))
+	require.Nil(t, reconciler.ReconcileSecretPropagation(ctx, dspa, params))
+	assert.Equal(t, source.Data, getSecret("team-a").Data)
+
+	// Assert the copies are deleted from the namespaces no longer listed
+	dspa.Spec.ObjectStorage.SecretPropagation.Namespaces = []string{"team-b"}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileSecretPropagation(ctx, dspa, params))
+	assert.False(t, propagated("team-a"))
+	assert.True(t, propagated("team-b"))
+
+	// Assert the copies are deleted along with their DSPA
+	dspa.Spec.ObjectStorage.SecretPropagation.Namespaces = []string{"team-a"}
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileSecretPropagation(ctx, dspa, params))
+	require.True(t, propagated("team-a"))
+	require.Nil(t, reconciler.CleanUpSecretPropagation(ctx, dspa))
+	assert.False(t, propagated("team-a"))
+	assert.True(t, propagated("team-b"))
+}
+
+func TestExtractParams_SecretPropagation(t *testing.T) {
+	tests := map[string]string{
+		"invalid namespace": "Team_A",
+		"DSPA namespace":    "testnamespace",
+	}
+	for name, namespace := range tests {
+		t.Run(name, func(t *testing.T) {
+			dspa := testutil.CreateDefaultDSPA()
+			dspa.Spec.ObjectStorage.SecretPropagation = &dspav1.SecretPropagation{Namespaces: []string{namespace}}
+			ctx, params, reconciler := CreateNewTestObjects()
+			err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+			assert.ErrorContains(t, err, "[spec.objectStorage.secretPropagation.namespaces]")
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Service mesh resources are deployed conditionally
+// as such they are handled separately
+var (
+	apiServerServiceMeshTemplatesDir    = "apiserver/service-mesh"
+	apiServerVirtualService             = "apiserver/service-mesh/virtualservice/virtualservice.yaml.tmpl"
+	mlPipelineUIServiceMeshTemplatesDir = "mlpipelines-ui/service-mesh"
+	commonServiceMeshTemplatesDir       = "common/service-mesh"
+)
+
+var (
+	virtualServiceGVK      = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "VirtualService"}
+	destinationRuleGVK     = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "DestinationRule"}
+	authorizationPolicyGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "AuthorizationPolicy"}
+	peerAuthenticationGVK  = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1beta1", Kind: "PeerAuthentication"}
+)
+
+//+kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices;destinationrules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies;peerauthentications,verbs=get;list;watch;create;update;patch;delete
+
+// deleteServiceMeshResources deletes the service mesh resources of the given
+// kinds named name, clusters without the service mesh CRDs have none.
+func (r *DSPAReconciler) deleteServiceMeshResources(ctx context.Context, name, namespace string, gvks ...schema.GroupVersionKind) error {
+	for _, gvk := range gvks {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := r.DeleteResourceIfItExists(ctx, obj, types.NamespacedName{Name: name, Namespace: namespace})
+		if err != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	return nil
+}
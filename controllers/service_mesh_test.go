@@ -0,0 +1,166 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func serviceMeshTestDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.APIServer.EnableRoute = true
+	dspa.Spec.MlPipelineUI = &dspav1.MlPipelineUI{Deploy: true, Image: "quay.io/opendatahub/ds-pipelines-frontend:latest"}
+	dspa.Spec.PodToPodTLS = boolPtr(true)
+	dspa.Spec.ServiceMesh = &dspav1.ServiceMesh{
+		Enabled:           true,
+		Gateway:           "istio-system/ingressgateway",
+		Domain:            "apps.example.com",
+		AllowedNamespaces: []string{"workbenches"},
+		MTLSMode:          "STRICT",
+	}
+	return dspa
+}
+
+func TestDeployServiceMesh(t *testing.T) {
+	dspa := serviceMeshTestDSPA()
+	expectedAPIServerName := apiServerDefaultResourceNamePrefix + dspa.Name
+	expectedUIName := "ds-pipeline-ui-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	assert.Equal(t, "istio-system", params.ServiceMeshGatewayNamespace)
+	assert.False(t, params.PodToPodTLS)
+
+	// Run test reconciliation
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileUI(ctx, dspa, params))
+
+	// Assert the pods are injected with sidecars, without the OAuth proxy
+	for _, name := range []string{expectedAPIServerName, expectedUIName} {
+		deployment := &appsv1.Deployment{}
+		created, err := reconciler.IsResourceCreated(ctx, deployment, name, dspa.Namespace)
+		require.Nil(t, err)
+		require.True(t, created)
+		assert.Equal(t, "true", deployment.Spec.Template.Annotations["sidecar.istio.io/inject"])
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			assert.NotEqual(t, "oauth-proxy", container.Name)
+		}
+
+		created, err = reconciler.IsResourceCreated(ctx, &routev1.Route{}, name, dspa.Namespace)
+		require.Nil(t, err)
+		assert.False(t, created)
+	}
+	service := &corev1.Service{}
+	created, err := reconciler.IsResourceCreated(ctx, service, expectedUIName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, int32(3000), service.Spec.Ports[0].Port)
+
+	// Assert the VirtualServices are bound to the Gateway
+	virtualService := &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(virtualServiceGVK)
+	created, err = reconciler.IsResourceCreated(ctx, virtualService, expectedAPIServerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+	assert.Equal(t, []string{"ds-pipeline-testdspa-testnamespace.apps.example.com"}, hosts)
+	gateways, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways")
+	assert.Equal(t, []string{"istio-system/ingressgateway"}, gateways)
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(authorizationPolicyGVK)
+	created, err = reconciler.IsResourceCreated(ctx, policy, expectedUIName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rules")
+	namespaces, _, _ := unstructured.NestedStringSlice(rules[0].(map[string]interface{})["from"].([]interface{})[0].(map[string]interface{}), "source", "namespaces")
+	assert.Equal(t, []string{"testnamespace", "istio-system", "workbenches"}, namespaces)
+
+	// Assert disabling the mesh restores the Routes and removes the mesh resources
+	dspa.Spec.ServiceMesh.Enabled = false
+	dspa.Spec.PodToPodTLS = boolPtr(false)
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileUI(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &routev1.Route{}, expectedUIName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	virtualService = &unstructured.Unstructured{}
+	virtualService.SetGroupVersionKind(virtualServiceGVK)
+	created, err = reconciler.IsResourceCreated(ctx, virtualService, expectedAPIServerName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert the Gateway and domain are required
+	dspa = serviceMeshTestDSPA()
+	dspa.Spec.ServiceMesh.Domain = ""
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.serviceMesh.domain")
+}
+
+func TestDeployServiceMeshMTLS(t *testing.T) {
+	dspa := serviceMeshTestDSPA()
+	dspa.Spec.Database = &dspav1.Database{DisableHealthCheck: false, MariaDB: &dspav1.MariaDB{Deploy: true}}
+	expectedMariaDBName := "mariadb-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	require.Nil(t, reconciler.ReconcileCommon(ctx, dspa, params))
+	require.Nil(t, reconciler.ReconcileDatabase(ctx, dspa, params))
+
+	// Assert the database pods are injected with sidecars and only accept mTLS
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedMariaDBName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, "true", deployment.Spec.Template.Annotations["sidecar.istio.io/inject"])
+
+	peerAuthentication := &unstructured.Unstructured{}
+	peerAuthentication.SetGroupVersionKind(peerAuthenticationGVK)
+	created, err = reconciler.IsResourceCreated(ctx, peerAuthentication, expectedMariaDBName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	mode, _, _ := unstructured.NestedString(peerAuthentication.Object, "spec", "mtls", "mode")
+	assert.Equal(t, "STRICT", mode)
+
+	// Assert disabling the mesh removes the PeerAuthentications
+	dspa.Spec.ServiceMesh = nil
+	dspa.Spec.PodToPodTLS = boolPtr(false)
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileCommon(ctx, dspa, params))
+	peerAuthentication = &unstructured.Unstructured{}
+	peerAuthentication.SetGroupVersionKind(peerAuthenticationGVK)
+	created, err = reconciler.IsResourceCreated(ctx, peerAuthentication, expectedMariaDBName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+}
@@ -19,7 +19,6 @@ package controllers
 import (
 	"context"
 	"crypto/x509"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
@@ -32,20 +31,83 @@ import (
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const storageSecret = "minio/generated-secret/secret.yaml.tmpl"
 const storageRoute = "minio/route.yaml.tmpl"
 
+// legacyMinioServiceName is the name of the minio-service Service of the DSPAs
+// deployed before it was suffixed with the DSPA name
+const legacyMinioServiceName = "minio-service"
+
+// ErrBucketNotAccessible is returned by the object storage health check when
+// the endpoint is reachable but denies the configured credentials access to
+// the bucket.
+var ErrBucketNotAccessible = errors.New("bucket is not accessible with the configured credentials")
+
+// ErrBucketNotFound is returned by the object storage health check, and when
+// ensureBucket is Verify, when the bucket does not exist.
+var ErrBucketNotFound = errors.New("bucket does not exist")
+
+// ErrBucketCreationFailed is returned when ensureBucket is Create and the
+// bucket could not be created or its policy applied.
+var ErrBucketCreationFailed = errors.New("bucket could not be created")
+
+// S3 error codes returned for invalid credentials or missing permissions
+var s3AccessErrorCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AllAccessDisabled":     true,
+	"InvalidAccessKeyId":    true,
+	"SignatureDoesNotMatch": true,
+}
+
 var minioTemplates = []string{
-	"minio/default/deployment.yaml.tmpl",
-	"minio/default/pvc.yaml.tmpl",
+	minioDeploymentTemplate,
+	minioPVCTemplate,
 	"minio/default/service.yaml.tmpl",
 	"minio/default/service.minioservice.yaml.tmpl",
 	"minio/default/minio-sa.yaml.tmpl",
 	storageRoute,
 }
 
+const minioDeploymentTemplate = "minio/default/deployment.yaml.tmpl"
+
+const minioPVCTemplate = "minio/default/pvc.yaml.tmpl"
+
+// minioDistributedTemplates replace the Deployment and PVC of minioTemplates
+// when Minio is deployed on several nodes.
+var minioDistributedTemplates = []string{
+	"minio/distributed/statefulset.yaml.tmpl",
+	"minio/distributed/service-headless.yaml.tmpl",
+	"minio/distributed/poddisruptionbudget.yaml.tmpl",
+}
+
+// deployedMinioTemplates returns the templates deploying the managed Minio, as
+// a single pod or distributed, and its ServiceMonitor when its metrics are
+// exported.
+func deployedMinioTemplates(params *DSPAParams) []string {
+	var templates []string
+	for _, template := range minioTemplates {
+		if !params.MinioDistributed() || (template != minioDeploymentTemplate && template != minioPVCTemplate) {
+			templates = append(templates, template)
+		}
+	}
+	if params.MinioDistributed() {
+		templates = append(templates, minioDistributedTemplates...)
+	}
+	if params.scrapedByServiceMonitor(params.MinioMetrics != nil) {
+		templates = append(templates, minioMonitoringTemplates...)
+	}
+	return templates
+}
+
 func joinHostPort(host, port string) (string, error) {
 	if host == "" {
 		return "", errors.New("Object Storage Connection missing host")
@@ -74,7 +136,7 @@ func createCredentialProvidersChain(accessKey, secretKey string) *credentials.Cr
 	return credentials.New(&credentials.Chain{Providers: providers})
 }
 
-func getHttpsTransportWithCACert(log logr.Logger, pemCerts [][]byte) (*http.Transport, error) {
+func getHttpsTransportWithCACert(log logr.Logger, pemCerts [][]byte, fipsMode bool) (*http.Transport, error) {
 	transport, err := minio.DefaultTransport(true)
 	if err != nil {
 		return nil, fmt.Errorf("error creating default transport : %s", err)
@@ -95,30 +157,26 @@ func getHttpsTransportWithCACert(log logr.Logger, pemCerts [][]byte) (*http.Tran
 			return nil, fmt.Errorf("error parsing CA Certificate, ensure provided certs are in valid PEM format")
 		}
 	}
+	util.RestrictTLSConfigToFIPS(transport.TLSClientConfig, fipsMode)
 	return transport, nil
 }
 
-var ConnectAndQueryObjStore = func(
-	ctx context.Context,
-	log logr.Logger,
-	endpoint, bucket string,
-	accesskey, secretkey []byte,
-	secure bool,
-	pemCerts [][]byte,
-	objStoreConnectionTimeout time.Duration) (bool, error) {
+func newMinioClient(log logr.Logger, endpoint, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool) (*minio.Client, error) {
 	cred := createCredentialProvidersChain(string(accesskey), string(secretkey))
 
 	opts := &minio.Options{
 		Creds:  cred,
 		Secure: secure,
+		// The bucket location is looked up when no region is set
+		Region: region,
 	}
 
-	if len(pemCerts) != 0 {
-		tr, err := getHttpsTransportWithCACert(log, pemCerts)
+	if len(pemCerts) != 0 || fipsMode {
+		tr, err := getHttpsTransportWithCACert(log, pemCerts, fipsMode)
 		if err != nil {
 			errorMessage := "Encountered error when processing custom ca bundle."
 			log.Error(err, errorMessage)
-			return false, errors.New(errorMessage)
+			return nil, errors.New(errorMessage)
 		}
 		opts.Transport = tr
 	}
@@ -127,21 +185,103 @@ var ConnectAndQueryObjStore = func(
 	if err != nil {
 		errorMessage := fmt.Sprintf("Could not connect to object storage endpoint: %s", endpoint)
 		log.Error(err, errorMessage)
-		return false, errors.New(errorMessage)
+		return nil, errors.New(errorMessage)
+	}
+	return minioClient, nil
+}
+
+// EnsureObjStoreBucket verifies that bucket exists and, with the Create mode,
+// creates it in region and applies policy when it does not.
+var EnsureObjStoreBucket = func(
+	ctx context.Context,
+	log logr.Logger,
+	endpoint, bucket, region, policy string,
+	mode dspav1.EnsureBucketMode,
+	accesskey, secretkey []byte,
+	secure bool,
+	pemCerts [][]byte,
+	fipsMode bool,
+	objStoreConnectionTimeout time.Duration) error {
+	minioClient, err := newMinioClient(log, endpoint, region, accesskey, secretkey, secure, pemCerts, fipsMode)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, objStoreConnectionTimeout)
+	defer cancel()
+
+	exists, err := minioClient.BucketExists(ctx, bucket)
+	if err != nil {
+		if s3AccessErrorCodes[minio.ToErrorResponse(err).Code] {
+			return fmt.Errorf("%w: bucket %s at (%s) returned %s", ErrBucketNotAccessible, bucket, endpoint, minio.ToErrorResponse(err).Code)
+		}
+		return fmt.Errorf("could not verify bucket %s at (%s): %s", bucket, endpoint, err.Error())
+	}
+	if exists {
+		return nil
+	}
+	if mode != dspav1.EnsureBucketCreate {
+		return fmt.Errorf("%w: bucket %s at (%s)", ErrBucketNotFound, bucket, endpoint)
+	}
+
+	log.Info(fmt.Sprintf("Creating bucket %s", bucket))
+	if err := minioClient.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+		return fmt.Errorf("%w: bucket %s at (%s): %s", ErrBucketCreationFailed, bucket, endpoint, err.Error())
+	}
+	if policy != "" {
+		if err := minioClient.SetBucketPolicy(ctx, bucket, policy); err != nil {
+			return fmt.Errorf("%w: setting the policy of bucket %s at (%s): %s", ErrBucketCreationFailed, bucket, endpoint, err.Error())
+		}
+	}
+	return nil
+}
+
+var ConnectAndQueryObjStore = func(
+	ctx context.Context,
+	log logr.Logger,
+	endpoint, bucket, region string,
+	accesskey, secretkey []byte,
+	secure bool,
+	pemCerts [][]byte,
+	fipsMode bool,
+	objStoreConnectionTimeout time.Duration) (bool, error) {
+	minioClient, err := newMinioClient(log, endpoint, region, accesskey, secretkey, secure, pemCerts, fipsMode)
+	if err != nil {
+		return false, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, objStoreConnectionTimeout)
 	defer cancel()
 
-	// Attempt to run Stat on the Object.  It doesn't necessarily have to exist, we just want to verify we can successfully run an authenticated s3 command
-	_, err = minioClient.StatObject(ctx, bucket, "some-random-object", minio.GetObjectOptions{})
+	// HEAD the bucket, and list at most one of its objects, with the configured credentials,
+	// so that the check fails when the endpoint is reachable but the bucket is not usable
+	exists, err := minioClient.BucketExists(ctx, bucket)
+	if err == nil && !exists {
+		notFoundErr := fmt.Errorf("%w: bucket %s at (%s)", ErrBucketNotFound, bucket, endpoint)
+		log.Info(notFoundErr.Error())
+		return false, notFoundErr
+	}
+	if err == nil {
+		for object := range minioClient.ListObjects(ctx, bucket, minio.ListObjectsOptions{MaxKeys: 1}) {
+			if object.Err != nil {
+				err = object.Err
+			}
+			break
+		}
+	}
 	if err != nil {
 		switch err := err.(type) {
 
-		// In the case that the Error is NoSuchKey (or NoSuchBucket), we can verify that the endpoint worked and the object just doesn't exist
 		case minio.ErrorResponse:
-			if err.Code == "NoSuchKey" || err.Code == "NoSuchBucket" {
-				return true, nil
+			if err.Code == "NoSuchBucket" {
+				notFoundErr := fmt.Errorf("%w: bucket %s at (%s)", ErrBucketNotFound, bucket, endpoint)
+				log.Info(notFoundErr.Error())
+				return false, notFoundErr
+			}
+			if s3AccessErrorCodes[err.Code] {
+				accessErr := fmt.Errorf("%w: bucket %s at (%s) returned %s", ErrBucketNotAccessible, bucket, endpoint, err.Code)
+				log.Info(accessErr.Error())
+				return false, accessErr
 			}
 			// This condition is added to handle the service unavailble error when the external route pod takes long time to send successful readiness checks
 			if err.Code == "503 Service Unavailable" {
@@ -162,7 +302,7 @@ var ConnectAndQueryObjStore = func(
 			return false, errors.New(errorMessage)
 		}
 
-		// Every other error means the endpoint in inaccessible, or the credentials provided do not have, at a minimum GetObject, permissions
+		// Every other error means the endpoint in inaccessible, or the credentials provided do not have, at a minimum ListBucket, permissions
 		errorMessage := fmt.Sprintf("Could not connect to (%s), Error: %s", endpoint, err.Error())
 		log.Info(errorMessage)
 		return false, errors.New(errorMessage)
@@ -174,7 +314,7 @@ var ConnectAndQueryObjStore = func(
 
 func (r *DSPAReconciler) isObjectStorageAccessible(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) (bool, error) {
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 	if params.ObjectStorageHealthCheckDisabled(dsp) {
 		infoMessage := "Object Storage health check disabled, assuming object store is available and ready."
 		log.V(1).Info(infoMessage)
@@ -190,40 +330,99 @@ func (r *DSPAReconciler) isObjectStorageAccessible(ctx context.Context, dsp *dsp
 		return false, errors.New(errorMessage)
 	}
 
-	accesskey, err := base64.StdEncoding.DecodeString(params.ObjectStorageConnection.AccessKeyID)
-	if err != nil {
-		errorMessage := "Could not decode Object Storage Access Key ID"
-		log.Error(err, errorMessage)
+	if params.ObjectStorageConnection.AccessKeyID.Empty() {
+		errorMessage := "Object Storage Access Key ID was not retrieved"
+		log.Info(errorMessage)
 		return false, errors.New(errorMessage)
 	}
+	accesskey := params.ObjectStorageConnection.AccessKeyID.Bytes()
 
-	secretkey, err := base64.StdEncoding.DecodeString(params.ObjectStorageConnection.SecretAccessKey)
-	if err != nil {
-		errorMessage := "Could not decode Object Storage Secret Access Key"
-		log.Error(err, errorMessage)
+	if params.ObjectStorageConnection.SecretAccessKey.Empty() {
+		errorMessage := "Object Storage Secret Access Key was not retrieved"
+		log.Info(errorMessage)
 		return false, errors.New(errorMessage)
 	}
+	secretkey := params.ObjectStorageConnection.SecretAccessKey.Bytes()
 
 	objStoreConnectionTimeout := config.GetDurationConfigWithDefault(config.ObjStoreConnectionTimeoutConfigName, config.DefaultObjStoreConnectionTimeout)
 
 	log.V(1).Info(fmt.Sprintf("Object Store connection timeout: %s", objStoreConnectionTimeout))
 
-	verified, err := ConnectAndQueryObjStore(ctx, log, endpoint, params.ObjectStorageConnection.Bucket, accesskey, secretkey,
-		*params.ObjectStorageConnection.Secure, params.APICustomPemCerts, objStoreConnectionTimeout)
+	region := ""
+	if params.UsingExternalStorage(dsp) {
+		region = dsp.Spec.ObjectStorage.ExternalStorage.Region
+		// The region may also be composed from a data connection
+		if region == "" && dsp.Spec.ObjectStorage.ExternalStorage.DataConnectionRef != "" && params.ObjectStorageConnection.Region != "auto" {
+			region = params.ObjectStorageConnection.Region
+		}
+	}
 
-	if err != nil {
-		log.Info("Object Storage Health Check Failed")
-	} else {
-		log.Info("Object Storage Health Check Successful")
+	for _, bucket := range params.ObjectStorageConnection.DistinctBuckets() {
+		if mode := params.EnsureBucketMode(dsp); mode != dspav1.EnsureBucketSkip {
+			err = EnsureObjStoreBucket(ctx, log, endpoint, bucket, region,
+				dsp.Spec.ObjectStorage.BucketPolicy, mode, accesskey, secretkey,
+				*params.ObjectStorageConnection.Secure, params.APICustomPemCerts, params.FIPSEnabled, objStoreConnectionTimeout)
+			if err != nil {
+				log.Info("Object Storage Health Check Failed: " + err.Error())
+				return false, err
+			}
+		}
+
+		verified, err := ConnectAndQueryObjStore(ctx, log, endpoint, bucket, region, accesskey, secretkey,
+			*params.ObjectStorageConnection.Secure, params.APICustomPemCerts, params.FIPSEnabled, objStoreConnectionTimeout)
+		if err != nil || !verified {
+			// Failing over is left to unreachable endpoints, rather than misconfigured buckets
+			if len(params.ObjectStorageConnection.Endpoints) > 1 && !errors.Is(err, ErrBucketNotAccessible) && !errors.Is(err, ErrBucketNotFound) {
+				return r.failOverObjectStorage(ctx, log, dsp, params, accesskey, secretkey, objStoreConnectionTimeout, err)
+			}
+			log.Info("Object Storage Health Check Failed")
+			return verified, err
+		}
+	}
+
+	log.Info("Object Storage Health Check Successful")
+	return true, nil
+}
+
+// failOverObjectStorage probes the failover endpoints of the object storage
+// by priority once its primary endpoint is unreachable, and points params at
+// the first one passing the health check, so that the components are deployed
+// against it. The buckets of the failover endpoints are expected to exist, as
+// replicas of the primary bucket, and are not ensured. primaryErr is returned
+// when none passes the health check.
+func (r *DSPAReconciler) failOverObjectStorage(ctx context.Context, log logr.Logger, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, accesskey, secretkey []byte, objStoreConnectionTimeout time.Duration, primaryErr error) (bool, error) {
+	primary := params.ObjectStorageConnection.Endpoints[0]
+	for i, failover := range params.ObjectStorageConnection.Endpoints[1:] {
+		endpoint, err := joinHostPort(failover.Host, failover.Port)
+		if err != nil {
+			continue
+		}
+		log.Info(fmt.Sprintf("Object Storage endpoint %s is not available, probing failover endpoint %s", primary.Endpoint, failover.Endpoint))
+		// Regions composed as auto are looked up from the bucket
+		region := failover.Region
+		if region == "auto" {
+			region = ""
+		}
+		verified, err := ConnectAndQueryObjStore(ctx, log, endpoint, failover.Bucket, region, accesskey, secretkey,
+			*failover.Secure, params.APICustomPemCerts, params.FIPSEnabled, objStoreConnectionTimeout)
+		if err == nil && verified {
+			params.ObjectStorageConnection.failOver(i + 1)
+			message := fmt.Sprintf("Object Storage failed over from %s to %s: %s", primary.Endpoint, failover.Endpoint, primaryErr)
+			log.Info(message)
+			r.recordEvent(dsp, corev1.EventTypeWarning, config.ObjectStorageFailedOver, message)
+			return true, nil
+		}
 	}
-	return verified, err
+	log.Info("Object Storage Health Check Failed, no failover endpoint is available")
+	return false, primaryErr
 }
 
 // ReconcileStorage will set up Storage Connection.
 func (r *DSPAReconciler) ReconcileStorage(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) error {
 
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
 	storageSpecified := dsp.Spec.ObjectStorage != nil
 	// Storage field can be specified as an empty obj, confirm that subfields are also specified
@@ -245,9 +444,19 @@ func (r *DSPAReconciler) ReconcileStorage(ctx context.Context, dsp *dspav1.DataS
 			if err != nil {
 				return err
 			}
+			err = r.deleteMigratedSecret(ctx, dsp, params.ObjectStorageConnection.MigrateCredentialsSecretFrom)
+			if err != nil {
+				return err
+			}
+		}
+		if params.MinioNamespace != dsp.Namespace {
+			err := r.copySecretToComponentNamespace(ctx, dsp, params.ObjectStorageConnection.CredentialsSecret.SecretName, params.MinioNamespace)
+			if err != nil {
+				return err
+			}
 		}
 		log.Info("Applying object storage resources.")
-		for _, template := range minioTemplates {
+		for _, template := range deployedMinioTemplates(params) {
 			if dsp.Spec.ObjectStorage.EnableExternalRoute || template != storageRoute {
 				err := r.Apply(dsp, params, template)
 				if err != nil {
@@ -255,6 +464,17 @@ func (r *DSPAReconciler) ReconcileStorage(ctx context.Context, dsp *dspav1.DataS
 				}
 			}
 		}
+		if err := r.cleanUpMinioMode(ctx, dsp, params); err != nil {
+			return err
+		}
+		if err := r.deleteLegacyMinioService(ctx, dsp); err != nil {
+			return err
+		}
+		if !params.scrapedByServiceMonitor(params.MinioMetrics != nil) {
+			if err := r.deleteExporterMonitoring(ctx, dsp, config.MinioHostPrefix, params.MinioNamespace, minioMonitoringTemplates); err != nil {
+				return err
+			}
+		}
 		// If no storage was not specified, deploy minio by default.
 		// Update the CR with the state of minio to accurately portray
 		// desired state.
@@ -275,3 +495,49 @@ func (r *DSPAReconciler) ReconcileStorage(ctx context.Context, dsp *dspav1.DataS
 
 	return nil
 }
+
+// deleteLegacyMinioService deletes the minio-service Service of dsp, named
+// minio-service-<name> since, so that DSPAs sharing a namespace no longer
+// conflict on it. Services controlled by other DSPAs are kept.
+func (r *DSPAReconciler) deleteLegacyMinioService(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication) error {
+	service := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: legacyMinioServiceName, Namespace: dsp.Namespace}, service)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(service, dsp) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, service))
+}
+
+// cleanUpMinioMode deletes the Minio Deployment once the distributed Minio is
+// deployed, and the distributed Minio once Minio is deployed as a single pod
+// again. The PVCs are kept, so that switching back restores their objects.
+func (r *DSPAReconciler) cleanUpMinioMode(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+	dspaNN := types.NamespacedName{Name: dsp.Name, Namespace: dsp.Namespace}
+	nn := types.NamespacedName{Name: config.MinioHostPrefix + "-" + dsp.Name, Namespace: params.MinioNamespace}
+	if params.MinioDistributed() {
+		r.appliedManifests.forgetTemplate(dspaNN, minioDeploymentTemplate)
+		return r.DeleteResourceIfItExists(ctx, &appsv1.Deployment{}, nn)
+	}
+
+	err := r.Get(ctx, nn, &appsv1.StatefulSet{})
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID).Info("Deleting the distributed Minio.")
+	for _, template := range minioDistributedTemplates {
+		r.appliedManifests.forgetTemplate(dspaNN, template)
+	}
+	return r.deleteResourcesIfTheyExist(ctx, nn.Namespace,
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: nn.Name}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: nn.Name + "-hl"}},
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: nn.Name}},
+	)
+}
@@ -0,0 +1,106 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// storageBinding returns the buckets and database the runs of the DSPA are
+// stored in. The buckets of the primary endpoint are recorded, those of the
+// failover endpoints replicate them.
+func (p *DSPAParams) storageBinding() *dspav1.StorageBinding {
+	storage := &dspav1.StorageBinding{
+		ArtifactBucket: p.ObjectStorageConnection.ArtifactBucket,
+		PipelineBucket: p.ObjectStorageConnection.PipelineBucket,
+		DatabaseName:   p.DBConnection.DBName,
+	}
+	if len(p.ObjectStorageConnection.Endpoints) > 0 {
+		storage.ArtifactBucket = p.ObjectStorageConnection.Endpoints[0].Bucket
+		storage.PipelineBucket = p.ObjectStorageConnection.Endpoints[0].Bucket
+	}
+	return storage
+}
+
+// CheckStorageBinding returns the changes of the buckets and database of dsp
+// in its spec since they were recorded in its status, with how to remediate
+// them. Once dsp is annotated with the StorageChangeAcknowledgedAnnotation set
+// to true, the recorded storage is cleared so that the changes are applied,
+// and recorded again once the health checks pass, see recordStorageBinding.
+func (r *DSPAReconciler) CheckStorageBinding(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) (string, error) {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	current := params.storageBinding()
+	recorded := dsp.Status.Storage
+	if recorded == nil {
+		return "", nil
+	}
+
+	var changes, reverts []string
+	for _, field := range []struct{ name, from, to string }{
+		{"artifacts bucket", recorded.ArtifactBucket, current.ArtifactBucket},
+		{"pipeline definitions bucket", recorded.PipelineBucket, current.PipelineBucket},
+		{"database name", recorded.DatabaseName, current.DatabaseName},
+	} {
+		if field.from != "" && field.from != field.to {
+			changes = append(changes, fmt.Sprintf("the %s changed from %s to %s", field.name, field.from, field.to))
+			reverts = append(reverts, field.from)
+		}
+	}
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	if dsp.Annotations[config.StorageChangeAcknowledgedAnnotation] == "true" {
+		log.Info(fmt.Sprintf("Applying the acknowledged storage change of the DSPA: %s", strings.Join(changes, ", ")))
+		// Only patch the metadata, the spec of dsp holds the defaults of its
+		// components by now
+		dspaMeta := &metav1.PartialObjectMetadata{ObjectMeta: *dsp.ObjectMeta.DeepCopy()}
+		dspaMeta.SetGroupVersionKind(dspav1.GroupVersion.WithKind("DataSciencePipelinesApplication"))
+		patch := client.MergeFrom(dspaMeta.DeepCopy())
+		delete(dspaMeta.Annotations, config.StorageChangeAcknowledgedAnnotation)
+		if err := r.Patch(ctx, dspaMeta, patch); err != nil {
+			return "", err
+		}
+		delete(dsp.Annotations, config.StorageChangeAcknowledgedAnnotation)
+		dsp.ResourceVersion = dspaMeta.ResourceVersion
+		dspaStatus.SetStorageBinding(nil)
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s after the DSPA was deployed, the artifacts and lineage of its existing runs are stored in "+
+		"%s. The change is not applied: revert the spec, or migrate the data of the existing runs and annotate the DSPA "+
+		"with %s=true to apply it", strings.Join(changes, ", "), strings.Join(reverts, ", "),
+		config.StorageChangeAcknowledgedAnnotation), nil
+}
+
+// recordStorageBinding records the buckets and database of dsp in its status
+// once its database and object storage health checks passed, so that a DSPA
+// deployed with a mistyped bucket can still be fixed.
+func recordStorageBinding(params *DSPAParams, dspaStatus dspastatus.DSPAStatus) {
+	if dspaStatus.GetStorageBinding() == nil {
+		dspaStatus.SetStorageBinding(params.storageBinding())
+	}
+}
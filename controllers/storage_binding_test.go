@@ -0,0 +1,109 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckStorageBinding(t *testing.T) {
+	ctx, params, reconciler := CreateNewTestObjects()
+	dspa := testutil.CreateDefaultDSPA()
+	require.Nil(t, reconciler.Create(ctx, dspa))
+	params.ObjectStorageConnection.ArtifactBucket = "artifacts"
+	params.ObjectStorageConnection.PipelineBucket = "artifacts"
+	params.DBConnection.DBName = "mlpipeline"
+
+	// Assert the storage is not recorded before the health checks pass, so that a mistyped bucket can be fixed
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	unsupported, err := reconciler.CheckStorageBinding(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Empty(t, unsupported)
+	assert.Nil(t, dspaStatus.GetStorageBinding())
+
+	// Assert the storage is recorded once the health checks pass
+	recordStorageBinding(params, dspaStatus)
+	recorded := &dspav1.StorageBinding{ArtifactBucket: "artifacts", PipelineBucket: "artifacts", DatabaseName: "mlpipeline"}
+	assert.Equal(t, recorded, dspaStatus.GetStorageBinding())
+	dspa.Status.Storage = recorded
+
+	// Assert a changed bucket or database name is not applied, with how to remediate it
+	params.ObjectStorageConnection.ArtifactBucket = "other-artifacts"
+	params.DBConnection.DBName = "otherdb"
+	dspaStatus = dspastatus.NewDSPAStatus(dspa)
+	unsupported, err = reconciler.CheckStorageBinding(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Contains(t, unsupported, "the artifacts bucket changed from artifacts to other-artifacts")
+	assert.Contains(t, unsupported, "the database name changed from mlpipeline to otherdb")
+	assert.Contains(t, unsupported, config.StorageChangeAcknowledgedAnnotation)
+	recordStorageBinding(params, dspaStatus)
+	assert.Equal(t, recorded, dspaStatus.GetStorageBinding())
+	dspaStatus.SetSpecChangeUnsupported(unsupported)
+	condition := meta.FindStatusCondition(dspaStatus.GetConditions(), config.SpecChangeUnsupported)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, config.StorageChanged, condition.Reason)
+
+	// Assert the change is only acknowledged with the annotation set to true
+	dspa.Annotations = map[string]string{config.StorageChangeAcknowledgedAnnotation: "false"}
+	dspaStatus = dspastatus.NewDSPAStatus(dspa)
+	unsupported, err = reconciler.CheckStorageBinding(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.NotEmpty(t, unsupported)
+	assert.Equal(t, recorded, dspaStatus.GetStorageBinding())
+
+	// Assert an acknowledged change is applied, and the annotation removed without writing the defaulted spec
+	require.Nil(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dspa), dspa))
+	dspa.Annotations = map[string]string{config.StorageChangeAcknowledgedAnnotation: "true"}
+	require.Nil(t, reconciler.Update(ctx, dspa))
+	dspa.Status.Storage = recorded
+	defaulted := config.DefaultSignedUrlExpiryTimeSeconds
+	dspa.Spec.APIServer.ArtifactSignedURLExpirySeconds = &defaulted
+	dspaStatus = dspastatus.NewDSPAStatus(dspa)
+	unsupported, err = reconciler.CheckStorageBinding(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Empty(t, unsupported)
+	assert.Nil(t, meta.FindStatusCondition(dspaStatus.GetConditions(), config.SpecChangeUnsupported))
+	assert.NotContains(t, dspa.Annotations, config.StorageChangeAcknowledgedAnnotation)
+	updated := &dspav1.DataSciencePipelinesApplication{}
+	require.Nil(t, reconciler.Get(ctx, client.ObjectKeyFromObject(dspa), updated))
+	assert.NotContains(t, updated.Annotations, config.StorageChangeAcknowledgedAnnotation)
+	assert.Nil(t, updated.Spec.APIServer.ArtifactSignedURLExpirySeconds)
+	assert.Equal(t, updated.ResourceVersion, dspa.ResourceVersion)
+
+	// Assert the new storage is recorded once the health checks pass
+	assert.Nil(t, dspaStatus.GetStorageBinding())
+	recordStorageBinding(params, dspaStatus)
+	assert.Equal(t, &dspav1.StorageBinding{ArtifactBucket: "other-artifacts", PipelineBucket: "artifacts", DatabaseName: "otherdb"},
+		dspaStatus.GetStorageBinding())
+
+	// Assert the buckets of the primary endpoint are recorded while failed over
+	params.ObjectStorageConnection.Endpoints = []ObjectStorageEndpoint{{Bucket: "primary"}, {Bucket: "replica"}}
+	params.ObjectStorageConnection.failOver(1)
+	assert.Equal(t, "primary", params.storageBinding().ArtifactBucket)
+}
@@ -19,19 +19,26 @@ package controllers
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestDeployStorage(t *testing.T) {
@@ -100,6 +107,153 @@ func TestDeployStorage(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestDeployStorageDeletesLegacyMinioService(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			ObjectStorage: &dspav1.ObjectStorage{
+				Minio: &dspav1.Minio{
+					Deploy: true,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+	dspa.UID = "testdspa-uid"
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+
+	// The minio-service Service deployed before it was suffixed with the DSPA name
+	legacy := &corev1.Service{}
+	legacy.Name, legacy.Namespace = "minio-service", testNamespace
+	legacy.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(dspa,
+		dspav1.GroupVersion.WithKind("DataSciencePipelinesApplication"))}
+	require.Nil(t, reconciler.Create(ctx, legacy))
+
+	// Assert it is deleted once the suffixed Service is deployed
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.Service{}, "minio-service-testdspa", testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, "minio-service", testNamespace)
+	assert.False(t, created)
+	assert.Nil(t, err)
+
+	// Assert the one of another DSPA is kept
+	legacy = &corev1.Service{}
+	legacy.Name, legacy.Namespace = "minio-service", testNamespace
+	legacy.OwnerReferences = []metav1.OwnerReference{{APIVersion: dspav1.GroupVersion.String(),
+		Kind: "DataSciencePipelinesApplication", Name: "other", UID: "other-uid", Controller: boolPtr(true)}}
+	require.Nil(t, reconciler.Create(ctx, legacy))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, "minio-service", testNamespace)
+	assert.True(t, created)
+	assert.Nil(t, err)
+}
+
+func TestDeployStorageDeploymentStrategy(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedStorageName := "minio-testdspa"
+
+	// Construct DSPA Spec with deployed Minio Object Storage
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: true,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+
+	// Enrich DSPA with name+namespace
+	dspa.Name = testDSPAName
+	dspa.Namespace = testNamespace
+
+	for _, strategy := range []appsv1.DeploymentStrategyType{"", appsv1.RollingUpdateDeploymentStrategyType} {
+		dspa.Spec.ObjectStorage.Minio.DeploymentStrategy = strategy
+
+		// Create Context, Fake Controller and Params
+		ctx, params, reconciler := CreateNewTestObjects()
+		err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+		assert.Nil(t, err)
+
+		// Run test reconciliation
+		err = reconciler.ReconcileStorage(ctx, dspa, params)
+		assert.Nil(t, err)
+
+		// Assert the ObjectStorage Deployment is Recreated unless set otherwise
+		expectedStrategy := strategy
+		if expectedStrategy == "" {
+			expectedStrategy = appsv1.RecreateDeploymentStrategyType
+		}
+		deployment := &appsv1.Deployment{}
+		created, err := reconciler.IsResourceCreated(ctx, deployment, expectedStorageName, testNamespace)
+		assert.True(t, created)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedStrategy, deployment.Spec.Strategy.Type)
+	}
+}
+
+func TestDeployStorageDistributed(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage.Minio.Deploy = true
+	expectedStorageName := "minio-" + dspa.Name
+
+	// Create Context, Fake Controller and Params, and deploy Minio as a single pod first
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	dspa.Spec.ObjectStorage.Minio.Replicas = 6
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+
+	// Assert a distributed StatefulSet erasure coding the objects replaced the Deployment
+	statefulSet := &appsv1.StatefulSet{}
+	created, err := reconciler.IsResourceCreated(ctx, statefulSet, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	assert.Equal(t, int32(6), *statefulSet.Spec.Replicas)
+	container := statefulSet.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{"server", "http://minio-testdspa-{0...5}.minio-testdspa-hl.testnamespace.svc.cluster.local/data"}, container.Args)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "MINIO_STORAGE_CLASS_STANDARD", Value: "EC:3"})
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedStorageName+"-hl", dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert a single node deploys the Deployment again, and deletes the StatefulSet
+	dspa.Spec.ObjectStorage.Minio.Replicas = 1
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileStorage(ctx, dspa, params))
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &appsv1.StatefulSet{}, expectedStorageName, dspa.Namespace)
+	require.Nil(t, err)
+	assert.False(t, created)
+
+	// Assert too few nodes to erasure code the objects are rejected
+	dspa.Spec.ObjectStorage.Minio.Replicas = 3
+	assert.ErrorContains(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log), "spec.objectStorage.minio.replicas")
+}
+
 func TestDeployStorageWithExternalRouteEnabled(t *testing.T) {
 	testNamespace := "testnamespace"
 	testDSPAName := "testdspa"
@@ -269,9 +423,246 @@ func TestDefaultDeployBehaviorStorage(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// connectAndQueryObjStore keeps the live connection function, tests below
+// replace ConnectAndQueryObjStore with mocks.
+var connectAndQueryObjStore = ConnectAndQueryObjStore
+
+func TestConnectAndQueryObjStoreAccessDenied(t *testing.T) {
+	// Object store that rejects every request made with the given credentials
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+		}
+	}))
+	defer server.Close()
+
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	verified, err := connectAndQueryObjStore(context.Background(), logr.Discard(), endpoint, "mlpipeline", "us-east-1",
+		[]byte("fooaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.False(t, verified)
+	assert.ErrorIs(t, err, ErrBucketNotAccessible)
+}
+
+func TestConnectAndQueryObjStoreBucket(t *testing.T) {
+	// Object store with a bucket whose objects may only be listed by the allowed credentials
+	var locationRequested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case r.URL.Query().Has("location"):
+			locationRequested = true
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		case !strings.HasPrefix(r.URL.Path, "/mlpipeline"):
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method != http.MethodHead {
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`))
+			}
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case strings.Contains(r.Header.Get("Authorization"), "allowedaccesskey"):
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>mlpipeline</Name><KeyCount>0</KeyCount><MaxKeys>1</MaxKeys><IsTruncated>false</IsTruncated></ListBucketResult>`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+		}
+	}))
+	defer server.Close()
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+
+	// Assert a missing bucket is reported distinctly from denied access
+	verified, err := connectAndQueryObjStore(context.Background(), logr.Discard(), endpoint, "otherbucket", "us-east-1",
+		[]byte("allowedaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.False(t, verified)
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+
+	// Assert the objects of the bucket must be listable
+	verified, err = connectAndQueryObjStore(context.Background(), logr.Discard(), endpoint, "mlpipeline", "us-east-1",
+		[]byte("deniedaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.False(t, verified)
+	assert.ErrorIs(t, err, ErrBucketNotAccessible)
+
+	// Assert the check passes with bucket-level access, without looking up the configured region
+	verified, err = connectAndQueryObjStore(context.Background(), logr.Discard(), endpoint, "mlpipeline", "us-east-1",
+		[]byte("allowedaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.True(t, verified)
+	assert.Nil(t, err)
+	assert.False(t, locationRequested)
+}
+
+func TestEnsureObjStoreBucket(t *testing.T) {
+	// Object store without buckets that accepts bucket creation
+	var created bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		switch {
+		case r.URL.Query().Has("location"):
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		case r.Method == http.MethodPut:
+			created = true
+			w.WriteHeader(http.StatusOK)
+		case created:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method != http.MethodHead {
+				_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+
+	// Assert Verify reports the missing bucket without creating it
+	err := EnsureObjStoreBucket(context.Background(), logr.Discard(), endpoint, "mlpipeline", "", "", dspav1.EnsureBucketVerify,
+		[]byte("fooaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	assert.False(t, created)
+
+	// Assert Create creates the missing bucket
+	err = EnsureObjStoreBucket(context.Background(), logr.Discard(), endpoint, "mlpipeline", "", "", dspav1.EnsureBucketCreate,
+		[]byte("fooaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, created)
+
+	// Assert Verify succeeds once the bucket exists
+	err = EnsureObjStoreBucket(context.Background(), logr.Discard(), endpoint, "mlpipeline", "", "", dspav1.EnsureBucketVerify,
+		[]byte("fooaccesskey"), []byte("foosecretkey"), false, nil, false, 5*time.Second)
+	assert.Nil(t, err)
+}
+
+func TestObjectStorageFailover(t *testing.T) {
+	defer func(connect func(context.Context, logr.Logger, string, string, string, []byte, []byte, bool, [][]byte, bool, time.Duration) (bool, error)) {
+		ConnectAndQueryObjStore = connect
+	}(ConnectAndQueryObjStore)
+	available := map[string]bool{}
+	var probed []string
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
+		probed = append(probed, endpoint+"/"+bucket)
+		if !available[endpoint] {
+			return false, errors.New("connection refused")
+		}
+		return true, nil
+	}
+
+	maxRetries := int32(5)
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage = &dspav1.ObjectStorage{ExternalStorage: &dspav1.ExternalStorage{
+		Host:   "s3.us-east-1.amazonaws.com",
+		Bucket: "mybucket",
+		Scheme: "https",
+		Region: "us-east-1",
+		S3CredentialSecret: &dspav1.S3CredentialSecret{
+			SecretName: "storage-credentials",
+			AccessKey:  "accesskey",
+			SecretKey:  "secretkey",
+		},
+		FailoverEndpoints: []dspav1.ObjectStorageEndpoint{
+			{Host: "s3.eu-west-1.amazonaws.com", Region: "eu-west-1", Bucket: "mybucket-replica", Priority: 2},
+			{Host: "minio.dr.example.com", Port: "9000", Scheme: "http", Priority: 1},
+		},
+		MaxRetries: &maxRetries,
+	}}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: dspa.Namespace},
+		Data:       map[string][]byte{"accesskey": []byte("accesskey"), "secretkey": []byte("secretkey")},
+	}))
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "http://minio.dr.example.com:9000,https://s3.eu-west-1.amazonaws.com", params.ObjectStorageConnection.StandbyEndpoints())
+
+	// Assert the primary endpoint is used while it is available
+	available["s3.us-east-1.amazonaws.com"] = true
+	verified, err := reconciler.isObjectStorageAccessible(ctx, dspa, params)
+	assert.True(t, verified)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://s3.us-east-1.amazonaws.com", params.ObjectStorageConnection.Endpoint)
+
+	// Assert the failover endpoints are probed by priority, and the first available one is used
+	available["s3.us-east-1.amazonaws.com"] = false
+	available["s3.eu-west-1.amazonaws.com"] = true
+	probed = nil
+	verified, err = reconciler.isObjectStorageAccessible(ctx, dspa, params)
+	assert.True(t, verified)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"s3.us-east-1.amazonaws.com/mybucket",
+		"minio.dr.example.com:9000/mybucket",
+		"s3.eu-west-1.amazonaws.com/mybucket-replica",
+	}, probed)
+	assert.Equal(t, "https://s3.eu-west-1.amazonaws.com", params.ObjectStorageConnection.Endpoint)
+	assert.Equal(t, "mybucket-replica", params.ObjectStorageConnection.ArtifactBucket)
+	assert.Equal(t, "eu-west-1", params.ObjectStorageConnection.Region)
+	assert.Equal(t, "https://s3.us-east-1.amazonaws.com,http://minio.dr.example.com:9000", params.ObjectStorageConnection.StandbyEndpoints())
+
+	// Assert the API Server is deployed against the active endpoint, with the retry and failover configuration
+	require.Nil(t, reconciler.ReconcileAPIServer(ctx, dspa, params))
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, apiServerDefaultResourceNamePrefix+dspa.Name, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	env := map[string]string{}
+	for _, envVar := range deployment.Spec.Template.Spec.Containers[0].Env {
+		env[envVar.Name] = envVar.Value
+	}
+	assert.Equal(t, "s3.eu-west-1.amazonaws.com", env["MINIO_SERVICE_SERVICE_HOST"])
+	assert.Equal(t, "mybucket-replica", env["OBJECTSTORECONFIG_BUCKETNAME"])
+	assert.Equal(t, "5", env["OBJECTSTORECONFIG_MAXRETRIES"])
+	assert.Equal(t, "https://s3.us-east-1.amazonaws.com,http://minio.dr.example.com:9000", env["OBJECTSTORECONFIG_FAILOVERENDPOINTS"])
+
+	// Assert the primary error is reported when no endpoint is available
+	available["s3.eu-west-1.amazonaws.com"] = false
+	_, params, _ = CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	verified, err = reconciler.isObjectStorageAccessible(ctx, dspa, params)
+	assert.False(t, verified)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, "https://s3.us-east-1.amazonaws.com", params.ObjectStorageConnection.Endpoint)
+
+	// Assert separate buckets cannot be set along with the failover endpoints
+	dspa.Spec.ObjectStorage.Buckets = &dspav1.ObjectStorageBuckets{Artifacts: "run-artifacts"}
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "[spec.objectStorage.buckets] cannot be set along with [spec.objectStorage.externalStorage.failoverEndpoints]")
+}
+
+func TestObjectStorageTransferAcceleration(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.ObjectStorage = &dspav1.ObjectStorage{ExternalStorage: &dspav1.ExternalStorage{
+		Host:   "s3.us-east-1.amazonaws.com",
+		Bucket: "mybucket",
+		Scheme: "https",
+		S3CredentialSecret: &dspav1.S3CredentialSecret{
+			SecretName: "storage-credentials",
+			AccessKey:  "accesskey",
+			SecretKey:  "secretkey",
+		},
+		TransferAcceleration: true,
+	}}
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, reconciler.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-credentials", Namespace: dspa.Namespace},
+		Data:       map[string][]byte{"accesskey": []byte("accesskey"), "secretkey": []byte("secretkey")},
+	}))
+
+	// Assert the components connect to the acceleration endpoint
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	assert.Equal(t, "https://s3-accelerate.amazonaws.com", params.ObjectStorageConnection.Endpoint)
+	assert.Equal(t, "mybucket", params.ObjectStorageConnection.Bucket)
+
+	// Assert acceleration is rejected for other hosts, and for bucket names with dots
+	dspa.Spec.ObjectStorage.ExternalStorage.Bucket = "my.bucket"
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "not supported for bucket names containing dots")
+	dspa.Spec.ObjectStorage.ExternalStorage.Host = "s3.example.com"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "only supported for AWS S3 hosts")
+}
+
 func TestIsDatabaseAccessibleTrue(t *testing.T) {
 	// Override the live connection function with a mock version
-	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, objStoreConnectionTimeout time.Duration) (bool, error) {
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
 		return true, nil
 	}
 
@@ -298,8 +689,8 @@ func TestIsDatabaseAccessibleTrue(t *testing.T) {
 			Host:            "foo",
 			Port:            "1337",
 			Secure:          &SecureConnection,
-			AccessKeyID:     base64.StdEncoding.EncodeToString([]byte("fooaccesskey")),
-			SecretAccessKey: base64.StdEncoding.EncodeToString([]byte("foosecretkey")),
+			AccessKeyID:     NewCredential([]byte("fooaccesskey")),
+			SecretAccessKey: NewCredential([]byte("foosecretkey")),
 		},
 	}
 
@@ -309,7 +700,7 @@ func TestIsDatabaseAccessibleTrue(t *testing.T) {
 
 func TestIsDatabaseNotAccessibleFalse(t *testing.T) {
 	// Override the live connection function with a mock version
-	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, objStoreConnectionTimeout time.Duration) (bool, error) {
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
 		return false, errors.New("Object Store is not Accessible")
 	}
 
@@ -336,8 +727,8 @@ func TestIsDatabaseNotAccessibleFalse(t *testing.T) {
 			Host:            "foo",
 			Port:            "1337",
 			Secure:          &SecureConnection,
-			AccessKeyID:     base64.StdEncoding.EncodeToString([]byte("fooaccesskey")),
-			SecretAccessKey: base64.StdEncoding.EncodeToString([]byte("foosecretkey")),
+			AccessKeyID:     NewCredential([]byte("fooaccesskey")),
+			SecretAccessKey: NewCredential([]byte("foosecretkey")),
 		},
 	}
 
@@ -347,7 +738,7 @@ func TestIsDatabaseNotAccessibleFalse(t *testing.T) {
 
 func TestDisabledHealthCheckReturnsTrue(t *testing.T) {
 	// Override the live connection function with a mock version that would always return false if called
-	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, objStoreConnectionTimeout time.Duration) (bool, error) {
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
 		return false, errors.New("Object Store is not Accessible")
 	}
 
@@ -374,8 +765,8 @@ func TestDisabledHealthCheckReturnsTrue(t *testing.T) {
 			Host:            "foo",
 			Port:            "1337",
 			Secure:          &SecureConnection,
-			AccessKeyID:     base64.StdEncoding.EncodeToString([]byte("fooaccesskey")),
-			SecretAccessKey: base64.StdEncoding.EncodeToString([]byte("foosecretkey")),
+			AccessKeyID:     NewCredential([]byte("fooaccesskey")),
+			SecretAccessKey: NewCredential([]byte("foosecretkey")),
 		},
 	}
 
@@ -387,7 +778,7 @@ func TestDisabledHealthCheckReturnsTrue(t *testing.T) {
 
 func TestIsDatabaseAccessibleBadAccessKey(t *testing.T) {
 	// Override the live connection function with a mock version
-	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, objStoreConnectionTimeout time.Duration) (bool, error) {
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
 		return true, nil
 	}
 
@@ -414,8 +805,8 @@ func TestIsDatabaseAccessibleBadAccessKey(t *testing.T) {
 			Host:            "foo",
 			Port:            "1337",
 			Secure:          &SecureConnection,
-			AccessKeyID:     "this-is-not-a-base64-encoded-string",
-			SecretAccessKey: base64.StdEncoding.EncodeToString([]byte("foosecretkey")),
+			AccessKeyID:     Credential{},
+			SecretAccessKey: NewCredential([]byte("foosecretkey")),
 		},
 	}
 
@@ -425,7 +816,7 @@ func TestIsDatabaseAccessibleBadAccessKey(t *testing.T) {
 
 func TestIsDatabaseAccessibleBadSecretKey(t *testing.T) {
 	// Override the live connection function with a mock version
-	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, objStoreConnectionTimeout time.Duration) (bool, error) {
+	ConnectAndQueryObjStore = func(ctx context.Context, log logr.Logger, endpoint, bucket, region string, accesskey, secretkey []byte, secure bool, pemCerts [][]byte, fipsMode bool, objStoreConnectionTimeout time.Duration) (bool, error) {
 		return true, nil
 	}
 
@@ -452,8 +843,8 @@ func TestIsDatabaseAccessibleBadSecretKey(t *testing.T) {
 			Host:            "foo",
 			Port:            "1337",
 			Secure:          &SecureConnection,
-			AccessKeyID:     base64.StdEncoding.EncodeToString([]byte("fooaccesskey")),
-			SecretAccessKey: "this-is-not-a-base64-encoded-string",
+			AccessKeyID:     NewCredential([]byte("fooaccesskey")),
+			SecretAccessKey: Credential{},
 		},
 	}
 
@@ -550,13 +941,21 @@ S9IA40yOaVHMI51Fr1i1EIWvP8oJY8rAPWq45JnfFen3tOqKfw==
 	_, _, reconciler := CreateNewTestObjects()
 
 	validCerts := [][]byte{[]byte(validCert)}
-	transport, err := getHttpsTransportWithCACert(reconciler.Log, validCerts)
+	transport, err := getHttpsTransportWithCACert(reconciler.Log, validCerts, false)
 	assert.Nil(t, err)
 	assert.NotNil(t, transport)
 
 	invalidCert := "invalidCert"
 	invalidCerts := [][]byte{[]byte(invalidCert)}
-	transport, err = getHttpsTransportWithCACert(reconciler.Log, invalidCerts)
+	transport, err = getHttpsTransportWithCACert(reconciler.Log, invalidCerts, false)
 	assert.NotNil(t, err)
 	assert.Nil(t, transport)
+
+	// Assert the transport is restricted to FIPS approved cipher suites with the DSPA in FIPS mode
+	transport, err = getHttpsTransportWithCACert(reconciler.Log, validCerts, false)
+	assert.Nil(t, err)
+	assert.Nil(t, transport.TLSClientConfig.CipherSuites)
+	transport, err = getHttpsTransportWithCACert(reconciler.Log, validCerts, true)
+	assert.Nil(t, err)
+	assert.Equal(t, util.FIPSCipherSuites, transport.TLSClientConfig.CipherSuites)
 }
@@ -0,0 +1,122 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dspaCRDName is the name of the DataSciencePipelinesApplication CRD.
+var dspaCRDName = "datasciencepipelinesapplications." + dspav1.GroupVersion.Group
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
+
+// StorageVersionMigrator rewrites the DSPAs persisted in an older version of
+// the CRD to its current storage version when the operator starts, then
+// prunes the older versions from the CRD stored versions so that they can be
+// removed from the CRD in a later release.
+type StorageVersionMigrator struct {
+	Client client.Client
+	// APIReader reads without the manager cache, so CRDs are not watched
+	APIReader client.Reader
+	Log       logr.Logger
+}
+
+// Start runs the migration once, failures are logged and retried on the next
+// operator start rather than preventing the manager from starting.
+func (m *StorageVersionMigrator) Start(ctx context.Context) error {
+	if err := m.Migrate(ctx); err != nil {
+		m.Log.Error(err, "Encountered error when migrating DSPAs to the CRD storage version")
+	}
+	return nil
+}
+
+// NeedLeaderElection only runs the migration on the leader, as it rewrites DSPAs.
+func (m *StorageVersionMigrator) NeedLeaderElection() bool {
+	return true
+}
+
+// Migrate rewrites every DSPA in the storage version of the CRD, and sets the
+// CRD stored versions to the storage version once all DSPAs are rewritten.
+func (m *StorageVersionMigrator) Migrate(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.APIReader.Get(ctx, types.NamespacedName{Name: dspaCRDName}, crd); err != nil {
+		return err
+	}
+	storageVersion := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			storageVersion = version.Name
+		}
+	}
+	if storageVersion == "" {
+		return fmt.Errorf("CRD %s has no storage version", dspaCRDName)
+	}
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		m.Log.V(1).Info(fmt.Sprintf("DSPAs are stored in version %s, no migration needed.", storageVersion))
+		return nil
+	}
+
+	m.Log.Info(fmt.Sprintf("Migrating DSPAs stored in versions %v to version %s.", crd.Status.StoredVersions, storageVersion))
+	gv := schema.GroupVersion{Group: dspav1.GroupVersion.Group, Version: storageVersion}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gv.WithKind("DataSciencePipelinesApplicationList"))
+	if err := m.APIReader.List(ctx, list); err != nil {
+		return err
+	}
+	for _, item := range list.Items {
+		nn := types.NamespacedName{Name: item.GetName(), Namespace: item.GetNamespace()}
+		// An unchanged update is enough, the API server persists it in the storage version
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			dspa := &unstructured.Unstructured{}
+			dspa.SetGroupVersionKind(gv.WithKind("DataSciencePipelinesApplication"))
+			if err := m.APIReader.Get(ctx, nn, dspa); err != nil {
+				return err
+			}
+			return m.Client.Update(ctx, dspa)
+		})
+		if apierrs.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to migrate DSPA %s to version %s: %w", nn, storageVersion, err)
+		}
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.APIReader.Get(ctx, types.NamespacedName{Name: dspaCRDName}, crd); err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		return m.Client.Status().Update(ctx, crd)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to prune the stored versions of CRD %s: %w", dspaCRDName, err)
+	}
+	m.Log.Info(fmt.Sprintf("Migrated %d DSPAs to version %s.", len(list.Items), storageVersion))
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+func TestStorageVersionMigration(t *testing.T) {
+	ctx, _, reconciler := CreateNewTestObjects()
+	utilruntime.Must(apiextensionsv1.AddToScheme(reconciler.Scheme))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: dspaCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Served: true, Storage: true},
+				{Name: "v1alpha1", Served: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			StoredVersions: []string{"v1alpha1", "v1"},
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, crd))
+	dspa := testutil.CreateEmptyDSPA()
+	require.Nil(t, reconciler.Create(ctx, dspa))
+	resourceVersion := dspa.ResourceVersion
+
+	migrator := &StorageVersionMigrator{Client: reconciler.Client, APIReader: reconciler.Client, Log: reconciler.Log}
+
+	// Assert DSPAs are rewritten and older versions pruned from the CRD
+	require.Nil(t, migrator.Migrate(ctx))
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: dspaCRDName}, crd))
+	assert.Equal(t, []string{"v1"}, crd.Status.StoredVersions)
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: dspa.Name, Namespace: dspa.Namespace}, dspa))
+	assert.NotEqual(t, resourceVersion, dspa.ResourceVersion)
+
+	// Assert nothing is rewritten once migrated
+	resourceVersion = dspa.ResourceVersion
+	require.Nil(t, migrator.Migrate(ctx))
+	require.Nil(t, reconciler.Get(ctx, types.NamespacedName{Name: dspa.Name, Namespace: dspa.Namespace}, dspa))
+	assert.Equal(t, resourceVersion, dspa.ResourceVersion)
+}
@@ -20,8 +20,11 @@ package controllers
 
 import (
 	"context"
+	cryptoTls "crypto/tls"
 	"github.com/go-logr/logr"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	manifests "github.com/opendatahub-io/data-science-pipelines-operator/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -77,16 +80,19 @@ func (s *ControllerSuite) SetupTest() {
 		port, username, password, dbname, tls string,
 		dbConnectionTimeout time.Duration,
 		pemCerts [][]byte,
+		fipsMode bool,
+		clientCerts []cryptoTls.Certificate,
 		extraParams map[string]string) (bool, error) {
 		return true, nil
 	}
 	ConnectAndQueryObjStore = func(
 		ctx context.Context,
 		log logr.Logger,
-		endpoint, bucket string,
+		endpoint, bucket, region string,
 		accesskey, secretkey []byte,
 		secure bool,
 		pemCerts [][]byte,
+		fipsMode bool,
 		objStoreConnectionTimeout time.Duration) (bool, error) {
 		return true, nil
 	}
@@ -137,10 +143,10 @@ func (s *ControllerSuite) SetupSuite() {
 	assert.NoError(s.T(), err)
 
 	err = (&DSPAReconciler{
-		Client:        k8sClient,
-		Log:           ctrl.Log.WithName("controllers").WithName("ds-pipelines-controller"),
-		Scheme:        scheme.Scheme,
-		TemplatesPath: "../config/internal/",
+		Client:    k8sClient,
+		Log:       ctrl.Log.WithName("controllers").WithName("ds-pipelines-controller"),
+		Scheme:    scheme.Scheme,
+		Templates: config.CachedTemplates(manifests.Templates()),
 	}).SetupWithManager(mgr)
 	assert.NoError(s.T(), err)
 
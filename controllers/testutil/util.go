@@ -29,7 +29,9 @@ import (
 
 	mf "github.com/manifestival/manifestival"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -56,6 +58,8 @@ type Case struct {
 type Expectation struct {
 	Created    []string
 	NotCreated []string
+	// Conditions are DSPA manifests holding the status conditions expected of the deployed DSPAs
+	Conditions []string
 }
 
 // ResourceDoesNotExists will check against the client provided
@@ -144,6 +148,42 @@ func CompareResources(uc UtilContext, path string, t *testing.T) {
 	require.True(t, result)
 }
 
+// CompareConditions compares the status conditions of the DSPA found
+// locally in path against those of the DSPA in the k8s cluster, until
+// they match or the timeout is reached. Only the status of the expected
+// conditions and, when set, their reason are compared.
+func CompareConditions(uc UtilContext, path string, t *testing.T) {
+	manifest, err := mf.NewManifest(path, uc.Opts)
+	require.NoError(t, err)
+	manifest, err = manifest.Transform(mf.InjectNamespace(uc.Ns))
+	require.NoError(t, err)
+	expected := &dspav1.DataSciencePipelinesApplication{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(manifest.Resources()[0].Object, expected))
+
+	mismatch := ""
+	assert.Eventually(t, func() bool {
+		actual := &dspav1.DataSciencePipelinesApplication{}
+		err := uc.Client.Get(uc.Ctx, client.ObjectKeyFromObject(expected), actual)
+		if err != nil {
+			mismatch = err.Error()
+			return false
+		}
+		for _, expectedCondition := range expected.Status.Conditions {
+			condition := meta.FindStatusCondition(actual.Status.Conditions, expectedCondition.Type)
+			if condition == nil || condition.Status != expectedCondition.Status ||
+				(expectedCondition.Reason != "" && condition.Reason != expectedCondition.Reason) {
+				mismatch = fmt.Sprintf("expected condition %s to be %s %s, got %+v",
+					expectedCondition.Type, expectedCondition.Status, expectedCondition.Reason, condition)
+				return false
+			}
+		}
+		return true
+	}, timeout, interval)
+	if t.Failed() {
+		t.Log(mismatch)
+	}
+}
+
 // DirExists checks whether dir at path exists
 func DirExists(path string) (bool, error) {
 	_, err := os.Stat(path)
@@ -197,6 +237,17 @@ func GenerateDeclarativeTestCases(t *testing.T) []Case {
 			}
 		}
 
+		caseConditionsDir := fmt.Sprintf("%s/expected/conditions", caseDir)
+		caseConditionsFound, err := DirExists(caseConditionsDir)
+		assert.NoError(t, err, "Failed to read 'conditions' dir.")
+		if caseConditionsFound {
+			conditions, err := os.ReadDir(caseConditionsDir)
+			assert.NoError(t, err, "Failed to read 'conditions' dir.")
+			for _, f := range conditions {
+				newCase.Expected.Conditions = append(newCase.Expected.Conditions, fmt.Sprintf("%s/%s", caseConditionsDir, f.Name()))
+			}
+		}
+
 		newCase.Description = fmt.Sprintf("[%s] - When a DSPA is deployed", caseName)
 
 		newCase.Config = fmt.Sprintf("%s/config.yaml", caseDir)
@@ -235,6 +286,38 @@ func CreateEmptyDSPA() *dspav1.DataSciencePipelinesApplication {
 	return dspa
 }
 
+// CreateDefaultDSPA returns a DSPA deploying the API server, MLMD and MariaDB,
+// with an external object storage.
+func CreateDefaultDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := &dspav1.DataSciencePipelinesApplication{
+		Spec: dspav1.DSPASpec{
+			PodToPodTLS: boolPtr(false),
+			APIServer: &dspav1.APIServer{
+				Deploy: true,
+			},
+			MLMD: &dspav1.MLMD{
+				Deploy: true,
+			},
+			Database: &dspav1.Database{
+				DisableHealthCheck: false,
+				MariaDB: &dspav1.MariaDB{
+					Deploy: true,
+				},
+			},
+			ObjectStorage: &dspav1.ObjectStorage{
+				DisableHealthCheck: false,
+				Minio: &dspav1.Minio{
+					Deploy: false,
+					Image:  "someimage",
+				},
+			},
+		},
+	}
+	dspa.Name = "testdspa"
+	dspa.Namespace = "testnamespace"
+	return dspa
+}
+
 func CreateDSPAWithAPIServerCABundle(key string, cfgmapName string) *dspav1.DataSciencePipelinesApplication {
 	dspa := CreateEmptyDSPA()
 	dspa.Spec.APIServer = &dspav1.APIServer{
@@ -0,0 +1,62 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedRecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prevProvider)
+
+	ctx, parent := tracer.Start(context.Background(), "Reconcile")
+	err := traced(ctx, "ReconcileDatabase", func(ctx context.Context) error {
+		return nil
+	})
+	assert.Nil(t, err)
+
+	expectedErr := errors.New("failed to apply")
+	err = traced(ctx, "ReconcileStorage", func(ctx context.Context) error {
+		return expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	assert.Equal(t, "ReconcileDatabase", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "ReconcileStorage", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "failed to apply", spans[1].Status().Description)
+}
@@ -0,0 +1,453 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const upgradeResourceNamePrefix = "ds-pipeline-upgrade-"
+
+const (
+	upgradeBackupJobNamePrefix  = "ds-pipeline-upgrade-backup-"
+	upgradeRestoreJobNamePrefix = "ds-pipeline-upgrade-restore-"
+)
+
+var upgradeBackupTemplates = []string{
+	"upgrade/pvc.yaml.tmpl",
+	"upgrade/backup-job.yaml.tmpl",
+}
+
+const upgradeRestoreTemplate = "upgrade/restore-job.yaml.tmpl"
+
+// Keys of the upgrade record ConfigMap, which tracks the upgrade across
+// reconciles and holds what is needed to roll it back.
+const (
+	upgradePhaseKey       = "phase"
+	upgradeMessageKey     = "message"
+	upgradeStartedAtKey   = "phaseStartedAt"
+	upgradeDeploymentsKey = "deployments"
+)
+
+// upgradeSavedDeployment is a DSP v1 Deployment as it was before the upgrade.
+type upgradeSavedDeployment struct {
+	Name   string                `json:"name"`
+	Labels map[string]string     `json:"labels,omitempty"`
+	Spec   appsv1.DeploymentSpec `json:"spec"`
+}
+
+// ReconcileUpgrade upgrades the DSP v1 deployment of a DSPA whose dspVersion
+// was changed to v2. The database is backed up and the v1 components scaled
+// down before the v2 components are deployed, the v2 API server migrating
+// the schema on start. If the v2 API server does not become available in
+// time, the v1 components are restored along with the database backup.
+//
+// It returns the current upgrade phase, empty when there is no upgrade. The
+// components are only reconciled while migrating or once the upgrade completed.
+func (r *DSPAReconciler) ReconcileUpgrade(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, dspaStatus dspastatus.DSPAStatus) (string, error) {
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	record := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: params.UpgradeResourceName, Namespace: dsp.Namespace}, record)
+	if apierrs.IsNotFound(err) {
+		record, err = r.startUpgrade(ctx, dsp, params)
+		if err != nil || record == nil {
+			return "", err
+		}
+		log.Info("Found DSP v1 components, starting the upgrade to DSP v2.")
+	} else if err != nil {
+		return "", err
+	}
+
+	switch record.Data[upgradePhaseKey] {
+	case config.UpgradeSnapshottingDatabase:
+		err = r.snapshotDatabase(ctx, dsp, params, record)
+	case config.UpgradeMigratingToV2:
+		err = r.migrateToV2(ctx, dsp, params, record)
+	case config.UpgradeRollingBack:
+		err = r.rollBackToV1(ctx, dsp, params, record)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	phase := record.Data[upgradePhaseKey]
+	message := record.Data[upgradeMessageKey]
+	switch phase {
+	case config.UpgradeSnapshottingDatabase, config.UpgradeMigratingToV2, config.UpgradeRollingBack:
+		condition := dspastatus.BuildTrueCondition(config.UpgradeProgressing, message)
+		condition.Reason = phase
+		dspaStatus.SetUpgradeStatus(condition)
+	default:
+		dspaStatus.SetUpgradeStatus(dspastatus.BuildFalseCondition(config.UpgradeProgressing, phase, message))
+	}
+	return phase, nil
+}
+
+// startUpgrade creates the upgrade record if the DSPA still has DSP v1
+// Deployments, saving them for a rollback. It returns nil when there is
+// nothing to upgrade.
+func (r *DSPAReconciler) startUpgrade(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) (*corev1.ConfigMap, error) {
+	deployments, err := r.listDeployments(ctx, dsp, "v1")
+	if err != nil || len(deployments) == 0 {
+		return nil, err
+	}
+
+	var saved []upgradeSavedDeployment
+	for _, deployment := range deployments {
+		// Storage stays up for the backup and is reconciled as usual
+		if isStorageDeployment(deployment.Name, dsp) {
+			continue
+		}
+		saved = append(saved, upgradeSavedDeployment{
+			Name:   deployment.Name,
+			Labels: deployment.Labels,
+			Spec:   deployment.Spec,
+		})
+	}
+	savedJSON, err := json.Marshal(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leftovers of a previous attempt must not be mistaken for this one
+	for _, name := range []string{upgradeBackupJobNamePrefix + dsp.Name, upgradeRestoreJobNamePrefix + dsp.Name} {
+		if err := r.deleteJobIfItExists(ctx, name, dsp.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	record := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      params.UpgradeResourceName,
+			Namespace: dsp.Namespace,
+			Labels: map[string]string{
+				"app":       params.UpgradeResourceName,
+				"component": "data-science-pipelines",
+				"dspa":      dsp.Name,
+			},
+		},
+		Data: map[string]string{
+			upgradeDeploymentsKey: string(savedJSON),
+		},
+	}
+	setUpgradePhase(record, config.UpgradeSnapshottingDatabase,
+		fmt.Sprintf("Backing up the database to PersistentVolumeClaim %s", params.UpgradeResourceName))
+	if err := controllerutil.SetControllerReference(dsp, record, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// snapshotDatabase runs the database backup Job, then scales the DSP v1
+// components down so nothing writes to the database during the migration.
+func (r *DSPAReconciler) snapshotDatabase(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, record *corev1.ConfigMap) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: upgradeBackupJobNamePrefix + dsp.Name, Namespace: dsp.Namespace}, job)
+	if apierrs.IsNotFound(err) {
+		return r.ApplyAll(dsp, params, upgradeBackupTemplates)
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case isJobSucceeded(job):
+		saved, err := savedDeployments(record)
+		if err != nil {
+			return err
+		}
+		for _, deployment := range saved {
+			if err := r.scaleDeployment(ctx, deployment.Name, dsp.Namespace, 0); err != nil {
+				return err
+			}
+		}
+		setUpgradePhase(record, config.UpgradeMigratingToV2,
+			fmt.Sprintf("Database backed up to PersistentVolumeClaim %s, waiting for the DSP v2 API server to become available", params.UpgradeResourceName))
+	case isJobFailed(job):
+		setUpgradePhase(record, config.UpgradeFailed,
+			fmt.Sprintf("Database backup Job %s failed, the DSP v1 components were left running. Delete ConfigMap %s to retry the upgrade",
+				job.Name, record.Name))
+	case upgradePhaseTimedOut(record):
+		setUpgradePhase(record, config.UpgradeFailed,
+			fmt.Sprintf("Database backup Job %s did not complete in time, the DSP v1 components were left running. Delete ConfigMap %s to retry the upgrade",
+				job.Name, record.Name))
+	default:
+		return nil
+	}
+	return r.Update(ctx, record)
+}
+
+// migrateToV2 waits for the v2 API server, which migrates the database schema
+// on start, to become available and rolls back if it does not in time.
+func (r *DSPAReconciler) migrateToV2(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, record *corev1.ConfigMap) error {
+	apiServer := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: params.APIServerDefaultResourceName, Namespace: dsp.Namespace}, apiServer)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+
+	switch {
+	case err == nil && apiServer.Labels[config.DSPVersionk8sLabel] == params.DSPVersion && isDeploymentRolledOut(apiServer):
+		if err := r.deleteJobIfItExists(ctx, upgradeBackupJobNamePrefix+dsp.Name, dsp.Namespace); err != nil {
+			return err
+		}
+		delete(record.Data, upgradeDeploymentsKey)
+		setUpgradePhase(record, config.UpgradeCompleted,
+			fmt.Sprintf("Upgraded to DSP %s, the database backup is kept in PersistentVolumeClaim %s", params.DSPVersion, params.UpgradeResourceName))
+	case err == nil && isDeploymentProgressDeadlineExceeded(apiServer), upgradePhaseTimedOut(record):
+		saved, err := savedDeployments(record)
+		if err != nil {
+			return err
+		}
+		// Bring the v1 components back stopped, they start once the database is restored
+		for _, deployment := range saved {
+			if err := r.restoreDeployment(ctx, dsp, deployment, 0); err != nil {
+				return err
+			}
+		}
+		if err := r.scaleDownNewDeployments(ctx, dsp, saved); err != nil {
+			return err
+		}
+		if err := r.Apply(dsp, params, upgradeRestoreTemplate); err != nil {
+			return err
+		}
+		setUpgradePhase(record, config.UpgradeRollingBack,
+			fmt.Sprintf("DSP %s API server did not become available, restoring the database from PersistentVolumeClaim %s",
+				params.DSPVersion, params.UpgradeResourceName))
+	default:
+		return nil
+	}
+	return r.Update(ctx, record)
+}
+
+// rollBackToV1 waits for the database restore Job and starts the DSP v1
+// components again once it succeeds.
+func (r *DSPAReconciler) rollBackToV1(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams, record *corev1.ConfigMap) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: upgradeRestoreJobNamePrefix + dsp.Name, Namespace: dsp.Namespace}, job)
+	if apierrs.IsNotFound(err) {
+		return r.Apply(dsp, params, upgradeRestoreTemplate)
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case isJobSucceeded(job):
+		saved, err := savedDeployments(record)
+		if err != nil {
+			return err
+		}
+		for _, deployment := range saved {
+			replicas := int32(1)
+			if deployment.Spec.Replicas != nil {
+				replicas = *deployment.Spec.Replicas
+			}
+			if err := r.scaleDeployment(ctx, deployment.Name, dsp.Namespace, replicas); err != nil {
+				return err
+			}
+		}
+		setUpgradePhase(record, config.UpgradeRolledBack,
+			fmt.Sprintf("Upgrade to DSP %s failed and was rolled back to DSP v1. Delete ConfigMap %s to retry the upgrade",
+				params.DSPVersion, record.Name))
+	case isJobFailed(job):
+		setUpgradePhase(record, config.UpgradeFailed,
+			fmt.Sprintf("Database restore Job %s failed, restore the database manually from PersistentVolumeClaim %s",
+				job.Name, params.UpgradeResourceName))
+	default:
+		return nil
+	}
+	return r.Update(ctx, record)
+}
+
+// listDeployments returns the Deployments of dsp labeled with the DSP version.
+func (r *DSPAReconciler) listDeployments(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	version string) ([]appsv1.Deployment, error) {
+	deployments := &appsv1.DeploymentList{}
+	err := r.List(ctx, deployments, client.InNamespace(dsp.Namespace), client.MatchingLabels{
+		"dspa":                    dsp.Name,
+		config.DSPVersionk8sLabel: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deployments.Items, nil
+}
+
+// restoreDeployment reverts a Deployment to its saved DSP v1 manifest.
+func (r *DSPAReconciler) restoreDeployment(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	saved upgradeSavedDeployment, replicas int32) error {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: saved.Name, Namespace: dsp.Namespace}, deployment)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	deployment.Name = saved.Name
+	deployment.Namespace = dsp.Namespace
+	deployment.Labels = saved.Labels
+	deployment.Spec = saved.Spec
+	deployment.Spec.Replicas = &replicas
+	if found {
+		return r.Update(ctx, deployment)
+	}
+	if err := controllerutil.SetControllerReference(dsp, deployment, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, deployment)
+}
+
+// scaleDownNewDeployments stops the components that were added by the
+// upgrade, as they have no DSP v1 manifest to revert to.
+func (r *DSPAReconciler) scaleDownNewDeployments(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	saved []upgradeSavedDeployment) error {
+	deployments, err := r.listDeployments(ctx, dsp, config.DSPV2VersionString)
+	if err != nil {
+		return err
+	}
+	restored := map[string]bool{}
+	for _, deployment := range saved {
+		restored[deployment.Name] = true
+	}
+	for _, deployment := range deployments {
+		if restored[deployment.Name] || isStorageDeployment(deployment.Name, dsp) {
+			continue
+		}
+		if err := r.scaleDeployment(ctx, deployment.Name, dsp.Namespace, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *DSPAReconciler) scaleDeployment(ctx context.Context, name, namespace string, replicas int32) error {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, deployment)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	deployment.Spec.Replicas = &replicas
+	return r.Update(ctx, deployment)
+}
+
+func (r *DSPAReconciler) deleteJobIfItExists(ctx context.Context, name, namespace string) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, job)
+	if apierrs.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// Background propagation removes the Job pods along with it
+	err = r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func savedDeployments(record *corev1.ConfigMap) ([]upgradeSavedDeployment, error) {
+	var saved []upgradeSavedDeployment
+	if err := json.Unmarshal([]byte(record.Data[upgradeDeploymentsKey]), &saved); err != nil {
+		return nil, fmt.Errorf("unable to read the DSP v1 deployments saved in ConfigMap %s: %w", record.Name, err)
+	}
+	return saved, nil
+}
+
+func setUpgradePhase(record *corev1.ConfigMap, phase, message string) {
+	record.Data[upgradePhaseKey] = phase
+	record.Data[upgradeMessageKey] = message
+	record.Data[upgradeStartedAtKey] = time.Now().UTC().Format(time.RFC3339)
+}
+
+// upgradePhaseTimedOut returns true if the current phase of the upgrade has
+// been running for longer than the upgrade timeout.
+func upgradePhaseTimedOut(record *corev1.ConfigMap) bool {
+	startedAt, err := time.Parse(time.RFC3339, record.Data[upgradeStartedAtKey])
+	if err != nil {
+		return false
+	}
+	timeout := config.GetDurationConfigWithDefault(config.UpgradeTimeoutConfigName, config.DefaultUpgradeTimeout)
+	return time.Since(startedAt) > timeout
+}
+
+// isStorageDeployment returns true for the database and object storage
+// Deployments managed by the DSPA, which the upgrade leaves running.
+func isStorageDeployment(name string, dsp *dspav1.DataSciencePipelinesApplication) bool {
+	return name == "mariadb-"+dsp.Name || name == "minio-"+dsp.Name
+}
+
+func isJobSucceeded(job *batchv1.Job) bool {
+	return job.Status.Succeeded > 0
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// isDeploymentRolledOut returns true once the latest spec of deployment has
+// ready replicas, a scaled down Deployment is Available but not rolled out.
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation || deployment.Status.ReadyReplicas == 0 ||
+		deployment.Status.UpdatedReplicas < deployment.Status.Replicas {
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentAvailable && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func isDeploymentProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
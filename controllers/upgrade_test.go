@@ -0,0 +1,215 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/dspastatus"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func upgradeTestDSPA() *dspav1.DataSciencePipelinesApplication {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.DSPVersion = "v2"
+	return dspa
+}
+
+func createUpgradeTestDeployment(t *testing.T, ctx context.Context, reconciler *DSPAReconciler,
+	dspa *dspav1.DataSciencePipelinesApplication, name, version string, replicas int32) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: dspa.Namespace,
+			Labels: map[string]string{
+				"app":                     name,
+				"dspa":                    dspa.Name,
+				config.DSPVersionk8sLabel: version,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: name, Image: name + ":" + version}},
+				},
+			},
+		},
+	}
+	require.Nil(t, reconciler.Create(ctx, deployment))
+}
+
+func getUpgradeTestDeployment(t *testing.T, ctx context.Context, reconciler *DSPAReconciler, name, namespace string) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, name, namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	return deployment
+}
+
+func completeUpgradeTestJob(t *testing.T, ctx context.Context, reconciler *DSPAReconciler, name, namespace string) {
+	job := &batchv1.Job{}
+	created, err := reconciler.IsResourceCreated(ctx, job, name, namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	job.Status.Succeeded = 1
+	require.Nil(t, reconciler.Update(ctx, job))
+}
+
+func TestReconcileUpgradeNoV1Deployments(t *testing.T) {
+	dspa := upgradeTestDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	createUpgradeTestDeployment(t, ctx, reconciler, dspa, params.APIServerDefaultResourceName, "v2", 1)
+
+	// Assert a DSPA that already runs DSP v2 is not upgraded
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	phase, err := reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	assert.Nil(t, err)
+	assert.Empty(t, phase)
+	assert.Empty(t, util.GetConditionByType(config.UpgradeProgressing, dspaStatus.GetConditions()).Type)
+
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.ConfigMap{}, params.UpgradeResourceName, dspa.Namespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestReconcileUpgrade(t *testing.T) {
+	dspa := upgradeTestDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	createUpgradeTestDeployment(t, ctx, reconciler, dspa, params.APIServerDefaultResourceName, "v1", 1)
+	createUpgradeTestDeployment(t, ctx, reconciler, dspa, "mariadb-"+dspa.Name, "v1", 1)
+
+	// Assert the database backup is started while the v1 components keep running
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	phase, err := reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeSnapshottingDatabase, phase)
+	condition := util.GetConditionByType(config.UpgradeProgressing, dspaStatus.GetConditions())
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, config.UpgradeSnapshottingDatabase, condition.Reason)
+
+	phase, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeSnapshottingDatabase, phase)
+	created, err := reconciler.IsResourceCreated(ctx, &corev1.PersistentVolumeClaim{}, params.UpgradeResourceName, dspa.Namespace)
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int32(1), *getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace).Spec.Replicas)
+
+	// Assert the v1 components, but not the database, are scaled down once backed up
+	completeUpgradeTestJob(t, ctx, reconciler, upgradeBackupJobNamePrefix+dspa.Name, dspa.Namespace)
+	phase, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeMigratingToV2, phase)
+	assert.Equal(t, int32(0), *getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace).Spec.Replicas)
+	assert.Equal(t, int32(1), *getUpgradeTestDeployment(t, ctx, reconciler, "mariadb-"+dspa.Name, dspa.Namespace).Spec.Replicas)
+
+	// Assert the upgrade completes once the v2 API server is rolled out
+	apiServer := getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace)
+	apiServer.Labels[config.DSPVersionk8sLabel] = "v2"
+	apiServer.Status = appsv1.DeploymentStatus{
+		Replicas:        1,
+		UpdatedReplicas: 1,
+		ReadyReplicas:   1,
+		Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+		},
+	}
+	require.Nil(t, reconciler.Update(ctx, apiServer))
+
+	phase, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeCompleted, phase)
+	condition = util.GetConditionByType(config.UpgradeProgressing, dspaStatus.GetConditions())
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, config.UpgradeCompleted, condition.Reason)
+	created, err = reconciler.IsResourceCreated(ctx, &batchv1.Job{}, upgradeBackupJobNamePrefix+dspa.Name, dspa.Namespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestReconcileUpgradeRollback(t *testing.T) {
+	dspa := upgradeTestDSPA()
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	createUpgradeTestDeployment(t, ctx, reconciler, dspa, params.APIServerDefaultResourceName, "v1", 2)
+
+	dspaStatus := dspastatus.NewDSPAStatus(dspa)
+	_, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	_, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	completeUpgradeTestJob(t, ctx, reconciler, upgradeBackupJobNamePrefix+dspa.Name, dspa.Namespace)
+	phase, err := reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	require.Equal(t, config.UpgradeMigratingToV2, phase)
+
+	// Simulate the v2 components being applied, with an API server that fails to roll out
+	apiServer := getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace)
+	apiServer.Labels[config.DSPVersionk8sLabel] = "v2"
+	apiServer.Spec.Template.Spec.Containers[0].Image = "apiserver:v2"
+	apiServer.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+	}
+	require.Nil(t, reconciler.Update(ctx, apiServer))
+	workflowControllerName := "ds-pipeline-workflow-controller-" + dspa.Name
+	createUpgradeTestDeployment(t, ctx, reconciler, dspa, workflowControllerName, "v2", 1)
+
+	// Assert the v1 components are restored stopped while the database is restored
+	phase, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeRollingBack, phase)
+	apiServer = getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace)
+	assert.Equal(t, "v1", apiServer.Labels[config.DSPVersionk8sLabel])
+	assert.Equal(t, params.APIServerDefaultResourceName+":v1", apiServer.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, int32(0), *apiServer.Spec.Replicas)
+	assert.Equal(t, int32(0), *getUpgradeTestDeployment(t, ctx, reconciler, workflowControllerName, dspa.Namespace).Spec.Replicas)
+
+	// Assert the v1 components are started again once the database is restored
+	completeUpgradeTestJob(t, ctx, reconciler, upgradeRestoreJobNamePrefix+dspa.Name, dspa.Namespace)
+	phase, err = reconciler.ReconcileUpgrade(ctx, dspa, params, dspaStatus)
+	require.Nil(t, err)
+	assert.Equal(t, config.UpgradeRolledBack, phase)
+	assert.Equal(t, int32(2), *getUpgradeTestDeployment(t, ctx, reconciler, params.APIServerDefaultResourceName, dspa.Namespace).Spec.Replicas)
+	condition := util.GetConditionByType(config.UpgradeProgressing, dspaStatus.GetConditions())
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, config.UpgradeRolledBack, condition.Reason)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// cosignSignatureAnnotation holds the signature of the payload of a layer
+	// of a cosign signature manifest
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// cosignSignatureType is the critical type of the simple signing payloads
+	cosignSignatureType = "cosign container image signature"
+	// maxCosignPayloadSize bounds the payloads read from the registry, the
+	// simple signing payloads are a few hundred bytes
+	maxCosignPayloadSize = 1 << 20
+)
+
+// ErrNoValidSignature is returned when none of the cosign signatures of an
+// image is valid for the public key, or the image has no signature.
+var ErrNoValidSignature = errors.New("no valid cosign signature")
+
+// cosignManifest is the OCI manifest of the sha256-<digest>.sig tag cosign
+// pushes, each layer a signed simple signing payload.
+type cosignManifest struct {
+	Layers []struct {
+		MediaType   string            `json:"mediaType"`
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// cosignPayload is the simple signing payload of a cosign signature.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// ParseCosignPublicKey parses the PEM encoded public key image signatures are
+// verified with, an ECDSA, RSA or Ed25519 key as generated by cosign.
+func ParseCosignPublicKey(publicKeyPEM string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, errors.New("cosign public key is not PEM encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cosign public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported cosign public key type %T", key)
+}
+
+// VerifyCosignSignature verifies that image, pinned by digest, has a cosign
+// signature valid for publicKey, looked up in the sha256-<digest>.sig tag of
+// its repository. Verified images are cached for the ttl of the resolver.
+// Only key based signatures are supported, keyless signatures require the
+// Fulcio and Rekor transparency logs.
+func (r *ImageDigestResolver) VerifyCosignSignature(ctx context.Context, image string, publicKey crypto.PublicKey) error {
+	if !IsDigestPinned(image) {
+		return fmt.Errorf("image %s must be pinned by digest to verify its signature", image)
+	}
+	digest := image[strings.Index(image, "@")+1:]
+
+	cacheKey := "cosign:" + image
+	r.mu.Lock()
+	cached, ok := r.cache[cacheKey]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return nil
+	}
+
+	ref := ParseImageReference(image)
+	signatureURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s.sig", ref.Registry, ref.Repository,
+		strings.Replace(digest, ":", "-", 1))
+	body, err := r.registryGet(ctx, signatureURL, "application/vnd.oci.image.manifest.v1+json")
+	if err != nil {
+		return fmt.Errorf("unable to fetch the signatures of image %s: %w", image, err)
+	}
+	manifest := cosignManifest{}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return fmt.Errorf("invalid signature manifest of image %s: %w", image, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+		blobURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", ref.Registry, ref.Repository, layer.Digest)
+		payload, err := r.registryGet(ctx, blobURL, "")
+		if err != nil {
+			return fmt.Errorf("unable to fetch the signature payload of image %s: %w", image, err)
+		}
+		if err := verifyCosignPayload(payload, signature, layer.Digest, digest, publicKey); err == nil {
+			r.mu.Lock()
+			r.cache[cacheKey] = cachedDigest{digest: digest, expires: time.Now().Add(r.ttl)}
+			r.mu.Unlock()
+			return nil
+		}
+	}
+	return fmt.Errorf("%w for image %s", ErrNoValidSignature, image)
+}
+
+// verifyCosignPayload verifies that payload is the layer blobDigest, signed
+// by publicKey, and signs the manifest digest of the image.
+func verifyCosignPayload(payload, signature []byte, blobDigest, imageDigest string, publicKey crypto.PublicKey) error {
+	sum := sha256.Sum256(payload)
+	if blobDigest != "sha256:"+hex.EncodeToString(sum[:]) {
+		return errors.New("signature payload does not match its digest")
+	}
+
+	valid := false
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, sum[:], signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature) == nil
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, payload, signature)
+	}
+	if !valid {
+		return errors.New("signature is not valid for the public key")
+	}
+
+	signed := cosignPayload{}
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return err
+	}
+	if signed.Critical.Type != cosignSignatureType {
+		return fmt.Errorf("unsupported signature type %q", signed.Critical.Type)
+	}
+	if signed.Critical.Image.DockerManifestDigest != imageDigest {
+		return fmt.Errorf("signature is for digest %s", signed.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// registryGet returns the body of a GET of registryURL, retried once with an
+// anonymous bearer token when the registry challenges the request.
+func (r *ImageDigestResolver) registryGet(ctx context.Context, registryURL, accept string) ([]byte, error) {
+	get := func(token string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, registryURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r.client.Do(req)
+	}
+
+	resp, err := get("")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, err := r.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, err
+		}
+		resp, err = get(token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, registryURL)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxCosignPayloadSize))
+}
@@ -0,0 +1,135 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cosignRegistry serves the cosign signatures of the org/image repository,
+// signed payloads by the digest of the image they sign.
+func cosignRegistry(t *testing.T, signatures map[string][]signedPayload) *httptest.Server {
+	blobs := map[string][]byte{}
+	manifests := map[string][]byte{}
+	for digest, signed := range signatures {
+		manifest := map[string]interface{}{}
+		var layers []map[string]interface{}
+		for _, s := range signed {
+			sum := sha256.Sum256(s.payload)
+			blobDigest := "sha256:" + hex.EncodeToString(sum[:])
+			blobs[blobDigest] = s.payload
+			layers = append(layers, map[string]interface{}{
+				"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+				"digest":      blobDigest,
+				"annotations": map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(s.signature)},
+			})
+		}
+		manifest["layers"] = layers
+		body, err := json.Marshal(manifest)
+		require.Nil(t, err)
+		manifests["/v2/org/image/manifests/"+strings.Replace(digest, ":", "-", 1)+".sig"] = body
+	}
+
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body, ok := manifests[r.URL.Path]; ok {
+			w.Write(body)
+		} else if body, ok := blobs[strings.TrimPrefix(r.URL.Path, "/v2/org/image/blobs/")]; ok {
+			w.Write(body)
+		} else {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+type signedPayload struct {
+	payload   []byte
+	signature []byte
+}
+
+func signCosignPayload(t *testing.T, key *ecdsa.PrivateKey, digest string) signedPayload {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"org/image"},`+
+		`"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+	sum := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	require.Nil(t, err)
+	return signedPayload{payload: payload, signature: signature}
+}
+
+func TestVerifyCosignSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.Nil(t, err)
+	publicKey, err := ParseCosignPublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	require.Nil(t, err)
+
+	signed := "sha256:" + strings.Repeat("a", 64)
+	otherKeySigned := "sha256:" + strings.Repeat("b", 64)
+	otherDigestSigned := "sha256:" + strings.Repeat("c", 64)
+	unsigned := "sha256:" + strings.Repeat("d", 64)
+	server := cosignRegistry(t, map[string][]signedPayload{
+		signed:            {signCosignPayload(t, otherKey, signed), signCosignPayload(t, key, signed)},
+		otherKeySigned:    {signCosignPayload(t, otherKey, otherKeySigned)},
+		otherDigestSigned: {signCosignPayload(t, key, signed)},
+	})
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "https://")
+	resolver := NewImageDigestResolver(server.Client(), time.Hour)
+
+	// Assert an image with a signature valid for the key is verified, among other signatures
+	assert.Nil(t, resolver.VerifyCosignSignature(context.Background(), registry+"/org/image@"+signed, publicKey))
+
+	// Assert images signed with another key, signatures of other digests and unsigned images are rejected
+	err = resolver.VerifyCosignSignature(context.Background(), registry+"/org/image@"+otherKeySigned, publicKey)
+	assert.ErrorIs(t, err, ErrNoValidSignature)
+	err = resolver.VerifyCosignSignature(context.Background(), registry+"/org/image@"+otherDigestSigned, publicKey)
+	assert.ErrorIs(t, err, ErrNoValidSignature)
+	err = resolver.VerifyCosignSignature(context.Background(), registry+"/org/image@"+unsigned, publicKey)
+	assert.ErrorContains(t, err, "unable to fetch the signatures of image")
+
+	// Assert images must be pinned by digest
+	err = resolver.VerifyCosignSignature(context.Background(), registry+"/org/image:v1", publicKey)
+	assert.ErrorContains(t, err, "must be pinned by digest")
+}
+
+func TestParseCosignPublicKey(t *testing.T) {
+	_, err := ParseCosignPublicKey("not a key")
+	assert.ErrorContains(t, err, "not PEM encoded")
+	_, err = ParseCosignPublicKey(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("invalid")})))
+	assert.ErrorContains(t, err, "invalid cosign public key")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"os"
+	"strings"
+)
+
+// FIPSEnabledPath is the kernel setting reporting whether the node the
+// operator runs on is in FIPS mode. On OpenShift, FIPS is enabled cluster
+// wide at install time, so this also reflects the cluster setting.
+var FIPSEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+// FIPSCipherSuites are the FIPS 140-2 approved TLS 1.2 cipher suites, TLS 1.3
+// suites are not configurable and are all approved.
+var FIPSCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// IsFIPSEnabled returns true if the host kernel is running in FIPS mode.
+func IsFIPSEnabled() bool {
+	data, err := os.ReadFile(FIPSEnabledPath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == "1"
+}
+
+// RestrictTLSConfigToFIPS limits cfg to TLS 1.2+ and FIPS approved cipher
+// suites when fipsMode is set, the spec.fipsMode of the DSPA, which defaults
+// to whether the host is in FIPS mode.
+func RestrictTLSConfigToFIPS(cfg *tls.Config, fipsMode bool) {
+	if !fipsMode {
+		return
+	}
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.CipherSuites = FIPSCipherSuites
+}
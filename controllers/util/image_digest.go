@@ -0,0 +1,258 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRegistry = "registry-1.docker.io"
+	digestHeader    = "Docker-Content-Digest"
+)
+
+// Manifest media types accepted when resolving a tag, so that the digest of
+// the multi-arch index is returned rather than a platform specific manifest.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// IsDigestPinned returns true if image references a manifest digest, e.g.
+// quay.io/org/image@sha256:<digest>.
+func IsDigestPinned(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
+
+// ImageReference is an image split into the parts needed to query its registry.
+type ImageReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+// ParseImageReference splits image into registry, repository and tag,
+// applying the same defaults as container runtimes (docker hub, library/
+// and the latest tag).
+func ParseImageReference(image string) ImageReference {
+	ref := ImageReference{Registry: defaultRegistry}
+	remainder := image
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		remainder = parts[1]
+	}
+	if i := strings.Index(remainder, "@"); i >= 0 {
+		remainder = remainder[:i]
+	}
+	ref.Tag = "latest"
+	if i := strings.LastIndex(remainder, ":"); i >= 0 {
+		ref.Tag = remainder[i+1:]
+		remainder = remainder[:i]
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(remainder, "/") {
+		remainder = "library/" + remainder
+	}
+	ref.Repository = remainder
+	return ref
+}
+
+// ImageDigestResolver resolves image tags to manifest digests using the
+// registry HTTP API, caching results for ttl.
+type ImageDigestResolver struct {
+	client *http.Client
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedDigest
+}
+
+type cachedDigest struct {
+	digest  string
+	expires time.Time
+}
+
+func NewImageDigestResolver(client *http.Client, ttl time.Duration) *ImageDigestResolver {
+	return &ImageDigestResolver{
+		client: client,
+		ttl:    ttl,
+		cache:  map[string]cachedDigest{},
+	}
+}
+
+// Resolve returns the manifest digest image currently points to. Images that
+// are already pinned by digest are returned as is, without a registry call.
+func (r *ImageDigestResolver) Resolve(ctx context.Context, image string) (string, error) {
+	if IsDigestPinned(image) {
+		return image[strings.Index(image, "@")+1:], nil
+	}
+
+	r.mu.Lock()
+	cached, ok := r.cache[image]
+	r.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.digest, nil
+	}
+
+	digest, err := r.fetchDigest(ctx, ParseImageReference(image))
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve digest of image %s: %w", image, err)
+	}
+
+	r.mu.Lock()
+	r.cache[image] = cachedDigest{digest: digest, expires: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return digest, nil
+}
+
+// PinnedImage returns image referenced by digest, e.g. quay.io/org/image:tag
+// becomes quay.io/org/image@sha256:<digest>.
+func PinnedImage(image, digest string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	lastSlash := strings.LastIndex(image, "/")
+	if i := strings.LastIndex(image, ":"); i > lastSlash {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
+
+func (r *ImageDigestResolver) fetchDigest(ctx context.Context, ref ImageReference) (string, error) {
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.Registry, ref.Repository, ref.Tag)
+
+	resp, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	// Registries that allow anonymous pulls still require a bearer token,
+	// fetch one from the realm advertised in the challenge and retry once.
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", err
+		}
+		resp, err = r.headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", resp.Status, manifestURL)
+	}
+	digest := resp.Header.Get(digestHeader)
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry did not return a sha256 digest for %s", manifestURL)
+	}
+	return digest, nil
+}
+
+func (r *ImageDigestResolver) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
+
+func (r *ImageDigestResolver) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	params := parseAuthChallenge(challenge)
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", err
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+var authChallengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// parseAuthChallenge parses a `Bearer realm="...",service="..."` header.
+func parseAuthChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	scheme, rest, found := strings.Cut(challenge, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return params
+	}
+	for _, match := range authChallengeParam.FindAllStringSubmatch(rest, -1) {
+		params[match[1]] = match[2]
+	}
+	return params
+}
+
+// ImageIDDigest returns the digest of the imageID reported in the status of a
+// container, e.g. docker-pullable://quay.io/org/image@sha256:..., or an
+// empty string when the container runtime reports no repository digest.
+func ImageIDDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return ""
+}
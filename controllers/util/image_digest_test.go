@@ -0,0 +1,116 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := map[string]struct {
+		image    string
+		expected ImageReference
+	}{
+		"Registry, repository and tag": {
+			image:    "quay.io/opendatahub/ds-pipelines-api-server:v2.0",
+			expected: ImageReference{Registry: "quay.io", Repository: "opendatahub/ds-pipelines-api-server", Tag: "v2.0"},
+		},
+		"Registry with port and no tag": {
+			image:    "registry.local:5000/org/image",
+			expected: ImageReference{Registry: "registry.local:5000", Repository: "org/image", Tag: "latest"},
+		},
+		"Docker hub official image": {
+			image:    "mariadb:10",
+			expected: ImageReference{Registry: "registry-1.docker.io", Repository: "library/mariadb", Tag: "10"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseImageReference(test.image))
+		})
+	}
+}
+
+func TestPinnedImage(t *testing.T) {
+	assert.Equal(t, "quay.io/org/image@sha256:abc", PinnedImage("quay.io/org/image:v1", "sha256:abc"))
+	assert.Equal(t, "registry.local:5000/image@sha256:abc", PinnedImage("registry.local:5000/image", "sha256:abc"))
+	assert.Equal(t, "quay.io/org/image@sha256:abc", PinnedImage("quay.io/org/image@sha256:old", "sha256:abc"))
+}
+
+func TestImageIDDigest(t *testing.T) {
+	assert.Equal(t, "sha256:abc", ImageIDDigest("docker-pullable://quay.io/org/image@sha256:abc"))
+	assert.Equal(t, "sha256:abc", ImageIDDigest("quay.io/org/image@sha256:abc"))
+	assert.Empty(t, ImageIDDigest("sha256:abc"))
+}
+
+func TestImageDigestResolver(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	manifestRequests := 0
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			assert.Equal(t, "repository:org/image:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+		case "/v2/org/image/manifests/v1":
+			manifestRequests++
+			if r.Header.Get("Authorization") != "Bearer anonymous" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/image:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", digest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry := strings.TrimPrefix(server.URL, "https://")
+	resolver := NewImageDigestResolver(server.Client(), time.Hour)
+
+	resolved, err := resolver.Resolve(context.Background(), registry+"/org/image:v1")
+	require.Nil(t, err)
+	assert.Equal(t, digest, resolved)
+
+	// Resolved digests are cached
+	resolved, err = resolver.Resolve(context.Background(), registry+"/org/image:v1")
+	require.Nil(t, err)
+	assert.Equal(t, digest, resolved)
+	assert.Equal(t, 2, manifestRequests)
+
+	// Pinned images are not looked up
+	resolved, err = resolver.Resolve(context.Background(), registry+"/org/image@sha256:pinned")
+	require.Nil(t, err)
+	assert.Equal(t, "sha256:pinned", resolved)
+
+	_, err = resolver.Resolve(context.Background(), registry+"/org/missing:v1")
+	assert.NotNil(t, err)
+}
@@ -20,9 +20,12 @@ import (
 	"fmt"
 	mf "github.com/manifestival/manifestival"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"io/fs"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"os"
-	"path/filepath"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 
@@ -33,8 +36,10 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -63,8 +68,8 @@ func BoolPointer(b bool) *bool {
 	return &b
 }
 
-func GetTemplatesInDir(templatesDirectory, componentSubdirectory string) ([]string, error) {
-	files, err := os.ReadDir(templatesDirectory + componentSubdirectory)
+func GetTemplatesInDir(templatesFS fs.FS, componentSubdirectory string) ([]string, error) {
+	files, err := fs.ReadDir(templatesFS, componentSubdirectory)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +77,7 @@ func GetTemplatesInDir(templatesDirectory, componentSubdirectory string) ([]stri
 	var templates []string
 	for _, f := range files {
 		if !f.IsDir() {
-			templates = append(templates, filepath.Join(componentSubdirectory, f.Name()))
+			templates = append(templates, path.Join(componentSubdirectory, f.Name()))
 		}
 	}
 	return templates, nil
@@ -174,6 +179,27 @@ func GetRouteHostname(ctx context.Context, routeName, ns string, client client.C
 	return routeHostname, nil
 }
 
+// GetIngressHostname returns the URL of the first host of the Ingress
+// ingressName, as GetRouteHostname does for Routes.
+func GetIngressHostname(ctx context.Context, ingressName, ns string, client client.Client) (string, error) {
+	ingress := &networkingv1.Ingress{}
+	err := client.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: ns}, ingress)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if len(ingress.Spec.Rules) == 0 || ingress.Spec.Rules[0].Host == "" {
+		return "", nil
+	}
+	scheme := "http"
+	if len(ingress.Spec.TLS) > 0 {
+		scheme = "https"
+	}
+	return scheme + "://" + ingress.Spec.Rules[0].Host, nil
+}
+
 func GetServiceIfAvailable(ctx context.Context, svcName, ns string, client client.Client) (bool, *v1.Service, error) {
 	service := &v1.Service{}
 	namespacedNamed := types.NamespacedName{Name: svcName, Namespace: ns}
@@ -215,6 +241,37 @@ func GetSecret(ctx context.Context, secretName, ns string, client client.Client)
 	return secret, nil
 }
 
+// NamespaceInScope returns true if DSPAs in namespace should be reconciled by
+// this operator. Namespaces in the denied list are always out of scope, when
+// an allowed list or namespace label selector is configured the namespace must
+// also match them.
+func NamespaceInScope(ctx context.Context, namespace string, client client.Client) (bool, error) {
+	if slices.Contains(config.GetStringSliceConfigWithDefault(config.DeniedNamespacesConfigName, nil), namespace) {
+		return false, nil
+	}
+
+	allowed := config.GetStringSliceConfigWithDefault(config.AllowedNamespacesConfigName, nil)
+	if len(allowed) > 0 && !slices.Contains(allowed, namespace) {
+		return false, nil
+	}
+
+	selectorString := config.GetStringConfigWithDefault(config.NamespaceSelectorConfigName, "")
+	if selectorString == "" {
+		return true, nil
+	}
+	selector, err := labels.Parse(selectorString)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector [%s] in operator config: %w", selectorString, err)
+	}
+
+	ns := &v1.Namespace{}
+	err = client.Get(ctx, types.NamespacedName{Name: namespace}, ns)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
+
 // DSPAWithSupportedDSPVersion returns True if dspa's dsp version is supported, return False otherwise.
 // Note that the procedure verifies the DSPA's .spec.dspVerson field. Not to be confused with the apiversion.
 func DSPAWithSupportedDSPVersion(dspa *dspav1.DataSciencePipelinesApplication) bool {
@@ -280,3 +337,48 @@ func AddDeploymentPodLabelTransformer(labelKey, labelValue string) mf.Transforme
 		return nil
 	}
 }
+
+// AddLabelsTransformer sets labels on resources and on the pods of
+// deployments, and sets defaultLabels where they do not set them already.
+func AddLabelsTransformer(labels, defaultLabels map[string]string) mf.Transformer {
+	merge := func(existing map[string]string) map[string]string {
+		if existing == nil {
+			existing = map[string]string{}
+		}
+		for key, value := range defaultLabels {
+			if _, ok := existing[key]; !ok {
+				existing[key] = value
+			}
+		}
+		for key, value := range labels {
+			existing[key] = value
+		}
+		return existing
+	}
+	return func(mfObj *unstructured.Unstructured) error {
+		mfObj.SetLabels(merge(mfObj.GetLabels()))
+		if mfObj.GetKind() != "Deployment" {
+			return nil
+		}
+		podLabels, _, err := unstructured.NestedStringMap(mfObj.Object, "spec", "template", "metadata", "labels")
+		if err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedStringMap(mfObj.Object, merge(podLabels), "spec", "template", "metadata", "labels"); err != nil {
+			return fmt.Errorf("failed to set pod labels: %w", err)
+		}
+		return nil
+	}
+}
+
+// OverrideImageRegistry replaces the registry of image with registry, e.g.
+// quay.io/org/image:tag becomes mirror.example.com/org/image:tag. Images
+// without an explicit registry are prefixed with registry.
+func OverrideImageRegistry(image, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return registry + "/" + parts[1]
+	}
+	return registry + "/" + image
+}
@@ -0,0 +1,196 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNamespaceInScope(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewClientBuilder().WithObjects(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "labeled", Labels: map[string]string{"opendatahub.io/dashboard": "true"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}},
+	).Build()
+	defer viper.Reset()
+
+	tests := map[string]struct {
+		denied     string
+		allowed    string
+		selector   string
+		namespace  string
+		expected   bool
+		expectsErr bool
+	}{
+		"No restrictions": {
+			namespace: "unlabeled",
+			expected:  true,
+		},
+		"Denied namespace": {
+			denied:    "other,unlabeled",
+			namespace: "unlabeled",
+			expected:  false,
+		},
+		"Namespace not in allowed list": {
+			allowed:   "labeled",
+			namespace: "unlabeled",
+			expected:  false,
+		},
+		"Namespace in allowed list": {
+			allowed:   "labeled, unlabeled",
+			namespace: "unlabeled",
+			expected:  true,
+		},
+		"Denied takes precedence over allowed": {
+			allowed:   "unlabeled",
+			denied:    "unlabeled",
+			namespace: "unlabeled",
+			expected:  false,
+		},
+		"Namespace matches selector": {
+			selector:  "opendatahub.io/dashboard=true",
+			namespace: "labeled",
+			expected:  true,
+		},
+		"Namespace does not match selector": {
+			selector:  "opendatahub.io/dashboard=true",
+			namespace: "unlabeled",
+			expected:  false,
+		},
+		"Invalid selector": {
+			selector:   "!!",
+			namespace:  "labeled",
+			expected:   false,
+			expectsErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			viper.Reset()
+			if test.denied != "" {
+				viper.Set(config.DeniedNamespacesConfigName, test.denied)
+			}
+			if test.allowed != "" {
+				viper.Set(config.AllowedNamespacesConfigName, test.allowed)
+			}
+			if test.selector != "" {
+				viper.Set(config.NamespaceSelectorConfigName, test.selector)
+			}
+
+			inScope, err := NamespaceInScope(ctx, test.namespace, client)
+			assert.Equal(t, test.expected, inScope)
+			if test.expectsErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestOverrideImageRegistry(t *testing.T) {
+	tests := map[string]struct {
+		image    string
+		registry string
+		expected string
+	}{
+		"Image with registry": {
+			image:    "quay.io/opendatahub/ds-pipelines-api-server:latest",
+			registry: "mirror.example.com",
+			expected: "mirror.example.com/opendatahub/ds-pipelines-api-server:latest",
+		},
+		"Image with registry port": {
+			image:    "registry.local:5000/org/image@sha256:abc",
+			registry: "mirror.example.com/",
+			expected: "mirror.example.com/org/image@sha256:abc",
+		},
+		"Image without registry": {
+			image:    "library/mariadb:10",
+			registry: "mirror.example.com/docker",
+			expected: "mirror.example.com/docker/library/mariadb:10",
+		},
+		"Image name only": {
+			image:    "mariadb:10",
+			registry: "mirror.example.com",
+			expected: "mirror.example.com/mariadb:10",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, OverrideImageRegistry(test.image, test.registry))
+		})
+	}
+}
+
+func TestIsFIPSEnabled(t *testing.T) {
+	defaultPath := FIPSEnabledPath
+	defer func() { FIPSEnabledPath = defaultPath }()
+
+	FIPSEnabledPath = filepath.Join(t.TempDir(), "fips_enabled")
+	assert.False(t, IsFIPSEnabled())
+
+	assert.Nil(t, os.WriteFile(FIPSEnabledPath, []byte("0\n"), 0600))
+	assert.False(t, IsFIPSEnabled())
+
+	assert.Nil(t, os.WriteFile(FIPSEnabledPath, []byte("1\n"), 0600))
+	assert.True(t, IsFIPSEnabled())
+}
+
+func TestRestrictTLSConfigToFIPS(t *testing.T) {
+	cfg := &tls.Config{}
+	RestrictTLSConfigToFIPS(cfg, false)
+	assert.Equal(t, uint16(0), cfg.MinVersion)
+	assert.Nil(t, cfg.CipherSuites)
+
+	RestrictTLSConfigToFIPS(cfg, true)
+	assert.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	assert.Equal(t, FIPSCipherSuites, cfg.CipherSuites)
+}
+
+func TestGetTemplatesInDir(t *testing.T) {
+	templates := fstest.MapFS{
+		"apiserver/default/deployment.yaml.tmpl": {},
+		"apiserver/default/service.yaml.tmpl":    {},
+		"apiserver/route/route.yaml.tmpl":        {},
+	}
+
+	// Assert subdirectories are not listed
+	found, err := GetTemplatesInDir(templates, "apiserver/default")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"apiserver/default/deployment.yaml.tmpl", "apiserver/default/service.yaml.tmpl"}, found)
+	found, err = GetTemplatesInDir(templates, "apiserver")
+	assert.Nil(t, err)
+	assert.Empty(t, found)
+
+	_, err = GetTemplatesInDir(templates, "missing")
+	assert.Error(t, err)
+}
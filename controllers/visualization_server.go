@@ -0,0 +1,64 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var visualizationServerTemplatesDir = "visualization-server/default"
+
+// visualizationServerRoute is a resource deployed conditionally
+// as such it is handled separately
+const visualizationServerRoute = "visualization-server/route/route.yaml.tmpl"
+
+const visualizationServerDefaultResourceNamePrefix = "ds-pipeline-visualizationserver-"
+
+func (r *DSPAReconciler) ReconcileVisualizationServer(ctx context.Context, dsp *dspav1.DataSciencePipelinesApplication,
+	params *DSPAParams) error {
+
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
+
+	if dsp.Spec.VisualizationServer == nil || !dsp.Spec.VisualizationServer.Deploy {
+		log.Info("Skipping Application of VisualizationServer Resources")
+		return nil
+	}
+
+	log.Info("Applying VisualizationServer Resources")
+	err := r.ApplyDir(dsp, params, visualizationServerTemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	if dsp.Spec.VisualizationServer.EnableRoute {
+		err := r.Apply(dsp, params, visualizationServerRoute)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespacedNamed := types.NamespacedName{Name: params.VisualizationServerDefaultResourceName, Namespace: dsp.Namespace}
+		err := r.deleteExternalRoute(ctx, params, namespacedNamed)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("Finished applying VisualizationServer Resources")
+	return nil
+}
@@ -0,0 +1,110 @@
+//go:build test_all || test_unit
+
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDeployVisualizationServer(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedName := "ds-pipeline-visualizationserver-testdspa"
+
+	// Construct DSPASpec with deployed Visualization Server
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.VisualizationServer = &dspav1.VisualizationServer{
+		Deploy:               true,
+		Image:                "visualization-server:test",
+		EnableRoute:          true,
+		KernelTimeoutSeconds: 300,
+	}
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	assert.NotNil(t, params.VisualizationServer.Resources)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileVisualizationServer(ctx, dspa, params)
+	require.Nil(t, err)
+
+	// Ensure the Deployment runs the kernel with the configured timeout, behind an OAuth proxy
+	deployment := &appsv1.Deployment{}
+	created, err := reconciler.IsResourceCreated(ctx, deployment, expectedName, testNamespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	containers := deployment.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	assert.Equal(t, "visualization-server:test", containers[0].Image)
+	assert.Contains(t, containers[0].Env, corev1.EnvVar{Name: "KERNEL_TIMEOUT", Value: "300"})
+	assert.Equal(t, "oauth-proxy", containers[1].Name)
+
+	created, err = reconciler.IsResourceCreated(ctx, &corev1.Service{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.True(t, created)
+	created, err = reconciler.IsResourceCreated(ctx, &routev1.Route{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.True(t, created)
+
+	// Ensure the Route is removed once disabled
+	dspa.Spec.VisualizationServer.EnableRoute = false
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+	err = reconciler.ReconcileVisualizationServer(ctx, dspa, params)
+	require.Nil(t, err)
+	created, err = reconciler.IsResourceCreated(ctx, &routev1.Route{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+}
+
+func TestDontDeployVisualizationServer(t *testing.T) {
+	testNamespace := "testnamespace"
+	testDSPAName := "testdspa"
+	expectedName := "ds-pipeline-visualizationserver-testdspa"
+
+	// Construct DSPASpec without a Visualization Server
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Namespace = testNamespace
+	dspa.Name = testDSPAName
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileVisualizationServer(ctx, dspa, params)
+	assert.Nil(t, err)
+
+	// Ensure the Deployment doesn't exist
+	created, err := reconciler.IsResourceCreated(ctx, &appsv1.Deployment{}, expectedName, testNamespace)
+	assert.Nil(t, err)
+	assert.False(t, created)
+}
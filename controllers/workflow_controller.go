@@ -25,9 +25,9 @@ var workflowControllerTemplatesDir = "workflow-controller"
 func (r *DSPAReconciler) ReconcileWorkflowController(dsp *dspav1.DataSciencePipelinesApplication,
 	params *DSPAParams) error {
 
-	log := r.Log.WithValues("namespace", dsp.Namespace).WithValues("dspa_name", dsp.Name)
+	log := dspaLogger(r.Log, dsp.Namespace, dsp.Name, params.ReconcileID)
 
-	if dsp.Spec.WorkflowController == nil || !dsp.Spec.WorkflowController.Deploy {
+	if params.WorkflowController == nil || !params.WorkflowController.Deploy {
 		log.Info("Skipping Application of WorkflowController Resources")
 		return nil
 	}
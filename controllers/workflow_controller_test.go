@@ -18,11 +18,17 @@ limitations under the License.
 package controllers
 
 import (
+	"encoding/json"
 	"testing"
 
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
+	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
 )
 
 func TestDeployWorkflowController(t *testing.T) {
@@ -119,3 +125,108 @@ func TestDontDeployWorkflowController(t *testing.T) {
 	assert.False(t, created)
 	assert.Nil(t, err)
 }
+
+func TestDeployWorkflowControllerPodDefaults(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.WorkflowController = &dspav1.WorkflowController{Deploy: true}
+	dspa.Spec.PodDefaults = &dspav1.PodDefaults{
+		Tolerations:      []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+		NodeSelector:     map[string]string{"nvidia.com/gpu.present": "true"},
+		RuntimeClassName: "nvidia",
+		Env:              []corev1.EnvVar{{Name: "NVIDIA_VISIBLE_DEVICES", Value: "all"}},
+		EnvFrom:          []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "feature-store"}}}},
+		Volumes:          []corev1.Volume{{Name: "certs", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "certs"}}}}},
+		VolumeMounts:     []corev1.VolumeMount{{Name: "certs", MountPath: "/etc/certs", ReadOnly: true}},
+	}
+	expectedWorkflowControllerName := "ds-pipeline-workflow-controller-" + dspa.Name
+
+	// Create Context, Fake Controller and Params
+	ctx, params, reconciler := CreateNewTestObjects()
+	err := params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	require.Nil(t, err)
+
+	// Run test reconciliation
+	err = reconciler.ReconcileWorkflowController(dspa, params)
+	require.Nil(t, err)
+
+	// Assert the pod defaults are applied to all pipeline steps through the workflow defaults
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedWorkflowControllerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	workflowDefaults := struct {
+		Spec struct {
+			Volumes      []corev1.Volume     `json:"volumes"`
+			Tolerations  []corev1.Toleration `json:"tolerations"`
+			NodeSelector map[string]string   `json:"nodeSelector"`
+			PodSpecPatch string              `json:"podSpecPatch"`
+		} `json:"spec"`
+	}{}
+	require.Nil(t, yaml.Unmarshal([]byte(configMap.Data["workflowDefaults"]), &workflowDefaults))
+	assert.Equal(t, dspa.Spec.PodDefaults.Tolerations, workflowDefaults.Spec.Tolerations)
+	assert.Equal(t, dspa.Spec.PodDefaults.NodeSelector, workflowDefaults.Spec.NodeSelector)
+	podSpecPatch := corev1.PodSpec{}
+	require.Nil(t, json.Unmarshal([]byte(workflowDefaults.Spec.PodSpecPatch), &podSpecPatch))
+	assert.Equal(t, "nvidia", *podSpecPatch.RuntimeClassName)
+	assert.Equal(t, "main", podSpecPatch.Containers[0].Name)
+	assert.Equal(t, dspa.Spec.PodDefaults.Env, podSpecPatch.Containers[0].Env)
+	assert.Equal(t, dspa.Spec.PodDefaults.Volumes, workflowDefaults.Spec.Volumes)
+	assert.Equal(t, dspa.Spec.PodDefaults.EnvFrom, podSpecPatch.Containers[0].EnvFrom)
+	assert.Equal(t, dspa.Spec.PodDefaults.VolumeMounts, podSpecPatch.Containers[0].VolumeMounts)
+
+	// Assert volume mounts must mount a pod default volume
+	dspa.Spec.PodDefaults.VolumeMounts[0].Name = "missing"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.podDefaults.volumeMounts")
+	dspa.Spec.PodDefaults.VolumeMounts[0].Name = "certs"
+
+	// Assert a custom workflow controller config is not silently left without the pod defaults
+	dspa.Spec.WorkflowController.CustomConfig = "custom-config"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.podDefaults")
+
+	// Assert an unset workflow controller is rejected rather than dereferenced
+	params.WorkflowController = nil
+	assert.ErrorContains(t, params.SetupWorkflowDefaults(dspa), "spec.podDefaults")
+}
+
+func TestDeployWorkflowControllerArtifactStepResources(t *testing.T) {
+	dspa := testutil.CreateDefaultDSPA()
+	dspa.Spec.WorkflowController = &dspav1.WorkflowController{Deploy: true}
+	dspa.Spec.APIServer.ArtifactStepResources = &dspav1.ResourceRequirements{
+		Requests: &dspav1.Resources{CPU: resource.MustParse("100m"), Memory: resource.MustParse("256Mi")},
+		Limits:   &dspav1.Resources{Memory: resource.MustParse("2Gi")},
+	}
+	expectedWorkflowControllerName := "ds-pipeline-workflow-controller-" + dspa.Name
+
+	ctx, params, reconciler := CreateNewTestObjects()
+	require.Nil(t, params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log))
+	require.Nil(t, reconciler.ReconcileWorkflowController(dspa, params))
+
+	// Assert the resources are set on the executor, quantities left unset are not limited
+	configMap := &corev1.ConfigMap{}
+	created, err := reconciler.IsResourceCreated(ctx, configMap, expectedWorkflowControllerName, dspa.Namespace)
+	require.Nil(t, err)
+	require.True(t, created)
+	executor := corev1.Container{}
+	require.Nil(t, yaml.Unmarshal([]byte(configMap.Data["executor"]), &executor))
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}, executor.Resources)
+
+	// Assert requests above their limits are rejected
+	dspa.Spec.APIServer.ArtifactStepResources.Requests.Memory = resource.MustParse("4Gi")
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "the memory request 4Gi must not exceed its limit 2Gi")
+	dspa.Spec.APIServer.ArtifactStepResources.Requests.Memory = resource.MustParse("256Mi")
+
+	// Assert a custom workflow controller config is not silently left without the resources
+	dspa.Spec.WorkflowController.CustomConfig = "custom-config"
+	err = params.ExtractParams(ctx, dspa, reconciler.Client, reconciler.Log)
+	assert.ErrorContains(t, err, "spec.apiServer.artifactStepResources")
+
+	// Assert an unset workflow controller is rejected rather than dereferenced
+	params.WorkflowController = nil
+	assert.ErrorContains(t, params.SetupArtifactStepResources(), "spec.apiServer.artifactStepResources")
+}
@@ -17,22 +17,29 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"github.com/golang/glog"
+	manifests "github.com/opendatahub-io/data-science-pipelines-operator/config"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers/config"
 	"github.com/spf13/viper"
+	"net/http"
 	"os"
 	"strings"
 	"time"
+	// Embed the IANA time zone database, the operator image does not ship one
+	// and cronScheduleTimezone is validated against it
+	_ "time/tzdata"
 
-	"github.com/fsnotify/fsnotify"
 	dspav1 "github.com/opendatahub-io/data-science-pipelines-operator/api/v1"
 	"github.com/opendatahub-io/data-science-pipelines-operator/controllers"
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -42,6 +49,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -52,6 +60,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(buildv1.AddToScheme(scheme))
 	utilruntime.Must(imagev1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
@@ -62,7 +71,7 @@ func init() {
 	controllers.InitMetrics()
 }
 
-func initConfig(configPath string) error {
+func initConfig(configPath string) (*controllers.ConfigReloader, error) {
 	// Import environment variable, support nested vars e.g. OBJECTSTORECONFIG_ACCESSKEY
 	replacer := strings.NewReplacer(".", "_")
 	viper.SetEnvKeyReplacer(replacer)
@@ -76,90 +85,291 @@ func initConfig(configPath string) error {
 	viper.AddConfigPath(configPath)
 	err := viper.ReadInConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, c := range config.GetConfigRequiredFields() {
 		if !viper.IsSet(c) {
-			return fmt.Errorf("missing required field in config: %s", c)
+			return nil, fmt.Errorf("missing required field in config: %s", c)
 		}
 	}
 
-	// Watch cfg file for live changes
-	viper.WatchConfig()
-	viper.OnConfigChange(func(e fsnotify.Event) {
-		// Read in cfg again
-		err := viper.ReadInConfig()
-		if err != nil {
-			return
+	// The reloader watches the cfg file for live changes once the manager
+	// starts, and rolls them out to the DSPAs
+	return controllers.NewConfigReloader(ctrl.Log.WithName("config-reloader")), nil
+}
+
+// cacheSyncedCheck reports ready once the manager's informer caches have synced.
+func cacheSyncedCheck(mgr ctrl.Manager) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches not yet synced")
 		}
-	})
+		return nil
+	}
+}
 
-	return nil
+// logLevelServer serves the log level endpoint on its own listener, as the
+// metrics endpoint is not authenticated and changing the level must not be
+// open to anyone who can reach the metrics port.
+type logLevelServer struct {
+	server *http.Server
+}
+
+// Start serves the endpoint until the manager stops.
+func (s *logLevelServer) Start(ctx context.Context) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.server.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return s.server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection serves the endpoint on every replica, each has its own level.
+func (s *logLevelServer) NeedLeaderElection() bool {
+	return false
 }
 
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var leaderElectionID string
+	var leaderElectionNamespace string
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
 	var probeAddr string
 	var configPath string
 	var maxConcurrentReconciles int
+	var logLevelEndpoint string
+	var logLevelAddr string
+	var readinessEndpoint string
+	var inventoryEndpoint string
+	var tracingEndpoint string
+	var tracingInsecure bool
+	var tracingSamplingRatio float64
+	var templatesDir string
+	var featureGates string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&configPath, "config", "", "Path to JSON file containing config")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionID, "leader-election-id", "f9eb95d5.opendatahub.io", "Name of the Lease used for leader election.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "Namespace the leader election Lease is created in. Defaults to the namespace the operator runs in.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second, "Duration non-leader replicas wait before attempting to acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second, "Duration the leader retries refreshing leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second, "Duration replicas wait between leader election actions.")
 	flag.IntVar(&maxConcurrentReconciles, "MaxConcurrentReconciles", config.DefaultMaxConcurrentReconciles, "Maximum concurrent reconciles")
+	flag.StringVar(&logLevelEndpoint, "log-level-endpoint", "/log-level", "Path used to query and change the log level at runtime. Set to empty to disable.")
+	flag.StringVar(&logLevelAddr, "log-level-bind-address", "127.0.0.1:8082", "The address the log level endpoint binds to. Changing the level is not authenticated, so it only listens on localhost by default.")
+	flag.StringVar(&readinessEndpoint, "dspa-readiness-endpoint", "/readyz/dspa/", "Path prefix on the metrics endpoint serving the readiness of a DSPA at <prefix><namespace>/<name>. Set to empty to disable.")
+	flag.StringVar(&inventoryEndpoint, "dspa-inventory-endpoint", "/inventory/dspa", "Path on the metrics endpoint serving the counts of the DSPAs of the cluster by version, readiness, storage type and component health. Set to empty to disable.")
+	flag.StringVar(&tracingEndpoint, "tracing-otlp-endpoint", "", "OTLP/gRPC collector endpoint (host:port) that reconcile traces are exported to. Tracing is disabled when empty.")
+	flag.BoolVar(&tracingInsecure, "tracing-insecure", false, "Disable TLS when connecting to the OTLP collector.")
+	flag.Float64Var(&tracingSamplingRatio, "tracing-sampling-ratio", 1.0, "Fraction of reconciles that are traced, between 0 and 1.")
+	flag.StringVar(&templatesDir, "templates-dir", "", "Directory the manifest templates are read from instead of those embedded in the binary, for development against config/internal. These are re-read on every reconcile.")
+	flag.StringVar(&featureGates, "feature-gates", "", "Comma-separated list of name=true|false pairs enabling or disabling operator feature gates, overridden per DSPA by its "+config.FeatureGatesAnnotation+" annotation. Known gates: "+strings.Join(config.FeatureGateNames(), ", ")+".")
+	// Production config emits JSON, use --zap-devel for human-readable console output
 	opts := zap.Options{
-		Development: true,
+		Development: false,
 		TimeEncoder: zapcore.TimeEncoderOfLayout(time.RFC3339),
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// Use an atomic level so it can be changed at runtime without a restart
+	logLevel, ok := opts.Level.(uberzap.AtomicLevel)
+	if !ok {
+		logLevel = uberzap.NewAtomicLevelAt(zapcore.InfoLevel)
+		if opts.Development {
+			logLevel.SetLevel(zapcore.DebugLevel)
+		}
+		opts.Level = logLevel
+	}
+
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	err := initConfig(configPath)
+	configReloader, err := initConfig(configPath)
 	if err != nil {
 		glog.Fatal(err)
 	}
 
+	if err := config.SetFeatureGates(featureGates); err != nil {
+		setupLog.Error(err, "invalid --feature-gates")
+		os.Exit(1)
+	}
+
+	if tracingEndpoint != "" {
+		shutdownTracing, err := controllers.InitTracing(context.Background(), tracingEndpoint, tracingInsecure, tracingSamplingRatio)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "problem shutting down tracing")
+			}
+		}()
+		setupLog.Info("tracing enabled", "endpoint", tracingEndpoint)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "f9eb95d5.opendatahub.io",
+		LeaderElectionID:       leaderElectionID,
+		// Step down as soon as the manager is stopped, so that a standby
+		// replica can take over without waiting for the lease to expire.
+		// Safe because the process exits right after mgr.Start returns.
+		LeaderElectionReleaseOnCancel: true,
+		LeaderElectionNamespace:       leaderElectionNamespace,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	if logLevelEndpoint != "" {
+		// GET returns the current level, PUT with {"level":"debug"} changes it
+		mux := http.NewServeMux()
+		mux.Handle(logLevelEndpoint, logLevel)
+		if err := mgr.Add(&logLevelServer{server: &http.Server{
+			Addr:              logLevelAddr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}}); err != nil {
+			setupLog.Error(err, "unable to set up log level endpoint")
+			os.Exit(1)
+		}
+	}
+
+	if readinessEndpoint != "" {
+		// The aggregated readiness of each DSPA, for load balancers and smoke tests
+		if err := mgr.AddMetricsExtraHandler(readinessEndpoint, &controllers.DSPAReadinessHandler{
+			Client: mgr.GetClient(),
+			Prefix: readinessEndpoint,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up DSPA readiness endpoint")
+			os.Exit(1)
+		}
+	}
+
+	if inventoryEndpoint != "" {
+		if err := mgr.AddMetricsExtraHandler(inventoryEndpoint, &controllers.DSPAInventoryHandler{
+			Client: mgr.GetClient(),
+		}); err != nil {
+			setupLog.Error(err, "unable to set up DSPA inventory endpoint")
+			os.Exit(1)
+		}
+	}
+	// The same counts as metrics, computed on every scrape
+	metrics.Registry.MustRegister(&controllers.DSPAInventoryCollector{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("inventory"),
+	})
+
+	// Watch the cfg file for live changes
+	if err := mgr.Add(configReloader); err != nil {
+		setupLog.Error(err, "unable to set up the config reloader")
+		os.Exit(1)
+	}
+
+	// The embedded templates never change, parse them once
+	templates := config.CachedTemplates(manifests.Templates())
+	if templatesDir != "" {
+		setupLog.Info("Reading manifest templates from " + templatesDir)
+		templates = os.DirFS(templatesDir)
+	}
+
+	// Throttle the resource writes of the reconcilers, reads hit the manager cache
+	reconcilerClient := mgr.GetClient()
+	if applyQPS := config.GetFloatConfigWithDefault(config.RateLimitApplyQPSConfigName, config.DefaultRateLimitApplyQPS); applyQPS > 0 {
+		applyBurst := config.GetIntConfigWithDefault(config.RateLimitApplyBurstConfigName, config.DefaultRateLimitApplyBurst)
+		reconcilerClient = controllers.NewApplyRateLimitedClient(reconcilerClient, applyQPS, applyBurst)
+	}
+
 	if err = (&controllers.DSPAReconciler{
-		Client:                  mgr.GetClient(),
+		Client:                  reconcilerClient,
 		Scheme:                  mgr.GetScheme(),
 		Log:                     ctrl.Log,
-		TemplatesPath:           "config/internal/",
+		Templates:               templates,
 		MaxConcurrentReconciles: maxConcurrentReconciles,
+		Recorder:                mgr.GetEventRecorderFor("datasciencepipelinesapplication-controller"),
+		Notifier:                controllers.NotifierFromConfig(),
+		ConfigReloader:          configReloader,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DSPAParams")
 		os.Exit(1)
 	}
 
+	// Rewrite DSPAs persisted by older operator versions in the current storage version
+	if err := mgr.Add(&controllers.StorageVersionMigrator{
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("storage-version-migration"),
+	}); err != nil {
+		setupLog.Error(err, "unable to set up the DSPA storage version migration")
+		os.Exit(1)
+	}
+
+	// Optionally provision a DSPA in every project namespace
+	if config.GetBoolConfigWithDefault(config.ProjectIntegrationEnabledConfigName, false) {
+		if err = (&controllers.ProjectIntegrationReconciler{
+			Client: mgr.GetClient(),
+			Log:    ctrl.Log.WithName("project-integration"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ProjectIntegration")
+			os.Exit(1)
+		}
+	}
+
+	// Pipelines and RecurringRuns are synced for the DSPAs enabling the
+	// DeclarativePipelines feature gate, which can be set per DSPA
+	if err = controllers.NewPipelineReconciler(mgr.GetClient(), ctrl.Log.WithName("pipeline")).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Pipeline")
+		os.Exit(1)
+	}
+	if err = controllers.NewRecurringRunReconciler(mgr.GetClient(), ctrl.Log.WithName("recurringrun")).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RecurringRun")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	// Standby replicas report ready once their informer caches are synced,
+	// so they can take over reconciliation immediately after acquiring the lease.
+	if err := mgr.AddReadyzCheck("readyz", cacheSyncedCheck(mgr)); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
 
+	if enableLeaderElection {
+		go func() {
+			<-mgr.Elected()
+			setupLog.Info("acquired leadership, starting reconciliation", "lease", leaderElectionID)
+		}()
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,79 @@
+//go:build test_integration
+
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/anthhub/forwarder"
+	TestUtil "github.com/opendatahub-io/data-science-pipelines-operator/tests/util"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *IntegrationTestSuite) TestPipelineRunArtifacts() {
+
+	suite.T().Run("Should store the artifacts of a Pipeline Run", func(t *testing.T) {
+		pipelineDisplayName := "[Demo] iris-training"
+		pipelineID, err := TestUtil.RetrievePipelineId(t, suite.Clientmgr.httpClient, APIServerURL, pipelineDisplayName)
+		require.NoError(t, err)
+		runID, err := TestUtil.CreatePipelineRun(t, suite.Clientmgr.httpClient, APIServerURL, pipelineID, pipelineDisplayName)
+		require.NoError(t, err)
+		require.NotEmpty(t, runID)
+		err = TestUtil.WaitForRunCompletion(t, suite.Clientmgr.httpClient, APIServerURL, runID)
+		require.NoError(t, err)
+
+		connection, err := TestUtil.GetStorageConnection(suite.Ctx, suite.Clientmgr.k8sClient, suite.DSPA.Name, suite.DSPANamespace)
+		require.NoError(t, err)
+
+		// Storage served in the cluster is reached through a port forward
+		endpoint := ""
+		storageURL, err := url.Parse(connection.Endpoint)
+		require.NoError(t, err)
+		if strings.HasSuffix(storageURL.Hostname(), ".svc.cluster.local") {
+			if endpointType != "service" {
+				t.Skip("the object storage is only reachable from the cluster")
+			}
+			remotePort, err := strconv.Atoi(storageURL.Port())
+			require.NoError(t, err)
+			hostParts := strings.Split(storageURL.Hostname(), ".")
+			result, err := forwarder.WithForwarders(suite.Ctx, []*forwarder.Option{
+				{
+					LocalPort:   StorageLocalPort,
+					RemotePort:  remotePort,
+					ServiceName: hostParts[0],
+					Namespace:   hostParts[1],
+				},
+			}, kubeconfig)
+			require.NoError(t, err)
+			defer result.Close()
+			_, err = result.Ready()
+			require.NoError(t, err)
+			endpoint = fmt.Sprintf("127.0.0.1:%d", StorageLocalPort)
+		}
+
+		artifacts, err := TestUtil.ListArtifacts(suite.Ctx, connection, endpoint, runID)
+		require.NoError(t, err)
+		require.NotEmpty(t, artifacts, "expected the artifacts of run %s in bucket %s", runID, connection.Bucket)
+		loggr.Info(fmt.Sprintf("Found %d artifacts of run %s", len(artifacts), runID))
+	})
+}
@@ -64,6 +64,7 @@ var (
 	skipDeploy           bool
 	skipCleanup          bool
 	PortforwardLocalPort int
+	StorageLocalPort     int
 	DSPA                 *dspav1.DataSciencePipelinesApplication
 	forwarderResult      *forwarder.Result
 	endpointType         string
@@ -84,6 +85,7 @@ const (
 	DefaultPollInterval         = 2
 	DefaultDeleteTimeout        = 120
 	DefaultPortforwardLocalPort = 8888
+	DefaultStorageLocalPort     = 9000
 	DefaultSkipDeploy           = false
 	DefaultSkipCleanup          = false
 	DefaultDSPAPath             = ""
@@ -139,6 +141,7 @@ func init() {
 	flag.DurationVar(&DeleteTimeout, "DeleteTimeout", DefaultDeleteTimeout, "Seconds to wait for deployment deletions. Consider increasing this on resource starved environments.")
 	DeleteTimeout *= time.Second
 	flag.IntVar(&PortforwardLocalPort, "PortforwardLocalPort", DefaultPortforwardLocalPort, "Local port to use for port forwarding dspa server.")
+	flag.IntVar(&StorageLocalPort, "StorageLocalPort", DefaultStorageLocalPort, "Local port to use for port forwarding the dspa object storage.")
 
 	flag.BoolVar(&skipDeploy, "skipDeploy", DefaultSkipDeploy, "Skip DSPA deployment. Use this if you have already "+
 		"manually deployed a DSPA, and want to skip this part.")
@@ -74,7 +74,7 @@ func WaitForDSPAReady(t *testing.T, ctx context.Context, client client.Client, d
 	if err != nil {
 		return err
 	}
-	if dspa.Spec.EnableRoute {
+	if dspa.Spec.APIServer.EnableRoute {
 		err = WaitFor(ctx, timeout, interval, func() (bool, error) {
 			_, err := GetDSPARoute(client, dspaNS, dspa.ObjectMeta.Name)
 			if err != nil {
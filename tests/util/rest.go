@@ -152,3 +152,51 @@ func CheckPipelineRunStatus(t *testing.T, httpClient http.Client, APIServerURL s
 	}
 	return state, nil
 }
+
+// CreatePipelineRun creates a run of the pipeline and returns its ID.
+func CreatePipelineRun(t *testing.T, httpClient http.Client, APIServerURL string, pipelineID string, PipelineDisplayName string) (string, error) {
+	body := FormatRequestBody(t, pipelineID, PipelineDisplayName)
+	response, err := httpClient.Post(fmt.Sprintf("%s/apis/v2beta1/runs", APIServerURL), "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	responseData, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	if response.StatusCode != 200 {
+		return "", fmt.Errorf("failed to create run, status code %d: %s", response.StatusCode, string(responseData))
+	}
+	var run struct {
+		RunID string `json:"run_id"`
+	}
+	err = json.Unmarshal(responseData, &run)
+	require.NoError(t, err)
+	return run.RunID, nil
+}
+
+// WaitForRunCompletion waits for the run with the given ID to succeed, unlike
+// WaitForPipelineRunCompletion it is not affected by the other runs.
+func WaitForRunCompletion(t *testing.T, httpClient http.Client, APIServerURL string, runID string) error {
+	timeout := time.After(6 * time.Minute)
+	ticker := time.NewTicker(6 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-timeout:
+			return fmt.Errorf("timed out waiting for run %s completion", runID)
+		case <-ticker.C:
+			response, err := httpClient.Get(fmt.Sprintf("%s/apis/v2beta1/runs/%s", APIServerURL, runID))
+			require.NoError(t, err)
+			responseData, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+			var run struct {
+				State string `json:"state"`
+			}
+			err = json.Unmarshal(responseData, &run)
+			require.NoError(t, err)
+			switch run.State {
+			case "SUCCEEDED":
+				return nil
+			case "SKIPPED", "FAILED", "CANCELING", "CANCELED", "PAUSED":
+				return fmt.Errorf("run %s status: %s", runID, run.State)
+			}
+		}
+	}
+}
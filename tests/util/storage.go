@@ -0,0 +1,91 @@
+/*
+Copyright 2024.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testUtil
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StorageConnection is the object storage a DSPA stores its artifacts in, as
+// published in its connection info ConfigMap.
+type StorageConnection struct {
+	Endpoint        string // scheme://host:port
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// GetStorageConnection reads the object storage connection of the DSPA from its
+// connection info ConfigMap and the credentials from the Secret it references.
+func GetStorageConnection(ctx context.Context, client client.Client, dspaName, dspaNS string) (*StorageConnection, error) {
+	configMap := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("ds-pipeline-connection-%s", dspaName), Namespace: dspaNS}, configMap)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	err = client.Get(ctx, types.NamespacedName{Name: configMap.Data["DSP_OBJECT_STORAGE_CREDENTIALS_SECRET"], Namespace: dspaNS}, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageConnection{
+		Endpoint:        configMap.Data["DSP_OBJECT_STORAGE_ENDPOINT"],
+		Bucket:          configMap.Data["DSP_OBJECT_STORAGE_ARTIFACT_BUCKET"],
+		Region:          configMap.Data["DSP_OBJECT_STORAGE_REGION"],
+		AccessKeyID:     string(secret.Data[configMap.Data["DSP_OBJECT_STORAGE_ACCESS_KEY_KEY"]]),
+		SecretAccessKey: string(secret.Data[configMap.Data["DSP_OBJECT_STORAGE_SECRET_KEY_KEY"]]),
+	}, nil
+}
+
+// ListArtifacts lists the keys of the objects stored in the bucket of the
+// storage connection that contain substr, reaching the storage at endpoint
+// (host:port) when set, e.g. through a port forward.
+func ListArtifacts(ctx context.Context, connection *StorageConnection, endpoint, substr string) ([]string, error) {
+	secure := strings.HasPrefix(connection.Endpoint, "https://")
+	if endpoint == "" {
+		endpoint = strings.TrimPrefix(strings.TrimPrefix(connection.Endpoint, "https://"), "http://")
+	}
+	minioClient, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(connection.AccessKeyID, connection.SecretAccessKey, ""),
+		Secure: secure,
+		Region: connection.Region,
+		// The test storage is served with self-signed certificates
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for object := range minioClient.ListObjects(ctx, connection.Bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		if strings.Contains(object.Key, substr) {
+			keys = append(keys, object.Key)
+		}
+	}
+	return keys, nil
+}